@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/storage"
 )
 
@@ -319,6 +320,9 @@ type SetCharmConfig struct {
 
 // SetCharm sets the charm for a given application.
 func (c *Client) SetCharm(branchName string, cfg SetCharmConfig) error {
+	if branchName != "" && branchName != model.GenerationMaster && c.BestAPIVersion() < 10 {
+		return errors.NotSupportedf("upgrading an application charm under a branch")
+	}
 	var storageConstraints map[string]params.StorageConstraints
 	if len(cfg.StorageConstraints) > 0 {
 		storageConstraints = make(map[string]params.StorageConstraints)
@@ -730,6 +734,23 @@ func (c *Client) SetConstraints(application string, constraints constraints.Valu
 	return c.facade.FacadeCall("SetConstraints", args, nil)
 }
 
+// SetBranchConstraints specifies the constraints for the given application
+// under the input branch. Only units tracking the branch are affected.
+func (c *Client) SetBranchConstraints(branchName, application string, constraints constraints.Value) error {
+	if branchName == model.GenerationMaster {
+		return c.SetConstraints(application, constraints)
+	}
+	if c.BestAPIVersion() < 10 {
+		return errors.NotSupportedf("setting application constraints under a branch")
+	}
+	args := params.SetConstraints{
+		ApplicationName: application,
+		Constraints:     constraints,
+		BranchName:      branchName,
+	}
+	return c.facade.FacadeCall("SetConstraints", args, nil)
+}
+
 // Expose changes the juju-managed firewall to expose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Expose(application string) error {
@@ -258,7 +258,7 @@ func (s *applicationSuite) TestSetCharm(c *gc.C) {
 	toUint64Ptr := func(v uint64) *uint64 {
 		return &v
 	}
-	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+	client := application.NewClient(basetesting.BestVersionCaller{APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
 		called = true
 		c.Assert(request, gc.Equals, "SetCharm")
 		args, ok := a.(params.ApplicationSetCharm)
@@ -281,7 +281,7 @@ func (s *applicationSuite) TestSetCharm(c *gc.C) {
 		c.Assert(args.Generation, gc.Equals, newBranchName)
 
 		return nil
-	})
+	}, BestVersion: 10})
 	cfg := application.SetCharmConfig{
 		ApplicationName: "application",
 		CharmID: charmstore.CharmID{
@@ -306,6 +306,21 @@ func (s *applicationSuite) TestSetCharm(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *applicationSuite) TestSetCharmBranchNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call %q", request)
+		return nil
+	})
+	cfg := application.SetCharmConfig{
+		ApplicationName: "application",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/application-1"),
+		},
+	}
+	err := client.SetCharm(newBranchName, cfg)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *applicationSuite) TestDestroyDeprecated(c *gc.C) {
 	var called bool
 	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
@@ -1012,6 +1027,45 @@ func (s *applicationSuite) TestGetConstraintsAPIv4(c *gc.C) {
 	})
 }
 
+func (s *applicationSuite) TestSetBranchConstraints(c *gc.C) {
+	cons := constraints.MustParse("mem=4G")
+	called := false
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Assert(request, gc.Equals, "SetConstraints")
+				c.Assert(a, jc.DeepEquals, params.SetConstraints{
+					ApplicationName: "foo",
+					Constraints:     cons,
+					BranchName:      "new-branch",
+				})
+				return nil
+			},
+		),
+		BestVersion: 10,
+	})
+
+	err := client.SetBranchConstraints("new-branch", "foo", cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetBranchConstraintsNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call %q", request)
+				return nil
+			},
+		),
+		BestVersion: 9,
+	})
+
+	err := client.SetBranchConstraints("new-branch", "foo", constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *applicationSuite) TestSetApplicationConfig(c *gc.C) {
 	fooConfig := map[string]string{
 		"foo":   "bar",
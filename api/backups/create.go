@@ -26,3 +26,26 @@ func (c *Client) Create(notes string, keepCopy, noDownload bool) (*params.Backup
 
 	return &result, nil
 }
+
+// CreateEncrypted sends a request to create a backup of juju's state,
+// with the backup archive encrypted using the given key. It returns
+// the metadata associated with the resulting backup and a filename
+// for download.
+func (c *Client) CreateEncrypted(notes string, keepCopy, noDownload bool, key params.BackupsEncryptionKey) (*params.BackupsMetadataResult, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("backup encryption on this version of Juju")
+	}
+	var result params.BackupsMetadataResult
+	args := params.BackupsCreateArgs{
+		Notes:      notes,
+		KeepCopy:   keepCopy,
+		NoDownload: noDownload,
+		Encryption: &key,
+	}
+
+	if err := c.facade.FacadeCall("Create", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return &result, nil
+}
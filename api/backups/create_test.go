@@ -47,3 +47,28 @@ func (s *createSuite) TestCreate(c *gc.C) {
 	meta := backupstesting.UpdateNotes(s.Meta, "important")
 	s.checkMetadataResult(c, result, meta)
 }
+
+func (s *createSuite) TestCreateEncrypted(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "Create")
+
+			c.Assert(paramsIn, gc.FitsTypeOf, params.BackupsCreateArgs{})
+			p := paramsIn.(params.BackupsCreateArgs)
+			c.Check(p.Encryption, jc.DeepEquals, &params.BackupsEncryptionKey{Passphrase: "sekrit"})
+
+			if result, ok := resp.(*params.BackupsMetadataResult); ok {
+				*result = apiserverbackups.CreateResult(s.Meta, "test-filename")
+				result.Encryption = "passphrase"
+			} else {
+				c.Fatalf("wrong output structure")
+			}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := s.client.CreateEncrypted("", false, false, params.BackupsEncryptionKey{Passphrase: "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Encryption, gc.Equals, "passphrase")
+}
@@ -0,0 +1,23 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Schedule returns the scheduled backup configuration of the
+// controller, and the outcome of the last scheduled backup.
+func (c *Client) Schedule() (*params.BackupsScheduleResult, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("scheduled backups on this version of Juju")
+	}
+	var result params.BackupsScheduleResult
+	if err := c.facade.FacadeCall("Schedule", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
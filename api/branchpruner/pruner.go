@@ -0,0 +1,36 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchpruner
+
+import (
+	"time"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const apiName = "BranchPruner"
+
+// Facade allows calls to "BranchPruner" endpoints
+type Facade struct {
+	facade base.FacadeCaller
+	*common.ModelWatcher
+}
+
+// NewFacade builds a facade for the branch pruner endpoints
+func NewFacade(caller base.APICaller) *Facade {
+	facadeCaller := base.NewFacadeCaller(caller, apiName)
+	return &Facade{facade: facadeCaller, ModelWatcher: common.NewModelWatcher(facadeCaller)}
+}
+
+// Prune removes branches that were committed or aborted more than the input
+// maximum age ago. Branches are not pruned by collection size, so the size
+// argument is ignored.
+func (s *Facade) Prune(maxAge time.Duration, _ int) error {
+	p := params.BranchPruneArgs{
+		MaxAge: maxAge,
+	}
+	return s.facade.FacadeCall("Prune", p, nil)
+}
@@ -0,0 +1,25 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchrollout
+
+import (
+	"github.com/juju/juju/api/base"
+)
+
+const apiName = "BranchRollout"
+
+// Facade allows calls to "BranchRollout" endpoints.
+type Facade struct {
+	facade base.FacadeCaller
+}
+
+// NewFacade builds a facade for the branch rollout endpoints.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{facade: base.NewFacadeCaller(caller, apiName)}
+}
+
+// ProgressRollouts advances the running rollouts of all in-flight branches.
+func (f *Facade) ProgressRollouts() error {
+	return f.facade.FacadeCall("ProgressRollouts", nil, nil)
+}
@@ -317,3 +317,27 @@ func (c *Client) SetOperatorStatus(appName string, status status.Status, message
 	}
 	return result.OneError()
 }
+
+// RecordOperatorEvents records the events reported by the cloud
+// for the operator of the specified application.
+func (c *Client) RecordOperatorEvents(appName string, events []status.StatusInfo) error {
+	if c.facade.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("recording operator events")
+	}
+	var result params.ErrorResults
+	arg := params.CloudEvents{Tag: names.NewApplicationTag(appName).String()}
+	for _, event := range events {
+		arg.Events = append(arg.Events, params.EntityStatus{
+			Status: event.Status,
+			Info:   event.Message,
+			Data:   event.Data,
+			Since:  event.Since,
+		})
+	}
+	args := params.CloudEventsArgs{Args: []params.CloudEvents{arg}}
+	err := c.facade.FacadeCall("RecordOperatorEvents", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
@@ -4,6 +4,8 @@
 package caasunitprovisioner_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -450,3 +452,47 @@ func (s *unitprovisionerSuite) TestSetOperatorStatus(c *gc.C) {
 	err := client.SetOperatorStatus("gitlab", status.Error, "broken", map[string]interface{}{"foo": "bar"})
 	c.Assert(err, gc.ErrorMatches, "FAIL")
 }
+
+func (s *unitprovisionerSuite) TestRecordOperatorEvents(c *gc.C) {
+	since := time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASUnitProvisioner")
+		c.Check(version, gc.Equals, 2)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "RecordOperatorEvents")
+		c.Assert(arg, jc.DeepEquals, params.CloudEventsArgs{
+			Args: []params.CloudEvents{{
+				Tag: "application-gitlab",
+				Events: []params.EntityStatus{{
+					Status: "FailedMount",
+					Info:   "Unable to mount volumes",
+					Since:  &since,
+				}},
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		return nil
+	})
+
+	client := caasunitprovisioner.NewClient(basetesting.BestVersionCaller{apiCaller, 2})
+	err := client.RecordOperatorEvents("gitlab", []status.StatusInfo{{
+		Status:  "FailedMount",
+		Message: "Unable to mount volumes",
+		Since:   &since,
+	}})
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
+
+func (s *unitprovisionerSuite) TestRecordOperatorEventsNotSupported(c *gc.C) {
+	client := newClient(func(_ string, _ int, _, _ string, _, _ interface{}) error {
+		c.Fatal("unexpected API call")
+		return nil
+	})
+	err := client.RecordOperatorEvents("gitlab", []status.StatusInfo{{Status: "FailedMount"}})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -5,6 +5,7 @@ package controller
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
@@ -267,6 +268,10 @@ type MigrationSpec struct {
 	TargetUser           string
 	TargetPassword       string
 	TargetMacaroons      []macaroon.Slice
+
+	// Resume requests that the model's last, aborted migration to the
+	// same target controller is resumed.
+	Resume bool
 }
 
 // Validate performs sanity checks on the migration configuration it
@@ -275,16 +280,20 @@ func (s *MigrationSpec) Validate() error {
 	if !names.IsValidModel(s.ModelUUID) {
 		return errors.NotValidf("model UUID")
 	}
-	if !names.IsValidModel(s.TargetControllerUUID) {
+	return validateMigrationTarget(s.TargetControllerUUID, s.TargetAddrs, s.TargetUser, s.TargetPassword, s.TargetMacaroons)
+}
+
+func validateMigrationTarget(controllerUUID string, addrs []string, user, password string, macs []macaroon.Slice) error {
+	if !names.IsValidModel(controllerUUID) {
 		return errors.NotValidf("controller UUID")
 	}
-	if len(s.TargetAddrs) < 1 {
+	if len(addrs) < 1 {
 		return errors.NotValidf("empty target API addresses")
 	}
-	if !names.IsValidUser(s.TargetUser) {
+	if !names.IsValidUser(user) {
 		return errors.NotValidf("target user")
 	}
-	if s.TargetPassword == "" && len(s.TargetMacaroons) == 0 {
+	if password == "" && len(macs) == 0 {
 		return errors.NotValidf("missing authentication secrets")
 	}
 	return nil
@@ -297,40 +306,278 @@ func (s *MigrationSpec) Validate() error {
 // but we don't need that at the client side yet (and may never) so
 // this call just supports starting one migration at a time.
 func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
+	if spec.Resume && c.BestAPIVersion() < 8 {
+		return "", errors.NotSupportedf("resuming a migration by this controller")
+	}
+	args, err := migrationArgs(spec)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	response := params.InitiateMigrationResults{}
+	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return "", errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.MigrationId, nil
+}
+
+// MigrationBinary describes a charm, agent binary or resource
+// that would be transferred during a model migration.
+type MigrationBinary struct {
+	Name string
+	Size int64
+}
+
+// MigrationDryRunResult holds the binaries that would be transferred
+// to the target controller by a model migration.
+type MigrationDryRunResult struct {
+	Charms    []MigrationBinary
+	Tools     []MigrationBinary
+	Resources []MigrationBinary
+}
+
+// MigrationDryRun checks whether the specified model could be migrated,
+// without starting the migration. The source and target prechecks are
+// run and the model description is validated by the target controller.
+// The charms, agent binaries and resources that would be transferred are
+// returned along with their sizes.
+func (c *Client) MigrationDryRun(spec MigrationSpec) (MigrationDryRunResult, error) {
+	if c.BestAPIVersion() < 8 {
+		return MigrationDryRunResult{}, errors.NotSupportedf("migration dry run by this controller")
+	}
+	args, err := migrationArgs(spec)
+	if err != nil {
+		return MigrationDryRunResult{}, errors.Trace(err)
+	}
+	response := params.MigrationDryRunResults{}
+	if err := c.facade.FacadeCall("MigrationDryRun", args, &response); err != nil {
+		return MigrationDryRunResult{}, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return MigrationDryRunResult{}, errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return MigrationDryRunResult{}, errors.Trace(result.Error)
+	}
+	return MigrationDryRunResult{
+		Charms:    migrationBinaries(result.Charms),
+		Tools:     migrationBinaries(result.Tools),
+		Resources: migrationBinaries(result.Resources),
+	}, nil
+}
+
+func migrationBinaries(in []params.MigrationBinary) []MigrationBinary {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]MigrationBinary, len(in))
+	for i, b := range in {
+		out[i] = MigrationBinary{Name: b.Name, Size: b.Size}
+	}
+	return out
+}
+
+func migrationArgs(spec MigrationSpec) (params.InitiateMigrationArgs, error) {
 	if err := spec.Validate(); err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
-	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
+	targetInfo, err := migrationTargetInfo(
+		spec.TargetControllerUUID,
+		spec.TargetAddrs,
+		spec.TargetCACert,
+		spec.TargetUser,
+		spec.TargetPassword,
+		spec.TargetMacaroons,
+	)
 	if err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
-	args := params.InitiateMigrationArgs{
+	return params.InitiateMigrationArgs{
 		Specs: []params.MigrationSpec{{
-			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
-			TargetInfo: params.MigrationTargetInfo{
-				ControllerTag: names.NewControllerTag(spec.TargetControllerUUID).String(),
-				Addrs:         spec.TargetAddrs,
-				CACert:        spec.TargetCACert,
-				AuthTag:       names.NewUserTag(spec.TargetUser).String(),
-				Password:      spec.TargetPassword,
-				Macaroons:     macsJSON,
-			},
+			ModelTag:   names.NewModelTag(spec.ModelUUID).String(),
+			TargetInfo: targetInfo,
+			Resume:     spec.Resume,
 		}},
+	}, nil
+}
+
+func migrationTargetInfo(
+	controllerUUID string,
+	addrs []string,
+	caCert, user, password string,
+	macs []macaroon.Slice,
+) (params.MigrationTargetInfo, error) {
+	macsJSON, err := macaroonsToJSON(macs)
+	if err != nil {
+		return params.MigrationTargetInfo{}, errors.Trace(err)
 	}
-	response := params.InitiateMigrationResults{}
-	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
+	return params.MigrationTargetInfo{
+		ControllerTag: names.NewControllerTag(controllerUUID).String(),
+		Addrs:         addrs,
+		CACert:        caCert,
+		AuthTag:       names.NewUserTag(user).String(),
+		Password:      password,
+		Macaroons:     macsJSON,
+	}, nil
+}
+
+// MigrationBatchSpec holds the details required to migrate a set of
+// hosted models to another controller.
+type MigrationBatchSpec struct {
+	// Owner and Cloud, when set, restrict the batch to the models
+	// owned by the user and deployed to the cloud. All hosted models
+	// are migrated otherwise.
+	Owner string
+	Cloud string
+
+	// Concurrency is the maximum number of the batch's migrations
+	// which may be in progress at once.
+	Concurrency int
+
+	TargetControllerUUID string
+	TargetAddrs          []string
+	TargetCACert         string
+	TargetUser           string
+	TargetPassword       string
+	TargetMacaroons      []macaroon.Slice
+}
+
+// Validate performs sanity checks on the migration batch
+// configuration it holds.
+func (s *MigrationBatchSpec) Validate() error {
+	if s.Owner != "" && !names.IsValidUser(s.Owner) {
+		return errors.NotValidf("owner %q", s.Owner)
+	}
+	if s.Cloud != "" && !names.IsValidCloud(s.Cloud) {
+		return errors.NotValidf("cloud %q", s.Cloud)
+	}
+	if s.Concurrency < 1 {
+		return errors.NotValidf("concurrency %d", s.Concurrency)
+	}
+	return validateMigrationTarget(s.TargetControllerUUID, s.TargetAddrs, s.TargetUser, s.TargetPassword, s.TargetMacaroons)
+}
+
+// InitiateMigrationBatch queues the migration of the models selected
+// by the spec, returning the ID of the migration batch. The
+// controller starts the migrations a few at a time, as allowed by the
+// spec's concurrency.
+func (c *Client) InitiateMigrationBatch(spec MigrationBatchSpec) (string, error) {
+	if c.BestAPIVersion() < 8 {
+		return "", errors.NotSupportedf("migration batches by this controller")
+	}
+	if err := spec.Validate(); err != nil {
+		return "", errors.Annotatef(err, "client-side validation failed")
+	}
+	targetInfo, err := migrationTargetInfo(
+		spec.TargetControllerUUID,
+		spec.TargetAddrs,
+		spec.TargetCACert,
+		spec.TargetUser,
+		spec.TargetPassword,
+		spec.TargetMacaroons,
+	)
+	if err != nil {
+		return "", errors.Annotatef(err, "client-side validation failed")
+	}
+	args := params.InitiateMigrationBatchArgs{
+		TargetInfo:  targetInfo,
+		Concurrency: spec.Concurrency,
+	}
+	if spec.Owner != "" {
+		args.OwnerTag = names.NewUserTag(spec.Owner).String()
+	}
+	if spec.Cloud != "" {
+		args.CloudTag = names.NewCloudTag(spec.Cloud).String()
+	}
+	var result params.InitiateMigrationBatchResult
+	if err := c.facade.FacadeCall("InitiateMigrationBatch", args, &result); err != nil {
 		return "", errors.Trace(err)
 	}
+	return result.BatchId, nil
+}
+
+// MigrationBatchStatus holds the progress of a batch of model
+// migrations.
+type MigrationBatchStatus struct {
+	Id                   string
+	InitiatedBy          string
+	StartTime            time.Time
+	TargetControllerUUID string
+	Concurrency          int
+	Models               []MigrationBatchModel
+}
+
+// MigrationBatchModel holds the progress of the migration of a model
+// in a batch. Phase is empty while the migration is queued, or if it
+// couldn't be started, in which case StartError is set.
+type MigrationBatchModel struct {
+	UUID        string
+	Name        string
+	Owner       string
+	MigrationId string
+	Phase       string
+	Message     string
+	StartError  string
+}
+
+// MigrationBatchStatus returns the progress of the migrations in the
+// specified migration batch.
+func (c *Client) MigrationBatchStatus(id string) (MigrationBatchStatus, error) {
+	if c.BestAPIVersion() < 8 {
+		return MigrationBatchStatus{}, errors.NotSupportedf("migration batches by this controller")
+	}
+	args := params.MigrationBatchArgs{BatchIds: []string{id}}
+	var response params.MigrationBatchStatusResults
+	if err := c.facade.FacadeCall("MigrationBatchStatus", args, &response); err != nil {
+		return MigrationBatchStatus{}, errors.Trace(err)
+	}
 	if len(response.Results) != 1 {
-		return "", errors.New("unexpected number of results returned")
+		return MigrationBatchStatus{}, errors.New("unexpected number of results returned")
 	}
 	result := response.Results[0]
 	if result.Error != nil {
-		return "", errors.Trace(result.Error)
+		return MigrationBatchStatus{}, errors.Trace(result.Error)
 	}
-	return result.MigrationId, nil
+	controllerTag, err := names.ParseControllerTag(result.TargetController)
+	if err != nil {
+		return MigrationBatchStatus{}, errors.Trace(err)
+	}
+	status := MigrationBatchStatus{
+		Id:                   result.BatchId,
+		InitiatedBy:          result.InitiatedBy,
+		StartTime:            result.StartTime,
+		TargetControllerUUID: controllerTag.Id(),
+		Concurrency:          result.Concurrency,
+	}
+	for _, m := range result.Models {
+		modelTag, err := names.ParseModelTag(m.ModelTag)
+		if err != nil {
+			return MigrationBatchStatus{}, errors.Trace(err)
+		}
+		ownerTag, err := names.ParseUserTag(m.OwnerTag)
+		if err != nil {
+			return MigrationBatchStatus{}, errors.Trace(err)
+		}
+		status.Models = append(status.Models, MigrationBatchModel{
+			UUID:        modelTag.Id(),
+			Name:        m.Name,
+			Owner:       ownerTag.Id(),
+			MigrationId: m.MigrationId,
+			Phase:       m.Phase,
+			Message:     m.Message,
+			StartError:  m.StartError,
+		})
+	}
+	return status, nil
 }
 
 func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
@@ -123,10 +123,44 @@ func specToArgs(spec controller.MigrationSpec) params.InitiateMigrationArgs {
 				Password:      spec.TargetPassword,
 				Macaroons:     string(macsJSON),
 			},
+			Resume: spec.Resume,
 		}},
 	}
 }
 
+func (s *Suite) TestInitiateMigrationResume(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.InitiateMigrationResults)
+			*out = params.InitiateMigrationResults{
+				Results: []params.InitiateMigrationResult{{MigrationId: "id"}},
+			}
+			return nil
+		},
+		BestVersion: 8,
+	}
+	client := controller.NewClient(apiCaller)
+	spec := makeSpec()
+	spec.Resume = true
+	id, err := client.InitiateMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(id, gc.Equals, "id")
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.InitiateMigration", []interface{}{specToArgs(spec)}},
+	})
+}
+
+func (s *Suite) TestInitiateMigrationResumeNotSupported(c *gc.C) {
+	client, stub := makeInitiateMigrationClient(params.InitiateMigrationResults{})
+	spec := makeSpec()
+	spec.Resume = true
+	_, err := client.InitiateMigration(spec)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	c.Check(stub.Calls(), gc.HasLen, 0)
+}
+
 func (s *Suite) TestInitiateMigrationError(c *gc.C) {
 	client, _ := makeInitiateMigrationClient(params.InitiateMigrationResults{
 		Results: []params.InitiateMigrationResult{{
@@ -170,6 +204,178 @@ func (s *Suite) TestInitiateMigrationValidationError(c *gc.C) {
 	c.Check(stub.Calls(), gc.HasLen, 0) // API call shouldn't have happened
 }
 
+func (s *Suite) TestMigrationDryRun(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.MigrationDryRunResults)
+			*out = params.MigrationDryRunResults{
+				Results: []params.MigrationDryRunResult{{
+					Charms: []params.MigrationBinary{{Name: "cs:wordpress-1", Size: 1024}},
+					Tools:  []params.MigrationBinary{{Name: "2.6.0-bionic-amd64", Size: 4096}},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 8,
+	}
+	client := controller.NewClient(apiCaller)
+	spec := makeSpec()
+	result, err := client.MigrationDryRun(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, controller.MigrationDryRunResult{
+		Charms: []controller.MigrationBinary{{Name: "cs:wordpress-1", Size: 1024}},
+		Tools:  []controller.MigrationBinary{{Name: "2.6.0-bionic-amd64", Size: 4096}},
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.MigrationDryRun", []interface{}{specToArgs(spec)}},
+	})
+}
+
+func (s *Suite) TestMigrationDryRunError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			out := result.(*params.MigrationDryRunResults)
+			*out = params.MigrationDryRunResults{
+				Results: []params.MigrationDryRunResult{{
+					Error: common.ServerError(errors.New("target prechecks failed: boom")),
+				}},
+			}
+			return nil
+		},
+		BestVersion: 8,
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.MigrationDryRun(makeSpec())
+	c.Check(err, gc.ErrorMatches, "target prechecks failed: boom")
+}
+
+func (s *Suite) TestMigrationDryRunNotSupported(c *gc.C) {
+	client, stub := makeInitiateMigrationClient(params.InitiateMigrationResults{})
+	_, err := client.MigrationDryRun(makeSpec())
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	c.Check(stub.Calls(), gc.HasLen, 0)
+}
+
+func (s *Suite) TestInitiateMigrationBatch(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.InitiateMigrationBatchResult)
+			out.BatchId = "1"
+			return nil
+		},
+		BestVersion: 8,
+	}
+	client := controller.NewClient(apiCaller)
+	controllerUUID := randomUUID()
+	batchId, err := client.InitiateMigrationBatch(controller.MigrationBatchSpec{
+		Owner:                "bob",
+		Concurrency:          2,
+		TargetControllerUUID: controllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "someone",
+		TargetPassword:       "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(batchId, gc.Equals, "1")
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.InitiateMigrationBatch", []interface{}{params.InitiateMigrationBatchArgs{
+			OwnerTag: "user-bob",
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: names.NewControllerTag(controllerUUID).String(),
+				Addrs:         []string{"1.2.3.4:5"},
+				CACert:        "cert",
+				AuthTag:       "user-someone",
+				Password:      "secret",
+			},
+			Concurrency: 2,
+		}}},
+	})
+}
+
+func (s *Suite) TestInitiateMigrationBatchValidationError(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			return nil
+		},
+		BestVersion: 8,
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.InitiateMigrationBatch(controller.MigrationBatchSpec{
+		TargetControllerUUID: randomUUID(),
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetUser:           "someone",
+		TargetPassword:       "secret",
+	})
+	c.Check(err, gc.ErrorMatches, "client-side validation failed: concurrency 0 not valid")
+	c.Check(stub.Calls(), gc.HasLen, 0)
+}
+
+func (s *Suite) TestMigrationBatchStatus(c *gc.C) {
+	controllerUUID := randomUUID()
+	modelUUID := randomUUID()
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.MigrationBatchStatusResults)
+			*out = params.MigrationBatchStatusResults{
+				Results: []params.MigrationBatchStatusResult{{
+					BatchId:          "1",
+					InitiatedBy:      "admin",
+					TargetController: names.NewControllerTag(controllerUUID).String(),
+					Concurrency:      2,
+					Models: []params.MigrationBatchModelStatus{{
+						ModelTag:    names.NewModelTag(modelUUID).String(),
+						Name:        "mymodel",
+						OwnerTag:    "user-bob",
+						MigrationId: modelUUID + ":0",
+						Phase:       "IMPORT",
+						Message:     "importing",
+					}},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 8,
+	}
+	client := controller.NewClient(apiCaller)
+	status, err := client.MigrationBatchStatus("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status, jc.DeepEquals, controller.MigrationBatchStatus{
+		Id:                   "1",
+		InitiatedBy:          "admin",
+		TargetControllerUUID: controllerUUID,
+		Concurrency:          2,
+		Models: []controller.MigrationBatchModel{{
+			UUID:        modelUUID,
+			Name:        "mymodel",
+			Owner:       "bob",
+			MigrationId: modelUUID + ":0",
+			Phase:       "IMPORT",
+			Message:     "importing",
+		}},
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.MigrationBatchStatus", []interface{}{params.MigrationBatchArgs{BatchIds: []string{"1"}}}},
+	})
+}
+
+func (s *Suite) TestMigrationBatchNotSupported(c *gc.C) {
+	client, stub := makeInitiateMigrationClient(params.InitiateMigrationResults{})
+	_, err := client.MigrationBatchStatus("1")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.InitiateMigrationBatch(controller.MigrationBatchSpec{})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	c.Check(stub.Calls(), gc.HasLen, 0)
+}
+
 func (s *Suite) TestHostedModelConfigs_CallError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
 		return errors.New("boom")
@@ -0,0 +1,83 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinehealth
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+)
+
+const machineHealthFacade = "MachineHealth"
+
+// Client provides access to the machine health API facade.
+type Client struct {
+	facade base.FacadeCaller
+	*common.ModelWatcher
+}
+
+// NewClient returns a new client-side machine health facade.
+func NewClient(caller base.APICaller) *Client {
+	facadeCaller := base.NewFacadeCaller(caller, machineHealthFacade)
+	return &Client{
+		facade:       facadeCaller,
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
+	}
+}
+
+// LostMachine describes a provisioned machine whose agent is lost.
+type LostMachine struct {
+	Tag        names.MachineTag
+	InstanceId instance.Id
+
+	// Since holds the time since which the machine's agent has been
+	// lost.
+	Since time.Time
+}
+
+// LostMachines returns the provisioned machines in the model whose
+// agents are lost, and which have not already been marked as failed.
+func (c *Client) LostMachines() ([]LostMachine, error) {
+	var result params.LostMachinesResult
+	if err := c.facade.FacadeCall("LostMachines", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	machines := make([]LostMachine, len(result.Machines))
+	for i, m := range result.Machines {
+		tag, err := names.ParseMachineTag(m.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machines[i] = LostMachine{
+			Tag:        tag,
+			InstanceId: instance.Id(m.InstanceId),
+			Since:      m.Since,
+		}
+	}
+	return machines, nil
+}
+
+// MarkMachineFailed sets the status of the machine to error with the
+// given message, and if reprovision is true, has the provisioner start
+// a new instance for it.
+func (c *Client) MarkMachineFailed(tag names.MachineTag, message string, reprovision bool) error {
+	args := params.MachineFailures{
+		Failures: []params.MachineFailure{{
+			Tag:         tag.String(),
+			Message:     message,
+			Reprovision: reprovision,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("MarkMachinesFailed", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -0,0 +1,93 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinehealth_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/machinehealth"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type machineHealthSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&machineHealthSuite{})
+
+func (s *machineHealthSuite) TestLostMachines(c *gc.C) {
+	since := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	caller := testing.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "MachineHealth")
+		c.Check(request, gc.Equals, "LostMachines")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.LostMachinesResult{})
+		*result.(*params.LostMachinesResult) = params.LostMachinesResult{
+			Machines: []params.LostMachine{
+				{Tag: "machine-0", InstanceId: "i-0", Since: since},
+				{Tag: "machine-3", InstanceId: "i-3", Since: since},
+			},
+		}
+		return nil
+	})
+	client := machinehealth.NewClient(caller)
+	machines, err := client.LostMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, jc.DeepEquals, []machinehealth.LostMachine{
+		{Tag: names.NewMachineTag("0"), InstanceId: instance.Id("i-0"), Since: since},
+		{Tag: names.NewMachineTag("3"), InstanceId: instance.Id("i-3"), Since: since},
+	})
+}
+
+func (s *machineHealthSuite) TestLostMachinesBadTag(c *gc.C) {
+	caller := testing.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		*result.(*params.LostMachinesResult) = params.LostMachinesResult{
+			Machines: []params.LostMachine{{Tag: "unit-foo-0"}},
+		}
+		return nil
+	})
+	client := machinehealth.NewClient(caller)
+	machines, err := client.LostMachines()
+	c.Assert(err, gc.ErrorMatches, `"unit-foo-0" is not a valid machine tag`)
+	c.Assert(machines, gc.IsNil)
+}
+
+func (s *machineHealthSuite) TestLostMachinesError(c *gc.C) {
+	caller := testing.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+	client := machinehealth.NewClient(caller)
+	_, err := client.LostMachines()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *machineHealthSuite) TestMarkMachineFailed(c *gc.C) {
+	caller := testing.APICallerFunc(func(facade string, version int, id, request string, arg, result interface{}) error {
+		c.Check(facade, gc.Equals, "MachineHealth")
+		c.Check(request, gc.Equals, "MarkMachinesFailed")
+		c.Check(arg, jc.DeepEquals, params.MachineFailures{
+			Failures: []params.MachineFailure{{
+				Tag:         "machine-1",
+				Message:     "instance i-1 is stopped",
+				Reprovision: true,
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*result.(*params.ErrorResults) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "bad"}}},
+		}
+		return nil
+	})
+	client := machinehealth.NewClient(caller)
+	err := client.MarkMachineFailed(names.NewMachineTag("1"), "instance i-1 is stopped", true)
+	c.Assert(err, gc.ErrorMatches, "bad")
+}
@@ -133,6 +133,65 @@ func (client *Client) destroyMachines(method string, machines []string) ([]param
 	return allResults, nil
 }
 
+// ReplaceMachines replaces each of the given machines with a new machine
+// with the same series, constraints and placement, moving the old
+// machine's units to the new one before destroying the old machine.
+func (client *Client) ReplaceMachines(machines ...string) ([]params.ReplaceMachineResult, error) {
+	if client.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("replacing machines")
+	}
+	args := params.Entities{
+		Entities: make([]params.Entity, 0, len(machines)),
+	}
+	allResults := make([]params.ReplaceMachineResult, len(machines))
+	index := make([]int, 0, len(machines))
+	for i, machineId := range machines {
+		if !names.IsValidMachine(machineId) {
+			allResults[i].Error = &params.Error{
+				Message: errors.NotValidf("machine ID %q", machineId).Error(),
+			}
+			continue
+		}
+		index = append(index, i)
+		args.Entities = append(args.Entities, params.Entity{
+			Tag: names.NewMachineTag(machineId).String(),
+		})
+	}
+	if len(args.Entities) > 0 {
+		var result params.ReplaceMachineResults
+		if err := client.facade.FacadeCall("ReplaceMachines", args, &result); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n := len(result.Results); n != len(args.Entities) {
+			return nil, errors.Errorf("expected %d result(s), got %d", len(args.Entities), n)
+		}
+		for i, result := range result.Results {
+			allResults[index[i]] = result
+		}
+	}
+	return allResults, nil
+}
+
+// PreviewMachines returns the series, architecture, instance type, image
+// and availability zone that would be chosen for new machines with the
+// supplied parameters, without adding the machines or starting anything.
+func (client *Client) PreviewMachines(machineParams []params.AddMachineParams) ([]params.MachinePreviewResult, error) {
+	if client.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("previewing machines")
+	}
+	args := params.AddMachines{
+		MachineParams: machineParams,
+	}
+	var results params.MachinePreviewResults
+	if err := client.facade.FacadeCall("PreviewMachines", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != len(machineParams) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(machineParams), n)
+	}
+	return results.Results, nil
+}
+
 // UpgradeSeriesPrepare notifies the controller that a series upgrade is taking
 // place for a given machine and as such the machine is guarded against
 // operations that would impede, fail, or interfere with the upgrade process.
@@ -14,6 +14,7 @@ import (
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/machinemanager"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -215,6 +216,90 @@ func (s *MachinemanagerSuite) clientToTestDestroyMachinesWithParams(c *gc.C, v i
 	return client, expectedResults
 }
 
+func (s *MachinemanagerSuite) TestReplaceMachines(c *gc.C) {
+	expectedResults := []params.ReplaceMachineResult{{
+		Machine: "machine-2",
+		Units:   []params.Entity{{Tag: "unit-foo-0"}},
+	}, {
+		Error: &params.Error{Message: "boo"},
+	}}
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 7,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "ReplaceMachines")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{
+						{Tag: "machine-0"},
+						{Tag: "machine-0-lxd-1"},
+					},
+				})
+				c.Assert(response, gc.FitsTypeOf, &params.ReplaceMachineResults{})
+				out := response.(*params.ReplaceMachineResults)
+				*out = params.ReplaceMachineResults{Results: expectedResults}
+				return nil
+			})})
+	results, err := client.ReplaceMachines("0", "invalid", "0/lxd/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ReplaceMachineResult{
+		expectedResults[0],
+		{Error: &params.Error{Message: `machine ID "invalid" not valid`}},
+		expectedResults[1],
+	})
+}
+
+func (s *MachinemanagerSuite) TestReplaceMachinesNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 6,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			})})
+	_, err := client.ReplaceMachines("0")
+	c.Assert(err, gc.ErrorMatches, "replacing machines not supported")
+}
+
+func (s *MachinemanagerSuite) TestPreviewMachines(c *gc.C) {
+	machines := []params.AddMachineParams{{
+		Series:      "bionic",
+		Constraints: constraints.MustParse("mem=8G"),
+	}}
+	expectedResults := []params.MachinePreviewResult{{
+		Series:           "bionic",
+		Arch:             "amd64",
+		InstanceType:     "m5.large",
+		ImageId:          "ami-0123",
+		AvailabilityZone: "us-east-1a",
+	}}
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 8,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Assert(request, gc.Equals, "PreviewMachines")
+				c.Assert(a, jc.DeepEquals, params.AddMachines{MachineParams: machines})
+				c.Assert(response, gc.FitsTypeOf, &params.MachinePreviewResults{})
+				out := response.(*params.MachinePreviewResults)
+				*out = params.MachinePreviewResults{Results: expectedResults}
+				return nil
+			})})
+	results, err := client.PreviewMachines(machines)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expectedResults)
+}
+
+func (s *MachinemanagerSuite) TestPreviewMachinesNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 7,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			})})
+	_, err := client.PreviewMachines([]params.AddMachineParams{{}})
+	c.Assert(err, gc.ErrorMatches, "previewing machines not supported")
+}
+
 func (s *MachinemanagerSuite) TestDestroyMachinesWithParamsV5NoWait(c *gc.C) {
 	// MaxWait will be ignored in all versions < 6, so expect the argument
 	// to apiserver to always be nl.
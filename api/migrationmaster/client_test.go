@@ -94,10 +94,12 @@ func (s *ClientSuite) TestMigrationStatus(c *gc.C) {
 					Password:      "secret",
 					Macaroons:     string(macsJSON),
 				},
+				Resume: true,
 			},
 			MigrationId:      "id",
 			Phase:            "IMPORT",
 			PhaseChangedTime: timestamp,
+			KeepImport:       true,
 		}
 		return nil
 	})
@@ -121,6 +123,8 @@ func (s *ClientSuite) TestMigrationStatus(c *gc.C) {
 			AuthTag:       names.NewUserTag("admin"),
 			Password:      "secret",
 		},
+		Resume:     true,
+		KeepImport: true,
 	})
 }
 
@@ -172,6 +176,21 @@ func (s *ClientSuite) TestSetStatusMessageError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
 
+func (s *ClientSuite) TestSetKeepImport(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	err := client.SetKeepImport(true)
+	c.Assert(err, jc.ErrorIsNil)
+	expectedArg := params.SetMigrationKeepImportArgs{KeepImport: true}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.SetKeepImport", []interface{}{"", expectedArg}},
+	})
+}
+
 func (s *ClientSuite) TestModelInfo(c *gc.C) {
 	var stub jujutesting.Stub
 	owner := names.NewUserTag("owner")
@@ -60,6 +60,66 @@ func (c *Client) Import(bytes []byte) error {
 	return c.caller.FacadeCall("Import", serialized, nil)
 }
 
+// CanResumeImport returns true if the target controller can resume a
+// failed import, keeping the binaries already uploaded for the model.
+func (c *Client) CanResumeImport() bool {
+	return c.caller.BestAPIVersion() >= 3
+}
+
+// ResumeImport takes a serialized model and imports it into the target
+// controller, replacing any model left behind by an earlier failed
+// import of it while keeping the binaries already uploaded.
+func (c *Client) ResumeImport(bytes []byte) error {
+	if !c.CanResumeImport() {
+		return errors.NotSupportedf("resuming a model import with this controller")
+	}
+	serialized := params.SerializedModel{Bytes: bytes}
+	return c.caller.FacadeCall("ResumeImport", serialized, nil)
+}
+
+// ImportedBinaries returns the binaries that the target controller
+// already holds for a model being imported.
+func (c *Client) ImportedBinaries(modelUUID string) (coremigration.ImportedBinaries, error) {
+	var empty coremigration.ImportedBinaries
+	if !c.CanResumeImport() {
+		return empty, errors.NotSupportedf("listing imported binaries with this controller")
+	}
+	args := params.ModelArgs{ModelTag: names.NewModelTag(modelUUID).String()}
+	var result params.MigrationImportedBinaries
+	if err := c.caller.FacadeCall("ImportedBinaries", args, &result); err != nil {
+		return empty, errors.Trace(err)
+	}
+	out := coremigration.ImportedBinaries{
+		Charms:    make(map[string]string),
+		Tools:     make(map[version.Binary]string),
+		Resources: make(map[string]string),
+	}
+	for _, ch := range result.Charms {
+		out.Charms[ch.ID] = ch.SHA256
+	}
+	for _, tools := range result.Tools {
+		v, err := version.ParseBinary(tools.ID)
+		if err != nil {
+			return empty, errors.Annotate(err, "parsing agent binary version")
+		}
+		out.Tools[v] = tools.SHA256
+	}
+	for _, res := range result.Resources {
+		out.Resources[res.Application+"/"+res.Name] = res.FingerprintHex
+	}
+	return out, nil
+}
+
+// ValidateImport takes a serialized model and checks that it could be
+// imported into the target controller, without importing it.
+func (c *Client) ValidateImport(bytes []byte) error {
+	if c.caller.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("validating a model import with this controller")
+	}
+	serialized := params.SerializedModel{Bytes: bytes}
+	return c.caller.FacadeCall("ValidateImport", serialized, nil)
+}
+
 // Abort removes all data relating to a previously imported model.
 func (c *Client) Abort(modelUUID string) error {
 	args := params.ModelArgs{ModelTag: names.NewModelTag(modelUUID).String()}
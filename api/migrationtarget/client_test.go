@@ -89,6 +89,107 @@ func (s *ClientSuite) TestImport(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
 
+func (s *ClientSuite) TestValidateImport(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			return errors.New("boom")
+		},
+		BestVersion: 2,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+
+	err := client.ValidateImport([]byte("foo"))
+
+	expectedArg := params.SerializedModel{Bytes: []byte("foo")}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.ValidateImport", []interface{}{"", expectedArg}},
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ClientSuite) TestValidateImportNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+
+	err := client.ValidateImport([]byte("foo"))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
+func (s *ClientSuite) TestResumeImport(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			return errors.New("boom")
+		},
+		BestVersion: 3,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+	c.Check(client.CanResumeImport(), jc.IsTrue)
+
+	err := client.ResumeImport([]byte("foo"))
+
+	expectedArg := params.SerializedModel{Bytes: []byte("foo")}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.ResumeImport", []interface{}{"", expectedArg}},
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ClientSuite) TestResumeImportNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	c.Check(client.CanResumeImport(), jc.IsFalse)
+
+	err := client.ResumeImport([]byte("foo"))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
+func (s *ClientSuite) TestImportedBinaries(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			out := result.(*params.MigrationImportedBinaries)
+			*out = params.MigrationImportedBinaries{
+				Charms: []params.MigrationImportedBinary{{ID: "cs:foo-1", SHA256: "charm-sha"}},
+				Tools:  []params.MigrationImportedBinary{{ID: "2.6.0-bionic-amd64", SHA256: "tools-sha"}},
+				Resources: []params.MigrationImportedResource{{
+					Application:    "foo",
+					Name:           "bar",
+					FingerprintHex: "abcd",
+				}},
+			}
+			return nil
+		},
+		BestVersion: 3,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+
+	binaries, err := client.ImportedBinaries("uuid")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(binaries, jc.DeepEquals, coremigration.ImportedBinaries{
+		Charms:    map[string]string{"cs:foo-1": "charm-sha"},
+		Tools:     map[version.Binary]string{version.MustParseBinary("2.6.0-bionic-amd64"): "tools-sha"},
+		Resources: map[string]string{"foo/bar": "abcd"},
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.ImportedBinaries", []interface{}{"", params.ModelArgs{
+			ModelTag: names.NewModelTag("uuid").String(),
+		}}},
+	})
+}
+
+func (s *ClientSuite) TestImportedBinariesNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+
+	_, err := client.ImportedBinaries("uuid")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
 func (s *ClientSuite) TestAbort(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
@@ -4,6 +4,8 @@
 package modelgeneration
 
 import (
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/juju/errors"
@@ -56,6 +58,49 @@ func (c *Client) CommitBranch(branchName string) (int, error) {
 	return result.Result, nil
 }
 
+// StartRollout begins moving the units of the input application onto the
+// input branch in waves of either waveSize units or wavePercent percent of
+// the application's units. The rollout halts if a unit goes into error, or if
+// the units in a wave are not active and idle within the wave timeout.
+func (c *Client) StartRollout(branchName, appName string, waveSize, wavePercent int, waveTimeout time.Duration) error {
+	if c.facade.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("StartRollout not supported by this version of Juju")
+	}
+	arg := params.BranchRolloutArg{
+		BranchName:  branchName,
+		Application: appName,
+		WaveSize:    waveSize,
+		WavePercent: wavePercent,
+		WaveTimeout: waveTimeout,
+	}
+	var result params.ErrorResult
+	if err := c.facade.FacadeCall("StartRollout", arg, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return nil
+}
+
+// AbortBranch aborts the branch with the input name, completing it without
+// applying any of its changes. Units tracking the branch revert to tracking
+// the master generation.
+func (c *Client) AbortBranch(branchName string) error {
+	if c.facade.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("AbortBranch not supported by this version of Juju")
+	}
+	var result params.ErrorResult
+	err := c.facade.FacadeCall("AbortBranch", argForBranch(branchName), &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return errors.Trace(result.Error)
+	}
+	return nil
+}
+
 // TrackBranch sets the input units and/or applications
 // to track changes made under the input branch name.
 func (c *Client) TrackBranch(branchName string, entities []string) error {
@@ -143,6 +188,23 @@ func generationInfoFromResult(
 				ApplicationName: a.ApplicationName,
 				UnitProgress:    a.UnitProgress,
 				ConfigChanges:   a.ConfigChanges,
+				CharmURL:        a.CharmURL,
+				Resources:       a.Resources,
+				Constraints:     a.Constraints,
+			}
+			if r := a.Rollout; r != nil {
+				waveSize := strconv.Itoa(r.WaveSize)
+				if r.WavePercent > 0 {
+					waveSize = fmt.Sprintf("%d%%", r.WavePercent)
+				}
+				bApp.Rollout = &model.GenerationRollout{
+					Status:      r.Status,
+					Message:     r.Message,
+					WaveSize:    waveSize,
+					WaveTimeout: r.WaveTimeout.String(),
+					Wave:        r.Wave,
+					WaveUnits:   r.WaveUnits,
+				}
 			}
 			if detailed {
 				bApp.UnitDetail = &model.GenerationUnits{
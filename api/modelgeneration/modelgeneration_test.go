@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -99,6 +100,66 @@ func (s *modelGenerationSuite) TestCommitBranch(c *gc.C) {
 	c.Check(newGenID, gc.Equals, 2)
 }
 
+func (s *modelGenerationSuite) TestStartRollout(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+	s.fCaller.EXPECT().BestAPIVersion().Return(2)
+
+	arg := params.BranchRolloutArg{
+		BranchName:  s.branchName,
+		Application: "redis",
+		WaveSize:    2,
+		WaveTimeout: time.Minute,
+	}
+	s.fCaller.EXPECT().FacadeCall("StartRollout", arg, gomock.Any()).SetArg(2, params.ErrorResult{}).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.StartRollout(s.branchName, "redis", 2, 0, time.Minute)
+	c.Assert(err, gc.IsNil)
+}
+
+func (s *modelGenerationSuite) TestStartRolloutNotSupported(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+	s.fCaller.EXPECT().BestAPIVersion().Return(1)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.StartRollout(s.branchName, "redis", 2, 0, time.Minute)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *modelGenerationSuite) TestAbortBranch(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+	s.fCaller.EXPECT().BestAPIVersion().Return(2)
+
+	arg := params.BranchArg{BranchName: s.branchName}
+	s.fCaller.EXPECT().FacadeCall("AbortBranch", arg, gomock.Any()).SetArg(2, params.ErrorResult{}).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.AbortBranch(s.branchName)
+	c.Assert(err, gc.IsNil)
+}
+
+func (s *modelGenerationSuite) TestAbortBranchError(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+	s.fCaller.EXPECT().BestAPIVersion().Return(2)
+
+	resultSource := params.ErrorResult{Error: &params.Error{Message: "branch was already committed"}}
+	arg := params.BranchArg{BranchName: s.branchName}
+	s.fCaller.EXPECT().FacadeCall("AbortBranch", arg, gomock.Any()).SetArg(2, resultSource).Return(nil)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.AbortBranch(s.branchName)
+	c.Assert(err, gc.ErrorMatches, "branch was already committed")
+}
+
+func (s *modelGenerationSuite) TestAbortBranchNotSupported(c *gc.C) {
+	defer s.setUpMocks(c).Finish()
+	s.fCaller.EXPECT().BestAPIVersion().Return(1)
+
+	api := modelgeneration.NewStateFromCaller(s.fCaller)
+	err := api.AbortBranch(s.branchName)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *modelGenerationSuite) TestHasActiveBranch(c *gc.C) {
 	defer s.setUpMocks(c).Finish()
 
@@ -126,6 +187,17 @@ func (s *modelGenerationSuite) TestGenerationInfo(c *gc.C) {
 				UnitsTracking:   []string{"redis/0"},
 				UnitsPending:    []string{"redis/1"},
 				ConfigChanges:   map[string]interface{}{"databases": 8},
+				CharmURL:        "cs:redis-2",
+				Resources:       map[string]int{"bin": 3},
+				Constraints:     "mem=4096M",
+				Rollout: &params.BranchRollout{
+					WavePercent: 50,
+					WaveTimeout: 5 * time.Minute,
+					Status:      "halted",
+					Message:     `unit "redis/0" is in error: hook failed`,
+					Wave:        1,
+					WaveUnits:   []string{"redis/0"},
+				},
 			},
 		},
 	}}}
@@ -156,6 +228,17 @@ func (s *modelGenerationSuite) TestGenerationInfo(c *gc.C) {
 					UnitsPending:  []string{"redis/1"},
 				},
 				ConfigChanges: map[string]interface{}{"databases": 8},
+				CharmURL:      "cs:redis-2",
+				Resources:     map[string]int{"bin": 3},
+				Constraints:   "mem=4096M",
+				Rollout: &model.GenerationRollout{
+					Status:      "halted",
+					Message:     `unit "redis/0" is in error: hook failed`,
+					WaveSize:    "50%",
+					WaveTimeout: "5m0s",
+					Wave:        1,
+					WaveUnits:   []string{"redis/0"},
+				},
 			}},
 		},
 	})
@@ -350,6 +350,32 @@ func (c *Client) DumpModel(model names.ModelTag, simplified bool) (map[string]in
 	return asMap, nil
 }
 
+// ExportModel returns the serialized representation of the specified
+// model, along with the charms, agent binaries and resources it uses,
+// in the same form as is used for model migration.
+func (c *Client) ExportModel(model names.ModelTag) (params.SerializedModel, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 8 {
+		return params.SerializedModel{}, errors.NotSupportedf("exporting models with ModelManager v%d", bestVer)
+	}
+
+	var results params.SerializedModelResults
+	entities := params.Entities{
+		Entities: []params.Entity{{Tag: model.String()}},
+	}
+	err := c.facade.FacadeCall("ExportModels", entities, &results)
+	if err != nil {
+		return params.SerializedModel{}, errors.Trace(err)
+	}
+	if count := len(results.Results); count != 1 {
+		return params.SerializedModel{}, errors.Errorf("unexpected result count: %d", count)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.SerializedModel{}, result.Error
+	}
+	return *result.Result, nil
+}
+
 func (c *Client) dumpModelV2(model names.ModelTag) (map[string]interface{}, error) {
 	var results params.MapResults
 	entities := params.Entities{
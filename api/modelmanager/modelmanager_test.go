@@ -813,6 +813,67 @@ func (s *dumpModelSuite) TestDumpModelErrorV2(c *gc.C) {
 	c.Assert(out, gc.IsNil)
 }
 
+func (s *dumpModelSuite) TestExportModel(c *gc.C) {
+	expected := params.SerializedModel{
+		Bytes:  []byte("model-uuid: some-uuid\n"),
+		Charms: []string{"cs:mysql-1"},
+		Tools: []params.SerializedModelTools{{
+			Version: "2.6.0-bionic-amd64",
+			URI:     "/tools/2.6.0-bionic-amd64",
+		}},
+	}
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 8,
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, args, result interface{}) error {
+				c.Check(objType, gc.Equals, "ModelManager")
+				c.Check(request, gc.Equals, "ExportModels")
+				c.Check(version, gc.Equals, 8)
+				c.Assert(args, gc.DeepEquals, params.Entities{[]params.Entity{{coretesting.ModelTag.String()}}})
+				res, ok := result.(*params.SerializedModelResults)
+				c.Assert(ok, jc.IsTrue)
+				*res = params.SerializedModelResults{Results: []params.SerializedModelResult{{
+					Result: &expected,
+				}}}
+				return nil
+			}),
+	}
+	client := modelmanager.NewClient(apiCaller)
+	out, err := client.ExportModel(coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, jc.DeepEquals, expected)
+}
+
+func (s *dumpModelSuite) TestExportModelError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 8,
+		APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, args, result interface{}) error {
+			res, ok := result.(*params.SerializedModelResults)
+			c.Assert(ok, jc.IsTrue)
+			*res = params.SerializedModelResults{Results: []params.SerializedModelResult{{
+				Error: &params.Error{Message: "fake error"},
+			}}}
+			return nil
+		}),
+	}
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.ExportModel(coretesting.ModelTag)
+	c.Assert(err, gc.ErrorMatches, "fake error")
+}
+
+func (s *dumpModelSuite) TestExportModelNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 7,
+		APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, args, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		}),
+	}
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.ExportModel(coretesting.ModelTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *dumpModelSuite) TestDumpModelDB(c *gc.C) {
 	expected := map[string]interface{}{
 		"models": []map[string]interface{}{{
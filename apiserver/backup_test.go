@@ -40,8 +40,8 @@ func (s *backupsCommonSuite) SetUpTest(c *gc.C) {
 	s.backupURL = s.server.URL + fmt.Sprintf("/model/%s/backups", s.State.ModelUUID())
 	s.fake = &backupstesting.FakeBackups{}
 	s.PatchValue(apiserver.NewBackups,
-		func(st *state.State, m *state.Model) (backups.Backups, io.Closer) {
-			return s.fake, ioutil.NopCloser(nil)
+		func(st *state.State, m *state.Model) (backups.Backups, io.Closer, error) {
+			return s.fake, ioutil.NopCloser(nil), nil
 		},
 	)
 }
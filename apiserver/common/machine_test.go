@@ -251,6 +251,7 @@ type mockMachine struct {
 	hasVote, wantsVote bool
 	status             status.Status
 	statusErr          error
+	instanceStatus     status.Status
 	destroyErr         error
 	forceDestroyErr    error
 	forceDestroyCalled bool
@@ -290,6 +291,12 @@ func (m *mockMachine) Status() (status.StatusInfo, error) {
 	}, m.statusErr
 }
 
+func (m *mockMachine) InstanceStatus() (status.StatusInfo, error) {
+	return status.StatusInfo{
+		Status: m.instanceStatus,
+	}, nil
+}
+
 func (m *mockMachine) HardwareCharacteristics() (*instance.HardwareCharacteristics, error) {
 	return m.hw, nil
 }
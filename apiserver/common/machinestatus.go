@@ -12,13 +12,15 @@ import (
 // required to status.
 type MachineStatusGetter interface {
 	Status() (status.StatusInfo, error)
+	InstanceStatus() (status.StatusInfo, error)
 	AgentPresence() (bool, error)
 	Id() string
 	Life() state.Life
 }
 
 // MachineStatus returns the machine agent status for a given
-// machine, with special handling for agent presence.
+// machine, with special handling for agent presence and for
+// instances preempted by the cloud.
 func (c *ModelPresenceContext) MachineStatus(machine MachineStatusGetter) (status.StatusInfo, error) {
 	machineStatus, err := machine.Status()
 	if err != nil {
@@ -31,6 +33,19 @@ func (c *ModelPresenceContext) MachineStatus(machine MachineStatusGetter) (statu
 		return machineStatus, nil
 	}
 
+	if machine.Life() != state.Dead {
+		instanceStatus, err := machine.InstanceStatus()
+		if err != nil {
+			logger.Debugf("error getting instance status for machine %s: %v", machine.Id(), err)
+		} else if instanceStatus.Status == status.Preempted {
+			// The agent can't be running without its instance,
+			// whatever its presence says.
+			machineStatus.Status = status.Down
+			machineStatus.Message = "instance was preempted by the cloud"
+			return machineStatus, nil
+		}
+	}
+
 	agentAlive, err := c.machinePresence(machine)
 	if err != nil {
 		// We don't want any presence errors affecting status.
@@ -113,3 +113,20 @@ func (s *MachineStatusSuite) TestNotDownIfPending(c *gc.C) {
 	s.machine.status = status.Pending
 	s.checkUntouched(c)
 }
+
+func (s *MachineStatusSuite) TestDownIfPreempted(c *gc.C) {
+	s.machine.instanceStatus = status.Preempted
+	agent, err := s.ctx.MachineStatus(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(agent, jc.DeepEquals, status.StatusInfo{
+		Status:  status.Down,
+		Message: "instance was preempted by the cloud",
+	})
+}
+
+func (s *MachineStatusSuite) TestPreemptedAndDead(c *gc.C) {
+	s.machine.instanceStatus = status.Preempted
+	s.machine.life = state.Dead
+	// Status is untouched if machine is Dead.
+	s.checkUntouched(c)
+}
@@ -413,6 +413,11 @@ func (p *ProvisionerAPI) ContainerManagerConfig(args params.ContainerManagerConf
 		cfg[config.ContainerImageMetadataURLKey] = url
 	}
 	cfg[config.ContainerImageStreamKey] = mConfig.ContainerImageStream()
+	if args.Type == instance.LXD {
+		if project := mConfig.ContainerLXDProject(); project != "" {
+			cfg[config.ContainerLXDProjectKey] = project
+		}
+	}
 
 	result.ManagerConfig = cfg
 	return result, nil
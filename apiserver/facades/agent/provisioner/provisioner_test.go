@@ -1431,12 +1431,23 @@ func (s *withImageMetadataSuite) SetUpTest(c *gc.C) {
 	s.ConfigAttrs = map[string]interface{}{
 		config.ContainerImageStreamKey:      "daily",
 		config.ContainerImageMetadataURLKey: "https://images.linuxcontainers.org/",
+		config.ContainerLXDProjectKey:       "juju",
 	}
 	s.setUpTest(c, false)
 }
 
 func (s *withImageMetadataSuite) TestContainerManagerConfigImageMetadata(c *gc.C) {
 	cfg := s.getManagerConfig(c, instance.LXD)
+	c.Assert(cfg, jc.DeepEquals, map[string]string{
+		container.ConfigModelUUID:           coretesting.ModelTag.Id(),
+		config.ContainerImageStreamKey:      "daily",
+		config.ContainerImageMetadataURLKey: "https://images.linuxcontainers.org/",
+		config.ContainerLXDProjectKey:       "juju",
+	})
+}
+
+func (s *withImageMetadataSuite) TestContainerManagerConfigNoLXDProjectForKVM(c *gc.C) {
+	cfg := s.getManagerConfig(c, instance.KVM)
 	c.Assert(cfg, jc.DeepEquals, map[string]string{
 		container.ConfigModelUUID:           coretesting.ModelTag.Id(),
 		config.ContainerImageStreamKey:      "daily",
@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/environs/tags"
@@ -102,20 +103,26 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine, env environs.Envi
 		return nil, errors.Annotate(err, "cannot get controller configuration")
 	}
 
+	appUserData, err := p.machineApplicationCloudInitUserData(m)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get application cloud-init user data")
+	}
+
 	return &params.ProvisioningInfo{
-		Constraints:       cons,
-		Series:            m.Series(),
-		Placement:         m.Placement(),
-		Jobs:              jobs,
-		Volumes:           volumes,
-		VolumeAttachments: volumeAttachments,
-		Tags:              tags,
-		SubnetsToZones:    subnetsToZones,
-		EndpointBindings:  endpointBindings,
-		ImageMetadata:     imageMetadata,
-		ControllerConfig:  controllerCfg,
-		CloudInitUserData: env.Config().CloudInitUserData(),
-		CharmLXDProfiles:  pNames,
+		Constraints:                  cons,
+		Series:                       m.Series(),
+		Placement:                    m.Placement(),
+		Jobs:                         jobs,
+		Volumes:                      volumes,
+		VolumeAttachments:            volumeAttachments,
+		Tags:                         tags,
+		SubnetsToZones:               subnetsToZones,
+		EndpointBindings:             endpointBindings,
+		ImageMetadata:                imageMetadata,
+		ControllerConfig:             controllerCfg,
+		CloudInitUserData:            env.Config().CloudInitUserData(),
+		CharmLXDProfiles:             pNames,
+		ApplicationCloudInitUserData: appUserData,
 	}, nil
 }
 
@@ -350,6 +357,41 @@ func (p *ProvisionerAPI) machineLXDProfileNames(m *state.Machine, env environs.E
 	return names, nil
 }
 
+// machineApplicationCloudInitUserData returns the cloud-init user data
+// configured for the applications with units assigned to the machine,
+// merged in application name order.
+func (p *ProvisionerAPI) machineApplicationCloudInitUserData(m *state.Machine) (map[string]interface{}, error) {
+	units, err := m.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	appNames := set.NewStrings()
+	for _, unit := range units {
+		appNames.Add(unit.ApplicationName())
+	}
+	var userData map[string]interface{}
+	for _, appName := range appNames.SortedValues() {
+		app, err := p.st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		appConfig, err := app.ApplicationConfig()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		raw := appConfig.GetString(config.CloudInitUserDataKey, "")
+		if raw == "" {
+			continue
+		}
+		appUserData, err := config.ParseCloudInitUserData(raw)
+		if err != nil {
+			return nil, errors.Annotatef(err, "application %q", appName)
+		}
+		userData = instancecfg.MergeCloudInitUserData(userData, appUserData)
+	}
+	return userData, nil
+}
+
 func (p *ProvisionerAPI) machineEndpointBindings(m *state.Machine) (map[string]string, error) {
 	units, err := m.Units()
 	if err != nil {
@@ -8,11 +8,13 @@ import (
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/facades/agent/provisioner"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/testing"
@@ -463,6 +465,44 @@ func (s *withoutControllerSuite) TestProviderInfoCloudInitUserData(c *gc.C) {
 		"package_upgrade": false})
 }
 
+func (s *withoutControllerSuite) TestProviderInfoApplicationCloudInitUserData(c *gc.C) {
+	m, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	fields := environschema.Fields{
+		"cloudinit-userdata": {Type: environschema.Tstring},
+	}
+	for name, userData := range map[string]string{
+		"wordpress": "packages: [htop]\npostruncmd: [touch /tmp/wordpress]",
+		"mysql":     "packages: [sysstat]\npackage_upgrade: true",
+	} {
+		app := s.AddTestingApplication(c, name, s.AddTestingCharm(c, name))
+		err := app.UpdateApplicationConfig(coreapplication.ConfigAttributes{
+			"cloudinit-userdata": userData,
+		}, nil, fields, nil)
+		c.Assert(err, jc.ErrorIsNil)
+		unit, err := app.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.AssignToMachine(m)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: m.Tag().String()},
+	}}
+	result, err := s.provisioner.ProvisioningInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Result.ApplicationCloudInitUserData, jc.DeepEquals, map[string]interface{}{
+		"packages":        []interface{}{"sysstat", "htop"},
+		"postruncmd":      []interface{}{"touch /tmp/wordpress"},
+		"package_upgrade": true,
+	})
+}
+
 var validCloudInitUserData = `
 packages:
   - 'python-keystoneclient'
@@ -850,10 +850,16 @@ func (u *UniterAPI) CharmURL(args params.Entities) (params.StringBoolResults, er
 			var unitOrApplication state.Entity
 			unitOrApplication, err = u.st.FindEntity(tag)
 			if err == nil {
-				charmURLer := unitOrApplication.(interface {
-					CharmURL() (*charm.URL, bool)
-				})
-				curl, ok := charmURLer.CharmURL()
+				var curl *charm.URL
+				var ok bool
+				if app, isApp := unitOrApplication.(*state.Application); isApp {
+					curl, ok, err = u.applicationCharmURL(app)
+				} else {
+					charmURLer := unitOrApplication.(interface {
+						CharmURL() (*charm.URL, bool)
+					})
+					curl, ok = charmURLer.CharmURL()
+				}
 				if curl != nil {
 					result.Results[i].Result = curl.String()
 					result.Results[i].Ok = ok
@@ -865,6 +871,83 @@ func (u *UniterAPI) CharmURL(args params.Entities) (params.StringBoolResults, er
 	return result, nil
 }
 
+// Watch starts a NotifyWatcher for each given unit or application.
+// When a unit watches its own application, the watcher also triggers
+// on changes to the model's branches, so that the unit is notified
+// when the charm URL of the branch it is tracking changes.
+func (u *UniterAPI) Watch(args params.Entities) (params.NotifyWatchResults, error) {
+	unitTag, isUnit := u.auth.GetAuthTag().(names.UnitTag)
+	if !isUnit {
+		return u.AgentEntityWatcher.Watch(args)
+	}
+	appName, err := names.UnitApplication(unitTag.Id())
+	if err != nil {
+		return params.NotifyWatchResults{}, errors.Trace(err)
+	}
+	appTag := names.NewApplicationTag(appName).String()
+
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		if entity.Tag == appTag {
+			watcherId, err := u.watchApplicationCharmURL(appName)
+			result.Results[i].NotifyWatcherId = watcherId
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		entityResult, err := u.AgentEntityWatcher.Watch(params.Entities{
+			Entities: []params.Entity{entity},
+		})
+		if err != nil {
+			return params.NotifyWatchResults{}, errors.Trace(err)
+		}
+		result.Results[i] = entityResult.Results[0]
+	}
+	return result, nil
+}
+
+// watchApplicationCharmURL returns the ID of a NotifyWatcher that
+// triggers on changes to the application or to the model's branches.
+func (u *UniterAPI) watchApplicationCharmURL(appName string) (string, error) {
+	app, err := u.st.Application(appName)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	watch := common.NewMultiNotifyWatcher(app.Watch(), u.st.WatchBranches())
+	// Consume the initial event.
+	if _, ok := <-watch.Changes(); ok {
+		return u.resources.Register(watch), nil
+	}
+	return "", watcher.EnsureErr(watch)
+}
+
+// applicationCharmURL returns the charm URL of the input application.
+// When requested by a unit of the application, the charm URL set under the
+// branch that the unit is tracking is returned in preference, so that only
+// tracking units are upgraded to it.
+func (u *UniterAPI) applicationCharmURL(app *state.Application) (*charm.URL, bool, error) {
+	unitTag, isUnit := u.auth.GetAuthTag().(names.UnitTag)
+	if !isUnit {
+		curl, ok := app.CharmURL()
+		return curl, ok, nil
+	}
+	unit, err := u.getUnit(unitTag)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if unit.ApplicationName() != app.Name() {
+		curl, ok := app.CharmURL()
+		return curl, ok, nil
+	}
+	curl, err := unit.TrackedCharmURL()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	_, force := app.CharmURL()
+	return curl, force, nil
+}
+
 // SetCharmURL sets the charm URL for each given unit. An error will
 // be returned if a unit is dead, or the charm URL is not know.
 func (u *UniterAPI) SetCharmURL(args params.EntitiesCharmURL) (params.ErrorResults, error) {
@@ -868,6 +868,57 @@ func (s *uniterSuite) TestCharmURL(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestCharmURLTrackingBranch(c *gc.C) {
+	c.Assert(s.State.AddBranch("new-branch", "test-user"), jc.ErrorIsNil)
+	branch, err := s.State.Branch("new-branch")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(branch.AssignUnit(s.wordpressUnit.Name()), jc.ErrorIsNil)
+
+	newCharm := s.Factory.MakeCharm(c, &factory.CharmParams{
+		Name: "wordpress",
+		URL:  "cs:quantal/wordpress-4",
+	})
+	err = s.wordpress.SetBranchCharm("new-branch", state.SetCharmConfig{Charm: newCharm})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The unit tracking the branch is directed to the branch charm.
+	args := params.Entities{Entities: []params.Entity{{Tag: "application-wordpress"}}}
+	result, err := s.uniter.CharmURL(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringBoolResults{
+		Results: []params.StringBoolResult{{Result: newCharm.String()}},
+	})
+}
+
+func (s *uniterSuite) TestWatchApplicationTrackingBranch(c *gc.C) {
+	c.Assert(s.State.AddBranch("new-branch", "test-user"), jc.ErrorIsNil)
+	branch, err := s.State.Branch("new-branch")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(branch.AssignUnit(s.wordpressUnit.Name()), jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{{Tag: "application-wordpress"}}}
+	result, err := s.uniter.Watch(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{{NotifyWatcherId: "1"}},
+	})
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	// Setting the charm under the branch notifies the unit, so that
+	// it can upgrade to the branch charm.
+	newCharm := s.Factory.MakeCharm(c, &factory.CharmParams{
+		Name: "wordpress",
+		URL:  "cs:quantal/wordpress-4",
+	})
+	err = s.wordpress.SetBranchCharm("new-branch", state.SetCharmConfig{Charm: newCharm})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *uniterSuite) TestSetCharmURL(c *gc.C) {
 	_, ok := s.wordpressUnit.CharmURL()
 	c.Assert(ok, jc.IsFalse)
@@ -74,6 +74,11 @@ type APIv8 struct {
 
 // APIv9 provides the Application API facade for version 9.
 type APIv9 struct {
+	*APIv10
+}
+
+// APIv10 provides the Application API facade for version 10.
+type APIv10 struct {
 	*APIBase
 }
 
@@ -155,13 +160,23 @@ func NewFacadeV8(ctx facade.Context) (*APIv8, error) {
 }
 
 func NewFacadeV9(ctx facade.Context) (*APIv9, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV10(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv9{api}, nil
 }
 
+// NewFacadeV10 provides the signature required for facade registration
+// for version 10.
+func NewFacadeV10(ctx facade.Context) (*APIv10, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv10{api}, nil
+}
+
 func newFacadeBase(ctx facade.Context) (*APIBase, error) {
 	model, err := ctx.State().Model()
 	if err != nil {
@@ -371,7 +386,7 @@ func (api *APIBase) Deploy(args params.ApplicationsDeploy) (params.ErrorResults,
 
 func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, schema.Defaults, error) {
 	if modelType != state.ModelTypeCAAS {
-		return trustFields, trustDefaults, nil
+		return AddTrustSchemaAndDefaults(cloudInitFields, nil)
 	}
 	// TODO(caas) - get the schema from the provider
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
@@ -571,6 +586,9 @@ func deployApplication(
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := validateCloudInitUserData(applicationConfig.Attributes()); err != nil {
+		return errors.Trace(err)
+	}
 
 	var settings = make(charm.Settings)
 	if len(charmYamlConfig) > 0 {
@@ -721,6 +739,7 @@ func parseSettingsCompatible(charmConfig *charm.Config, settings map[string]stri
 type setCharmParams struct {
 	AppName               string
 	Application           Application
+	BranchName            string
 	Channel               csparams.Channel
 	ConfigSettingsStrings map[string]string
 	ConfigSettingsYAML    string
@@ -869,6 +888,14 @@ func (api *APIBase) updateOneApplicationSeries(arg params.UpdateSeriesArg) error
 	return app.UpdateApplicationSeries(arg.Series, arg.Force)
 }
 
+// SetCharm sets the charm for a given for the application.
+// Version 9 and below always upgrade the application on the master
+// generation; charm upgrades under a branch were added in version 10.
+func (api *APIv9) SetCharm(args params.ApplicationSetCharm) error {
+	args.Generation = model.GenerationMaster
+	return api.APIBase.SetCharm(args)
+}
+
 // SetCharm sets the charm for a given for the application.
 func (api *APIBase) SetCharm(args params.ApplicationSetCharm) error {
 	if err := api.checkCanWrite(); err != nil {
@@ -889,6 +916,7 @@ func (api *APIBase) SetCharm(args params.ApplicationSetCharm) error {
 		setCharmParams{
 			AppName:               args.ApplicationName,
 			Application:           application,
+			BranchName:            args.Generation,
 			Channel:               channel,
 			ConfigSettingsStrings: args.ConfigSettings,
 			ConfigSettingsYAML:    args.ConfigSettingsYAML,
@@ -997,7 +1025,13 @@ func (api *APIBase) applicationSetCharm(
 		ResourceIDs:        params.ResourceIDs,
 		StorageConstraints: stateStorageConstraints,
 	}
-	return params.Application.SetCharm(cfg)
+	if params.BranchName == "" || params.BranchName == model.GenerationMaster {
+		return params.Application.SetCharm(cfg)
+	}
+	if err := params.Application.SetBranchCharm(params.BranchName, cfg); err != nil {
+		return errors.Trace(err)
+	}
+	return api.addAppToBranch(params.BranchName, params.AppName)
 }
 
 // charmConfigFromGetYaml will parse a yaml produced by juju get and generate
@@ -1652,6 +1686,14 @@ func (api *APIBase) getConstraints(entity string) (constraints.Value, error) {
 	}
 }
 
+// SetConstraints sets the constraints for a given application.
+// Version 9 and below always set the application constraints on the
+// master generation; constraints under a branch were added in version 10.
+func (api *APIv9) SetConstraints(args params.SetConstraints) error {
+	args.BranchName = model.GenerationMaster
+	return api.APIBase.SetConstraints(args)
+}
+
 // SetConstraints sets the constraints for a given application.
 func (api *APIBase) SetConstraints(args params.SetConstraints) error {
 	if err := api.checkCanWrite(); err != nil {
@@ -1664,7 +1706,13 @@ func (api *APIBase) SetConstraints(args params.SetConstraints) error {
 	if err != nil {
 		return err
 	}
-	return app.SetConstraints(args.Constraints)
+	if args.BranchName == "" || args.BranchName == model.GenerationMaster {
+		return app.SetConstraints(args.Constraints)
+	}
+	if err := app.SetBranchConstraints(args.BranchName, args.Constraints); err != nil {
+		return errors.Trace(err)
+	}
+	return api.addAppToBranch(args.BranchName, args.ApplicationName)
 }
 
 // AddRelation adds a relation between the specified endpoints and returns the relation info.
@@ -2119,6 +2167,9 @@ func (api *APIBase) setApplicationConfig(arg params.ApplicationConfigSet) error
 	}
 
 	if len(appConfigAttrs) > 0 {
+		if err := validateCloudInitUserData(appConfigAttrs); err != nil {
+			return errors.Trace(err)
+		}
 		if err := app.UpdateApplicationConfig(appConfigAttrs, nil, schema, defaults); err != nil {
 			return errors.Annotate(err, "updating application config values")
 		}
@@ -52,7 +52,7 @@ type ApplicationSuite struct {
 	env              environs.Environ
 	blockChecker     mockBlockChecker
 	authorizer       apiservertesting.FakeAuthorizer
-	api              *application.APIv9
+	api              *application.APIv10
 	deployParams     map[string]application.DeployApplicationParams
 }
 
@@ -80,7 +80,7 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		s.storageValidator,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv9{api}
+	s.api = &application.APIv10{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -324,6 +324,50 @@ func (s *ApplicationSuite) TestSetCharmConfigSettings(c *gc.C) {
 	})
 }
 
+func (s *ApplicationSuite) TestSetCharmBranch(c *gc.C) {
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+		Generation:      "new-branch",
+		ResourceIDs:     map[string]string{"blob": "pending-id"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCall(c, 2, "SetBranchCharm", "new-branch", state.SetCharmConfig{
+		Charm:       &state.Charm{},
+		ResourceIDs: map[string]string{"blob": "pending-id"},
+	})
+	s.backend.generation.CheckCall(c, 0, "AssignApplication", "postgresql")
+}
+
+func (s *ApplicationSuite) TestSetConstraintsBranch(c *gc.C) {
+	cons := constraints.MustParse("mem=4G")
+	err := s.api.SetConstraints(params.SetConstraints{
+		ApplicationName: "postgresql",
+		Constraints:     cons,
+		BranchName:      "new-branch",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "SetBranchConstraints")
+	app.CheckCall(c, 0, "SetBranchConstraints", "new-branch", cons)
+	s.backend.generation.CheckCall(c, 0, "AssignApplication", "postgresql")
+}
+
+func (s *ApplicationSuite) TestSetConstraintsBranchV9(c *gc.C) {
+	cons := constraints.MustParse("mem=4G")
+	apiV9 := &application.APIv9{s.api}
+	err := apiV9.SetConstraints(params.SetConstraints{
+		ApplicationName: "postgresql",
+		Constraints:     cons,
+		BranchName:      "new-branch",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "SetConstraints")
+	app.CheckCall(c, 0, "SetConstraints", cons)
+}
+
 func (s *ApplicationSuite) TestSetCharmConfigSettingsYAML(c *gc.C) {
 	err := s.api.SetCharm(params.ApplicationSetCharm{
 		ApplicationName: "postgresql",
@@ -1467,6 +1511,39 @@ func (s *ApplicationSuite) TestSetApplicationConfigBranch(c *gc.C) {
 	s.backend.generation.CheckCall(c, 0, "AssignApplication", "postgresql")
 }
 
+func (s *ApplicationSuite) TestSetApplicationConfigCloudInitUserData(c *gc.C) {
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"cloudinit-userdata": "packages: [htop]",
+			},
+			Generation: model.GenerationMaster,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "UpdateApplicationConfig")
+	c.Assert(app.Calls()[0].Args[0], jc.DeepEquals, coreapplication.ConfigAttributes{
+		"cloudinit-userdata": "packages: [htop]",
+	})
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigInvalidCloudInitUserData(c *gc.C) {
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"cloudinit-userdata": "runcmd: [ls]",
+			},
+			Generation: model.GenerationMaster,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches,
+		"cloudinit-userdata: runcmd not allowed, use preruncmd or postruncmd instead")
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestBlockSetApplicationConfig(c *gc.C) {
 	s.blockChecker.SetErrors(errors.New("blocked"))
 	_, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{})
@@ -80,7 +80,9 @@ type Application interface {
 	IsRemote() bool
 	Series() string
 	SetCharm(state.SetCharmConfig) error
+	SetBranchCharm(string, state.SetCharmConfig) error
 	SetConstraints(constraints.Value) error
+	SetBranchConstraints(string, constraints.Value) error
 	SetExposed() error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
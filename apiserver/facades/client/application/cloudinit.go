@@ -0,0 +1,33 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/environs/config"
+)
+
+// cloudInitFields holds the application config fields used to customise
+// the machines provisioned for the units of IAAS applications.
+var cloudInitFields = environschema.Fields{
+	config.CloudInitUserDataKey: {
+		Description: "Cloud-init user data added to the model's cloudinit-userdata",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+}
+
+// validateCloudInitUserData returns an error if the application config
+// attributes contain cloud-init user data that would not be accepted as
+// the model's cloudinit-userdata.
+func validateCloudInitUserData(attrs map[string]interface{}) error {
+	raw, ok := attrs[config.CloudInitUserDataKey].(string)
+	if !ok || raw == "" {
+		return nil
+	}
+	_, err := config.ParseCloudInitUserData(raw)
+	return errors.Annotate(err, config.CloudInitUserDataKey)
+}
@@ -159,6 +159,21 @@ func (a *mockApplication) SetCharm(cfg state.SetCharmConfig) error {
 	return a.NextErr()
 }
 
+func (a *mockApplication) SetBranchCharm(branchName string, cfg state.SetCharmConfig) error {
+	a.MethodCall(a, "SetBranchCharm", branchName, cfg)
+	return a.NextErr()
+}
+
+func (a *mockApplication) SetConstraints(cons constraints.Value) error {
+	a.MethodCall(a, "SetConstraints", cons)
+	return a.NextErr()
+}
+
+func (a *mockApplication) SetBranchConstraints(branchName string, cons constraints.Value) error {
+	a.MethodCall(a, "SetBranchConstraints", branchName, cons)
+	return a.NextErr()
+}
+
 func (a *mockApplication) DestroyOperation() *state.DestroyApplicationOperation {
 	a.MethodCall(a, "DestroyOperation")
 	return &state.DestroyApplicationOperation{}
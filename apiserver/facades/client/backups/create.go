@@ -8,7 +8,6 @@ import (
 	"github.com/juju/replicaset"
 
 	"github.com/juju/juju/apiserver/params"
-	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state/backups"
 )
 
@@ -30,8 +29,41 @@ func (a *API) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResul
 	return result, nil
 }
 
+// Create is the API method that requests juju to create a new backup
+// of its state.  It returns the metadata for that backup.
+//
+// Encryption of the backup archive is only supported by version 3
+// of the facade.
 func (a *APIv2) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
-	backupsMethods, closer := newBackups(a.backend)
+	if args.Encryption != nil {
+		return params.BackupsMetadataResult{}, errors.NotSupportedf("backup encryption")
+	}
+	return a.create(args)
+}
+
+// Create is the API method that requests juju to create a new backup
+// of its state, optionally encrypting the backup archive.  It returns
+// the metadata for that backup.
+func (a *APIv3) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
+	return a.create(args)
+}
+
+func (a *APIv2) create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
+	var encryption *backups.EncryptionKey
+	if args.Encryption != nil {
+		encryption = &backups.EncryptionKey{
+			Passphrase: args.Encryption.Passphrase,
+			PublicKey:  args.Encryption.PublicKey,
+		}
+		if err := encryption.Validate(); err != nil {
+			return params.BackupsMetadataResult{}, errors.Trace(err)
+		}
+	}
+
+	backupsMethods, closer, err := newBackups(a.backend)
+	if err != nil {
+		return params.BackupsMetadataResult{}, errors.Trace(err)
+	}
 	defer closer.Close()
 
 	session := a.backend.MongoSession().Copy()
@@ -39,7 +71,7 @@ func (a *APIv2) Create(args params.BackupsCreateArgs) (params.BackupsMetadataRes
 
 	result := params.BackupsMetadataResult{}
 	// Don't go if HA isn't ready.
-	err := waitUntilReady(session, 60)
+	err = waitUntilReady(session, 60)
 	if err != nil {
 		return result, errors.Annotatef(err, "HA not ready; try again later")
 	}
@@ -48,30 +80,13 @@ func (a *APIv2) Create(args params.BackupsCreateArgs) (params.BackupsMetadataRes
 	if err != nil {
 		return result, errors.Annotatef(err, "getting mongo info")
 	}
-	v, err := a.backend.MongoVersion()
-	if err != nil {
-		return result, errors.Annotatef(err, "discovering mongo version")
-	}
-	mongoVersion, err := mongo.NewVersion(v)
-	if err != nil {
-		return result, errors.Trace(err)
-	}
-	dbInfo, err := backups.NewDBInfo(mgoInfo, session, mongoVersion)
-	if err != nil {
-		return result, errors.Trace(err)
-	}
-	mSeries, err := a.backend.MachineSeries(a.machineID)
-	if err != nil {
-		return result, errors.Trace(err)
-	}
-
-	meta, err := backups.NewMetadataState(a.backend, a.machineID, mSeries)
+	meta, dbInfo, err := backups.PrepareBackup(a.backend, session, mgoInfo, a.machineID)
 	if err != nil {
 		return result, errors.Trace(err)
 	}
 	meta.Notes = args.Notes
 
-	fileName, err := backupsMethods.Create(meta, a.paths, dbInfo, args.KeepCopy, args.NoDownload)
+	fileName, err := backupsMethods.Create(meta, a.paths, dbInfo, args.KeepCopy, args.NoDownload, encryption)
 	if err != nil {
 		return result, errors.Trace(err)
 	}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/apiserver/facades/client/backups"
 	"github.com/juju/juju/apiserver/params"
+	statebackups "github.com/juju/juju/state/backups"
 )
 
 func (s *backupsSuite) TestCreateOkay(c *gc.C) {
@@ -54,3 +55,41 @@ func (s *backupsSuite) TestCreateError(c *gc.C) {
 	c.Logf("%v", err)
 	c.Check(err, gc.ErrorMatches, "failed!")
 }
+
+func (s *backupsSuite) TestCreateEncrypted(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	fake := s.setBackups(c, s.meta, "")
+	api, err := backups.NewAPIv3(&stateShim{State: s.State, Model: s.Model}, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.BackupsCreateArgs{
+		Encryption: &params.BackupsEncryptionKey{Passphrase: "sekrit"},
+	}
+
+	_, err = api.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fake.EncryptionArg, jc.DeepEquals, &statebackups.EncryptionKey{Passphrase: "sekrit"})
+}
+
+func (s *backupsSuite) TestCreateEncryptedNotSupported(c *gc.C) {
+	api, err := backups.NewAPIv2(&stateShim{State: s.State, Model: s.Model}, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.BackupsCreateArgs{
+		Encryption: &params.BackupsEncryptionKey{Passphrase: "sekrit"},
+	}
+
+	_, err = api.Create(args)
+	c.Check(err, gc.ErrorMatches, "backup encryption not supported")
+}
+
+func (s *backupsSuite) TestCreateEncryptedInvalidKey(c *gc.C) {
+	api, err := backups.NewAPIv3(&stateShim{State: s.State, Model: s.Model}, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.BackupsCreateArgs{
+		Encryption: &params.BackupsEncryptionKey{},
+	}
+
+	_, err = api.Create(args)
+	c.Check(err, gc.ErrorMatches, "empty encryption key not valid")
+}
@@ -0,0 +1,39 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Schedule returns the scheduled backup configuration of the
+// controller, along with the outcome of the last scheduled backup.
+func (a *APIv3) Schedule() (params.BackupsScheduleResult, error) {
+	var result params.BackupsScheduleResult
+
+	cfg, err := a.backend.ControllerConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Interval = cfg.BackupScheduleInterval()
+	result.RetentionCount = cfg.BackupRetentionCount()
+	result.RetentionDaily = cfg.BackupRetentionDaily()
+	result.RetentionWeekly = cfg.BackupRetentionWeekly()
+
+	info, err := a.backend.ScheduledBackupStatus()
+	if errors.IsNotFound(err) {
+		return result, nil
+	} else if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.LastStatus = &params.EntityStatus{
+		Status: info.Status,
+		Info:   info.Message,
+		Data:   info.Data,
+		Since:  info.Since,
+	}
+	return result, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	backupsAPI "github.com/juju/juju/apiserver/facades/client/backups"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/status"
+)
+
+func (s *backupsSuite) newAPIv3(c *gc.C) *backupsAPI.APIv3 {
+	api, err := backupsAPI.NewAPIv3(&stateShim{State: s.State, Model: s.Model}, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *backupsSuite) TestScheduleDisabled(c *gc.C) {
+	result, err := s.newAPIv3(c).Schedule()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Interval, gc.Equals, time.Duration(0))
+	c.Check(result.RetentionCount, gc.Equals, controller.DefaultBackupRetentionCount)
+	c.Check(result.LastStatus, gc.IsNil)
+}
+
+func (s *backupsSuite) TestSchedule(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.BackupScheduleInterval: "12h",
+		controller.BackupRetentionCount:   3,
+		controller.BackupRetentionDaily:   5,
+		controller.BackupRetentionWeekly:  2,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	now := time.Now()
+	err = s.State.SetScheduledBackupStatus(status.StatusInfo{
+		Status:  status.Available,
+		Message: "created backup backup-id",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.newAPIv3(c).Schedule()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Interval, gc.Equals, 12*time.Hour)
+	c.Check(result.RetentionCount, gc.Equals, 3)
+	c.Check(result.RetentionDaily, gc.Equals, 5)
+	c.Check(result.RetentionWeekly, gc.Equals, 2)
+	c.Assert(result.LastStatus, gc.NotNil)
+	c.Check(result.LastStatus.Status, gc.Equals, status.Available)
+	c.Check(result.LastStatus.Info, gc.Equals, "created backup backup-id")
+}
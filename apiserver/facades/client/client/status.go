@@ -76,6 +76,13 @@ func (c *Client) unitStatusHistory(unitTag names.UnitTag, filter status.StatusHi
 		}
 		statuses = append(statuses, agentStatusFromStatusInfo(agentStatuses, status.KindUnitAgent)...)
 	}
+	if kind == status.KindUnit || kind == status.KindCloudEvent {
+		events, err := unit.CloudContainerEventHistory().StatusHistory(filter)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		statuses = append(statuses, agentStatusFromStatusInfo(events, status.KindCloudEvent)...)
+	}
 
 	sort.Sort(byTime(statuses))
 	if kind == status.KindUnit && filter.Size > 0 {
@@ -87,6 +94,19 @@ func (c *Client) unitStatusHistory(unitTag names.UnitTag, filter status.StatusHi
 	return statuses, nil
 }
 
+// operatorEventHistory returns the events reported by the cloud for the given application's operator.
+func (c *Client) operatorEventHistory(appTag names.ApplicationTag, filter status.StatusHistoryFilter) ([]params.DetailedStatus, error) {
+	app, err := c.api.stateAccessor.Application(appTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	events, err := app.OperatorEventHistory().StatusHistory(filter)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return agentStatusFromStatusInfo(events, status.KindCloudEvent), nil
+}
+
 // machineStatusHistory returns status history for the given machine.
 func (c *Client) machineStatusHistory(machineTag names.MachineTag, filter status.StatusHistoryFilter, kind status.HistoryKind) ([]params.DetailedStatus, error) {
 	machine, err := c.api.stateAccessor.Machine(machineTag.Id())
@@ -146,6 +166,18 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 			if u, err = names.ParseUnitTag(request.Tag); err == nil {
 				hist, err = c.unitStatusHistory(u, filter, kind)
 			}
+		case status.KindCloudEvent:
+			var tag names.Tag
+			if tag, err = names.ParseTag(request.Tag); err == nil {
+				switch tag := tag.(type) {
+				case names.UnitTag:
+					hist, err = c.unitStatusHistory(tag, filter, kind)
+				case names.ApplicationTag:
+					hist, err = c.operatorEventHistory(tag, filter)
+				default:
+					err = errors.NotValidf("%q requires a unit or application, got %T", kind, tag)
+				}
+			}
 		default:
 			var m names.MachineTag
 			if m, err = names.ParseMachineTag(request.Tag); err == nil {
@@ -226,6 +258,9 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	if context.controllerTimestamp, err = c.api.stateAccessor.ControllerTimestamp(); err != nil {
 		return noStatus, errors.Annotate(err, "could not fetch controller timestamp")
 	}
+	if context.scheduledBackup, err = fetchScheduledBackupStatus(c.api.stateAccessor); err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch scheduled backup status")
+	}
 
 	logger.Tracef("Applications: %v", context.allAppsUnitsCharmBindings.applications)
 	logger.Tracef("Remote applications: %v", context.consumerRemoteApplications)
@@ -370,9 +405,28 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 		Offers:              context.processOffers(),
 		Relations:           context.processRelations(),
 		ControllerTimestamp: context.controllerTimestamp,
+		ScheduledBackup:     context.scheduledBackup,
 	}, nil
 }
 
+// fetchScheduledBackupStatus returns the outcome of the most recent
+// scheduled backup of the controller, or nil if the model is not the
+// controller model or no backup has been scheduled.
+func fetchScheduledBackupStatus(st Backend) (*params.DetailedStatus, error) {
+	if !st.IsController() {
+		return nil, nil
+	}
+	statusInfo, err := st.ScheduledBackupStatus()
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result params.DetailedStatus
+	populateStatusFromStatusInfoAndErr(&result, statusInfo, nil)
+	return &result, nil
+}
+
 // newToolsVersionAvailable will return a string representing a tools
 // version only if the latest check is newer than current tools.
 func (c *Client) modelStatus() (params.ModelStatusInfo, error) {
@@ -469,6 +523,7 @@ type statusContext struct {
 
 	// controller current timestamp
 	controllerTimestamp *time.Time
+	scheduledBackup     *params.DetailedStatus
 
 	allAppsUnitsCharmBindings applicationStatusInfo
 	relations                 map[string][]*state.Relation
@@ -1421,6 +1476,11 @@ func (c *contextMachine) Status() (status.StatusInfo, error) {
 	return c.context.status.MachineAgent(c.Id())
 }
 
+// Return the instance status for the machine.
+func (c *contextMachine) InstanceStatus() (status.StatusInfo, error) {
+	return c.context.status.MachineInstance(c.Id())
+}
+
 // processMachine retrieves version and status information for the given machine.
 // It also returns deprecated legacy status information.
 func (c *statusContext) processMachine(machine *state.Machine) (out params.DetailedStatus) {
@@ -69,6 +69,26 @@ func (s *statusSuite) TestFullStatus(c *gc.C) {
 	c.Check(resultMachine.LXDProfiles, gc.HasLen, 0)
 }
 
+func (s *statusSuite) TestFullStatusScheduledBackup(c *gc.C) {
+	client := s.APIState.Client()
+	fullStatus, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fullStatus.ScheduledBackup, gc.IsNil)
+
+	now := time.Now()
+	err = s.State.SetScheduledBackupStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: "creating backup: boom",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	fullStatus, err = client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fullStatus.ScheduledBackup, gc.NotNil)
+	c.Check(fullStatus.ScheduledBackup.Status, gc.Equals, "error")
+	c.Check(fullStatus.ScheduledBackup.Info, gc.Equals, "creating backup: boom")
+}
+
 func (s *statusSuite) TestUnsupportedNoModelMeterStatus(c *gc.C) {
 	s.addMachine(c)
 	c.Assert(s.State.SetSLA("unsupported", "test-user", []byte("")), jc.ErrorIsNil)
@@ -223,10 +223,53 @@ func (s *statusHistoryTestSuite) TestStatusHistoryCombined(c *gc.C) {
 	checkStatusInfo(c, h.Results[0].History.Statuses, expected)
 }
 
+func (s *statusHistoryTestSuite) TestStatusHistoryCloudEventsOnly(c *gc.C) {
+	s.st.unitHistory = statusInfoWithDates([]status.StatusInfo{
+		{
+			Status:  status.Active,
+			Message: "running",
+		},
+	})
+	s.st.eventHistory = statusInfoWithDates([]status.StatusInfo{
+		{
+			Status:  "Pulled",
+			Message: "Successfully pulled image",
+		},
+		{
+			Status:  "BackOff",
+			Message: "Back-off pulling image",
+		},
+	})
+	h := s.api.StatusHistory(params.StatusHistoryRequests{
+		Requests: []params.StatusHistoryRequest{{
+			Tag:    "unit-unit-0",
+			Kind:   status.KindCloudEvent.String(),
+			Filter: params.StatusHistoryFilter{Size: 10},
+		}}})
+	c.Assert(h.Results, gc.HasLen, 1)
+	c.Assert(h.Results[0].Error, gc.IsNil)
+	checkStatusInfo(c, h.Results[0].History.Statuses, reverseStatusInfo(s.st.eventHistory))
+	for _, s := range h.Results[0].History.Statuses {
+		c.Check(s.Kind, gc.Equals, status.KindCloudEvent.String())
+	}
+}
+
+func (s *statusHistoryTestSuite) TestStatusHistoryCloudEventsInvalidTag(c *gc.C) {
+	h := s.api.StatusHistory(params.StatusHistoryRequests{
+		Requests: []params.StatusHistoryRequest{{
+			Tag:    "machine-0",
+			Kind:   status.KindCloudEvent.String(),
+			Filter: params.StatusHistoryFilter{Size: 10},
+		}}})
+	c.Assert(h.Results, gc.HasLen, 1)
+	c.Assert(h.Results[0].Error, gc.ErrorMatches, `fetching status history for "machine-0": "cloud-event" requires a unit or application, got names.MachineTag not valid`)
+}
+
 type mockState struct {
 	client.Backend
 	unitHistory  []status.StatusInfo
 	agentHistory []status.StatusInfo
+	eventHistory []status.StatusInfo
 }
 
 func (m *mockState) ModelUUID() string {
@@ -248,12 +291,14 @@ func (m *mockState) Unit(name string) (client.Unit, error) {
 	return &mockUnit{
 		status: m.unitHistory,
 		agent:  &mockUnitAgent{m.agentHistory},
+		events: m.eventHistory,
 	}, nil
 }
 
 type mockUnit struct {
 	status statuses
 	agent  *mockUnitAgent
+	events statuses
 	client.Unit
 }
 
@@ -265,6 +310,10 @@ func (m *mockUnit) AgentHistory() status.StatusHistoryGetter {
 	return m.agent
 }
 
+func (m *mockUnit) CloudContainerEventHistory() status.StatusHistoryGetter {
+	return m.events
+}
+
 type mockUnitAgent struct {
 	statuses
 }
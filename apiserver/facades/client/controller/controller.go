@@ -12,10 +12,10 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/txn"
+	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v2-unstable"
 
-	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/migrationtarget"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/cloudspec"
@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/pubsub/controller"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/storage"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.controller")
@@ -46,10 +47,24 @@ type ControllerAPI struct {
 	hub        facade.Hub
 }
 
+// ControllerAPIv8 provides the v8 Controller API. The only difference
+// between this and v9 is that v8's ControllerConfig includes the values
+// of secret attributes.
+type ControllerAPIv8 struct {
+	*ControllerAPI
+}
+
+// ControllerAPIv7 provides the v7 Controller API. The only difference
+// between this and v8 is that v7 doesn't have the MigrationDryRun,
+// InitiateMigrationBatch and MigrationBatchStatus methods.
+type ControllerAPIv7 struct {
+	*ControllerAPIv8
+}
+
 // ControllerAPIv6 provides the v6 Controller API. The only difference
 // between this and v7 is that v6 doesn't have the IdentityProviderURL method.
 type ControllerAPIv6 struct {
-	*ControllerAPI
+	*ControllerAPIv7
 }
 
 // ControllerAPIv5 provides the v5 Controller API. The only difference
@@ -70,8 +85,8 @@ type ControllerAPIv3 struct {
 	*ControllerAPIv4
 }
 
-// NewControllerAPIv7 creates a new ControllerAPIv7.
-func NewControllerAPIv7(ctx facade.Context) (*ControllerAPI, error) {
+// NewControllerAPIv9 creates a new ControllerAPIv9.
+func NewControllerAPIv9(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
@@ -89,6 +104,24 @@ func NewControllerAPIv7(ctx facade.Context) (*ControllerAPI, error) {
 	)
 }
 
+// NewControllerAPIv8 creates a new ControllerAPIv8.
+func NewControllerAPIv8(ctx facade.Context) (*ControllerAPIv8, error) {
+	v9, err := NewControllerAPIv9(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv8{v9}, nil
+}
+
+// NewControllerAPIv7 creates a new ControllerAPIv7.
+func NewControllerAPIv7(ctx facade.Context) (*ControllerAPIv7, error) {
+	v8, err := NewControllerAPIv8(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv7{v8}, nil
+}
+
 // NewControllerAPIv6 creates a new ControllerAPIv6.
 func NewControllerAPIv6(ctx facade.Context) (*ControllerAPIv6, error) {
 	v7, err := NewControllerAPIv7(ctx)
@@ -181,6 +214,27 @@ func (c *ControllerAPI) checkHasAdmin() error {
 	return nil
 }
 
+// ControllerConfig returns the controller's configuration, without
+// the values of any secret attributes.
+func (c *ControllerAPI) ControllerConfig() (params.ControllerConfigResult, error) {
+	result, err := c.ControllerConfigAPI.ControllerConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for key := range result.Config {
+		if corecontroller.SecretAttributes.Contains(key) {
+			delete(result.Config, key)
+		}
+	}
+	return result, nil
+}
+
+// ControllerConfig returns the controller's configuration, including
+// the values of secret attributes.
+func (c *ControllerAPIv8) ControllerConfig() (params.ControllerConfigResult, error) {
+	return c.ControllerConfigAPI.ControllerConfig()
+}
+
 // IdentityProviderURL isn't on the v6 API.
 func (c *ControllerAPIv6) IdentityProviderURL() {}
 
@@ -501,63 +555,267 @@ func (c *ControllerAPI) InitiateMigration(reqArgs params.InitiateMigrationArgs)
 }
 
 func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string, error) {
+	hostedState, targetInfo, err := c.migrationSpecState(spec)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer hostedState.Release()
+
+	// Check if the migration is likely to succeed.
+	if err := runMigrationPrechecks(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence); err != nil {
+		return "", errors.Trace(err)
+	}
+
+	// Trigger the migration.
+	mig, err := hostedState.CreateMigration(state.MigrationSpec{
+		InitiatedBy: c.apiUser,
+		TargetInfo:  targetInfo,
+		Resume:      spec.Resume,
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return mig.Id(), nil
+}
+
+// MigrationDryRun checks whether one or more models could be migrated to
+// other controllers, without starting the migrations. The source and
+// target prechecks are run, and the exported model description is
+// validated by the target controller without being imported. The charms,
+// agent binaries and resources that would be transferred are reported
+// along with their sizes.
+func (c *ControllerAPI) MigrationDryRun(reqArgs params.InitiateMigrationArgs) (
+	params.MigrationDryRunResults, error,
+) {
+	out := params.MigrationDryRunResults{
+		Results: make([]params.MigrationDryRunResult, len(reqArgs.Specs)),
+	}
+	if err := c.checkHasAdmin(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, spec := range reqArgs.Specs {
+		result, err := c.dryRunOneMigration(spec)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		}
+		result.ModelTag = spec.ModelTag
+		out.Results[i] = result
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) dryRunOneMigration(spec params.MigrationSpec) (params.MigrationDryRunResult, error) {
+	hostedState, targetInfo, err := c.migrationSpecState(spec)
+	if err != nil {
+		return params.MigrationDryRunResult{}, errors.Trace(err)
+	}
+	defer hostedState.Release()
+
+	result, err := runMigrationDryRun(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence)
+	return result, errors.Trace(err)
+}
+
+// migrationSpecState returns the state of the model to be migrated, and
+// the details of the target controller, from the input migration spec.
+// The caller is responsible for releasing the returned state.
+func (c *ControllerAPI) migrationSpecState(spec params.MigrationSpec) (*state.PooledState, coremigration.TargetInfo, error) {
+	var empty coremigration.TargetInfo
+
 	modelTag, err := names.ParseModelTag(spec.ModelTag)
 	if err != nil {
-		return "", errors.Annotate(err, "model tag")
+		return nil, empty, errors.Annotate(err, "model tag")
 	}
 
 	// Ensure the model exists.
 	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
-		return "", errors.Annotate(err, "reading model")
+		return nil, empty, errors.Annotate(err, "reading model")
 	} else if !modelExists {
-		return "", errors.NotFoundf("model")
+		return nil, empty, errors.NotFoundf("model")
+	}
+
+	targetInfo, err := targetInfoFromParams(spec.TargetInfo)
+	if err != nil {
+		return nil, empty, errors.Trace(err)
 	}
 
 	hostedState, err := c.statePool.Get(modelTag.Id())
 	if err != nil {
-		return "", errors.Trace(err)
+		return nil, empty, errors.Trace(err)
 	}
-	defer hostedState.Release()
+	return hostedState, targetInfo, nil
+}
 
-	// Construct target info.
-	specTarget := spec.TargetInfo
+// targetInfoFromParams returns the details of a migration's target
+// controller from their API representation.
+func targetInfoFromParams(specTarget params.MigrationTargetInfo) (coremigration.TargetInfo, error) {
+	var empty coremigration.TargetInfo
 	controllerTag, err := names.ParseControllerTag(specTarget.ControllerTag)
 	if err != nil {
-		return "", errors.Annotate(err, "controller tag")
+		return empty, errors.Annotate(err, "controller tag")
 	}
 	authTag, err := names.ParseUserTag(specTarget.AuthTag)
 	if err != nil {
-		return "", errors.Annotate(err, "auth tag")
+		return empty, errors.Annotate(err, "auth tag")
 	}
 	var macs []macaroon.Slice
 	if specTarget.Macaroons != "" {
 		if err := json.Unmarshal([]byte(specTarget.Macaroons), &macs); err != nil {
-			return "", errors.Annotate(err, "invalid macaroons")
+			return empty, errors.Annotate(err, "invalid macaroons")
 		}
 	}
-	targetInfo := coremigration.TargetInfo{
+	return coremigration.TargetInfo{
 		ControllerTag: controllerTag,
 		Addrs:         specTarget.Addrs,
 		CACert:        specTarget.CACert,
 		AuthTag:       authTag,
 		Password:      specTarget.Password,
 		Macaroons:     macs,
+	}, nil
+}
+
+// InitiateMigrationBatch queues the migration of the controller's
+// hosted models to another controller. No more than the requested
+// number of the migrations are in progress at once; the queued
+// migrations are started as earlier ones finish. The models can be
+// restricted to those owned by a user or deployed to a cloud. The
+// prechecks for each model are run before its migration starts; models
+// failing them are reported in the batch's status.
+func (c *ControllerAPI) InitiateMigrationBatch(args params.InitiateMigrationBatchArgs) (
+	params.InitiateMigrationBatchResult, error,
+) {
+	var result params.InitiateMigrationBatchResult
+	if err := c.checkHasAdmin(); err != nil {
+		return result, errors.Trace(err)
 	}
 
-	// Check if the migration is likely to succeed.
-	if err := runMigrationPrechecks(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence); err != nil {
-		return "", errors.Trace(err)
+	targetInfo, err := targetInfoFromParams(args.TargetInfo)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	var ownerTag names.UserTag
+	if args.OwnerTag != "" {
+		if ownerTag, err = names.ParseUserTag(args.OwnerTag); err != nil {
+			return result, errors.Annotate(err, "owner tag")
+		}
+	}
+	var cloudTag names.CloudTag
+	if args.CloudTag != "" {
+		if cloudTag, err = names.ParseCloudTag(args.CloudTag); err != nil {
+			return result, errors.Annotate(err, "cloud tag")
+		}
 	}
 
-	// Trigger the migration.
-	mig, err := hostedState.CreateMigration(state.MigrationSpec{
+	modelUUIDs, err := c.state.AllModelUUIDs()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	var batchUUIDs []string
+	for _, modelUUID := range modelUUIDs {
+		if modelUUID == c.state.ControllerModelUUID() {
+			continue
+		}
+		model, ph, err := c.statePool.GetModel(modelUUID)
+		if err != nil {
+			// This model could have been removed.
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return result, errors.Trace(err)
+		}
+		matches := model.Life() == state.Alive &&
+			(args.OwnerTag == "" || model.Owner() == ownerTag) &&
+			(args.CloudTag == "" || model.Cloud() == cloudTag.Id())
+		ph.Release()
+		if matches {
+			batchUUIDs = append(batchUUIDs, modelUUID)
+		}
+	}
+	if len(batchUUIDs) == 0 {
+		return result, errors.NotFoundf("models to migrate")
+	}
+
+	batch, err := c.state.CreateMigrationBatch(state.MigrationBatchSpec{
 		InitiatedBy: c.apiUser,
 		TargetInfo:  targetInfo,
+		ModelUUIDs:  batchUUIDs,
+		Concurrency: args.Concurrency,
 	})
 	if err != nil {
-		return "", errors.Trace(err)
+		return result, errors.Trace(err)
 	}
-	return mig.Id(), nil
+	result.BatchId = batch.Id()
+	if err := c.statePool.StartQueuedMigrations(batch.Id(), c.batchMigrationPrecheck); err != nil {
+		// Models whose migrations couldn't be started remain queued,
+		// and are reported in the batch's status.
+		logger.Warningf("cannot start migrations in batch %s: %v", batch.Id(), err)
+	}
+	return result, nil
+}
+
+// batchMigrationPrecheck runs the prechecks for a model's migration
+// before the migration batch starts it.
+func (c *ControllerAPI) batchMigrationPrecheck(st *state.State, targetInfo *coremigration.TargetInfo) error {
+	return runMigrationPrechecks(st, c.statePool.SystemState(), targetInfo, c.presence)
+}
+
+// MigrationBatchStatus returns the progress of the migrations in one
+// or more migration batches.
+func (c *ControllerAPI) MigrationBatchStatus(args params.MigrationBatchArgs) (
+	params.MigrationBatchStatusResults, error,
+) {
+	out := params.MigrationBatchStatusResults{
+		Results: make([]params.MigrationBatchStatusResult, len(args.BatchIds)),
+	}
+	if err := c.checkHasAdmin(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, id := range args.BatchIds {
+		result, err := c.migrationBatchStatus(id)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		}
+		result.BatchId = id
+		out.Results[i] = result
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) migrationBatchStatus(id string) (params.MigrationBatchStatusResult, error) {
+	var result params.MigrationBatchStatusResult
+	batch, err := c.state.MigrationBatch(id)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	targetInfo, err := batch.TargetInfo()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	models, err := batch.Models()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	result.InitiatedBy = batch.InitiatedBy()
+	result.StartTime = batch.StartTime()
+	result.TargetController = targetInfo.ControllerTag.String()
+	result.Concurrency = batch.Concurrency()
+	for _, model := range models {
+		status := params.MigrationBatchModelStatus{
+			ModelTag:    names.NewModelTag(model.UUID).String(),
+			Name:        model.Name,
+			OwnerTag:    names.NewUserTag(model.Owner).String(),
+			MigrationId: model.MigrationId,
+			StartError:  model.StartError,
+		}
+		if model.MigrationId != "" {
+			status.Phase = model.Phase.String()
+			status.Message = model.StatusMessage
+		}
+		result.Models = append(result.Models, status)
+	}
+	return result, nil
 }
 
 // ModifyControllerAccess changes the model access granted to users.
@@ -634,90 +892,134 @@ func (c *ControllerAPI) ConfigSet(args params.ControllerConfigSet) error {
 // ConfigSet isn't on the v4 API.
 func (c *ControllerAPIv4) ConfigSet(_, _ struct{}) {}
 
+// MigrationDryRun isn't on the v7 API.
+func (c *ControllerAPIv7) MigrationDryRun(_, _ struct{}) {}
+
+// InitiateMigrationBatch isn't on the v7 API.
+func (c *ControllerAPIv7) InitiateMigrationBatch(_, _ struct{}) {}
+
+// MigrationBatchStatus isn't on the v7 API.
+func (c *ControllerAPIv7) MigrationBatchStatus(_, _ struct{}) {}
+
 // runMigrationPrechecks runs prechecks on the migration and updates
 // information in targetInfo as needed based on information
 // retrieved from the target controller.
 var runMigrationPrechecks = func(st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo, presence facade.Presence) error {
-	// Check model and source controller.
-	backend, err := migration.PrecheckShim(st, ctlrSt)
-	if err != nil {
-		return errors.Annotate(err, "creating backend")
-	}
-	modelPresence := presence.ModelPresence(st.ModelUUID())
-	controllerPresence := presence.ModelPresence(ctlrSt.ModelUUID())
-	if err := migration.SourcePrecheck(backend, modelPresence, controllerPresence); err != nil {
-		return errors.Annotate(err, "source prechecks failed")
-	}
+	return checkMigration(st, ctlrSt, targetInfo, presence, nil)
+}
 
-	// Check target controller.
-	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
-	if err != nil {
-		return errors.Annotate(err, "connect to target controller")
-	}
-	defer conn.Close()
-	modelInfo, err := makeModelInfo(st, ctlrSt)
-	if err != nil {
-		return errors.Trace(err)
-	}
-	client := migrationtarget.NewClient(conn)
-	if targetInfo.CACert == "" {
-		targetInfo.CACert, err = client.CACert()
+// runMigrationDryRun runs prechecks on the migration, then exports the
+// model and has the target controller validate the model description
+// without importing it. The binaries that would be transferred to the
+// target controller are returned.
+var runMigrationDryRun = func(st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo, presence facade.Presence) (params.MigrationDryRunResult, error) {
+	var result params.MigrationDryRunResult
+	err := checkMigration(st, ctlrSt, targetInfo, presence, func(client *migrationtarget.Client) error {
+		model, err := st.Export()
 		if err != nil {
-			if !params.IsCodeNotImplemented(err) {
-				return errors.Annotatef(err, "cannot retrieve CA certificate")
-			}
-			// If the call's not implemented, it indicates an earlier version
-			// of the controller, which we can't migrate to.
-			return errors.New("controller API version is too old")
+			return errors.Annotate(err, "exporting model")
 		}
-	}
-	err = client.Prechecks(modelInfo)
-	return errors.Annotate(err, "target prechecks failed")
+		serialized, err := common.SerializeModel(model)
+		if err != nil {
+			return errors.Annotate(err, "serializing model")
+		}
+		if err := client.ValidateImport(serialized.Bytes); err != nil {
+			return errors.Annotate(err, "target model validation failed")
+		}
+		result, err = migrationBinaries(st, serialized)
+		return errors.Trace(err)
+	})
+	return result, errors.Trace(err)
+}
+
+// checkMigration runs the source and target prechecks for the migration,
+// updating information in targetInfo as needed. If validate is not nil, it
+// is called with a client for the target controller once the prechecks
+// have passed.
+func checkMigration(
+	st, ctlrSt *state.State,
+	targetInfo *coremigration.TargetInfo,
+	presence facade.Presence,
+	validate func(*migrationtarget.Client) error,
+) error {
+	return migration.CheckMigration(
+		st, ctlrSt, targetInfo,
+		presence.ModelPresence(st.ModelUUID()),
+		presence.ModelPresence(ctlrSt.ModelUUID()),
+		validate,
+	)
 }
 
-func makeModelInfo(st, ctlrSt *state.State) (coremigration.ModelInfo, error) {
-	var empty coremigration.ModelInfo
+// migrationBinaries returns the charms, agent binaries and resources in
+// the serialized model, along with their sizes in the source controller.
+// Agent binaries that are not cached by the controller are reported with
+// a zero size.
+func migrationBinaries(st *state.State, serialized params.SerializedModel) (params.MigrationDryRunResult, error) {
+	var result params.MigrationDryRunResult
 
-	model, err := st.Model()
-	if err != nil {
-		return empty, errors.Trace(err)
+	charmStorage := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	for _, curl := range serialized.Charms {
+		size, err := charmSize(st, charmStorage, curl)
+		if err != nil {
+			return result, errors.Annotatef(err, "charm %q", curl)
+		}
+		result.Charms = append(result.Charms, params.MigrationBinary{Name: curl, Size: size})
 	}
 
-	// Retrieve agent version for the model.
-	conf, err := model.ModelConfig()
+	toolsStorage, err := st.ToolsStorage()
 	if err != nil {
-		return empty, errors.Trace(err)
+		return result, errors.Trace(err)
+	}
+	defer toolsStorage.Close()
+	for _, tools := range serialized.Tools {
+		var size int64
+		metadata, err := toolsStorage.Metadata(tools.Version)
+		if err == nil {
+			size = metadata.Size
+		} else if !errors.IsNotFound(err) {
+			return result, errors.Annotatef(err, "agent binaries %q", tools.Version)
+		}
+		result.Tools = append(result.Tools, params.MigrationBinary{Name: tools.Version, Size: size})
+	}
+
+	for _, res := range serialized.Resources {
+		// Placeholder resources have no content to transfer.
+		if res.ApplicationRevision.Timestamp.IsZero() {
+			continue
+		}
+		result.Resources = append(result.Resources, params.MigrationBinary{
+			Name: res.Application + "/" + res.Name,
+			Size: res.ApplicationRevision.Size,
+		})
 	}
-	agentVersion, _ := conf.AgentVersion()
 
-	// Retrieve agent version for the controller.
-	controllerModel, err := ctlrSt.Model()
+	sortMigrationBinaries(result.Charms)
+	sortMigrationBinaries(result.Tools)
+	sortMigrationBinaries(result.Resources)
+	return result, nil
+}
+
+func charmSize(st *state.State, charmStorage storage.Storage, curl string) (int64, error) {
+	parsed, err := charm.ParseURL(curl)
 	if err != nil {
-		return empty, errors.Trace(err)
+		return 0, errors.Trace(err)
 	}
-	controllerConfig, err := controllerModel.Config()
+	ch, err := st.Charm(parsed)
 	if err != nil {
-		return empty, errors.Trace(err)
+		return 0, errors.Trace(err)
 	}
-	controllerVersion, _ := controllerConfig.AgentVersion()
-
-	return coremigration.ModelInfo{
-		UUID:                   model.UUID(),
-		Name:                   model.Name(),
-		Owner:                  model.Owner(),
-		AgentVersion:           agentVersion,
-		ControllerAgentVersion: controllerVersion,
-	}, nil
+	reader, size, err := charmStorage.Get(ch.StoragePath())
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	_ = reader.Close()
+	return size, nil
 }
 
-func targetToAPIInfo(ti *coremigration.TargetInfo) *api.Info {
-	return &api.Info{
-		Addrs:     ti.Addrs,
-		CACert:    ti.CACert,
-		Tag:       ti.AuthTag,
-		Password:  ti.Password,
-		Macaroons: ti.Macaroons,
-	}
+func sortMigrationBinaries(binaries []params.MigrationBinary) {
+	sort.Slice(binaries, func(i, j int) bool {
+		return binaries[i].Name < binaries[j].Name
+	})
 }
 
 // grantControllerCloudAccess exists for backwards compatibility since older clients
@@ -25,6 +25,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
 	corecontroller "github.com/juju/juju/controller"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/permission"
@@ -64,7 +65,7 @@ func (s *controllerSuite) SetUpTest(c *gc.C) {
 	}
 	s.hub = pubsub.NewStructuredHub(nil)
 
-	controller, err := controller.NewControllerAPIv7(
+	controller, err := controller.NewControllerAPIv9(
 		facadetest.Context{
 			State_:     s.State,
 			StatePool_: s.StatePool,
@@ -287,6 +288,39 @@ func (s *controllerSuite) TestControllerConfig(c *gc.C) {
 	c.Assert(cfg.Config["api-port"], gc.Equals, cfgFromDB.APIPort())
 }
 
+func (s *controllerSuite) TestControllerConfigOmitsSecrets(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		"backup-s3-access-key": "access",
+		"backup-s3-secret-key": "secret",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err := s.controller.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Config["backup-s3-access-key"], gc.Equals, "access")
+	_, ok := cfg.Config["backup-s3-secret-key"]
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *controllerSuite) TestControllerConfigV8IncludesSecrets(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		"backup-s3-secret-key": "secret",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	endpoint, err := controller.NewControllerAPIv8(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: s.resources,
+			Auth_:      s.authorizer,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := endpoint.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.Config["backup-s3-secret-key"], gc.Equals, "secret")
+}
+
 func (s *controllerSuite) TestControllerConfigFromNonController(c *gc.C) {
 	st := s.Factory.MakeModel(c, &factory.ModelParams{
 		Name: "test"})
@@ -454,6 +488,49 @@ func (s *controllerSuite) TestInitiateMigration(c *gc.C) {
 	}
 }
 
+func (s *controllerSuite) TestInitiateMigrationResume(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	controller.SetPrecheckResult(s, nil)
+
+	spec := params.MigrationSpec{
+		ModelTag: m.ModelTag().String(),
+		TargetInfo: params.MigrationTargetInfo{
+			ControllerTag: randomControllerTag(),
+			Addrs:         []string{"1.1.1.1:1111"},
+			CACert:        "cert",
+			AuthTag:       names.NewUserTag("admin").String(),
+			Password:      "secret",
+		},
+	}
+	out, err := s.controller.InitiateMigration(params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{spec},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results[0].Error, gc.IsNil)
+
+	// Abort the first attempt, keeping the imported model.
+	mig, err := st.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetKeepImport(true), jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(coremigration.ABORT), jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(coremigration.ABORTDONE), jc.ErrorIsNil)
+
+	spec.Resume = true
+	out, err = s.controller.InitiateMigration(params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{spec},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results[0].Error, gc.IsNil)
+	c.Check(out.Results[0].MigrationId, gc.Equals, st.ModelUUID()+":1")
+
+	mig, err = st.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.Resume(), jc.IsTrue)
+}
+
 func (s *controllerSuite) TestInitiateMigrationSpecError(c *gc.C) {
 	// Create a hosted model to migrate.
 	st := s.Factory.MakeModel(c, nil)
@@ -571,6 +648,190 @@ func (s *controllerSuite) TestInitiateMigrationPrecheckFail(c *gc.C) {
 	c.Check(active, jc.IsFalse)
 }
 
+func (s *controllerSuite) TestMigrationDryRun(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	controller.SetDryRunResult(s, params.MigrationDryRunResult{
+		Charms:    []params.MigrationBinary{{Name: "cs:wordpress-1", Size: 1024}},
+		Tools:     []params.MigrationBinary{{Name: "2.6.0-bionic-amd64", Size: 4096}},
+		Resources: []params.MigrationBinary{{Name: "wordpress/data", Size: 512}},
+	}, nil)
+
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{
+			{
+				ModelTag: m.ModelTag().String(),
+				TargetInfo: params.MigrationTargetInfo{
+					ControllerTag: randomControllerTag(),
+					Addrs:         []string{"1.1.1.1:1111"},
+					CACert:        "cert",
+					AuthTag:       names.NewUserTag("admin").String(),
+					Password:      "secret",
+				},
+			}, {
+				ModelTag: randomModelTag(), // Doesn't exist.
+			},
+		},
+	}
+	out, err := s.controller.MigrationDryRun(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+
+	c.Check(out.Results[0], jc.DeepEquals, params.MigrationDryRunResult{
+		ModelTag:  m.ModelTag().String(),
+		Charms:    []params.MigrationBinary{{Name: "cs:wordpress-1", Size: 1024}},
+		Tools:     []params.MigrationBinary{{Name: "2.6.0-bionic-amd64", Size: 4096}},
+		Resources: []params.MigrationBinary{{Name: "wordpress/data", Size: 512}},
+	})
+	c.Check(out.Results[1].ModelTag, gc.Equals, args.Specs[1].ModelTag)
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "model not found")
+
+	// No migration is started.
+	active, err := st.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsFalse)
+}
+
+func (s *controllerSuite) TestMigrationDryRunFail(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	controller.SetDryRunResult(s, params.MigrationDryRunResult{}, errors.New("target prechecks failed: boom"))
+
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert",
+				AuthTag:       names.NewUserTag("admin").String(),
+				Password:      "secret",
+			},
+		}},
+	}
+	out, err := s.controller.MigrationDryRun(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "target prechecks failed: boom")
+}
+
+func (s *controllerSuite) TestInitiateMigrationBatch(c *gc.C) {
+	owner := names.NewUserTag("bob@remote")
+	st1 := s.Factory.MakeModel(c, &factory.ModelParams{Name: "first", Owner: owner})
+	defer st1.Close()
+	st2 := s.Factory.MakeModel(c, &factory.ModelParams{Name: "second", Owner: owner})
+	defer st2.Close()
+	s.Factory.MakeModel(c, &factory.ModelParams{Name: "other"}).Close()
+	controller.SetPrecheckResult(s, nil)
+
+	targetTag := randomControllerTag()
+	result, err := s.controller.InitiateMigrationBatch(params.InitiateMigrationBatchArgs{
+		OwnerTag: owner.String(),
+		TargetInfo: params.MigrationTargetInfo{
+			ControllerTag: targetTag,
+			Addrs:         []string{"1.1.1.1:1111"},
+			CACert:        "cert",
+			AuthTag:       names.NewUserTag("admin").String(),
+			Password:      "secret",
+		},
+		Concurrency: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.BatchId, gc.Equals, "1")
+
+	// Only the first of the owner's models is being migrated.
+	active, err := st1.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsTrue)
+	active, err = st2.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsFalse)
+
+	out, err := s.controller.MigrationBatchStatus(params.MigrationBatchArgs{
+		BatchIds: []string{"1", "2"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+	status := out.Results[0]
+	c.Assert(status.Error, gc.IsNil)
+	c.Check(status.BatchId, gc.Equals, "1")
+	c.Check(status.InitiatedBy, gc.Equals, s.Owner.Id())
+	c.Check(status.TargetController, gc.Equals, targetTag)
+	c.Check(status.Concurrency, gc.Equals, 1)
+	c.Check(status.Models, jc.DeepEquals, []params.MigrationBatchModelStatus{{
+		ModelTag:    names.NewModelTag(st1.ModelUUID()).String(),
+		Name:        "first",
+		OwnerTag:    owner.String(),
+		MigrationId: st1.ModelUUID() + ":0",
+		Phase:       "QUIESCE",
+		Message:     "starting",
+	}, {
+		ModelTag: names.NewModelTag(st2.ModelUUID()).String(),
+		Name:     "second",
+		OwnerTag: owner.String(),
+	}})
+	c.Check(out.Results[1].Error, gc.ErrorMatches, `migration batch "2" not found`)
+}
+
+func (s *controllerSuite) TestInitiateMigrationBatchPrecheckFailure(c *gc.C) {
+	owner := names.NewUserTag("bob@remote")
+	st := s.Factory.MakeModel(c, &factory.ModelParams{Name: "first", Owner: owner})
+	defer st.Close()
+	controller.SetPrecheckResult(s, errors.New("source prechecks failed: boom"))
+
+	result, err := s.controller.InitiateMigrationBatch(params.InitiateMigrationBatchArgs{
+		OwnerTag: owner.String(),
+		TargetInfo: params.MigrationTargetInfo{
+			ControllerTag: randomControllerTag(),
+			Addrs:         []string{"1.1.1.1:1111"},
+			CACert:        "cert",
+			AuthTag:       names.NewUserTag("admin").String(),
+			Password:      "secret",
+		},
+		Concurrency: 1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The model failing its prechecks isn't migrated.
+	active, err := st.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsFalse)
+
+	out, err := s.controller.MigrationBatchStatus(params.MigrationBatchArgs{
+		BatchIds: []string{result.BatchId},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Models, jc.DeepEquals, []params.MigrationBatchModelStatus{{
+		ModelTag:   names.NewModelTag(st.ModelUUID()).String(),
+		Name:       "first",
+		OwnerTag:   owner.String(),
+		StartError: "source prechecks failed: boom",
+	}})
+}
+
+func (s *controllerSuite) TestInitiateMigrationBatchNoModels(c *gc.C) {
+	_, err := s.controller.InitiateMigrationBatch(params.InitiateMigrationBatchArgs{
+		CloudTag: names.NewCloudTag("elsewhere").String(),
+		TargetInfo: params.MigrationTargetInfo{
+			ControllerTag: randomControllerTag(),
+			Addrs:         []string{"1.1.1.1:1111"},
+			AuthTag:       names.NewUserTag("admin").String(),
+			Password:      "secret",
+		},
+		Concurrency: 1,
+	})
+	c.Check(err, gc.ErrorMatches, "models to migrate not found")
+}
+
 func randomControllerTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewControllerTag(uuid).String()
@@ -4,4 +4,5 @@
 package machinemanager
 
 var InstanceTypes = instanceTypes
+var PreviewMachines = previewMachines
 var IsSeriesLessThan = isSeriesLessThan
@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/cloudimagemetadata"
 )
 
 type instanceTypesSuite struct{}
@@ -88,7 +89,9 @@ type mockBackend struct {
 	machinemanager.Backend
 	storagecommon.StorageAccess
 
-	cloudSpec environs.CloudSpec
+	cloudSpec     environs.CloudSpec
+	modelCons     constraints.Value
+	imageMetadata map[string][]cloudimagemetadata.Metadata
 }
 
 func (st *mockBackend) VolumeAccess() storagecommon.VolumeAccess {
@@ -111,6 +114,25 @@ func (b *mockBackend) CloudSpec(names.ModelTag) (environs.CloudSpec, error) {
 	return b.cloudSpec, nil
 }
 
+func (b *mockBackend) ResolveConstraints(cons constraints.Value) (constraints.Value, error) {
+	return constraints.NewValidator().Merge(b.modelCons, cons)
+}
+
+func (b *mockBackend) FindCloudImageMetadata(filter cloudimagemetadata.MetadataFilter) (map[string][]cloudimagemetadata.Metadata, error) {
+	result := make(map[string][]cloudimagemetadata.Metadata)
+	for source, metadata := range b.imageMetadata {
+		for _, m := range metadata {
+			if len(filter.Arches) == 0 || m.Arch == filter.Arches[0] {
+				result[source] = append(result[source], m)
+			}
+		}
+	}
+	if len(result) == 0 {
+		return nil, errors.NotFoundf("matching cloud image metadata")
+	}
+	return result, nil
+}
+
 func (b *mockBackend) Cloud(name string) (cloud.Cloud, error) {
 	return cloud.Cloud{}, nil
 }
@@ -71,6 +71,18 @@ type MachineManagerAPIV5 struct {
 // Version 6 of Machine Manager API.
 // Changes input parameters to DestroyMachineWithParams and ForceDestroyMachine.
 type MachineManagerAPIV6 struct {
+	*MachineManagerAPIV7
+}
+
+// Version 7 of Machine Manager API.
+// Adds ReplaceMachines.
+type MachineManagerAPIV7 struct {
+	*MachineManagerAPIV8
+}
+
+// Version 8 of Machine Manager API.
+// Adds PreviewMachines.
+type MachineManagerAPIV8 struct {
 	*MachineManagerAPI
 }
 
@@ -94,11 +106,29 @@ func NewFacadeV5(ctx facade.Context) (*MachineManagerAPIV5, error) {
 
 // NewFacadeV6 creates a new server-side MachineManager API facade.
 func NewFacadeV6(ctx facade.Context) (*MachineManagerAPIV6, error) {
+	machineManagerAPIv7, err := NewFacadeV7(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV6{machineManagerAPIv7}, nil
+}
+
+// NewFacadeV7 creates a new server-side MachineManager API facade.
+func NewFacadeV7(ctx facade.Context) (*MachineManagerAPIV7, error) {
+	machineManagerAPIv8, err := NewFacadeV8(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV7{machineManagerAPIv8}, nil
+}
+
+// NewFacadeV8 creates a new server-side MachineManager API facade.
+func NewFacadeV8(ctx facade.Context) (*MachineManagerAPIV8, error) {
 	machineManagerAPI, err := NewFacade(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &MachineManagerAPIV6{machineManagerAPI}, nil
+	return &MachineManagerAPIV8{machineManagerAPI}, nil
 }
 
 // NewMachineManagerAPI creates a new server-side MachineManager API facade.
@@ -394,6 +424,51 @@ func (mm *MachineManagerAPI) destroyMachine(args params.Entities, force, keep bo
 	return params.DestroyMachineResults{results}, nil
 }
 
+// ReplaceMachines replaces each of the given machines with a new machine
+// with the same series, constraints and placement, moving the old
+// machine's units to the new one before destroying the old machine.
+func (mm *MachineManagerAPI) ReplaceMachines(args params.Entities) (params.ReplaceMachineResults, error) {
+	if err := mm.checkCanWrite(); err != nil {
+		return params.ReplaceMachineResults{}, err
+	}
+	if err := mm.check.RemoveAllowed(); err != nil {
+		return params.ReplaceMachineResults{}, err
+	}
+	results := make([]params.ReplaceMachineResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		results[i] = mm.replaceMachine(entity)
+	}
+	return params.ReplaceMachineResults{Results: results}, nil
+}
+
+func (mm *MachineManagerAPI) replaceMachine(entity params.Entity) params.ReplaceMachineResult {
+	var result params.ReplaceMachineResult
+	machineTag, err := names.ParseMachineTag(entity.Tag)
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result
+	}
+	old, err := mm.st.Machine(machineTag.Id())
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result
+	}
+	principals := old.Principals()
+
+	m, err := mm.st.ReplaceMachine(machineTag.Id())
+	if m != nil {
+		result.Machine = names.NewMachineTag(m.Id()).String()
+	}
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result
+	}
+	for _, name := range principals {
+		result.Units = append(result.Units, params.Entity{Tag: names.NewUnitTag(name).String()})
+	}
+	return result
+}
+
 // UpgradeSeriesValidate validates that the incoming arguments correspond to a
 // valid series upgrade for the target machine.
 // If they do, a list of the machine's current units is returned for use in
@@ -655,6 +730,12 @@ func isSeriesLessThan(series1, series2 string) (bool, error) {
 	return version2 > version1, nil
 }
 
+// ReplaceMachines isn't on the v6 API.
+func (*MachineManagerAPIV6) ReplaceMachines(_, _ struct{}) {}
+
+// PreviewMachines isn't on the v7 API.
+func (*MachineManagerAPIV7) PreviewMachines(_, _ struct{}) {}
+
 // DEPRECATED: UpdateMachineSeries returns an error.
 func (mm *MachineManagerAPIV4) UpdateMachineSeries(_ params.UpdateSeriesArgs) (params.ErrorResults, error) {
 	return params.ErrorResults{
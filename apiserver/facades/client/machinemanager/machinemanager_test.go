@@ -449,8 +449,57 @@ func (s *MachineManagerSuite) setupUpgradeSeries(c *gc.C) {
 	}
 }
 
+func (s *MachineManagerSuite) TestReplaceMachines(c *gc.C) {
+	s.st.machines["0"] = &mockMachine{units: []string{"foo/0", "foo/1"}}
+	results, err := s.api.ReplaceMachines(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "machine-1"}, {Tag: "unit-foo-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ReplaceMachineResults{
+		Results: []params.ReplaceMachineResult{{
+			Machine: "machine-2",
+			Units:   []params.Entity{{"unit-foo-0"}, {"unit-foo-1"}},
+		}, {
+			Error: &params.Error{Message: "machine 1 not found", Code: params.CodeNotFound},
+		}, {
+			Error: &params.Error{Message: `"unit-foo-0" is not a valid machine tag`},
+		}},
+	})
+	var replaced []interface{}
+	for _, call := range s.st.Calls() {
+		if call.FuncName == "ReplaceMachine" {
+			replaced = append(replaced, call.Args...)
+		}
+	}
+	c.Assert(replaced, jc.DeepEquals, []interface{}{"0"})
+}
+
+func (s *MachineManagerSuite) TestReplaceMachinesPartialFailure(c *gc.C) {
+	s.st.machines["0"] = &mockMachine{units: []string{"foo/0"}}
+	s.st.SetErrors(errors.New(`cannot replace machine 0: cannot reassign unit "foo/0" to machine 2: boom`))
+	results, err := s.api.ReplaceMachines(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ReplaceMachineResults{
+		Results: []params.ReplaceMachineResult{{
+			Machine: "machine-2",
+			Error:   &params.Error{Message: `cannot replace machine 0: cannot reassign unit "foo/0" to machine 2: boom`},
+		}},
+	})
+}
+
+func (s *MachineManagerSuite) TestReplaceMachinesBlocked(c *gc.C) {
+	s.st.blockMsg = "TestReplaceMachinesBlocked"
+	s.st.block = state.RemoveBlock
+	_, err := s.api.ReplaceMachines(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue, gc.Commentf("error: %#v", err))
+}
+
 func (s *MachineManagerSuite) apiV5() machinemanager.MachineManagerAPIV5 {
-	return machinemanager.MachineManagerAPIV5{MachineManagerAPIV6: &machinemanager.MachineManagerAPIV6{s.api}}
+	return machinemanager.MachineManagerAPIV5{MachineManagerAPIV6: &machinemanager.MachineManagerAPIV6{&machinemanager.MachineManagerAPIV7{&machinemanager.MachineManagerAPIV8{s.api}}}}
 }
 
 func (s *MachineManagerSuite) TestUpgradeSeriesValidateOK(c *gc.C) {
@@ -878,6 +927,11 @@ func (st *mockState) Machine(id string) (machinemanager.Machine, error) {
 	}
 }
 
+func (st *mockState) ReplaceMachine(id string) (machinemanager.Machine, error) {
+	st.MethodCall(st, "ReplaceMachine", id)
+	return &mockMachine{id: "2"}, st.NextErr()
+}
+
 func (st *mockState) StorageInstance(tag names.StorageTag) (state.StorageInstance, error) {
 	st.MethodCall(st, "StorageInstance", tag)
 	return &mockStorage{
@@ -921,6 +975,7 @@ type mockMachine struct {
 	jtesting.Stub
 	machinemanager.Machine
 
+	id             string
 	keep           bool
 	series         string
 	units          []string
@@ -931,6 +986,11 @@ type mockMachine struct {
 	unitsF func() ([]machinemanager.Unit, error)
 }
 
+func (m *mockMachine) Id() string {
+	m.MethodCall(m, "Id")
+	return m.id
+}
+
 func (m *mockMachine) Destroy() error {
 	m.MethodCall(m, "Destroy")
 	return nil
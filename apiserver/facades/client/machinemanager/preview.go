@@ -0,0 +1,305 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/simplestreams"
+	providercommon "github.com/juju/juju/provider/common"
+	"github.com/juju/juju/state/cloudimagemetadata"
+	"github.com/juju/juju/state/stateenvirons"
+)
+
+// PreviewMachines returns the series, architecture, instance type, image
+// and availability zone that would be chosen for new machines with the
+// given parameters, without adding the machines or starting anything.
+func (mm *MachineManagerAPI) PreviewMachines(args params.AddMachines) (params.MachinePreviewResults, error) {
+	return previewMachines(mm, environs.GetEnviron, args)
+}
+
+func previewMachines(
+	mm *MachineManagerAPI,
+	getEnviron environGetFunc,
+	args params.AddMachines,
+) (params.MachinePreviewResults, error) {
+	if err := mm.checkCanRead(); err != nil {
+		return params.MachinePreviewResults{}, err
+	}
+	model, err := mm.st.Model()
+	if err != nil {
+		return params.MachinePreviewResults{}, errors.Trace(err)
+	}
+	modelConfig, err := model.Config()
+	if err != nil {
+		return params.MachinePreviewResults{}, errors.Trace(err)
+	}
+	cloudSpec := func() (environs.CloudSpec, error) {
+		credentialTag, _ := model.CloudCredential()
+		return stateenvirons.CloudSpec(mm.st, model.Cloud(), model.CloudRegion(), credentialTag)
+	}
+	env, err := getEnviron(common.EnvironConfigGetterFuncs{
+		CloudSpecFunc:   cloudSpec,
+		ModelConfigFunc: model.Config,
+	}, environs.New)
+	if err != nil {
+		return params.MachinePreviewResults{}, errors.Trace(err)
+	}
+
+	p := &previewer{
+		mm:          mm,
+		env:         env,
+		modelConfig: modelConfig,
+	}
+	results := make([]params.MachinePreviewResult, len(args.MachineParams))
+	for i, arg := range args.MachineParams {
+		result, err := p.preview(arg)
+		if err != nil {
+			result = params.MachinePreviewResult{Error: common.ServerError(err)}
+		}
+		results[i] = result
+	}
+	return params.MachinePreviewResults{Results: results}, nil
+}
+
+// previewer chooses instances for new machines in the same way as the
+// provisioner, without starting them. The instance type and image are
+// chosen by the provider, so only providers which implement
+// environs.InstanceSpecPreviewer are supported.
+type previewer struct {
+	mm          *MachineManagerAPI
+	env         environs.Environ
+	modelConfig *config.Config
+
+	// zoneMachines records the number of instances in each available
+	// zone, including those chosen for earlier previews, so that the
+	// previews are spread across zones as the machines would be.
+	zoneMachines map[string]int
+}
+
+func (p *previewer) preview(arg params.AddMachineParams) (params.MachinePreviewResult, error) {
+	if arg.ContainerType != "" || arg.ParentId != "" {
+		return params.MachinePreviewResult{}, errors.NotSupportedf("previewing containers")
+	}
+	if arg.Placement != nil {
+		if _, err := instance.ParseContainerType(arg.Placement.Scope); err == nil {
+			return params.MachinePreviewResult{}, errors.NotSupportedf("previewing containers")
+		}
+	}
+	if arg.InstanceId != "" {
+		return params.MachinePreviewResult{}, errors.NotSupportedf("previewing manually provisioned machines")
+	}
+	var zone string
+	if arg.Placement != nil {
+		if !strings.HasPrefix(arg.Placement.Directive, "zone=") || arg.Placement.Scope == instance.MachineScope {
+			return params.MachinePreviewResult{}, errors.NotSupportedf("previewing placement %q", arg.Placement)
+		}
+		zone = strings.TrimPrefix(arg.Placement.Directive, "zone=")
+	}
+	specPreviewer, ok := p.env.(environs.InstanceSpecPreviewer)
+	if !ok {
+		return params.MachinePreviewResult{}, errors.NotSupportedf("previewing machines on this cloud")
+	}
+	series := arg.Series
+	if series == "" {
+		series = config.PreferredSeries(p.modelConfig)
+	}
+	cons, err := p.mm.st.ResolveConstraints(arg.Constraints)
+	if err != nil {
+		return params.MachinePreviewResult{}, errors.Trace(err)
+	}
+
+	region, err := p.region()
+	if err != nil {
+		return params.MachinePreviewResult{}, errors.Trace(err)
+	}
+	images, err := p.images(series, region, cons)
+	if err != nil {
+		return params.MachinePreviewResult{}, errors.Trace(err)
+	}
+	spec, err := specPreviewer.PreviewInstanceSpec(p.mm.callContext, series, cons, images)
+	if err != nil {
+		return params.MachinePreviewResult{}, errors.Trace(err)
+	}
+
+	zone, err = p.zone(zone, cons)
+	if err != nil {
+		return params.MachinePreviewResult{}, errors.Trace(err)
+	}
+	return params.MachinePreviewResult{
+		Series:           series,
+		Arch:             spec.Image.Arch,
+		InstanceType:     spec.InstanceType.Name,
+		ImageId:          spec.Image.Id,
+		AvailabilityZone: zone,
+	}, nil
+}
+
+// region returns the cloud region in which images are looked for, if
+// the provider has regions.
+func (p *previewer) region() (simplestreams.CloudSpec, error) {
+	hasRegion, ok := p.env.(simplestreams.HasRegion)
+	if !ok {
+		return simplestreams.CloudSpec{}, nil
+	}
+	spec, err := hasRegion.Region()
+	return spec, errors.Annotate(err, "getting provider region information (cloud spec)")
+}
+
+// images returns the images that the provisioner could use for a machine
+// with the given series and constraints. As the provisioner does, it
+// uses the image metadata stored in the controller, falling back to the
+// model's image metadata sources if there is none; unlike the
+// provisioner, it does not store the metadata found in those sources.
+func (p *previewer) images(series string, region simplestreams.CloudSpec, cons constraints.Value) ([]*imagemetadata.ImageMetadata, error) {
+	var arches []string
+	if cons.Arch != nil {
+		arches = []string{*cons.Arch}
+	}
+	stream := p.modelConfig.ImageStream()
+	stored, err := p.mm.st.FindCloudImageMetadata(cloudimagemetadata.MetadataFilter{
+		Series: []string{series},
+		Arches: arches,
+		Region: region.Region,
+		Stream: stream,
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		logger.Infof("could not get image metadata from controller: %v", err)
+	}
+	var images []*imagemetadata.ImageMetadata
+	for _, metadata := range stored {
+		for _, m := range metadata {
+			images = append(images, &imagemetadata.ImageMetadata{
+				Id:          m.ImageId,
+				Arch:        m.Arch,
+				RegionAlias: m.Region,
+				RegionName:  m.Region,
+				Storage:     m.RootStorageType,
+				Stream:      m.Stream,
+				VirtType:    m.VirtType,
+				Version:     m.Version,
+			})
+		}
+	}
+	if len(images) > 0 {
+		return images, nil
+	}
+
+	sources, err := environs.ImageMetadataSources(p.env)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	imageConstraint := imagemetadata.NewImageConstraint(simplestreams.LookupParams{
+		CloudSpec: region,
+		Series:    []string{series},
+		Arches:    arches,
+		Stream:    stream,
+	})
+	for _, source := range sources {
+		found, _, err := imagemetadata.Fetch([]simplestreams.DataSource{source}, imageConstraint)
+		if err != nil {
+			logger.Warningf("encountered %v while getting published images metadata from %v", err, source.Description())
+			continue
+		}
+		images = append(images, found...)
+	}
+	if len(images) == 0 {
+		return nil, errors.NotFoundf("image metadata for series %v, arch %v", series, arches)
+	}
+	return images, nil
+}
+
+// zone returns the availability zone in which a machine would be started:
+// the given zone if it is available, or else the available zone allowed
+// by the constraints with the fewest instances. It returns "" if the
+// provider does not support availability zones.
+func (p *previewer) zone(zone string, cons constraints.Value) (string, error) {
+	zonedEnv, ok := p.env.(providercommon.ZonedEnviron)
+	if !ok {
+		if zone != "" {
+			return "", errors.NotSupportedf("availability zones")
+		}
+		return "", nil
+	}
+	if p.zoneMachines == nil {
+		zoneMachines, err := availabilityZoneMachines(zonedEnv, p.mm)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		p.zoneMachines = zoneMachines
+	}
+
+	if zone != "" {
+		if err := providercommon.ValidateAvailabilityZone(zonedEnv, p.mm.callContext, zone); err != nil {
+			return "", errors.Trace(err)
+		}
+		p.zoneMachines[zone]++
+		return zone, nil
+	}
+	var candidates []string
+	for name := range p.zoneMachines {
+		if !cons.HasZones() || set.NewStrings(*cons.Zones...).Contains(name) {
+			candidates = append(candidates, name)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", errors.NotFoundf("suitable availability zone")
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		ci, cj := p.zoneMachines[candidates[i]], p.zoneMachines[candidates[j]]
+		if ci != cj {
+			return ci < cj
+		}
+		return candidates[i] < candidates[j]
+	})
+	p.zoneMachines[candidates[0]]++
+	return candidates[0], nil
+}
+
+// availabilityZoneMachines returns the number of instances in each
+// available zone.
+func availabilityZoneMachines(env providercommon.ZonedEnviron, mm *MachineManagerAPI) (map[string]int, error) {
+	zones, err := env.AvailabilityZones(mm.callContext)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting availability zones")
+	}
+	result := make(map[string]int)
+	for _, z := range zones {
+		if z.Available() {
+			result[z.Name()] = 0
+		}
+	}
+	insts, err := env.AllInstances(mm.callContext)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting instances")
+	}
+	if len(insts) == 0 {
+		return result, nil
+	}
+	ids := make([]instance.Id, len(insts))
+	for i, inst := range insts {
+		ids[i] = inst.Id()
+	}
+	names, err := env.InstanceAvailabilityZoneNames(mm.callContext, ids)
+	if err != nil && err != environs.ErrPartialInstances {
+		return nil, errors.Annotate(err, "getting instance availability zones")
+	}
+	for _, name := range names {
+		if _, ok := result[name]; ok {
+			result[name]++
+		}
+	}
+	return result, nil
+}
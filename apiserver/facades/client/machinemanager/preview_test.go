@@ -0,0 +1,267 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/imagemetadata"
+	"github.com/juju/juju/environs/instances"
+	providercommon "github.com/juju/juju/provider/common"
+	"github.com/juju/juju/state/cloudimagemetadata"
+)
+
+type previewSuite struct {
+	backend *mockBackend
+	env     *previewEnviron
+	api     *machinemanager.MachineManagerAPI
+}
+
+var _ = gc.Suite(&previewSuite{})
+
+func (s *previewSuite) SetUpTest(c *gc.C) {
+	s.backend = &mockBackend{
+		imageMetadata: map[string][]cloudimagemetadata.Metadata{
+			"default cloud images": {{
+				MetadataAttributes: cloudimagemetadata.MetadataAttributes{Arch: "arm64", Series: "bionic"},
+				ImageId:            "ami-arm64",
+			}, {
+				MetadataAttributes: cloudimagemetadata.MetadataAttributes{Arch: "amd64", Series: "bionic"},
+				ImageId:            "ami-amd64",
+			}},
+		},
+	}
+	s.env = &previewEnviron{
+		instanceTypes: []instances.InstanceType{{
+			Name:     "small",
+			Arches:   []string{"amd64"},
+			CpuCores: 1,
+			Mem:      4096,
+			Cost:     10,
+		}, {
+			Name:     "large",
+			Arches:   []string{"amd64", "arm64"},
+			CpuCores: 4,
+			Mem:      16384,
+			Cost:     100,
+		}},
+		zones: []providercommon.AvailabilityZone{
+			&mockAvailabilityZone{"zone-a", true},
+			&mockAvailabilityZone{"zone-b", true},
+			&mockAvailabilityZone{"zone-c", false},
+		},
+		instanceZones: map[instance.Id]string{
+			"i-0": "zone-a",
+			"i-1": "zone-a",
+			"i-2": "zone-c",
+		},
+	}
+	authorizer := testing.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+	api, err := machinemanager.NewMachineManagerAPI(
+		s.backend, s.backend, &mockPool{}, authorizer, s.backend.ModelTag(),
+		context.NewCloudCallContext(), common.NewResources(),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *previewSuite) preview(c *gc.C, args ...params.AddMachineParams) []params.MachinePreviewResult {
+	getEnviron := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return s.env, nil
+	}
+	results, err := machinemanager.PreviewMachines(s.api, getEnviron, params.AddMachines{MachineParams: args})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, len(args))
+	return results.Results
+}
+
+func (s *previewSuite) TestPreviewMachines(c *gc.C) {
+	results := s.preview(c, params.AddMachineParams{
+		Series:      "bionic",
+		Constraints: constraints.MustParse("mem=8G"),
+	}, params.AddMachineParams{
+		Series: "bionic",
+	}, params.AddMachineParams{
+		Series:    "bionic",
+		Placement: &instance.Placement{Scope: "model-uuid", Directive: "zone=zone-a"},
+	})
+	c.Assert(results, jc.DeepEquals, []params.MachinePreviewResult{{
+		Series:           "bionic",
+		Arch:             "amd64",
+		InstanceType:     "large",
+		ImageId:          "ami-amd64",
+		AvailabilityZone: "zone-b",
+	}, {
+		// zone-b now has one machine and zone-a two.
+		Series:           "bionic",
+		Arch:             "amd64",
+		InstanceType:     "small",
+		ImageId:          "ami-amd64",
+		AvailabilityZone: "zone-b",
+	}, {
+		Series:           "bionic",
+		Arch:             "amd64",
+		InstanceType:     "small",
+		ImageId:          "ami-amd64",
+		AvailabilityZone: "zone-a",
+	}})
+}
+
+func (s *previewSuite) TestPreviewMachinesModelConstraints(c *gc.C) {
+	s.backend.modelCons = constraints.MustParse("arch=arm64")
+	results := s.preview(c, params.AddMachineParams{Series: "bionic"})
+	c.Assert(results, jc.DeepEquals, []params.MachinePreviewResult{{
+		Series:           "bionic",
+		Arch:             "arm64",
+		InstanceType:     "large",
+		ImageId:          "ami-arm64",
+		AvailabilityZone: "zone-b",
+	}})
+}
+
+func (s *previewSuite) TestPreviewMachinesErrors(c *gc.C) {
+	results := s.preview(c, params.AddMachineParams{
+		Series:        "bionic",
+		ContainerType: instance.LXD,
+	}, params.AddMachineParams{
+		Series:    "bionic",
+		Placement: &instance.Placement{Scope: "lxd", Directive: "0"},
+	}, params.AddMachineParams{
+		Series:     "bionic",
+		InstanceId: "manual:10.0.0.1",
+	}, params.AddMachineParams{
+		Series:    "bionic",
+		Placement: &instance.Placement{Scope: "model-uuid", Directive: "maas-node"},
+	}, params.AddMachineParams{
+		Series:    "bionic",
+		Placement: &instance.Placement{Scope: "model-uuid", Directive: "zone=zone-c"},
+	}, params.AddMachineParams{
+		Series:      "bionic",
+		Constraints: constraints.MustParse("cores=64"),
+	})
+	c.Assert(results[0].Error, gc.ErrorMatches, "previewing containers not supported")
+	c.Assert(results[1].Error, gc.ErrorMatches, "previewing containers not supported")
+	c.Assert(results[2].Error, gc.ErrorMatches, "previewing manually provisioned machines not supported")
+	c.Assert(results[3].Error, gc.ErrorMatches, `previewing placement "model-uuid:maas-node" not supported`)
+	c.Assert(results[4].Error, gc.ErrorMatches, `availability zone "zone-c" is unavailable`)
+	c.Assert(results[5].Error, gc.ErrorMatches, `no instance types in  matching constraints "cores=64"`)
+}
+
+func (s *previewSuite) TestPreviewMachinesInstanceSpecError(c *gc.C) {
+	s.env.err = errors.New("no instance types")
+	results := s.preview(c, params.AddMachineParams{Series: "bionic"})
+	c.Assert(results[0].Error, gc.ErrorMatches, "no instance types")
+}
+
+func (s *previewSuite) TestPreviewMachinesNotSupported(c *gc.C) {
+	// The environ can't preview instance specs.
+	env := struct{ providercommon.ZonedEnviron }{s.env}
+	getEnviron := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return env, nil
+	}
+	results, err := machinemanager.PreviewMachines(s.api, getEnviron, params.AddMachines{
+		MachineParams: []params.AddMachineParams{{Series: "bionic"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "previewing machines on this cloud not supported")
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeNotSupported)
+}
+
+func (s *previewSuite) TestPreviewMachinesPermissionDenied(c *gc.C) {
+	authorizer := testing.FakeAuthorizer{Tag: names.NewUserTag("bob")}
+	api, err := machinemanager.NewMachineManagerAPI(
+		s.backend, s.backend, &mockPool{}, authorizer, s.backend.ModelTag(),
+		context.NewCloudCallContext(), common.NewResources(),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = machinemanager.PreviewMachines(api, nil, params.AddMachines{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type previewEnviron struct {
+	providercommon.ZonedEnviron
+
+	instanceTypes []instances.InstanceType
+	err           error
+	zones         []providercommon.AvailabilityZone
+	instanceZones map[instance.Id]string
+}
+
+func (e *previewEnviron) PreviewInstanceSpec(
+	_ context.ProviderCallContext,
+	series string,
+	cons constraints.Value,
+	imageMetadata []*imagemetadata.ImageMetadata,
+) (*instances.InstanceSpec, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	arches := set.NewStrings()
+	for _, m := range imageMetadata {
+		arches.Add(m.Arch)
+	}
+	return instances.FindInstanceSpec(instances.ImageMetadataToImages(imageMetadata), &instances.InstanceConstraint{
+		Series:      series,
+		Arches:      arches.SortedValues(),
+		Constraints: cons,
+	}, e.instanceTypes)
+}
+
+func (e *previewEnviron) AvailabilityZones(context.ProviderCallContext) ([]providercommon.AvailabilityZone, error) {
+	return e.zones, nil
+}
+
+func (e *previewEnviron) AllInstances(context.ProviderCallContext) ([]instances.Instance, error) {
+	var result []instances.Instance
+	for id := range e.instanceZones {
+		result = append(result, &mockInstance{id: id})
+	}
+	return result, nil
+}
+
+func (e *previewEnviron) InstanceAvailabilityZoneNames(ctx context.ProviderCallContext, ids []instance.Id) ([]string, error) {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		result[i] = e.instanceZones[id]
+	}
+	return result, nil
+}
+
+type mockAvailabilityZone struct {
+	name      string
+	available bool
+}
+
+func (z *mockAvailabilityZone) Name() string {
+	return z.name
+}
+
+func (z *mockAvailabilityZone) Available() bool {
+	return z.available
+}
+
+type mockInstance struct {
+	instances.Instance
+	id instance.Id
+}
+
+func (i *mockInstance) Id() instance.Id {
+	return i.id
+}
@@ -8,10 +8,12 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common/storagecommon"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/cloudimagemetadata"
 )
 
 type Backend interface {
@@ -23,6 +25,9 @@ type Backend interface {
 	AddOneMachine(template state.MachineTemplate) (*state.Machine, error)
 	AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error)
 	AddMachineInsideMachine(template state.MachineTemplate, parentId string, containerType instance.ContainerType) (*state.Machine, error)
+	ReplaceMachine(id string) (Machine, error)
+	ResolveConstraints(cons constraints.Value) (constraints.Value, error)
+	FindCloudImageMetadata(filter cloudimagemetadata.MetadataFilter) (map[string][]cloudimagemetadata.Metadata, error)
 }
 
 type Pool interface {
@@ -39,6 +44,7 @@ type Model interface {
 }
 
 type Machine interface {
+	Id() string
 	Destroy() error
 	ForceDestroy() error
 	Series() string
@@ -66,6 +72,18 @@ func (s stateShim) Machine(name string) (Machine, error) {
 	return machineShim{m}, nil
 }
 
+func (s stateShim) ReplaceMachine(id string) (Machine, error) {
+	m, err := s.State.ReplaceMachine(id)
+	if m == nil {
+		return nil, err
+	}
+	return machineShim{m}, err
+}
+
+func (s stateShim) FindCloudImageMetadata(filter cloudimagemetadata.MetadataFilter) (map[string][]cloudimagemetadata.Metadata, error) {
+	return s.State.CloudImageMetadataStorage.FindMetadata(filter)
+}
+
 func (s stateShim) Model() (Model, error) {
 	return s.State.Model()
 }
@@ -7,7 +7,9 @@ import (
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/settings"
+	"github.com/juju/juju/state"
 )
 
 //go:generate mockgen -package mocks -destination mocks/package_mock.go github.com/juju/juju/apiserver/facades/client/modelgeneration State,Model,Generation,Application
@@ -30,6 +32,7 @@ type Model interface {
 // Generation defines the methods used by a generation.
 type Generation interface {
 	BranchName() string
+	Abort(string) error
 	Created() int64
 	CreatedBy() string
 	AssignAllUnits(string) error
@@ -37,6 +40,11 @@ type Generation interface {
 	AssignedUnits() map[string][]string
 	Commit(string) (int, error)
 	Config() map[string]settings.ItemChanges
+	CharmURLs() map[string]string
+	ResourceRevisions() map[string]map[string]int
+	Constraints() map[string]constraints.Value
+	Rollouts() map[string]state.BranchRollout
+	StartRollout(string, state.RolloutArgs) error
 }
 
 // Application describes application state used by the model generation API.
@@ -7,7 +7,9 @@ package mocks
 import (
 	gomock "github.com/golang/mock/gomock"
 	modelgeneration "github.com/juju/juju/apiserver/facades/client/modelgeneration"
+	constraints "github.com/juju/juju/core/constraints"
 	settings "github.com/juju/juju/core/settings"
+	state "github.com/juju/juju/state"
 	charm_v6 "gopkg.in/juju/charm.v6"
 	names_v2 "gopkg.in/juju/names.v2"
 	reflect "reflect"
@@ -170,6 +172,18 @@ func (m *MockGeneration) EXPECT() *MockGenerationMockRecorder {
 	return m.recorder
 }
 
+// Abort mocks base method
+func (m *MockGeneration) Abort(arg0 string) error {
+	ret := m.ctrl.Call(m, "Abort", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Abort indicates an expected call of Abort
+func (mr *MockGenerationMockRecorder) Abort(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Abort", reflect.TypeOf((*MockGeneration)(nil).Abort), arg0)
+}
+
 // AssignAllUnits mocks base method
 func (m *MockGeneration) AssignAllUnits(arg0 string) error {
 	ret := m.ctrl.Call(m, "AssignAllUnits", arg0)
@@ -218,6 +232,18 @@ func (mr *MockGenerationMockRecorder) BranchName() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BranchName", reflect.TypeOf((*MockGeneration)(nil).BranchName))
 }
 
+// CharmURLs mocks base method
+func (m *MockGeneration) CharmURLs() map[string]string {
+	ret := m.ctrl.Call(m, "CharmURLs")
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// CharmURLs indicates an expected call of CharmURLs
+func (mr *MockGenerationMockRecorder) CharmURLs() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CharmURLs", reflect.TypeOf((*MockGeneration)(nil).CharmURLs))
+}
+
 // Commit mocks base method
 func (m *MockGeneration) Commit(arg0 string) (int, error) {
 	ret := m.ctrl.Call(m, "Commit", arg0)
@@ -243,6 +269,18 @@ func (mr *MockGenerationMockRecorder) Config() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Config", reflect.TypeOf((*MockGeneration)(nil).Config))
 }
 
+// Constraints mocks base method
+func (m *MockGeneration) Constraints() map[string]constraints.Value {
+	ret := m.ctrl.Call(m, "Constraints")
+	ret0, _ := ret[0].(map[string]constraints.Value)
+	return ret0
+}
+
+// Constraints indicates an expected call of Constraints
+func (mr *MockGenerationMockRecorder) Constraints() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Constraints", reflect.TypeOf((*MockGeneration)(nil).Constraints))
+}
+
 // Created mocks base method
 func (m *MockGeneration) Created() int64 {
 	ret := m.ctrl.Call(m, "Created")
@@ -267,6 +305,42 @@ func (mr *MockGenerationMockRecorder) CreatedBy() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatedBy", reflect.TypeOf((*MockGeneration)(nil).CreatedBy))
 }
 
+// ResourceRevisions mocks base method
+func (m *MockGeneration) ResourceRevisions() map[string]map[string]int {
+	ret := m.ctrl.Call(m, "ResourceRevisions")
+	ret0, _ := ret[0].(map[string]map[string]int)
+	return ret0
+}
+
+// ResourceRevisions indicates an expected call of ResourceRevisions
+func (mr *MockGenerationMockRecorder) ResourceRevisions() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResourceRevisions", reflect.TypeOf((*MockGeneration)(nil).ResourceRevisions))
+}
+
+// Rollouts mocks base method
+func (m *MockGeneration) Rollouts() map[string]state.BranchRollout {
+	ret := m.ctrl.Call(m, "Rollouts")
+	ret0, _ := ret[0].(map[string]state.BranchRollout)
+	return ret0
+}
+
+// Rollouts indicates an expected call of Rollouts
+func (mr *MockGenerationMockRecorder) Rollouts() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollouts", reflect.TypeOf((*MockGeneration)(nil).Rollouts))
+}
+
+// StartRollout mocks base method
+func (m *MockGeneration) StartRollout(arg0 string, arg1 state.RolloutArgs) error {
+	ret := m.ctrl.Call(m, "StartRollout", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartRollout indicates an expected call of StartRollout
+func (mr *MockGenerationMockRecorder) StartRollout(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartRollout", reflect.TypeOf((*MockGeneration)(nil).StartRollout), arg0, arg1)
+}
+
 // MockApplication is a mock of Application interface
 type MockApplication struct {
 	ctrl     *gomock.Controller
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.modelgeneration")
@@ -31,7 +32,23 @@ type API struct {
 	model             Model
 }
 
-// NewModelGenerationFacade provides the signature required for facade registration.
+// APIV1 provides the ModelGeneration API facade for version 1.
+type APIV1 struct {
+	*API
+}
+
+// NewModelGenerationFacadeV1 provides the signature required for facade
+// registration for version 1.
+func NewModelGenerationFacadeV1(ctx facade.Context) (*APIV1, error) {
+	api, err := NewModelGenerationFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV1{api}, nil
+}
+
+// NewModelGenerationFacade provides the signature required for facade
+// registration for version 2.
 func NewModelGenerationFacade(ctx facade.Context) (*API, error) {
 	authorizer := ctx.Auth()
 	st := &stateShim{State: ctx.State()}
@@ -161,6 +178,68 @@ func (api *API) CommitBranch(arg params.BranchArg) (params.IntResult, error) {
 	return result, nil
 }
 
+// StartRollout begins moving the units of the input application onto the
+// input branch in waves. Each wave is started once the units in the previous
+// one are active and idle; the rollout halts if a unit goes into error or a
+// wave does not settle within the wave timeout.
+func (api *API) StartRollout(arg params.BranchRolloutArg) (params.ErrorResult, error) {
+	result := params.ErrorResult{}
+
+	isModelAdmin, err := api.hasAdminAccess()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !isModelAdmin && !api.isControllerAdmin {
+		return result, common.ErrPerm
+	}
+
+	generation, err := api.model.Branch(arg.BranchName)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	err = generation.StartRollout(arg.Application, state.RolloutArgs{
+		WaveSize:    arg.WaveSize,
+		WavePercent: arg.WavePercent,
+		WaveTimeout: arg.WaveTimeout,
+	})
+	if err != nil {
+		result.Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// AbortBranch aborts the input branch, marking it complete without applying
+// its changes to the model. Units tracking the branch revert to tracking
+// the master generation.
+func (api *API) AbortBranch(arg params.BranchArg) (params.ErrorResult, error) {
+	result := params.ErrorResult{}
+
+	isModelAdmin, err := api.hasAdminAccess()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !isModelAdmin && !api.isControllerAdmin {
+		return result, common.ErrPerm
+	}
+
+	generation, err := api.model.Branch(arg.BranchName)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	if err := generation.Abort(api.apiUser.Name()); err != nil {
+		result.Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// AbortBranch isn't on the V1 API.
+func (*APIV1) AbortBranch(_, _ struct{}) {}
+
+// StartRollout isn't on the V1 API.
+func (*APIV1) StartRollout(_, _ struct{}) {}
+
 // BranchInfo will return details of branch identified by the input argument,
 // including units on the branch and the configuration disjoint with the
 // master generation.
@@ -205,6 +284,10 @@ func (api *API) BranchInfo(args params.BranchInfoArgs) (params.GenerationResults
 
 func (api *API) oneBranchInfo(branch Generation, detailed bool) (params.Generation, error) {
 	delta := branch.Config()
+	charmURLs := branch.CharmURLs()
+	resources := branch.ResourceRevisions()
+	cons := branch.Constraints()
+	rollouts := branch.Rollouts()
 
 	var apps []params.GenerationApplication
 	for appName, tracking := range branch.AssignedUnits() {
@@ -229,9 +312,24 @@ func (api *API) oneBranchInfo(branch Generation, detailed bool) (params.Generati
 		}
 		branchApp.ConfigChanges = delta[appName].CurrentSettings(defaults)
 
-		// TODO (manadart 2019-04-12): Charm URL.
-
-		// TODO (manadart 2019-04-12): Resources.
+		branchApp.CharmURL = charmURLs[appName]
+		if appResources := resources[appName]; len(appResources) > 0 {
+			branchApp.Resources = appResources
+		}
+		if appCons, ok := cons[appName]; ok {
+			branchApp.Constraints = appCons.String()
+		}
+		if rollout, ok := rollouts[appName]; ok {
+			branchApp.Rollout = &params.BranchRollout{
+				WaveSize:    rollout.WaveSize,
+				WavePercent: rollout.WavePercent,
+				WaveTimeout: rollout.WaveTimeout,
+				Status:      string(rollout.Status),
+				Message:     rollout.Message,
+				Wave:        rollout.Wave,
+				WaveUnits:   rollout.WaveUnits,
+			}
+		}
 
 		// Only include unit names if detailed info was requested.
 		if detailed {
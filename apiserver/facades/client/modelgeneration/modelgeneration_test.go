@@ -4,6 +4,8 @@
 package modelgeneration_test
 
 import (
+	"time"
+
 	"github.com/golang/mock/gomock"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -14,8 +16,10 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/modelgeneration"
 	"github.com/juju/juju/apiserver/facades/client/modelgeneration/mocks"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/settings"
+	"github.com/juju/juju/state"
 )
 
 type modelGenerationSuite struct {
@@ -127,6 +131,47 @@ func (s *modelGenerationSuite) TestCommitGeneration(c *gc.C) {
 	c.Assert(result, gc.DeepEquals, params.IntResult{Result: 3, Error: nil})
 }
 
+func (s *modelGenerationSuite) TestStartRollout(c *gc.C) {
+	defer s.setupModelGenerationAPI(c, func(ctrl *gomock.Controller, _ *mocks.MockState, mod *mocks.MockModel) {
+		gen := mocks.NewMockGeneration(ctrl)
+		gen.EXPECT().StartRollout("redis", state.RolloutArgs{WavePercent: 25, WaveTimeout: time.Minute}).Return(nil)
+		mod.EXPECT().Branch(s.newBranchName).Return(gen, nil)
+	}).Finish()
+
+	result, err := s.api.StartRollout(params.BranchRolloutArg{
+		BranchName:  s.newBranchName,
+		Application: "redis",
+		WavePercent: 25,
+		WaveTimeout: time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResult{Error: nil})
+}
+
+func (s *modelGenerationSuite) TestAbortGeneration(c *gc.C) {
+	defer s.setupModelGenerationAPI(c, func(ctrl *gomock.Controller, _ *mocks.MockState, mod *mocks.MockModel) {
+		gen := mocks.NewMockGeneration(ctrl)
+		gen.EXPECT().Abort(s.apiUser).Return(nil)
+		mod.EXPECT().Branch(s.newBranchName).Return(gen, nil)
+	}).Finish()
+
+	result, err := s.api.AbortBranch(s.newBranchArg())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResult{Error: nil})
+}
+
+func (s *modelGenerationSuite) TestAbortGenerationCommittedError(c *gc.C) {
+	defer s.setupModelGenerationAPI(c, func(ctrl *gomock.Controller, _ *mocks.MockState, mod *mocks.MockModel) {
+		gen := mocks.NewMockGeneration(ctrl)
+		gen.EXPECT().Abort(s.apiUser).Return(errors.New("branch was already committed"))
+		mod.EXPECT().Branch(s.newBranchName).Return(gen, nil)
+	}).Finish()
+
+	result, err := s.api.AbortBranch(s.newBranchArg())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "branch was already committed")
+}
+
 func (s *modelGenerationSuite) TestHasActiveBranchTrue(c *gc.C) {
 	defer s.setupModelGenerationAPI(c, func(_ *gomock.Controller, _ *mocks.MockState, mockModel *mocks.MockModel) {
 		mockModel.EXPECT().Branch(s.newBranchName).Return(nil, nil)
@@ -168,6 +213,15 @@ func (s *modelGenerationSuite) testBranchInfo(c *gc.C, branchNames []string, det
 			settings.MakeDeletion("databases", 100),
 			settings.MakeModification("ignored-key", "unchanged", "unchanged"),
 		}})
+		gExp.CharmURLs().Return(map[string]string{"redis": "cs:redis-2"})
+		gExp.ResourceRevisions().Return(map[string]map[string]int{"redis": {"bin": 3}})
+		gExp.Constraints().Return(map[string]constraints.Value{"redis": constraints.MustParse("mem=4G")})
+		gExp.Rollouts().Return(map[string]state.BranchRollout{"redis": {
+			RolloutArgs: state.RolloutArgs{WaveSize: 2, WaveTimeout: time.Minute},
+			Status:      state.RolloutRunning,
+			Wave:        1,
+			WaveUnits:   units[:2],
+		}})
 		gExp.BranchName().Return(s.newBranchName)
 		gExp.AssignedUnits().Return(map[string][]string{"redis": units[:2]})
 		gExp.Created().Return(int64(666))
@@ -212,6 +266,16 @@ func (s *modelGenerationSuite) testBranchInfo(c *gc.C, branchNames []string, det
 		"password":  "added-pass",
 		"databases": 16,
 	})
+	c.Check(app.CharmURL, gc.Equals, "cs:redis-2")
+	c.Check(app.Resources, gc.DeepEquals, map[string]int{"bin": 3})
+	c.Check(app.Constraints, gc.Equals, "mem=4096M")
+	c.Check(app.Rollout, gc.DeepEquals, &params.BranchRollout{
+		WaveSize:    2,
+		WaveTimeout: time.Minute,
+		Status:      "running",
+		Wave:        1,
+		WaveUnits:   units[:2],
+	})
 
 	// Unit lists are only populated when detailed is true.
 	if detailed {
@@ -591,6 +591,18 @@ type fakeModelDescription struct {
 	UUID string `yaml:"model-uuid"`
 }
 
+func (m *fakeModelDescription) Type() string {
+	return "iaas"
+}
+
+func (m *fakeModelDescription) Machines() []description.Machine {
+	return nil
+}
+
+func (m *fakeModelDescription) Applications() []description.Application {
+	return nil
+}
+
 func (st *mockState) ModelUUID() string {
 	st.MethodCall(st, "ModelUUID")
 	return st.model.UUID()
@@ -39,6 +39,13 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
+// ModelManagerV8 defines the methods on the version 8 facade for the
+// modelmanager API endpoint.
+type ModelManagerV8 interface {
+	ModelManagerV7
+	ExportModels(args params.Entities) params.SerializedModelResults
+}
+
 // ModelManagerV7 defines the methods on the version 7 facade for the
 // modelmanager API endpoint.
 type ModelManagerV7 interface {
@@ -121,8 +128,14 @@ type ModelManagerAPI struct {
 	callContext context.ProviderCallContext
 }
 
+// ModelManagerAPIV7 provides a way to wrap the different calls between
+// version 7 and version 8 of the model manager API
+type ModelManagerAPIV7 struct {
+	*ModelManagerAPI
+}
+
 // ModelManagerAPIV6 provides a way to wrap the different calls between
-// version 6 and version 6 of the model manager API
+// version 6 and version 7 of the model manager API
 type ModelManagerAPIV6 struct {
 	*ModelManagerAPI
 }
@@ -152,7 +165,8 @@ type ModelManagerAPIV2 struct {
 }
 
 var (
-	_ ModelManagerV7 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV8 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV7 = (*ModelManagerAPIV7)(nil)
 	_ ModelManagerV6 = (*ModelManagerAPIV6)(nil)
 	_ ModelManagerV5 = (*ModelManagerAPIV5)(nil)
 	_ ModelManagerV4 = (*ModelManagerAPIV4)(nil)
@@ -160,8 +174,8 @@ var (
 	_ ModelManagerV2 = (*ModelManagerAPIV2)(nil)
 )
 
-// NewFacadeV7 is used for API registration.
-func NewFacadeV7(ctx facade.Context) (*ModelManagerAPI, error) {
+// NewFacadeV8 is used for API registration.
+func NewFacadeV8(ctx facade.Context) (*ModelManagerAPI, error) {
 	st := ctx.State()
 	pool := ctx.StatePool()
 	ctlrSt := pool.SystemState()
@@ -191,13 +205,22 @@ func NewFacadeV7(ctx facade.Context) (*ModelManagerAPI, error) {
 	)
 }
 
+// NewFacadeV7 is used for API registration.
+func NewFacadeV7(ctx facade.Context) (*ModelManagerAPIV7, error) {
+	v8, err := NewFacadeV8(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelManagerAPIV7{v8}, nil
+}
+
 // NewFacadeV6 is used for API registration.
 func NewFacadeV6(ctx facade.Context) (*ModelManagerAPIV6, error) {
-	v7, err := NewFacadeV7(ctx)
+	v8, err := NewFacadeV8(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return &ModelManagerAPIV6{v7}, nil
+	return &ModelManagerAPIV6{v8}, nil
 }
 
 // NewFacadeV5 is used for API registration.
@@ -674,6 +697,33 @@ func (m *ModelManagerAPI) newModel(
 }
 
 func (m *ModelManagerAPI) dumpModel(args params.Entity, simplified bool) ([]byte, error) {
+	var exportConfig state.ExportConfig
+	if simplified {
+		exportConfig.SkipActions = true
+		exportConfig.SkipAnnotations = true
+		exportConfig.SkipCloudImageMetadata = true
+		exportConfig.SkipCredentials = true
+		exportConfig.SkipIPAddresses = true
+		exportConfig.SkipSettings = true
+		exportConfig.SkipSSHHostKeys = true
+		exportConfig.SkipStatusHistory = true
+		exportConfig.SkipLinkLayerDevices = true
+	}
+
+	model, err := m.exportModel(args, exportConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	bytes, err := description.Serialize(model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return bytes, nil
+}
+
+// exportModel exports the model with the given tag, checking first
+// that the user is allowed to do so.
+func (m *ModelManagerAPI) exportModel(args params.Entity, exportConfig state.ExportConfig) (description.Model, error) {
 	modelTag, err := names.ParseModelTag(args.Tag)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -696,28 +746,11 @@ func (m *ModelManagerAPI) dumpModel(args params.Entity, simplified bool) ([]byte
 	}
 	defer release()
 
-	var exportConfig state.ExportConfig
-	if simplified {
-		exportConfig.SkipActions = true
-		exportConfig.SkipAnnotations = true
-		exportConfig.SkipCloudImageMetadata = true
-		exportConfig.SkipCredentials = true
-		exportConfig.SkipIPAddresses = true
-		exportConfig.SkipSettings = true
-		exportConfig.SkipSSHHostKeys = true
-		exportConfig.SkipStatusHistory = true
-		exportConfig.SkipLinkLayerDevices = true
-	}
-
 	model, err := st.ExportPartial(exportConfig)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	bytes, err := description.Serialize(model)
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	return bytes, nil
+	return model, nil
 }
 
 func (m *ModelManagerAPIV2) dumpModel(args params.Entity) (map[string]interface{}, error) {
@@ -807,6 +840,30 @@ func (m *ModelManagerAPIV2) DumpModels(args params.Entities) params.MapResults {
 	return results
 }
 
+// ExportModels exports the specified models, along with the lists of
+// charms, agent binaries and resources they use, in the same form as
+// is used for model migration. The user needs to either be a controller
+// admin, or have admin privileges on the model itself.
+func (m *ModelManagerAPI) ExportModels(args params.Entities) params.SerializedModelResults {
+	results := params.SerializedModelResults{
+		Results: make([]params.SerializedModelResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		model, err := m.exportModel(entity, state.ExportConfig{})
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		serialized, err := common.SerializeModel(model)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = &serialized
+	}
+	return results
+}
+
 // DumpModelsDB will gather all documents from all model collections
 // for the specified model. The map result contains a map of collection
 // names to lists of documents represented as maps.
@@ -1575,3 +1632,9 @@ func (*ModelManagerAPIV4) ChangeModelCredential(_, _ struct{}) {}
 
 // ModelDefaultsForClouds did not exist prior to v6.
 func (*ModelManagerAPIV5) ModelDefaultsForClouds(_, _ struct{}) {}
+
+// ExportModels did not exist prior to v8.
+func (*ModelManagerAPIV7) ExportModels(_, _ struct{}) {}
+
+// ExportModels did not exist prior to v8.
+func (*ModelManagerAPIV6) ExportModels(_, _ struct{}) {}
@@ -0,0 +1,42 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchpruner
+
+import (
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// API is the facade used by the branch pruner worker to remove
+// committed and aborted model branches.
+type API struct {
+	*common.ModelWatcher
+	st         *state.State
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns a new branch pruner API facade.
+func NewAPI(st *state.State, r facade.Resources, auth facade.Authorizer) (*API, error) {
+	m, err := st.Model()
+	if err != nil {
+		return nil, err
+	}
+
+	return &API{
+		ModelWatcher: common.NewModelWatcher(m, r, auth),
+		st:           st,
+		authorizer:   auth,
+	}, nil
+}
+
+// Prune removes branches that were completed before the input maximum age.
+func (api *API) Prune(p params.BranchPruneArgs) error {
+	if !api.authorizer.AuthController() {
+		return common.ErrPerm
+	}
+
+	return state.PruneBranches(api.st, p.MaxAge)
+}
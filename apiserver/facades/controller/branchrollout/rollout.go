@@ -0,0 +1,35 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchrollout
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+)
+
+// API is the facade used by the branch rollout worker to move application
+// units onto model branches in waves.
+type API struct {
+	st         *state.State
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns a new branch rollout API facade.
+func NewAPI(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	if !auth.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		st:         st,
+		authorizer: auth,
+	}, nil
+}
+
+// ProgressRollouts advances the running rollouts of all in-flight branches.
+func (api *API) ProgressRollouts() error {
+	return errors.Trace(api.st.ProgressBranchRollouts())
+}
@@ -207,6 +207,11 @@ func (m *mockApplication) SetOperatorStatus(sInfo status.StatusInfo) error {
 	return nil
 }
 
+func (m *mockApplication) RecordOperatorEvents(events []status.StatusInfo) error {
+	m.MethodCall(m, "RecordOperatorEvents", events)
+	return nil
+}
+
 func (m *mockApplication) SetStatus(sInfo status.StatusInfo) error {
 	m.MethodCall(m, "SetStatus", sInfo)
 	return nil
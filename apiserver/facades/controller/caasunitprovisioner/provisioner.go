@@ -31,6 +31,12 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.controller.caasunitprovisioner")
 
+// FacadeV1 implements the V1 API used by the CAAS unit provisioner. It
+// lacks the RecordOperatorEvents method.
+type FacadeV1 struct {
+	*Facade
+}
+
 type Facade struct {
 	*common.LifeGetter
 	resources          facade.Resources
@@ -72,6 +78,16 @@ func NewStateFacade(ctx facade.Context) (*Facade, error) {
 	)
 }
 
+// NewStateFacadeV1 provides the signature required for facade registration
+// of the V1 API.
+func NewStateFacadeV1(ctx facade.Context) (*FacadeV1, error) {
+	f, err := NewStateFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV1{f}, nil
+}
+
 // NewFacade returns a new CAAS unit provisioner Facade facade.
 func NewFacade(
 	resources facade.Resources,
@@ -558,6 +574,21 @@ func (a *Facade) updateStatus(params params.ApplicationUnitParams) (
 	return agentStatus, cloudContainerStatus
 }
 
+// cloudEvents converts the events reported by the cloud
+// for a unit or operator into status history values.
+func cloudEvents(events []params.EntityStatus) []status.StatusInfo {
+	var result []status.StatusInfo
+	for _, event := range events {
+		result = append(result, status.StatusInfo{
+			Status:  event.Status,
+			Message: event.Info,
+			Data:    event.Data,
+			Since:   event.Since,
+		})
+	}
+	return result
+}
+
 // updateUnitsFromCloud takes a slice of unit information provided by an external
 // source (typically a cloud update event) and merges that with the existing unit
 // data model in state. The passed in units are the complete set for the cloud, so
@@ -810,6 +841,7 @@ func (a *Facade) updateStateUnits(app Application, unitInfo *updateStateUnitPara
 			Ports:                &unitParams.Ports,
 			AgentStatus:          agentStatus,
 			CloudContainerStatus: cloudContainerStatus,
+			CloudContainerEvents: cloudEvents(unitParams.Events),
 		}
 	}
 
@@ -1271,3 +1303,30 @@ func (a *Facade) SetOperatorStatus(args params.SetStatus) (params.ErrorResults,
 	}
 	return result, nil
 }
+
+// RecordOperatorEvents isn't on the V1 API.
+func (*FacadeV1) RecordOperatorEvents(_, _ struct{}) {}
+
+// RecordOperatorEvents records the events reported by the cloud
+// for the operator of each given application in status history.
+func (a *Facade) RecordOperatorEvents(args params.CloudEventsArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		appTag, err := names.ParseApplicationTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		app, err := a.state.Application(appTag.Id())
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if err := app.RecordOperatorEvents(cloudEvents(arg.Events)); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
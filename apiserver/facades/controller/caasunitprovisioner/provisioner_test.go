@@ -370,6 +370,47 @@ func (s *CAASProvisionerSuite) TestUpdateApplicationsStatelessUnits(c *gc.C) {
 	})
 }
 
+func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsWithEvents(c *gc.C) {
+	s.st.application.units = []caasunitprovisioner.Unit{
+		&mockUnit{name: "gitlab/0", containerInfo: &mockContainerInfo{providerId: "uuid"}, life: state.Alive},
+	}
+	s.st.application.scale = 1
+
+	since := time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC)
+	units := []params.ApplicationUnitParams{
+		{ProviderId: "uuid", Address: "address", Ports: []string{"port"},
+			Status: "allocating", Info: "pulling image",
+			Events: []params.EntityStatus{{
+				Status: "BackOff",
+				Info:   "Back-off pulling image",
+				Data:   map[string]interface{}{"type": "Warning", "object": "Pod/gitlab-0"},
+				Since:  &since,
+			}},
+		},
+	}
+	args := params.UpdateApplicationUnitArgs{
+		Args: []params.UpdateApplicationUnits{
+			{ApplicationTag: "application-gitlab", Units: units, Scale: intPtr(1)},
+		},
+	}
+	results, err := s.facade.UpdateApplicationsUnits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	s.st.application.units[0].(*mockUnit).CheckCallNames(c, "Life", "UpdateOperation")
+	s.st.application.units[0].(*mockUnit).CheckCall(c, 1, "UpdateOperation", state.UnitUpdateProperties{
+		ProviderId: strPtr("uuid"),
+		Address:    strPtr("address"), Ports: &[]string{"port"},
+		CloudContainerStatus: &status.StatusInfo{Status: status.Waiting, Message: "pulling image"},
+		AgentStatus:          &status.StatusInfo{Status: status.Allocating, Message: "pulling image"},
+		CloudContainerEvents: []status.StatusInfo{{
+			Status:  "BackOff",
+			Message: "Back-off pulling image",
+			Data:    map[string]interface{}{"type": "Warning", "object": "Pod/gitlab-0"},
+			Since:   &since,
+		}},
+	})
+}
+
 func (s *CAASProvisionerSuite) TestUpdateApplicationsScaleChange(c *gc.C) {
 	s.st.application.units = []caasunitprovisioner.Unit{
 		&mockUnit{name: "gitlab/0", containerInfo: &mockContainerInfo{providerId: "uuid"}, life: state.Alive},
@@ -769,3 +810,28 @@ func (s *CAASProvisionerSuite) TestSetOperatorStatus(c *gc.C) {
 		Since:   &now,
 	})
 }
+
+func (s *CAASProvisionerSuite) TestRecordOperatorEvents(c *gc.C) {
+	since := time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC)
+	results, err := s.facade.RecordOperatorEvents(params.CloudEventsArgs{
+		Args: []params.CloudEvents{
+			{Tag: "application-gitlab", Events: []params.EntityStatus{{
+				Status: "FailedMount",
+				Info:   "Unable to mount volumes",
+				Since:  &since,
+			}}},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, jc.DeepEquals, &params.Error{
+		Message: `"unit-gitlab-0" is not a valid application tag`,
+	})
+	s.st.application.CheckCall(c, 0, "RecordOperatorEvents", []status.StatusInfo{{
+		Status:  "FailedMount",
+		Message: "Unable to mount volumes",
+		Since:   &since,
+	}})
+}
@@ -81,6 +81,7 @@ type Application interface {
 	Constraints() (constraints.Value, error)
 	GetPlacement() string
 	SetOperatorStatus(sInfo status.StatusInfo) error
+	RecordOperatorEvents(events []status.StatusInfo) error
 	SetStatus(statusInfo status.StatusInfo) error
 }
 
@@ -0,0 +1,58 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinehealth
+
+import (
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the methods the machine health facade needs from
+// state.State.
+type Backend interface {
+	state.ModelAccessor
+
+	// AllMachines returns all of the machines in the model.
+	AllMachines() ([]Machine, error)
+
+	// Machine returns the machine with the given id.
+	Machine(id string) (Machine, error)
+}
+
+// Machine defines the methods the machine health facade needs from
+// state.Machine.
+type Machine interface {
+	common.MachineStatusGetter
+	IsContainer() bool
+	IsManager() bool
+	IsManual() (bool, error)
+	InstanceId() (instance.Id, error)
+	SetStatus(status.StatusInfo) error
+	Reprovision(message string) error
+}
+
+type backendShim struct {
+	*state.State
+	*state.Model
+}
+
+// AllMachines implements Backend.
+func (b *backendShim) AllMachines() ([]Machine, error) {
+	machines, err := b.State.AllMachines()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Machine, len(machines))
+	for i, m := range machines {
+		result[i] = m
+	}
+	return result, nil
+}
+
+// Machine implements Backend.
+func (b *backendShim) Machine(id string) (Machine, error) {
+	return b.State.Machine(id)
+}
@@ -0,0 +1,177 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinehealth
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.machinehealth")
+
+// API implements the API facade used by the machine health worker to
+// find machines whose agents are lost, and to mark them as failed.
+type API struct {
+	*common.ModelWatcher
+	backend  Backend
+	presence common.ModelPresenceContext
+}
+
+// NewAPI returns a new machine health API facade. If presence is nil,
+// the agent presence recorded in state is used to decide whether a
+// machine's agent is lost.
+func NewAPI(
+	backend Backend,
+	presence common.ModelPresence,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, errors.Trace(common.ErrPerm)
+	}
+	return &API{
+		ModelWatcher: common.NewModelWatcher(backend, resources, authorizer),
+		backend:      backend,
+		presence:     common.ModelPresenceContext{Presence: presence},
+	}, nil
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(
+		&backendShim{st, m},
+		ctx.Presence().ModelPresence(st.ModelUUID()),
+		ctx.Resources(),
+		ctx.Auth(),
+	)
+}
+
+// LostMachines returns the provisioned machines whose agents are
+// reported as down. Containers, controllers, manually provisioned
+// machines and machines that have already been marked as failed are
+// not included.
+//
+// The time at which an agent's presence was lost isn't recorded, so
+// each machine is reported as lost since its agent or instance status
+// last changed, whichever is later.
+func (api *API) LostMachines() (params.LostMachinesResult, error) {
+	var result params.LostMachinesResult
+	machines, err := api.backend.AllMachines()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, m := range machines {
+		lost, err := api.isLost(m)
+		if err != nil {
+			return result, errors.Annotatef(err, "machine %s", m.Id())
+		}
+		if lost == nil {
+			continue
+		}
+		instId, err := m.InstanceId()
+		if err != nil {
+			return result, errors.Annotatef(err, "machine %s", m.Id())
+		}
+		result.Machines = append(result.Machines, params.LostMachine{
+			Tag:        names.NewMachineTag(m.Id()).String(),
+			InstanceId: string(instId),
+			Since:      *lost,
+		})
+	}
+	return result, nil
+}
+
+// isLost returns the time since which the machine's agent has been
+// lost, or nil if it isn't lost.
+func (api *API) isLost(m Machine) (*time.Time, error) {
+	if m.Life() != state.Alive || m.IsContainer() || m.IsManager() {
+		return nil, nil
+	}
+	if manual, err := m.IsManual(); err != nil || manual {
+		return nil, errors.Trace(err)
+	}
+	if _, err := m.InstanceId(); errors.IsNotProvisioned(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineStatus, err := m.Status()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if machineStatus.Status == status.Error {
+		return nil, nil
+	}
+	machineStatus, err = api.presence.MachineStatus(m)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if machineStatus.Status != status.Down {
+		return nil, nil
+	}
+
+	var since time.Time
+	if machineStatus.Since != nil {
+		since = *machineStatus.Since
+	}
+	instanceStatus, err := m.InstanceStatus()
+	if err != nil {
+		logger.Debugf("cannot get instance status of machine %s: %v", m.Id(), err)
+	} else if instanceStatus.Since != nil && instanceStatus.Since.After(since) {
+		since = *instanceStatus.Since
+	}
+	return &since, nil
+}
+
+// MarkMachinesFailed sets the status of each of the machines to error,
+// with the given message, and reprovisions those that are to be
+// reprovisioned.
+func (api *API) MarkMachinesFailed(args params.MachineFailures) params.ErrorResults {
+	results := make([]params.ErrorResult, len(args.Failures))
+	for i, failure := range args.Failures {
+		err := api.markMachineFailed(failure)
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{Results: results}
+}
+
+func (api *API) markMachineFailed(failure params.MachineFailure) error {
+	tag, err := names.ParseMachineTag(failure.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	m, err := api.backend.Machine(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	instId, err := m.InstanceId()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := m.SetStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: failure.Message,
+		Data:    map[string]interface{}{"instance-id": string(instId)},
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	if !failure.Reprovision {
+		return nil
+	}
+	return errors.Trace(m.Reprovision("replacing instance " + string(instId)))
+}
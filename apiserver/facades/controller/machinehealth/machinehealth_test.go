@@ -0,0 +1,238 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinehealth_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/machinehealth"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+type machineHealthSuite struct {
+	testing.IsolationSuite
+
+	backend *mockBackend
+	api     *machinehealth.API
+}
+
+var _ = gc.Suite(&machineHealthSuite{})
+
+func (s *machineHealthSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{}
+	api, err := machinehealth.NewAPI(
+		s.backend, nil, common.NewResources(),
+		apiservertesting.FakeAuthorizer{Controller: true},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *machineHealthSuite) TestRequiresController(c *gc.C) {
+	_, err := machinehealth.NewAPI(
+		s.backend, nil, common.NewResources(),
+		apiservertesting.FakeAuthorizer{Controller: false},
+	)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *machineHealthSuite) TestLostMachines(c *gc.C) {
+	agentSince := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	instanceSince := agentSince.Add(time.Hour)
+	restarted := newMockMachine("9", "i-9", false)
+	restarted.agentSince = &instanceSince
+	restarted.instanceSince = &agentSince
+	s.backend.machines = []*mockMachine{
+		newMockMachine("0", "i-0", false),
+		newMockMachine("1", "i-1", true),
+		newMockMachine("2", "", false),
+	}
+	s.backend.machines[0].agentSince = &agentSince
+	s.backend.machines[0].instanceSince = &instanceSince
+	manager := newMockMachine("3", "i-3", false)
+	manager.manager = true
+	manual := newMockMachine("4", "i-4", false)
+	manual.manual = true
+	container := newMockMachine("5/lxd/0", "i-5", false)
+	container.container = true
+	failed := newMockMachine("6", "i-6", false)
+	failed.status = status.Error
+	dying := newMockMachine("7", "i-7", false)
+	dying.life = state.Dying
+	pending := newMockMachine("8", "i-8", false)
+	pending.status = status.Pending
+	s.backend.machines = append(s.backend.machines, manager, manual, container, failed, dying, pending, restarted)
+
+	result, err := s.api.LostMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.LostMachinesResult{
+		Machines: []params.LostMachine{
+			{Tag: "machine-0", InstanceId: "i-0", Since: instanceSince},
+			{Tag: "machine-9", InstanceId: "i-9", Since: instanceSince},
+		},
+	})
+}
+
+func (s *machineHealthSuite) TestLostMachinesError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	_, err := s.api.LostMachines()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *machineHealthSuite) TestMarkMachinesFailed(c *gc.C) {
+	m0 := newMockMachine("0", "i-0", false)
+	m1 := newMockMachine("1", "i-1", false)
+	s.backend.machines = []*mockMachine{m0, m1}
+
+	results := s.api.MarkMachinesFailed(params.MachineFailures{
+		Failures: []params.MachineFailure{
+			{Tag: "machine-0", Message: "instance i-0 is missing"},
+			{Tag: "machine-1", Message: "instance i-1 is stopped", Reprovision: true},
+			{Tag: "machine-42", Message: "instance i-42 is missing"},
+			{Tag: "unit-foo-0"},
+		},
+	})
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.IsNil)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, "machine 42 not found")
+	c.Assert(results.Results[3].Error, gc.ErrorMatches, `"unit-foo-0" is not a valid machine tag`)
+
+	m0.CheckCalls(c, []testing.StubCall{{"SetStatus", []interface{}{status.StatusInfo{
+		Status:  status.Error,
+		Message: "instance i-0 is missing",
+		Data:    map[string]interface{}{"instance-id": "i-0"},
+	}}}})
+	m1.CheckCalls(c, []testing.StubCall{{"SetStatus", []interface{}{status.StatusInfo{
+		Status:  status.Error,
+		Message: "instance i-1 is stopped",
+		Data:    map[string]interface{}{"instance-id": "i-1"},
+	}}}, {"Reprovision", []interface{}{"replacing instance i-1"}}})
+}
+
+type mockBackend struct {
+	testing.Stub
+	machines []*mockMachine
+}
+
+func (b *mockBackend) AllMachines() ([]machinehealth.Machine, error) {
+	b.MethodCall(b, "AllMachines")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	result := make([]machinehealth.Machine, len(b.machines))
+	for i, m := range b.machines {
+		result[i] = m
+	}
+	return result, nil
+}
+
+func (b *mockBackend) Machine(id string) (machinehealth.Machine, error) {
+	b.MethodCall(b, "Machine", id)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	for _, m := range b.machines {
+		if m.id == id {
+			return m, nil
+		}
+	}
+	return nil, errors.NotFoundf("machine %s", id)
+}
+
+func (b *mockBackend) ModelConfig() (*config.Config, error) {
+	b.MethodCall(b, "ModelConfig")
+	return nil, errors.NotImplementedf("ModelConfig")
+}
+
+func (b *mockBackend) WatchForModelConfigChanges() state.NotifyWatcher {
+	b.MethodCall(b, "WatchForModelConfigChanges")
+	return nil
+}
+
+type mockMachine struct {
+	testing.Stub
+	id        string
+	instId    instance.Id
+	life      state.Life
+	status    status.Status
+	alive     bool
+	container bool
+	manager   bool
+	manual    bool
+
+	agentSince    *time.Time
+	instanceSince *time.Time
+}
+
+func newMockMachine(id string, instId instance.Id, alive bool) *mockMachine {
+	return &mockMachine{
+		id:     id,
+		instId: instId,
+		life:   state.Alive,
+		status: status.Started,
+		alive:  alive,
+	}
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) Life() state.Life {
+	return m.life
+}
+
+func (m *mockMachine) IsContainer() bool {
+	return m.container
+}
+
+func (m *mockMachine) IsManager() bool {
+	return m.manager
+}
+
+func (m *mockMachine) IsManual() (bool, error) {
+	return m.manual, nil
+}
+
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	if m.instId == "" {
+		return "", errors.NotProvisionedf("machine %s", m.id)
+	}
+	return m.instId, nil
+}
+
+func (m *mockMachine) Status() (status.StatusInfo, error) {
+	return status.StatusInfo{Status: m.status, Since: m.agentSince}, nil
+}
+
+func (m *mockMachine) InstanceStatus() (status.StatusInfo, error) {
+	return status.StatusInfo{Status: status.Running, Since: m.instanceSince}, nil
+}
+
+func (m *mockMachine) AgentPresence() (bool, error) {
+	return m.alive, nil
+}
+
+func (m *mockMachine) SetStatus(info status.StatusInfo) error {
+	m.MethodCall(m, "SetStatus", info)
+	return m.NextErr()
+}
+
+func (m *mockMachine) Reprovision(message string) error {
+	m.MethodCall(m, "Reprovision", message)
+	return m.NextErr()
+}
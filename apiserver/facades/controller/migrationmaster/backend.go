@@ -22,5 +22,9 @@ type Backend interface {
 	AgentVersion() (version.Number, error)
 	RemoveExportingModelDocs() error
 
+	// StartQueuedMigrations starts the queued migrations of a
+	// migration batch, once their prechecks have passed.
+	StartQueuedMigrations(batchId string) error
+
 	migration.StateExporter
 }
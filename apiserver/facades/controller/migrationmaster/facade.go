@@ -6,22 +6,21 @@ package migrationmaster
 import (
 	"encoding/json"
 
-	"github.com/juju/collections/set"
-	"github.com/juju/description"
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"github.com/juju/naturalsort"
-	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	coremigration "github.com/juju/juju/core/migration"
-	coremodel "github.com/juju/juju/core/model"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state/watcher"
 )
 
+var logger = loggo.GetLogger("juju.apiserver.migrationmaster")
+
 // API implements the API required for the model migration
 // master worker.
 type API struct {
@@ -103,10 +102,12 @@ func (api *API) MigrationStatus() (params.MasterMigrationStatus, error) {
 				Password:      target.Password,
 				Macaroons:     string(macsJSON),
 			},
+			Resume: mig.Resume(),
 		},
 		MigrationId:      mig.Id(),
 		Phase:            phase.String(),
 		PhaseChangedTime: mig.PhaseChangedTime(),
+		KeepImport:       mig.KeepImport(),
 	}, nil
 }
 
@@ -152,8 +153,17 @@ func (api *API) SetPhase(args params.SetMigrationPhaseArgs) error {
 		return errors.Errorf("invalid phase: %q", args.Phase)
 	}
 
-	err = mig.SetPhase(phase)
-	return errors.Annotate(err, "failed to set phase")
+	if err := mig.SetPhase(phase); err != nil {
+		return errors.Annotate(err, "failed to set phase")
+	}
+
+	// Make way for the next migrations in the batch.
+	if phase.IsTerminal() && mig.BatchId() != "" {
+		if err := api.backend.StartQueuedMigrations(mig.BatchId()); err != nil {
+			logger.Errorf("cannot start queued migrations in batch %s: %v", mig.BatchId(), err)
+		}
+	}
+	return nil
 }
 
 // Prechecks performs pre-migration checks on the model and
@@ -190,26 +200,24 @@ func (api *API) SetStatusMessage(args params.SetMigrationStatusMessageArgs) erro
 	return errors.Annotate(err, "failed to set status message")
 }
 
-// Export serializes the model associated with the API connection.
-func (api *API) Export() (params.SerializedModel, error) {
-	var serialized params.SerializedModel
-
-	model, err := api.backend.Export()
+// SetKeepImport records whether the imported model is kept on the
+// target controller if the migration is aborted.
+func (api *API) SetKeepImport(args params.SetMigrationKeepImportArgs) error {
+	mig, err := api.backend.LatestMigration()
 	if err != nil {
-		return serialized, err
+		return errors.Annotate(err, "could not get migration")
 	}
+	err = mig.SetKeepImport(args.KeepImport)
+	return errors.Annotate(err, "failed to set keep import")
+}
 
-	bytes, err := description.Serialize(model)
+// Export serializes the model associated with the API connection.
+func (api *API) Export() (params.SerializedModel, error) {
+	model, err := api.backend.Export()
 	if err != nil {
-		return serialized, err
-	}
-	serialized.Bytes = bytes
-	serialized.Charms = getUsedCharms(model)
-	serialized.Resources = getUsedResources(model)
-	if model.Type() == string(coremodel.IAAS) {
-		serialized.Tools = getUsedTools(model)
+		return params.SerializedModel{}, err
 	}
-	return serialized, nil
+	return common.SerializeModel(model)
 }
 
 // Reap removes all documents for the model associated with the API
@@ -299,97 +307,3 @@ func (api *API) MinionReports() (params.MinionReports, error) {
 
 	return out, nil
 }
-
-func getUsedCharms(model description.Model) []string {
-	result := set.NewStrings()
-	for _, application := range model.Applications() {
-		result.Add(application.CharmURL())
-	}
-	return result.Values()
-}
-
-func getUsedTools(model description.Model) []params.SerializedModelTools {
-	// Iterate through the model for all tools, and make a map of them.
-	usedVersions := make(map[version.Binary]bool)
-	// It is most likely that the preconditions will limit the number of
-	// tools versions in use, but that is not relied on here.
-	for _, machine := range model.Machines() {
-		addToolsVersionForMachine(machine, usedVersions)
-	}
-
-	for _, application := range model.Applications() {
-		for _, unit := range application.Units() {
-			tools := unit.Tools()
-			usedVersions[tools.Version()] = true
-		}
-	}
-
-	out := make([]params.SerializedModelTools, 0, len(usedVersions))
-	for v := range usedVersions {
-		out = append(out, params.SerializedModelTools{
-			Version: v.String(),
-			URI:     common.ToolsURL("", v),
-		})
-	}
-	return out
-}
-
-func addToolsVersionForMachine(machine description.Machine, usedVersions map[version.Binary]bool) {
-	tools := machine.Tools()
-	usedVersions[tools.Version()] = true
-	for _, container := range machine.Containers() {
-		addToolsVersionForMachine(container, usedVersions)
-	}
-}
-
-func getUsedResources(model description.Model) []params.SerializedModelResource {
-	var out []params.SerializedModelResource
-	for _, app := range model.Applications() {
-		for _, resource := range app.Resources() {
-			outRes := resourceToSerialized(app.Name(), resource)
-
-			// Hunt through the application's units and look for
-			// revisions of this resource. This is particularly
-			// efficient or clever but will be fine even with 1000's
-			// of units and 10's of resources.
-			outRes.UnitRevisions = make(map[string]params.SerializedModelResourceRevision)
-			for _, unit := range app.Units() {
-				for _, unitResource := range unit.Resources() {
-					if unitResource.Name() == resource.Name() {
-						outRes.UnitRevisions[unit.Name()] = revisionToSerialized(unitResource.Revision())
-					}
-				}
-			}
-
-			out = append(out, outRes)
-		}
-
-	}
-	return out
-}
-
-func resourceToSerialized(app string, desc description.Resource) params.SerializedModelResource {
-	return params.SerializedModelResource{
-		Application:         app,
-		Name:                desc.Name(),
-		ApplicationRevision: revisionToSerialized(desc.ApplicationRevision()),
-		CharmStoreRevision:  revisionToSerialized(desc.CharmStoreRevision()),
-	}
-}
-
-func revisionToSerialized(rr description.ResourceRevision) params.SerializedModelResourceRevision {
-	if rr == nil {
-		return params.SerializedModelResourceRevision{}
-	}
-	return params.SerializedModelResourceRevision{
-		Revision:       rr.Revision(),
-		Type:           rr.Type(),
-		Path:           rr.Path(),
-		Description:    rr.Description(),
-		Origin:         rr.Origin(),
-		FingerprintHex: rr.FingerprintHex(),
-		Size:           rr.Size(),
-		Timestamp:      rr.Timestamp(),
-		Username:       rr.Username(),
-	}
-}
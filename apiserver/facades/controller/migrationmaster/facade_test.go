@@ -117,6 +117,22 @@ func (s *Suite) TestMigrationStatus(c *gc.C) {
 	})
 }
 
+func (s *Suite) TestMigrationStatusResume(c *gc.C) {
+	s.backend.migration.resume = true
+	api := s.mustMakeAPI(c)
+	status, err := api.MigrationStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.Spec.Resume, jc.IsTrue)
+}
+
+func (s *Suite) TestMigrationStatusKeepImport(c *gc.C) {
+	s.backend.migration.keepImport = true
+	api := s.mustMakeAPI(c)
+	status, err := api.MigrationStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.KeepImport, jc.IsTrue)
+}
+
 func (s *Suite) TestModelInfo(c *gc.C) {
 	api := s.mustMakeAPI(c)
 	model, err := api.ModelInfo()
@@ -136,6 +152,24 @@ func (s *Suite) TestSetPhase(c *gc.C) {
 	c.Assert(s.backend.migration.phaseSet, gc.Equals, coremigration.ABORT)
 }
 
+func (s *Suite) TestSetPhaseStartsQueuedMigrations(c *gc.C) {
+	s.backend.migration.batchId = "7"
+	api := s.mustMakeAPI(c)
+
+	err := api.SetPhase(params.SetMigrationPhaseArgs{Phase: "ABORTDONE"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCall(c, 1, "StartQueuedMigrations", "7")
+}
+
+func (s *Suite) TestSetPhaseNonTerminalDoesNotStartQueuedMigrations(c *gc.C) {
+	s.backend.migration.batchId = "7"
+	api := s.mustMakeAPI(c)
+
+	err := api.SetPhase(params.SetMigrationPhaseArgs{Phase: "ABORT"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCallNames(c, "LatestMigration")
+}
+
 func (s *Suite) TestSetPhaseNoMigration(c *gc.C) {
 	s.backend.getErr = errors.New("boom")
 	api := s.mustMakeAPI(c)
@@ -183,6 +217,22 @@ func (s *Suite) TestSetStatusMessageError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "failed to set status message: blam")
 }
 
+func (s *Suite) TestSetKeepImport(c *gc.C) {
+	api := s.mustMakeAPI(c)
+
+	err := api.SetKeepImport(params.SetMigrationKeepImportArgs{KeepImport: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.backend.migration.keepImport, jc.IsTrue)
+}
+
+func (s *Suite) TestSetKeepImportError(c *gc.C) {
+	s.backend.migration.setKeepImportErr = errors.New("blam")
+	api := s.mustMakeAPI(c)
+
+	err := api.SetKeepImport(params.SetMigrationKeepImportArgs{KeepImport: true})
+	c.Assert(err, gc.ErrorMatches, "failed to set keep import: blam")
+}
+
 func (s *Suite) TestPrechecks(c *gc.C) {
 	api := s.mustMakeAPI(c)
 	err := api.Prechecks()
@@ -478,6 +528,11 @@ func (b *stubBackend) RemoveExportingModelDocs() error {
 	return b.removeErr
 }
 
+func (b *stubBackend) StartQueuedMigrations(batchId string) error {
+	b.stub.AddCall("StartQueuedMigrations", batchId)
+	return nil
+}
+
 func (b *stubBackend) Export() (description.Model, error) {
 	b.stub.AddCall("Export")
 	return b.model, nil
@@ -493,6 +548,11 @@ type stubMigration struct {
 	messageSet      string
 	minionReports   *state.MinionReports
 	externalControl bool
+	resume          bool
+
+	setKeepImportErr error
+	keepImport       bool
+	batchId          string
 }
 
 func (m *stubMigration) Id() string {
@@ -526,6 +586,26 @@ func (m *stubMigration) TargetInfo() (*coremigration.TargetInfo, error) {
 	}, nil
 }
 
+func (m *stubMigration) Resume() bool {
+	return m.resume
+}
+
+func (m *stubMigration) BatchId() string {
+	return m.batchId
+}
+
+func (m *stubMigration) KeepImport() bool {
+	return m.keepImport
+}
+
+func (m *stubMigration) SetKeepImport(keep bool) error {
+	if m.setKeepImportErr != nil {
+		return m.setKeepImportErr
+	}
+	m.keepImport = keep
+	return nil
+}
+
 func (m *stubMigration) SetPhase(phase coremigration.Phase) error {
 	if m.setPhaseErr != nil {
 		return m.setPhaseErr
@@ -9,6 +9,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/facade"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
 )
@@ -22,7 +23,11 @@ func NewFacade(ctx facade.Context) (*API, error) {
 		return nil, errors.Annotate(err, "creating precheck backend")
 	}
 	return NewAPI(
-		&backendShim{ctx.State()},
+		&backendShim{
+			State:    ctx.State(),
+			pool:     ctx.StatePool(),
+			presence: ctx.Presence(),
+		},
 		precheckBackend,
 		migration.PoolShim(ctx.StatePool()),
 		ctx.Resources(),
@@ -35,6 +40,21 @@ func NewFacade(ctx facade.Context) (*API, error) {
 // untested, but is simple enough to be verified by inspection.
 type backendShim struct {
 	*state.State
+	pool     *state.StatePool
+	presence facade.Presence
+}
+
+// StartQueuedMigrations implements Backend.
+func (s *backendShim) StartQueuedMigrations(batchId string) error {
+	controllerState := s.pool.SystemState()
+	return s.pool.StartQueuedMigrations(batchId, func(st *state.State, targetInfo *coremigration.TargetInfo) error {
+		return migration.CheckMigration(
+			st, controllerState, targetInfo,
+			s.presence.ModelPresence(st.ModelUUID()),
+			s.presence.ModelPresence(controllerState.ModelUUID()),
+			nil,
+		)
+	})
 }
 
 // ModelName implements Backend.
@@ -38,6 +38,18 @@ type API struct {
 	callContext   context.ProviderCallContext
 }
 
+// APIV2 implements the V2 API used by the migration master. It lacks
+// the ResumeImport and ImportedBinaries methods.
+type APIV2 struct {
+	*API
+}
+
+// APIV1 implements the V1 API used by the migration master. It also
+// lacks the ValidateImport method.
+type APIV1 struct {
+	*APIV2
+}
+
 // NewFacade is used for API registration.
 func NewFacade(ctx facade.Context) (*API, error) {
 	return NewAPI(
@@ -47,6 +59,24 @@ func NewFacade(ctx facade.Context) (*API, error) {
 		state.CallContext(ctx.State()))
 }
 
+// NewFacadeV2 is used for API registration.
+func NewFacadeV2(ctx facade.Context) (*APIV2, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV2{api}, nil
+}
+
+// NewFacadeV1 is used for API registration.
+func NewFacadeV1(ctx facade.Context) (*APIV1, error) {
+	api, err := NewFacadeV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV1{api}, nil
+}
+
 // NewAPI returns a new API. Accepts a NewEnvironFunc and context.ProviderCallContext
 // for testing purposes.
 func NewAPI(ctx facade.Context, getEnviron stateenvirons.NewEnvironFunc, getCAASBroker stateenvirons.NewCAASBrokerFunc, callCtx context.ProviderCallContext) (*API, error) {
@@ -127,6 +157,108 @@ func (api *API) Import(serialized params.SerializedModel) error {
 	return err
 }
 
+// ResumeImport takes a serialized Juju model, deserializes it, and
+// recreates it in the receiving controller. If an earlier, failed
+// attempt to migrate the model left it behind, it is replaced, but the
+// binaries already uploaded for it are kept.
+func (api *API) ResumeImport(serialized params.SerializedModel) error {
+	controller := state.NewController(api.pool)
+	_, st, err := migration.ResumeImportModel(controller, api.getClaimer, serialized.Bytes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return st.Close()
+}
+
+// ResumeImport isn't on the V2 API.
+func (*APIV2) ResumeImport(_, _ struct{}) {}
+
+// ImportedBinaries returns the charms, agent binaries and application
+// resources which have been uploaded for a model being imported.
+func (api *API) ImportedBinaries(args params.ModelArgs) (params.MigrationImportedBinaries, error) {
+	var result params.MigrationImportedBinaries
+	model, releaseModel, err := api.getImportingModel(args)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	defer releaseModel()
+
+	st, err := api.pool.Get(model.UUID())
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	defer st.Release()
+
+	charms, err := st.AllCharms()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, ch := range charms {
+		if ch.IsUploaded() && !ch.IsPlaceholder() {
+			result.Charms = append(result.Charms, params.MigrationImportedBinary{
+				ID:     ch.URL().String(),
+				SHA256: ch.BundleSha256(),
+			})
+		}
+	}
+
+	toolsStorage, err := st.ToolsStorage()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	defer toolsStorage.Close()
+	allTools, err := toolsStorage.AllMetadata()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, tools := range allTools {
+		result.Tools = append(result.Tools, params.MigrationImportedBinary{
+			ID:     tools.Version,
+			SHA256: tools.SHA256,
+		})
+	}
+
+	resources, err := st.Resources()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	apps, err := st.AllApplications()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, app := range apps {
+		appResources, err := resources.ListResources(app.Name())
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		for _, res := range appResources.Resources {
+			if res.IsPlaceholder() {
+				continue
+			}
+			result.Resources = append(result.Resources, params.MigrationImportedResource{
+				Application:    app.Name(),
+				Name:           res.Name,
+				FingerprintHex: res.Fingerprint.Hex(),
+			})
+		}
+	}
+	return result, nil
+}
+
+// ImportedBinaries isn't on the V2 API.
+func (*APIV2) ImportedBinaries(_, _ struct{}) {}
+
+// ValidateImport takes a serialized Juju model, deserializes it, and
+// checks that it could be recreated in the receiving controller,
+// without importing it.
+func (api *API) ValidateImport(serialized params.SerializedModel) error {
+	controller := state.NewController(api.pool)
+	return errors.Trace(migration.ValidateImportModel(controller, serialized.Bytes))
+}
+
+// ValidateImport isn't on the V1 API.
+func (*APIV1) ValidateImport(_, _ struct{}) {}
+
 func (api *API) getModel(modelTag string) (*state.Model, func(), error) {
 	tag, err := names.ParseModelTag(modelTag)
 	if err != nil {
@@ -84,6 +84,28 @@ func (s *Suite) TestFacadeRegistered(c *gc.C) {
 		Auth_:      s.authorizer,
 	})
 	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.APIV1))
+
+	factory, err = apiserver.AllFacades().GetFactory("MigrationTarget", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err = factory(&facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.APIV2))
+
+	factory, err = apiserver.AllFacades().GetFactory("MigrationTarget", 3)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err = factory(&facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.API))
 }
 
@@ -152,6 +174,83 @@ func (s *Suite) TestImport(c *gc.C) {
 	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeImporting)
 }
 
+func (s *Suite) TestValidateImport(c *gc.C) {
+	api := s.mustNewAPI(c)
+	uuid, bytes := s.makeExportedModel(c)
+	err := api.ValidateImport(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Check the model was not imported.
+	exists, err := s.State.ModelExists(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exists, jc.IsFalse)
+}
+
+func (s *Suite) TestValidateImportExisting(c *gc.C) {
+	api := s.mustNewAPI(c)
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = api.ValidateImport(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *Suite) TestResumeImport(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	curl, _ := app.CharmURL()
+
+	api := s.mustNewAPI(c)
+	uuid, bytes := s.makeExportedModel(c)
+	err := api.Import(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Upload the application's charm into the imported model.
+	st, err := s.StatePool.Get(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Release()
+	f := factory.NewFactory(st.State, s.StatePool)
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "wordpress", URL: curl.String()})
+
+	err = api.ResumeImport(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, ph, err := s.StatePool.GetModel(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	defer ph.Release()
+	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeImporting)
+
+	// The charm survived the import being replaced.
+	binaries, err := api.ImportedBinaries(params.ModelArgs{
+		ModelTag: names.NewModelTag(uuid).String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(binaries.Charms, jc.DeepEquals, []params.MigrationImportedBinary{{
+		ID:     curl.String(),
+		SHA256: ch.BundleSha256(),
+	}})
+	c.Check(binaries.Resources, gc.HasLen, 0)
+}
+
+func (s *Suite) TestResumeImportNew(c *gc.C) {
+	api := s.mustNewAPI(c)
+	uuid, bytes := s.makeExportedModel(c)
+	err := api.ResumeImport(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, ph, err := s.StatePool.GetModel(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	defer ph.Release()
+	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeImporting)
+}
+
+func (s *Suite) TestImportedBinariesNotImportingModel(c *gc.C) {
+	api := s.mustNewAPI(c)
+	_, err := api.ImportedBinaries(params.ModelArgs{ModelTag: s.Model.ModelTag().String()})
+	c.Assert(err, gc.ErrorMatches, "migration mode for the model is not importing")
+}
+
 func (s *Suite) TestImportLeadership(c *gc.C) {
 	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{
 		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{
@@ -57,6 +57,9 @@ func (cr *combinedRecorder) HandleRequest(hdr *rpc.Header, body interface{}) err
 	}
 	var args string
 	if cr.captureArgs {
+		if redacter, ok := body.(params.AuditRedacter); ok {
+			body = redacter.RedactedArgs()
+		}
 		jsonArgs, err := json.Marshal(body)
 		if err != nil {
 			return errors.Trace(err)
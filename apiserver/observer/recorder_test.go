@@ -64,6 +64,38 @@ func (s *recorderSuite) TestServerRequest(c *gc.C) {
 	})
 }
 
+func (s *recorderSuite) TestServerRequestRedactsArgs(c *gc.C) {
+	fake := &fakeobserver.Instance{}
+	log := &apitesting.FakeAuditLog{}
+	clock := testclock.NewClock(time.Now())
+	auditRecorder, err := auditlog.NewRecorder(log, clock, auditlog.ConversationArgs{
+		ConnectionID: 4567,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	factory := observer.NewRecorderFactory(fake, auditRecorder, observer.CaptureArgs)
+	recorder := factory()
+	hdr := &rpc.Header{
+		RequestId: 123,
+		Request:   rpc.Request{"Backups", 3, "", "Create"},
+	}
+	args := params.BackupsCreateArgs{
+		Notes:      "notes",
+		Encryption: &params.BackupsEncryptionKey{Passphrase: "sekrit"},
+	}
+	err = recorder.HandleRequest(hdr, args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The observer sees the real arguments.
+	fakeOb := fake.Calls()[0].Args[0].(*fakeobserver.RPCInstance)
+	fakeOb.CheckCall(c, 0, "ServerRequest", hdr, args)
+	c.Assert(args.Encryption.Passphrase, gc.Equals, "sekrit")
+
+	log.CheckCallNames(c, "AddConversation", "AddRequest")
+	request := log.Calls()[1].Args[0].(auditlog.Request)
+	c.Assert(request.Args, gc.Equals,
+		`{"notes":"notes","keep-copy":false,"no-download":false,"encryption":{"passphrase":"\u003credacted\u003e"}}`)
+}
+
 func (s *recorderSuite) TestServerRequestNoArgs(c *gc.C) {
 	fake := &fakeobserver.Instance{}
 	log := &apitesting.FakeAuditLog{}
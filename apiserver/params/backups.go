@@ -14,6 +14,33 @@ type BackupsCreateArgs struct {
 	Notes      string `json:"notes"`
 	KeepCopy   bool   `json:"keep-copy"`
 	NoDownload bool   `json:"no-download"`
+
+	// Encryption, if set, holds the key with which to encrypt
+	// the backup archive. It is only supported by version 3
+	// of the Backups facade.
+	Encryption *BackupsEncryptionKey `json:"encryption,omitempty"`
+}
+
+// RedactedArgs returns a copy of the args without the encryption
+// passphrase, for recording in the audit log.
+func (args BackupsCreateArgs) RedactedArgs() interface{} {
+	if args.Encryption != nil && args.Encryption.Passphrase != "" {
+		encryption := *args.Encryption
+		encryption.Passphrase = RedactedValue
+		args.Encryption = &encryption
+	}
+	return args
+}
+
+// BackupsEncryptionKey holds the key with which a backup archive is
+// encrypted. Exactly one of Passphrase and PublicKey must be set.
+type BackupsEncryptionKey struct {
+	// Passphrase is used to encrypt the archive symmetrically.
+	Passphrase string `json:"passphrase,omitempty"`
+
+	// PublicKey is an armored OpenPGP public key to which the
+	// archive is encrypted.
+	PublicKey string `json:"public-key,omitempty"`
 }
 
 // BackupsInfoArgs holds the args for the API Info method.
@@ -70,6 +97,10 @@ type BackupsMetadataResult struct {
 	Version  version.Number `json:"version"`
 	Series   string         `json:"series"`
 
+	// Encryption records how the backup archive was encrypted,
+	// if at all.
+	Encryption string `json:"encryption,omitempty"`
+
 	CACert       string `json:"ca-cert"`
 	CAPrivateKey string `json:"ca-private-key"`
 	Filename     string `json:"filename"`
@@ -80,3 +111,19 @@ type RestoreArgs struct {
 	// BackupId holds the id of the backup in server if any
 	BackupId string `json:"backup-id"`
 }
+
+// BackupsScheduleResult holds the scheduled backup configuration of
+// the controller, and the outcome of the most recent scheduled backup.
+type BackupsScheduleResult struct {
+	// Interval is the time between scheduled backups. A zero
+	// interval means scheduled backups are disabled.
+	Interval time.Duration `json:"interval"`
+
+	RetentionCount  int `json:"retention-count"`
+	RetentionDaily  int `json:"retention-daily"`
+	RetentionWeekly int `json:"retention-weekly"`
+
+	// LastStatus holds the outcome of the most recent scheduled
+	// backup, if any has been attempted.
+	LastStatus *EntityStatus `json:"last-status,omitempty"`
+}
@@ -32,3 +32,15 @@ const (
 )
 
 const MachineNonceHeader = "X-Juju-Nonce"
+
+// RedactedValue replaces secret values in API arguments recorded
+// in the audit log.
+const RedactedValue = "<redacted>"
+
+// AuditRedacter is implemented by API arguments that hold secret
+// values, which must not be recorded in the audit log.
+type AuditRedacter interface {
+	// RedactedArgs returns a copy of the arguments with the secret
+	// values replaced by RedactedValue.
+	RedactedArgs() interface{}
+}
@@ -700,19 +700,20 @@ type AgentVersionResult struct {
 
 // ProvisioningInfo holds machine provisioning info.
 type ProvisioningInfo struct {
-	Constraints       constraints.Value         `json:"constraints"`
-	Series            string                    `json:"series"`
-	Placement         string                    `json:"placement"`
-	Jobs              []multiwatcher.MachineJob `json:"jobs"`
-	Volumes           []VolumeParams            `json:"volumes,omitempty"`
-	VolumeAttachments []VolumeAttachmentParams  `json:"volume-attachments,omitempty"`
-	Tags              map[string]string         `json:"tags,omitempty"`
-	SubnetsToZones    map[string][]string       `json:"subnets-to-zones,omitempty"`
-	ImageMetadata     []CloudImageMetadata      `json:"image-metadata,omitempty"`
-	EndpointBindings  map[string]string         `json:"endpoint-bindings,omitempty"`
-	ControllerConfig  map[string]interface{}    `json:"controller-config,omitempty"`
-	CloudInitUserData map[string]interface{}    `json:"cloudinit-userdata,omitempty"`
-	CharmLXDProfiles  []string                  `json:"charm-lxd-profiles,omitempty"`
+	Constraints                  constraints.Value         `json:"constraints"`
+	Series                       string                    `json:"series"`
+	Placement                    string                    `json:"placement"`
+	Jobs                         []multiwatcher.MachineJob `json:"jobs"`
+	Volumes                      []VolumeParams            `json:"volumes,omitempty"`
+	VolumeAttachments            []VolumeAttachmentParams  `json:"volume-attachments,omitempty"`
+	Tags                         map[string]string         `json:"tags,omitempty"`
+	SubnetsToZones               map[string][]string       `json:"subnets-to-zones,omitempty"`
+	ImageMetadata                []CloudImageMetadata      `json:"image-metadata,omitempty"`
+	EndpointBindings             map[string]string         `json:"endpoint-bindings,omitempty"`
+	ControllerConfig             map[string]interface{}    `json:"controller-config,omitempty"`
+	CloudInitUserData            map[string]interface{}    `json:"cloudinit-userdata,omitempty"`
+	ApplicationCloudInitUserData map[string]interface{}    `json:"application-cloudinit-userdata,omitempty"`
+	CharmLXDProfiles             []string                  `json:"charm-lxd-profiles,omitempty"`
 }
 
 // ProvisioningInfoResult holds machine provisioning info or an error.
@@ -25,6 +25,11 @@ type InitiateMigrationArgs struct {
 type MigrationSpec struct {
 	ModelTag   string              `json:"model-tag"`
 	TargetInfo MigrationTargetInfo `json:"target-info"`
+
+	// Resume requests that an earlier, aborted migration of the model
+	// to the same target controller is resumed, reusing the binaries
+	// already uploaded to the target.
+	Resume bool `json:"resume,omitempty"`
 }
 
 // MigrationTargetInfo holds the details required to connect to and
@@ -52,6 +57,83 @@ type InitiateMigrationResult struct {
 	MigrationId string `json:"migration-id"`
 }
 
+// MigrationDryRunResults is used to return the results of checking
+// whether one or more model migrations would succeed.
+type MigrationDryRunResults struct {
+	Results []MigrationDryRunResult `json:"results"`
+}
+
+// MigrationDryRunResult is used to return the result of checking whether
+// a model migration would succeed. It lists the binaries that would be
+// transferred to the target controller.
+type MigrationDryRunResult struct {
+	ModelTag  string            `json:"model-tag"`
+	Error     *Error            `json:"error,omitempty"`
+	Charms    []MigrationBinary `json:"charms,omitempty"`
+	Tools     []MigrationBinary `json:"tools,omitempty"`
+	Resources []MigrationBinary `json:"resources,omitempty"`
+}
+
+// MigrationBinary describes a charm, agent binary or resource
+// that is transferred during a model migration.
+type MigrationBinary struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
+// InitiateMigrationBatchArgs holds the details required to start
+// migrating a set of models to another controller. Only the models
+// owned by OwnerTag and deployed to CloudTag are migrated, when these
+// are set.
+type InitiateMigrationBatchArgs struct {
+	OwnerTag    string              `json:"owner-tag,omitempty"`
+	CloudTag    string              `json:"cloud-tag,omitempty"`
+	TargetInfo  MigrationTargetInfo `json:"target-info"`
+	Concurrency int                 `json:"concurrency"`
+}
+
+// InitiateMigrationBatchResult is used to return the result of
+// starting a batch of model migrations.
+type InitiateMigrationBatchResult struct {
+	BatchId string `json:"batch-id"`
+}
+
+// MigrationBatchArgs identifies batches of model migrations.
+type MigrationBatchArgs struct {
+	BatchIds []string `json:"batch-ids"`
+}
+
+// MigrationBatchStatusResults holds the progress of one or more
+// batches of model migrations.
+type MigrationBatchStatusResults struct {
+	Results []MigrationBatchStatusResult `json:"results"`
+}
+
+// MigrationBatchStatusResult holds the progress of a batch of model
+// migrations.
+type MigrationBatchStatusResult struct {
+	BatchId          string                      `json:"batch-id"`
+	Error            *Error                      `json:"error,omitempty"`
+	InitiatedBy      string                      `json:"initiated-by,omitempty"`
+	StartTime        time.Time                   `json:"start-time"`
+	TargetController string                      `json:"target-controller,omitempty"`
+	Concurrency      int                         `json:"concurrency,omitempty"`
+	Models           []MigrationBatchModelStatus `json:"models,omitempty"`
+}
+
+// MigrationBatchModelStatus holds the progress of the migration of a
+// model in a batch. Phase is empty while the migration is queued, or
+// if it couldn't be started, in which case StartError is set.
+type MigrationBatchModelStatus struct {
+	ModelTag    string `json:"model-tag"`
+	Name        string `json:"name"`
+	OwnerTag    string `json:"owner-tag"`
+	MigrationId string `json:"migration-id,omitempty"`
+	Phase       string `json:"phase,omitempty"`
+	Message     string `json:"message,omitempty"`
+	StartError  string `json:"start-error,omitempty"`
+}
+
 // SetMigrationPhaseArgs provides a migration phase to the
 // migrationmaster.SetPhase API method.
 type SetMigrationPhaseArgs struct {
@@ -64,6 +146,13 @@ type SetMigrationStatusMessageArgs struct {
 	Message string `json:"message"`
 }
 
+// SetMigrationKeepImportArgs provides whether the imported model is
+// kept on the target controller to the migrationmaster.SetKeepImport
+// API method.
+type SetMigrationKeepImportArgs struct {
+	KeepImport bool `json:"keep-import"`
+}
+
 // SerializedModel wraps a buffer contain a serialised Juju model. It
 // also contains lists of the charms and tools used in the model.
 type SerializedModel struct {
@@ -73,6 +162,18 @@ type SerializedModel struct {
 	Resources []SerializedModelResource `json:"resources"`
 }
 
+// SerializedModelResult holds the result of exporting a single model,
+// or an error.
+type SerializedModelResult struct {
+	Result *SerializedModel `json:"result,omitempty"`
+	Error  *Error           `json:"error,omitempty"`
+}
+
+// SerializedModelResults holds the results of exporting several models.
+type SerializedModelResults struct {
+	Results []SerializedModelResult `json:"results"`
+}
+
 // SerializedModelTools holds the version and URI for a given tools
 // version.
 type SerializedModelTools struct {
@@ -109,6 +210,31 @@ type SerializedModelResourceRevision struct {
 	Username       string    `json:"username,omitempty"`
 }
 
+// MigrationImportedBinaries describes the binaries that a target
+// controller already holds for a model being imported.
+type MigrationImportedBinaries struct {
+	Charms    []MigrationImportedBinary   `json:"charms"`
+	Tools     []MigrationImportedBinary   `json:"tools"`
+	Resources []MigrationImportedResource `json:"resources"`
+}
+
+// MigrationImportedBinary identifies a charm, by URL, or agent binary,
+// by version, that has been uploaded to a target controller, along
+// with the hex encoded SHA256 hash of its content.
+type MigrationImportedBinary struct {
+	ID     string `json:"id"`
+	SHA256 string `json:"sha256"`
+}
+
+// MigrationImportedResource identifies an application resource that
+// has been uploaded to a target controller, along with the hex encoded
+// fingerprint of its content.
+type MigrationImportedResource struct {
+	Application    string `json:"application"`
+	Name           string `json:"name"`
+	FingerprintHex string `json:"fingerprint"`
+}
+
 // ModelArgs wraps a simple model tag.
 type ModelArgs struct {
 	ModelTag string `json:"model-tag"`
@@ -122,6 +248,7 @@ type MasterMigrationStatus struct {
 	MigrationId      string        `json:"migration-id"`
 	Phase            string        `json:"phase"`
 	PhaseChangedTime time.Time     `json:"phase-changed-time"`
+	KeepImport       bool          `json:"keep-import,omitempty"`
 }
 
 // MigrationModelInfo is used to report basic model information to the
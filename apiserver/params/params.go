@@ -404,6 +404,19 @@ type ApplicationUnitParams struct {
 	Status         string                     `json:"status"`
 	Info           string                     `json:"info"`
 	Data           map[string]interface{}     `json:"data,omitempty"`
+	Events         []EntityStatus             `json:"events,omitempty"`
+}
+
+// CloudEvents holds events reported by the cloud for an entity,
+// such as an application's operator.
+type CloudEvents struct {
+	Tag    string         `json:"tag"`
+	Events []EntityStatus `json:"events"`
+}
+
+// CloudEventsArgs holds the events reported by the cloud for a number of entities.
+type CloudEventsArgs struct {
+	Args []CloudEvents `json:"args"`
 }
 
 // DestroyApplicationUnits holds parameters for the deprecated
@@ -491,6 +504,10 @@ type GetConstraintsResults struct {
 type SetConstraints struct {
 	ApplicationName string            `json:"application"` //optional, if empty, model constraints are set.
 	Constraints     constraints.Value `json:"constraints"`
+
+	// BranchName identifies the "in-flight" branch that this request
+	// will set application constraints for.
+	BranchName string `json:"branch,omitempty"`
 }
 
 // ResolveCharms stores charm references for a ResolveCharms call.
@@ -994,6 +1011,77 @@ type DestroyMachineInfo struct {
 	DestroyedUnits []Entity `json:"destroyed-units,omitempty"`
 }
 
+// ReplaceMachineResults contains the results of a MachineManager.ReplaceMachines
+// API request.
+type ReplaceMachineResults struct {
+	Results []ReplaceMachineResult `json:"results,omitempty"`
+}
+
+// ReplaceMachineResult contains one of the results of a
+// MachineManager.ReplaceMachines API request.
+type ReplaceMachineResult struct {
+	// Machine is the tag of the machine added to replace the old one.
+	// It may be set even if Error is, if the units could not all be
+	// moved to it.
+	Machine string `json:"machine,omitempty"`
+
+	// Units is the tags of the principal units moved to the new machine.
+	Units []Entity `json:"units,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
+// MachinePreviewResults contains the results of a
+// MachineManager.PreviewMachines API request.
+type MachinePreviewResults struct {
+	Results []MachinePreviewResult `json:"results,omitempty"`
+}
+
+// MachinePreviewResult describes the instance that would be started
+// for a new machine, as returned by a MachineManager.PreviewMachines
+// API request.
+type MachinePreviewResult struct {
+	Series       string `json:"series,omitempty"`
+	Arch         string `json:"arch,omitempty"`
+	InstanceType string `json:"instance-type,omitempty"`
+	ImageId      string `json:"image-id,omitempty"`
+
+	// AvailabilityZone is empty if the provider does not support
+	// availability zones.
+	AvailabilityZone string `json:"availability-zone,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
+// LostMachinesResult contains the result of a MachineHealth.LostMachines
+// API request.
+type LostMachinesResult struct {
+	Machines []LostMachine `json:"machines,omitempty"`
+}
+
+// LostMachine describes a provisioned machine whose agent is lost.
+type LostMachine struct {
+	Tag        string    `json:"tag"`
+	InstanceId string    `json:"instance-id"`
+	Since      time.Time `json:"since"`
+}
+
+// MachineFailures holds the arguments of a MachineHealth.MarkMachinesFailed
+// API request.
+type MachineFailures struct {
+	Failures []MachineFailure `json:"failures"`
+}
+
+// MachineFailure describes why a machine is to be marked as failed.
+type MachineFailure struct {
+	Tag     string `json:"tag"`
+	Message string `json:"message"`
+
+	// Reprovision is true if a new instance should be started
+	// for the machine once it has been marked as failed.
+	Reprovision bool `json:"reprovision,omitempty"`
+}
+
 // DestroyUnitResults contains the results of a DestroyUnit API request.
 type DestroyUnitResults struct {
 	Results []DestroyUnitResult `json:"results,omitempty"`
@@ -1152,6 +1240,24 @@ type BranchTrackArg struct {
 	Entities   []Entity `json:"entities"`
 }
 
+// BranchRolloutArg identifies an in-flight branch and an application whose
+// units are to be moved onto the branch in waves.
+// Exactly one of WaveSize or WavePercent must be set.
+type BranchRolloutArg struct {
+	BranchName  string        `json:"branch"`
+	Application string        `json:"application"`
+	WaveSize    int           `json:"wave-size,omitempty"`
+	WavePercent int           `json:"wave-percent,omitempty"`
+	WaveTimeout time.Duration `json:"wave-timeout"`
+}
+
+// BranchPruneArgs holds the parameters for pruning completed branches.
+type BranchPruneArgs struct {
+	// MaxAge is the age beyond which committed or aborted
+	// branches are removed.
+	MaxAge time.Duration `json:"max-age"`
+}
+
 // GenerationApplication represents changes to an application
 // made under a branch.
 type GenerationApplication struct {
@@ -1172,6 +1278,46 @@ type GenerationApplication struct {
 	// Config changes are the effective new configuration values resulting from
 	// changes made under this branch.
 	ConfigChanges map[string]interface{} `json:"config"`
+
+	// CharmURL is the charm that units tracking the branch are upgraded to.
+	CharmURL string `json:"charm-url,omitempty"`
+
+	// Resources is the revisions of resources, keyed by name, activated
+	// for units tracking the branch.
+	Resources map[string]int `json:"resources,omitempty"`
+
+	// Constraints is the application constraints set under the branch.
+	Constraints string `json:"constraints,omitempty"`
+
+	// Rollout is the progress of moving the application's units onto the
+	// branch in waves, if such a rollout was started.
+	Rollout *BranchRollout `json:"rollout,omitempty"`
+}
+
+// BranchRollout describes the progress of moving
+// an application's units onto a branch in waves.
+type BranchRollout struct {
+	// WaveSize is the number of units in each wave.
+	WaveSize int `json:"wave-size,omitempty"`
+
+	// WavePercent is the percentage of the application's units in each wave.
+	WavePercent int `json:"wave-percent,omitempty"`
+
+	// WaveTimeout is the time allowed for units in a wave to become
+	// active and idle before the rollout is halted.
+	WaveTimeout time.Duration `json:"wave-timeout"`
+
+	// Status is one of "running", "completed" or "halted".
+	Status string `json:"status"`
+
+	// Message describes why the rollout was halted.
+	Message string `json:"message,omitempty"`
+
+	// Wave is the number of the current wave.
+	Wave int `json:"wave"`
+
+	// WaveUnits is the names of the units in the current wave.
+	WaveUnits []string `json:"wave-units,omitempty"`
 }
 
 // Generation represents a model generation's details including config changes.
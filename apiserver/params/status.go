@@ -29,6 +29,10 @@ type FullStatus struct {
 	Offers              map[string]ApplicationOfferStatus  `json:"offers"`
 	Relations           []RelationStatus                   `json:"relations"`
 	ControllerTimestamp *time.Time                         `json:"controller-timestamp"`
+
+	// ScheduledBackup holds the outcome of the most recent scheduled
+	// backup, and is only set for the controller model.
+	ScheduledBackup *DetailedStatus `json:"scheduled-backup,omitempty"`
 }
 
 // IsEmpty checks all collections on FullStatus to determine if the status is empty.
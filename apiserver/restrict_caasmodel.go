@@ -19,6 +19,8 @@ var commonModelFacadeNames = set.NewStrings(
 	"Annotations",
 	"Application",
 	"Block",
+	"BranchPruner",
+	"BranchRollout",
 	"CharmRevisionUpdater",
 	"Charms",
 	"Cleaner",
@@ -271,6 +271,11 @@ type Unit struct {
 	Stateful       bool
 	Status         status.StatusInfo
 	FilesystemInfo []FilesystemInfo
+
+	// Events holds the events reported by the cloud for the unit's
+	// pod and related resources, oldest first. Each event has the
+	// event reason as its status.
+	Events []status.StatusInfo
 }
 
 // Operator represents information about the status of an "operator pod".
@@ -278,6 +283,10 @@ type Operator struct {
 	Id     string
 	Dying  bool
 	Status status.StatusInfo
+
+	// Events holds the events reported by the cloud for the
+	// operator pod and related resources, oldest first.
+	Events []status.StatusInfo
 }
 
 // CharmStorageParams defines parameters used to create storage
@@ -57,6 +57,9 @@ type BaseSuite struct {
 	mockStorageClass           *mocks.MockStorageClassInterface
 	mockIngressInterface       *mocks.MockIngressInterface
 	mockNodes                  *mocks.MockNodeInterface
+	mockEvents                 *mocks.MockEventInterface
+	mockPolicy                 *mocks.MockPolicyV1beta1Interface
+	mockPodDisruptionBudgets   *mocks.MockPodDisruptionBudgetInterface
 
 	mockApiextensionsV1          *mocks.MockApiextensionsV1beta1Interface
 	mockApiextensionsClient      *mocks.MockApiExtensionsClientInterface
@@ -169,6 +172,9 @@ func (s *BaseSuite) setupK8sRestClient(c *gc.C, ctrl *gomock.Controller, namespa
 	s.mockNodes = mocks.NewMockNodeInterface(ctrl)
 	mockCoreV1.EXPECT().Nodes().AnyTimes().Return(s.mockNodes)
 
+	s.mockEvents = mocks.NewMockEventInterface(ctrl)
+	mockCoreV1.EXPECT().Events(namespace).AnyTimes().Return(s.mockEvents)
+
 	s.mockApps = mocks.NewMockAppsV1Interface(ctrl)
 	s.mockExtensions = mocks.NewMockExtensionsV1beta1Interface(ctrl)
 	s.mockStatefulSets = mocks.NewMockStatefulSetInterface(ctrl)
@@ -180,6 +186,11 @@ func (s *BaseSuite) setupK8sRestClient(c *gc.C, ctrl *gomock.Controller, namespa
 	s.mockApps.EXPECT().Deployments(namespace).AnyTimes().Return(s.mockDeployments)
 	s.mockExtensions.EXPECT().Ingresses(namespace).AnyTimes().Return(s.mockIngressInterface)
 
+	s.mockPolicy = mocks.NewMockPolicyV1beta1Interface(ctrl)
+	s.mockPodDisruptionBudgets = mocks.NewMockPodDisruptionBudgetInterface(ctrl)
+	s.k8sClient.EXPECT().PolicyV1beta1().AnyTimes().Return(s.mockPolicy)
+	s.mockPolicy.EXPECT().PodDisruptionBudgets(namespace).AnyTimes().Return(s.mockPodDisruptionBudgets)
+
 	s.mockStorage = mocks.NewMockStorageV1Interface(ctrl)
 	s.mockStorageClass = mocks.NewMockStorageClassInterface(ctrl)
 	s.k8sClient.EXPECT().StorageV1().AnyTimes().Return(s.mockStorage)
@@ -208,6 +219,10 @@ func (s *BaseSuite) k8sNotFoundError() *k8serrors.StatusError {
 	return k8serrors.NewNotFound(schema.GroupResource{}, "test")
 }
 
+func (s *BaseSuite) k8sInvalidError() *k8serrors.StatusError {
+	return k8serrors.NewInvalid(schema.GroupKind{}, "test", nil)
+}
+
 func (s *BaseSuite) k8sAlreadyExistsError() *k8serrors.StatusError {
 	return k8serrors.NewAlreadyExists(schema.GroupResource{}, "test")
 }
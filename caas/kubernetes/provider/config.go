@@ -28,6 +28,17 @@ const (
 	ingressSSLRedirectKey    = "kubernetes-ingress-ssl-redirect"
 	ingressSSLPassthroughKey = "kubernetes-ingress-ssl-passthrough"
 	ingressAllowHTTPKey      = "kubernetes-ingress-allow-http"
+	ingressHostnamesKey      = "kubernetes-ingress-hostnames"
+	ingressPathsKey          = "kubernetes-ingress-paths"
+	ingressAnnotationsKey    = "kubernetes-ingress-annotations"
+	ingressTLSSecretKey      = "kubernetes-ingress-tls-secret"
+	ingressTLSCertificateKey = "kubernetes-ingress-tls-certificate"
+	ingressTLSKeyKey         = "kubernetes-ingress-tls-key"
+
+	updateMaxSurgeKey       = "kubernetes-update-max-surge"
+	updateMaxUnavailableKey = "kubernetes-update-max-unavailable"
+	updatePartitionKey      = "kubernetes-update-partition"
+	pdbMinAvailableKey      = "kubernetes-pdb-min-available"
 )
 
 var configFields = environschema.Fields{
@@ -86,11 +97,62 @@ var configFields = environschema.Fields{
 		Type:        environschema.Tbool,
 		Group:       environschema.ProviderGroup,
 	},
+	ingressHostnamesKey: {
+		Description: "a space separated list of additional hostnames to route to the application",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	ingressPathsKey: {
+		Description: "a space separated list of http path prefixes to route to the application",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	ingressAnnotationsKey: {
+		Description: "a space separated set of annotations to add to the ingress resource",
+		Type:        environschema.Tattrs,
+		Group:       environschema.ProviderGroup,
+	},
+	ingressTLSSecretKey: {
+		Description: "the name of an existing secret holding the TLS certificate and key for the ingress",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	ingressTLSCertificateKey: {
+		Description: "the PEM encoded TLS certificate for the ingress",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	ingressTLSKeyKey: {
+		Description: "the PEM encoded TLS private key for the ingress",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	updateMaxSurgeKey: {
+		Description: "number or percentage of pods that can be created above the desired number during a rolling update",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	updateMaxUnavailableKey: {
+		Description: "number or percentage of pods that can be unavailable during a rolling update",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	updatePartitionKey: {
+		Description: "pods with an ordinal lower than the partition are not updated during a rolling update",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
+	pdbMinAvailableKey: {
+		Description: "number or percentage of pods that must remain available during a voluntary disruption",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
 }
 
 var schemaDefaults = schema.Defaults{
 	serviceTypeConfigKey:     defaultServiceType,
 	serviceAnnotationsKey:    schema.Omit,
+	ingressAnnotationsKey:    schema.Omit,
 	ingressClassKey:          defaultIngressClass,
 	ingressSSLRedirectKey:    defaultIngressSSLRedirect,
 	ingressSSLPassthroughKey: defaultIngressSSLPassthrough,
@@ -8,6 +8,7 @@ import (
 	_ "crypto/sha256"
 	_ "crypto/sha512"
 	"encoding/json"
+	"strings"
 
 	"github.com/docker/distribution/reference"
 	"github.com/juju/errors"
@@ -54,6 +55,32 @@ func createDockerConfigJSON(imageDetails *caas.ImageDetails) ([]byte, error) {
 	return json.Marshal(dockerConfig)
 }
 
+// parseRegistryCredentials parses registry credentials specified as
+// space separated "registry=username:password" values.
+func parseRegistryCredentials(value string) (DockerConfig, error) {
+	auths := make(DockerConfig)
+	for _, item := range strings.Fields(value) {
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			// Don't include the value as it may contain a password.
+			return nil, errors.NotValidf("registry credentials, expected registry=username:password")
+		}
+		registry := parts[0]
+		login := strings.SplitN(parts[1], ":", 2)
+		if len(login) != 2 || login[0] == "" || login[1] == "" {
+			return nil, errors.NotValidf("credentials for registry %q, expected username:password", registry)
+		}
+		if _, ok := auths[registry]; ok {
+			return nil, errors.NotValidf("duplicate credentials for registry %q", registry)
+		}
+		auths[registry] = DockerConfigEntry{
+			Username: login[0],
+			Password: login[1],
+		}
+	}
+	return auths, nil
+}
+
 // extractRegistryName returns the registry URL part of an images path
 func extractRegistryURL(imagePath string) (string, error) {
 	imageNamed, err := reference.ParseNormalizedNamed(imagePath)
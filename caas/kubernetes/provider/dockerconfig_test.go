@@ -67,3 +67,39 @@ func (s *DockerConfigSuite) TestCreateDockerConfigJSON(c *gc.C) {
 		},
 	})
 }
+
+func (s *DockerConfigSuite) TestParseRegistryCredentials(c *gc.C) {
+	auths, err := provider.ParseRegistryCredentials("registry.example.com=fred:sekr=t:1  gcr.io=_json_key:abc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(auths, jc.DeepEquals, provider.DockerConfig{
+		"registry.example.com": {Username: "fred", Password: "sekr=t:1"},
+		"gcr.io":               {Username: "_json_key", Password: "abc"},
+	})
+
+	auths, err = provider.ParseRegistryCredentials("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(auths, gc.HasLen, 0)
+}
+
+func (s *DockerConfigSuite) TestParseRegistryCredentialsInvalid(c *gc.C) {
+	for _, test := range []struct {
+		value string
+		err   string
+	}{{
+		value: "fred:secret",
+		err:   `registry credentials, expected registry=username:password not valid`,
+	}, {
+		value: "registry.example.com=fred",
+		err:   `credentials for registry "registry.example.com", expected username:password not valid`,
+	}, {
+		value: "registry.example.com=:secret",
+		err:   `credentials for registry "registry.example.com", expected username:password not valid`,
+	}, {
+		value: "gcr.io=fred:secret gcr.io=mary:secret",
+		err:   `duplicate credentials for registry "gcr.io" not valid`,
+	}} {
+		c.Logf("parsing %q", test.value)
+		_, err := provider.ParseRegistryCredentials(test.value)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+
+	"github.com/juju/juju/core/status"
+)
+
+// listEvents returns the events in the current namespace relating to
+// any of the specified objects, as named by eventObjectName.
+func (k *kubernetesClient) listEvents(objects set.Strings) ([]core.Event, error) {
+	events := k.CoreV1().Events(k.namespace)
+	var result []core.Event
+	for _, object := range objects.SortedValues() {
+		parts := strings.SplitN(object, "/", 2)
+		selector := fields.AndSelectors(
+			fields.OneTermEqualSelector("involvedObject.kind", parts[0]),
+			fields.OneTermEqualSelector("involvedObject.name", parts[1]),
+		)
+		eventList, err := events.List(v1.ListOptions{
+			IncludeUninitialized: true,
+			FieldSelector:        selector.String(),
+		})
+		if err != nil {
+			return nil, errors.Annotatef(err, "listing events for %s", object)
+		}
+		result = append(result, eventList.Items...)
+	}
+	return result, nil
+}
+
+// eventObjectName returns the kind and name of the
+// object an event relates to, eg "Pod/mariadb-0".
+func eventObjectName(kind, name string) string {
+	return kind + "/" + name
+}
+
+// podEventObjects returns the names of the objects for which events
+// are relevant to a unit or operator pod; these are the pod itself,
+// its persistent volume claims and any of the specified services.
+func podEventObjects(pod core.Pod, serviceNames ...string) set.Strings {
+	objects := set.NewStrings(eventObjectName("Pod", pod.Name))
+	for _, vol := range pod.Spec.Volumes {
+		if vol.PersistentVolumeClaim != nil && vol.PersistentVolumeClaim.ClaimName != "" {
+			objects.Add(eventObjectName("PersistentVolumeClaim", vol.PersistentVolumeClaim.ClaimName))
+		}
+	}
+	for _, name := range serviceNames {
+		objects.Add(eventObjectName("Service", name))
+	}
+	return objects
+}
+
+// podsEventObjects returns the names of the objects for which events
+// are relevant to any of the specified pods.
+func podsEventObjects(pods []core.Pod, serviceNames ...string) set.Strings {
+	objects := set.NewStrings()
+	for _, pod := range pods {
+		objects = objects.Union(podEventObjects(pod, serviceNames...))
+	}
+	return objects
+}
+
+// eventsForObjects returns the events relating to any of the specified
+// objects, oldest first. Each event is returned as a status value with
+// the event reason as the status so it can be recorded in status history.
+func eventsForObjects(events []core.Event, objects set.Strings) []status.StatusInfo {
+	var result []status.StatusInfo
+	for _, evt := range events {
+		object := eventObjectName(evt.InvolvedObject.Kind, evt.InvolvedObject.Name)
+		if !objects.Contains(object) {
+			continue
+		}
+		since := evt.LastTimestamp.Time
+		if since.IsZero() {
+			since = evt.FirstTimestamp.Time
+		}
+		if since.IsZero() {
+			since = evt.CreationTimestamp.Time
+		}
+		result = append(result, status.StatusInfo{
+			Status:  status.Status(evt.Reason),
+			Message: evt.Message,
+			Data: map[string]interface{}{
+				"type":   evt.Type,
+				"object": object,
+			},
+			Since: &since,
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Since.Before(*result[j].Since)
+	})
+	return result
+}
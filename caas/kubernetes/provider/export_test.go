@@ -25,6 +25,8 @@ var (
 	OperatorPod              = operatorPod
 	ExtractRegistryURL       = extractRegistryURL
 	CreateDockerConfigJSON   = createDockerConfigJSON
+	ParseRegistryCredentials = parseRegistryCredentials
+	AddImagePullSecrets      = addImagePullSecrets
 	NewStorageConfig         = newStorageConfig
 	NewKubernetesWatcher     = newKubernetesWatcher
 	CompileK8sCloudCheckers  = compileK8sCloudCheckers
@@ -0,0 +1,114 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"crypto/tls"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
+)
+
+// splitConfigList splits a config value holding a
+// space or comma separated list into its elements.
+func splitConfigList(value string) []string {
+	return strings.Fields(strings.Replace(value, ",", " ", -1))
+}
+
+// ingressHosts returns the hostnames to be routed to an exposed
+// application, starting with the juju-external-hostname if set.
+func ingressHosts(config application.ConfigAttributes) []string {
+	var hosts []string
+	seen := set.NewStrings()
+	candidates := append(
+		[]string{config.GetString(caas.JujuExternalHostNameKey, "")},
+		splitConfigList(config.GetString(ingressHostnamesKey, ""))...,
+	)
+	for _, host := range candidates {
+		if host == "" || seen.Contains(host) {
+			continue
+		}
+		seen.Add(host)
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
+
+// ingressPaths returns the http path prefixes to be routed to
+// an exposed application. If no paths are configured, the
+// juju-application-path is used.
+func ingressPaths(appName string, config application.ConfigAttributes) []string {
+	paths := splitConfigList(config.GetString(ingressPathsKey, ""))
+	if len(paths) == 0 {
+		paths = []string{config.GetString(caas.JujuApplicationPath, caas.JujuDefaultApplicationPath)}
+	}
+	for i, httpPath := range paths {
+		if httpPath == "$appname" {
+			httpPath = appName
+		}
+		if !strings.HasPrefix(httpPath, "/") {
+			httpPath = "/" + httpPath
+		}
+		paths[i] = httpPath
+	}
+	return paths
+}
+
+func ingressTLSSecretName(deploymentName string) string {
+	return deploymentName + "-ingress-tls"
+}
+
+// ensureIngressTLSSecret returns the name of the secret holding the TLS
+// certificate for an exposed application, or "" if TLS is not configured.
+// If the certificate and key are supplied in the application config, a
+// secret is created for them; otherwise any such secret is removed.
+func (k *kubernetesClient) ensureIngressTLSSecret(
+	appName, deploymentName string, config application.ConfigAttributes,
+) (string, error) {
+	secretName := config.GetString(ingressTLSSecretKey, "")
+	certPEM := config.GetString(ingressTLSCertificateKey, "")
+	keyPEM := config.GetString(ingressTLSKeyKey, "")
+	jujuSecretName := ingressTLSSecretName(deploymentName)
+
+	if certPEM == "" && keyPEM == "" {
+		if err := k.deleteSecret(jujuSecretName); err != nil {
+			return "", errors.Trace(err)
+		}
+		return secretName, nil
+	}
+	if secretName != "" {
+		return "", errors.NotValidf("specifying both %q and a TLS certificate", ingressTLSSecretKey)
+	}
+	if certPEM == "" {
+		return "", errors.NotValidf("%q without %q", ingressTLSKeyKey, ingressTLSCertificateKey)
+	}
+	if keyPEM == "" {
+		return "", errors.NotValidf("%q without %q", ingressTLSCertificateKey, ingressTLSKeyKey)
+	}
+	if _, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM)); err != nil {
+		return "", errors.Annotate(err, "invalid TLS certificate or key")
+	}
+	secret := &core.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      jujuSecretName,
+			Namespace: k.namespace,
+			Labels:    map[string]string{labelApplication: appName},
+		},
+		Type: core.SecretTypeTLS,
+		Data: map[string][]byte{
+			core.TLSCertKey:       []byte(certPEM),
+			core.TLSPrivateKeyKey: []byte(keyPEM),
+		},
+	}
+	if err := k.ensureSecret(secret); err != nil {
+		return "", errors.Trace(err)
+	}
+	return jujuSecretName, nil
+}
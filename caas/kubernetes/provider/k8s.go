@@ -92,6 +92,10 @@ type kubernetesClient struct {
 	// creating k8s resources.
 	namespace string
 
+	// existingNamespace is true when the model uses a pre-existing
+	// namespace which juju must neither create nor delete.
+	existingNamespace bool
+
 	annotations k8sannotations.Annotation
 
 	lock   sync.Mutex
@@ -108,9 +112,10 @@ type kubernetesClient struct {
 // run "go generate" from the package directory.
 //go:generate mockgen -package mocks -destination mocks/k8sclient_mock.go k8s.io/client-go/kubernetes Interface
 //go:generate mockgen -package mocks -destination mocks/appv1_mock.go k8s.io/client-go/kubernetes/typed/apps/v1 AppsV1Interface,DeploymentInterface,StatefulSetInterface
-//go:generate mockgen -package mocks -destination mocks/corev1_mock.go k8s.io/client-go/kubernetes/typed/core/v1 CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface,SecretInterface,NodeInterface
+//go:generate mockgen -package mocks -destination mocks/corev1_mock.go k8s.io/client-go/kubernetes/typed/core/v1 CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface,SecretInterface,NodeInterface,EventInterface
 //go:generate mockgen -package mocks -destination mocks/extenstionsv1_mock.go k8s.io/client-go/kubernetes/typed/extensions/v1beta1 ExtensionsV1beta1Interface,IngressInterface
 //go:generate mockgen -package mocks -destination mocks/storagev1_mock.go k8s.io/client-go/kubernetes/typed/storage/v1 StorageV1Interface,StorageClassInterface
+//go:generate mockgen -package mocks -destination mocks/policyv1beta1_mock.go k8s.io/client-go/kubernetes/typed/policy/v1beta1 PolicyV1beta1Interface,PodDisruptionBudgetInterface
 
 // NewK8sClientFunc defines a function which returns a k8s client based on the supplied config.
 type NewK8sClientFunc func(c *rest.Config) (kubernetes.Interface, apiextensionsclientset.Interface, error)
@@ -153,6 +158,11 @@ func NewK8sBroker(
 			Add(annotationModelUUIDKey, modelUUID),
 	}
 
+	if ns := newCfg.existingNamespace(); ns != "" {
+		client.namespace = ns
+		client.existingNamespace = true
+	}
+
 	if controllerUUID != "" {
 		// controllerUUID could be empty in add-k8s without -c because there might be no controller yet.
 		client.annotations.Add(annotationControllerUUIDKey, controllerUUID)
@@ -206,6 +216,9 @@ func (k *kubernetesClient) PrepareForBootstrap(ctx environs.BootstrapContext, co
 Please bootstrap again and choose a different controller name.`, k.namespace),
 	)
 
+	if k.existingNamespace {
+		return errors.NotSupportedf("bootstrapping into existing namespace %q", k.namespace)
+	}
 	k.namespace = DecideControllerNamespace(controllerName)
 
 	// ensure no existing namespace has the same name.
@@ -237,6 +250,9 @@ Please bootstrap again and choose a different controller name.`, k.namespace),
 
 // Create implements environs.BootstrapEnviron.
 func (k *kubernetesClient) Create(context.ProviderCallContext, environs.CreateParams) error {
+	if k.existingNamespace {
+		return k.adoptNamespace(k.namespace)
+	}
 	// must raise errors.AlreadyExistsf if it's already exist.
 	return k.createNamespace(k.namespace)
 }
@@ -344,6 +360,21 @@ func (*kubernetesClient) Provider() caas.ContainerEnvironProvider {
 
 // Destroy is part of the Broker interface.
 func (k *kubernetesClient) Destroy(callbacks context.ProviderCallContext) error {
+	if k.existingNamespace {
+		// The namespace was not created by juju so it is left in place,
+		// but the resources juju created in it are removed.
+		if err := k.deleteModelResources(); err != nil {
+			return errors.Annotate(err, "deleting model resources")
+		}
+		if err := k.deleteSecret(registryCredentialsSecretName); err != nil {
+			return errors.Annotate(err, "deleting image registry credentials")
+		}
+		if err := k.releaseNamespace(); err != nil {
+			return errors.Annotate(err, "releasing model namespace")
+		}
+		return errors.Trace(k.deleteModelStorageClasses())
+	}
+
 	watcher, err := k.WatchNamespace()
 	if err != nil {
 		return errors.Trace(err)
@@ -354,16 +385,8 @@ func (k *kubernetesClient) Destroy(callbacks context.ProviderCallContext) error
 		return errors.Annotate(err, "deleting model namespace")
 	}
 
-	// Delete any storage classes created as part of this model.
-	// Storage classes live outside the namespace so need to be deleted separately.
-	modelSelector := fmt.Sprintf("%s==%s", labelModel, k.namespace)
-	err = k.StorageV1().StorageClasses().DeleteCollection(&v1.DeleteOptions{
-		PropagationPolicy: &defaultPropagationPolicy,
-	}, v1.ListOptions{
-		LabelSelector: modelSelector,
-	})
-	if err != nil && !k8serrors.IsNotFound(err) {
-		return errors.Annotate(err, "deleting model storage classes")
+	if err := k.deleteModelStorageClasses(); err != nil {
+		return errors.Trace(err)
 	}
 	for {
 		select {
@@ -384,6 +407,21 @@ func (k *kubernetesClient) Destroy(callbacks context.ProviderCallContext) error
 	}
 }
 
+// deleteModelStorageClasses deletes any storage classes created as part of this model.
+// Storage classes live outside the namespace so need to be deleted separately.
+func (k *kubernetesClient) deleteModelStorageClasses() error {
+	modelSelector := fmt.Sprintf("%s==%s", labelModel, k.namespace)
+	err := k.StorageV1().StorageClasses().DeleteCollection(&v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	}, v1.ListOptions{
+		LabelSelector: modelSelector,
+	})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Annotate(err, "deleting model storage classes")
+	}
+	return nil
+}
+
 // APIVersion returns the version info for the cluster.
 func (k *kubernetesClient) APIVersion() (string, error) {
 	body, err := k.CoreV1().RESTClient().Get().AbsPath("/version").Do().Raw()
@@ -566,6 +604,11 @@ func (k *kubernetesClient) EnsureOperator(appName, agentPath string, config *caa
 	if err != nil {
 		return errors.Annotate(err, "generating operator podspec")
 	}
+	pullSecrets, err := k.ensureModelImagePullSecrets()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	addImagePullSecrets(&pod.Spec, pullSecrets)
 	// Take a copy for use with statefulset.
 	podWithoutStorage := pod
 
@@ -965,6 +1008,9 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 	if err := k.deleteDeployment(deploymentName); err != nil {
 		return errors.Trace(err)
 	}
+	if err := k.deletePodDisruptionBudget(deploymentName); err != nil {
+		return errors.Trace(err)
+	}
 	secrets := k.CoreV1().Secrets(k.namespace)
 	secretList, err := secrets.List(v1.ListOptions{
 		LabelSelector: applicationSelector(appName),
@@ -1073,6 +1119,9 @@ func (k *kubernetesClient) EnsureService(
 	if err != nil {
 		return errors.Annotatef(err, "parsing unit spec for %s", appName)
 	}
+	if err = applyRolloutConfig(unitSpec, config); err != nil {
+		return errors.Annotatef(err, "configuring update strategy for %s", appName)
+	}
 	if len(params.Devices) > 0 {
 		if err = k.configureDevices(unitSpec, params.Devices); err != nil {
 			return errors.Annotatef(err, "configuring devices for %s", appName)
@@ -1177,6 +1226,11 @@ func (k *kubernetesClient) EnsureService(
 		}
 		cleanups = append(cleanups, func() { k.deleteSecret(imageSecretName) })
 	}
+	pullSecrets, err := k.ensureModelImagePullSecrets()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	addImagePullSecrets(&unitSpec.Pod, pullSecrets)
 	// Add a deployment controller or stateful set configured to create the specified number of units/pods.
 	// Defensively check to see if a stateful set is already used.
 	useStatefulSet := len(params.Filesystems) > 0
@@ -1220,6 +1274,9 @@ func (k *kubernetesClient) EnsureService(
 		}
 		cleanups = append(cleanups, func() { k.deleteDeployment(appName) })
 	}
+	if err := k.ensurePodDisruptionBudget(appName, deploymentName, annotations.Copy(), unitSpec.DisruptionBudget); err != nil {
+		return errors.Annotatef(err, "creating or updating pod disruption budget for %v", appName)
+	}
 
 	var ports []core.ContainerPort
 	for _, c := range unitSpec.Pod.Containers {
@@ -1510,6 +1567,9 @@ func (k *kubernetesClient) configureDeployment(
 			},
 		},
 	}
+	if strategy := deploymentStrategy(unitSpec.UpdateStrategy); strategy != nil {
+		deployment.Spec.Strategy = *strategy
+	}
 	return k.ensureDeployment(deployment)
 }
 
@@ -1565,6 +1625,9 @@ func (k *kubernetesClient) configureStatefulSet(
 			PodManagementPolicy: apps.ParallelPodManagement,
 		},
 	}
+	if strategy := statefulSetUpdateStrategy(unitSpec.UpdateStrategy); strategy != nil {
+		statefulset.Spec.UpdateStrategy = *strategy
+	}
 	podSpec := unitSpec.Pod
 	if err := k.configurePodFiles(&podSpec, containers, cfgName); err != nil {
 		return errors.Trace(err)
@@ -1601,6 +1664,12 @@ func (k *kubernetesClient) ensureStatefulSet(spec *apps.StatefulSet, existingPod
 	existing.Spec.Selector = spec.Spec.Selector
 	existing.Spec.Replicas = spec.Spec.Replicas
 	existing.Spec.Template.Spec.Containers = existingPodSpec.Containers
+	// Replace the update strategy even if none is specified, so that a
+	// partition left from a finished or abandoned rollout is cleared.
+	existing.Spec.UpdateStrategy = spec.Spec.UpdateStrategy
+	if existing.Spec.UpdateStrategy.Type == "" {
+		existing.Spec.UpdateStrategy.Type = apps.RollingUpdateStatefulSetStrategyType
+	}
 	_, err = statefulsets.Update(existing)
 	return errors.Trace(err)
 }
@@ -1734,21 +1803,15 @@ func (k *kubernetesClient) deleteService(deploymentName string) error {
 func (k *kubernetesClient) ExposeService(appName string, resourceTags map[string]string, config application.ConfigAttributes) error {
 	logger.Debugf("creating/updating ingress resource for %s", appName)
 
-	host := config.GetString(caas.JujuExternalHostNameKey, "")
-	if host == "" {
+	hosts := ingressHosts(config)
+	if len(hosts) == 0 {
 		return errors.Errorf("external hostname required")
 	}
 	ingressClass := config.GetString(ingressClassKey, defaultIngressClass)
 	ingressSSLRedirect := config.GetBool(ingressSSLRedirectKey, defaultIngressSSLRedirect)
 	ingressSSLPassthrough := config.GetBool(ingressSSLPassthroughKey, defaultIngressSSLPassthrough)
 	ingressAllowHTTP := config.GetBool(ingressAllowHTTPKey, defaultIngressAllowHTTPKey)
-	httpPath := config.GetString(caas.JujuApplicationPath, caas.JujuDefaultApplicationPath)
-	if httpPath == "$appname" {
-		httpPath = appName
-	}
-	if !strings.HasPrefix(httpPath, "/") {
-		httpPath = "/" + httpPath
-	}
+	httpPaths := ingressPaths(appName, config)
 
 	deploymentName := k.deploymentName(appName)
 	svc, err := k.CoreV1().Services(k.namespace).Get(deploymentName, v1.GetOptions{})
@@ -1758,38 +1821,70 @@ func (k *kubernetesClient) ExposeService(appName string, resourceTags map[string
 	if len(svc.Spec.Ports) == 0 {
 		return errors.Errorf("cannot create ingress rule for service %q without a port", svc.Name)
 	}
+	annotations := map[string]string{
+		"ingress.kubernetes.io/rewrite-target":  "",
+		"ingress.kubernetes.io/ssl-redirect":    strconv.FormatBool(ingressSSLRedirect),
+		"kubernetes.io/ingress.class":           ingressClass,
+		"kubernetes.io/ingress.allow-http":      strconv.FormatBool(ingressAllowHTTP),
+		"ingress.kubernetes.io/ssl-passthrough": strconv.FormatBool(ingressSSLPassthrough),
+	}
+	// Merge any ingress annotations from the CLI.
+	extraAnnotations, err := config.GetStringMap(ingressAnnotationsKey, nil)
+	if err != nil {
+		return errors.Annotatef(err, "unexpected annotations: %#v", config.Get(ingressAnnotationsKey, nil))
+	}
+	for k, v := range extraAnnotations {
+		annotations[k] = v
+	}
+
+	var paths []v1beta1.HTTPIngressPath
+	for _, httpPath := range httpPaths {
+		paths = append(paths, v1beta1.HTTPIngressPath{
+			Path: httpPath,
+			Backend: v1beta1.IngressBackend{
+				ServiceName: svc.Name, ServicePort: svc.Spec.Ports[0].TargetPort},
+		})
+	}
+	var rules []v1beta1.IngressRule
+	for _, host := range hosts {
+		rules = append(rules, v1beta1.IngressRule{
+			Host: host,
+			IngressRuleValue: v1beta1.IngressRuleValue{
+				HTTP: &v1beta1.HTTPIngressRuleValue{Paths: paths},
+			},
+		})
+	}
 	spec := &v1beta1.Ingress{
 		ObjectMeta: v1.ObjectMeta{
-			Name:   deploymentName,
-			Labels: resourceTags,
-			Annotations: map[string]string{
-				"ingress.kubernetes.io/rewrite-target":  "",
-				"ingress.kubernetes.io/ssl-redirect":    strconv.FormatBool(ingressSSLRedirect),
-				"kubernetes.io/ingress.class":           ingressClass,
-				"kubernetes.io/ingress.allow-http":      strconv.FormatBool(ingressAllowHTTP),
-				"ingress.kubernetes.io/ssl-passthrough": strconv.FormatBool(ingressSSLPassthrough),
-			},
+			Name:        deploymentName,
+			Labels:      resourceTags,
+			Annotations: annotations,
 		},
 		Spec: v1beta1.IngressSpec{
-			Rules: []v1beta1.IngressRule{{
-				Host: host,
-				IngressRuleValue: v1beta1.IngressRuleValue{
-					HTTP: &v1beta1.HTTPIngressRuleValue{
-						Paths: []v1beta1.HTTPIngressPath{{
-							Path: httpPath,
-							Backend: v1beta1.IngressBackend{
-								ServiceName: svc.Name, ServicePort: svc.Spec.Ports[0].TargetPort},
-						}}},
-				}}},
+			Rules: rules,
 		},
 	}
+	tlsSecretName, err := k.ensureIngressTLSSecret(appName, deploymentName, config)
+	if err != nil {
+		return errors.Annotatef(err, "configuring TLS for %s", appName)
+	}
+	if tlsSecretName != "" {
+		spec.Spec.TLS = []v1beta1.IngressTLS{{
+			Hosts:      hosts,
+			SecretName: tlsSecretName,
+		}}
+	}
 	return k.ensureIngress(spec)
 }
 
 // UnexposeService removes external access to the specified service.
 func (k *kubernetesClient) UnexposeService(appName string) error {
 	logger.Debugf("deleting ingress resource for %s", appName)
-	return k.deleteIngress(appName)
+	deploymentName := k.deploymentName(appName)
+	if err := k.deleteIngress(deploymentName); err != nil {
+		return errors.Trace(err)
+	}
+	return k.deleteSecret(ingressTLSSecretName(deploymentName))
 }
 
 func (k *kubernetesClient) ensureIngress(spec *v1beta1.Ingress) error {
@@ -1801,8 +1896,7 @@ func (k *kubernetesClient) ensureIngress(spec *v1beta1.Ingress) error {
 	return errors.Trace(err)
 }
 
-func (k *kubernetesClient) deleteIngress(appName string) error {
-	deploymentName := k.deploymentName(appName)
+func (k *kubernetesClient) deleteIngress(deploymentName string) error {
 	ingress := k.ExtensionsV1beta1().Ingresses(k.namespace)
 	err := ingress.Delete(deploymentName, &v1.DeleteOptions{
 		PropagationPolicy: &defaultPropagationPolicy,
@@ -1902,6 +1996,13 @@ func (k *kubernetesClient) Units(appName string) ([]caas.Unit, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	// The application's service name depends on whether
+	// it was deployed using the legacy naming scheme.
+	serviceNames := []string{appName, "juju-" + appName}
+	events, err := k.listEvents(podsEventObjects(podsList.Items, serviceNames...))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	var units []caas.Unit
 	now := time.Now()
@@ -1939,6 +2040,7 @@ func (k *kubernetesClient) Units(appName string) ([]caas.Unit, error) {
 				Message: statusMessage,
 				Since:   &since,
 			},
+			Events: eventsForObjects(events, podEventObjects(p, serviceNames...)),
 		}
 
 		volumesByName := make(map[string]core.Volume)
@@ -2107,6 +2209,13 @@ func (k *kubernetesClient) Operator(appName string) (*caas.Operator, error) {
 	terminated := opPod.DeletionTimestamp != nil
 	now := time.Now()
 	statusMessage, opStatus, since, err := k.getPODStatus(opPod, now)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	events, err := k.listEvents(podEventObjects(opPod))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	return &caas.Operator{
 		Id:    string(opPod.UID),
 		Dying: terminated,
@@ -2115,6 +2224,7 @@ func (k *kubernetesClient) Operator(appName string) (*caas.Operator, error) {
 			Message: statusMessage,
 			Since:   &since,
 		},
+		Events: eventsForObjects(events, podEventObjects(opPod)),
 	}, nil
 }
 
@@ -2382,8 +2492,10 @@ func operatorConfigMap(appName, operatorName string, config *caas.OperatorConfig
 }
 
 type unitSpec struct {
-	Pod     core.PodSpec `json:"pod"`
-	Service *K8sServiceSpec
+	Pod              core.PodSpec `json:"pod"`
+	Service          *K8sServiceSpec
+	UpdateStrategy   *K8sUpdateStrategySpec
+	DisruptionBudget *K8sDisruptionBudgetSpec
 }
 
 var containerTemplate = `
@@ -2471,6 +2583,8 @@ func makeUnitSpec(appName, deploymentName string, podSpec *caas.PodSpec) (*unitS
 		unitSpec.Pod.AutomountServiceAccountToken = spec.AutomountServiceAccountToken
 		unitSpec.Pod.ReadinessGates = spec.ReadinessGates
 		unitSpec.Service = spec.Service
+		unitSpec.UpdateStrategy = spec.UpdateStrategy
+		unitSpec.DisruptionBudget = spec.DisruptionBudget
 	}
 	return &unitSpec, nil
 }
@@ -4,6 +4,7 @@
 package provider_test
 
 import (
+	"encoding/json"
 	"net/url"
 	"strings"
 	"time"
@@ -18,6 +19,8 @@ import (
 	apps "k8s.io/api/apps/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	k8sstorage "k8s.io/api/storage/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
@@ -388,6 +391,23 @@ func (s *K8sSuite) TestMakeUnitSpecConfigPairs(c *gc.C) {
 	})
 }
 
+func (s *K8sSuite) TestAddImagePullSecrets(c *gc.C) {
+	pod := core.PodSpec{
+		ImagePullSecrets: []core.LocalObjectReference{
+			{Name: "charm-pull-secret"},
+			{Name: "juju-image-registry-credentials"},
+		},
+	}
+	provider.AddImagePullSecrets(&pod, []core.LocalObjectReference{
+		{Name: "team-pull-secret"},
+		{Name: "charm-pull-secret"},
+	})
+	c.Assert(pod.ImagePullSecrets, jc.DeepEquals, []core.LocalObjectReference{
+		{Name: "charm-pull-secret"},
+		{Name: "team-pull-secret"},
+	})
+}
+
 func (s *K8sSuite) TestOperatorPodConfig(c *gc.C) {
 	tags := map[string]string{
 		"fred": "mary",
@@ -711,6 +731,249 @@ func (s *K8sBrokerSuite) TestCreateAlreadyExists(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
 }
 
+func (s *K8sBrokerSuite) setupExistingNamespace(c *gc.C) *gomock.Controller {
+	cfg, err := s.cfg.Apply(map[string]interface{}{provider.NamespaceKey: "existing"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.cfg = cfg
+	s.namespace = "existing"
+	return s.setupController(c)
+}
+
+func (s *K8sBrokerSuite) TestCreateExistingNamespace(c *gc.C) {
+	ctrl := s.setupExistingNamespace(c)
+	defer ctrl.Finish()
+	c.Assert(s.broker.GetCurrentNamespace(), gc.Equals, "existing")
+
+	ns := &core.Namespace{ObjectMeta: v1.ObjectMeta{
+		Name:   "existing",
+		Labels: map[string]string{"team": "a"},
+	}}
+	adopted := s.ensureJujuNamespaceAnnotations(false, &core.Namespace{ObjectMeta: v1.ObjectMeta{
+		Name:   "existing",
+		Labels: map[string]string{"team": "a", "juju-model-uuid": s.cfg.UUID()},
+	}})
+	gomock.InOrder(
+		s.mockNamespaces.EXPECT().Get("existing", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(ns, nil),
+		s.mockNamespaces.EXPECT().Update(adopted).Times(1).
+			Return(adopted, nil),
+	)
+
+	err := s.broker.Create(
+		&context.CloudCallContext{},
+		environs.CreateParams{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestCreateExistingNamespaceNotFound(c *gc.C) {
+	ctrl := s.setupExistingNamespace(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockNamespaces.EXPECT().Get("existing", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+	)
+
+	err := s.broker.Create(
+		&context.CloudCallContext{},
+		environs.CreateParams{},
+	)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *K8sBrokerSuite) TestCreateExistingNamespaceUsedByAnotherModel(c *gc.C) {
+	ctrl := s.setupExistingNamespace(c)
+	defer ctrl.Finish()
+
+	ns := &core.Namespace{ObjectMeta: v1.ObjectMeta{
+		Name:   "existing",
+		Labels: map[string]string{"juju-model-uuid": "deadbeef-0bad-400d-8000-4b1d0d06f00d"},
+	}}
+	gomock.InOrder(
+		s.mockNamespaces.EXPECT().Get("existing", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(ns, nil),
+	)
+
+	err := s.broker.Create(
+		&context.CloudCallContext{},
+		environs.CreateParams{},
+	)
+	c.Assert(err, gc.ErrorMatches, `namespace "existing" is already used by model "deadbeef-0bad-400d-8000-4b1d0d06f00d"`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *K8sBrokerSuite) TestDestroyExistingNamespace(c *gc.C) {
+	ctrl := s.setupExistingNamespace(c)
+	defer ctrl.Finish()
+
+	ns := s.ensureJujuNamespaceAnnotations(false, &core.Namespace{ObjectMeta: v1.ObjectMeta{
+		Name:   "existing",
+		Labels: map[string]string{"team": "a", "juju-model-uuid": s.cfg.UUID()},
+	}})
+	released := &core.Namespace{ObjectMeta: v1.ObjectMeta{
+		Name:        "existing",
+		Labels:      map[string]string{"team": "a"},
+		Annotations: map[string]string{},
+	}}
+	noResources := s.expectLabelledResources("juju-app", nil, nil)
+	noResources = append(noResources, s.expectLabelledResources("juju-operator", nil, nil)...)
+	gomock.InOrder(append(noResources,
+		s.mockSecrets.EXPECT().Delete("juju-image-registry-credentials", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockNamespaces.EXPECT().Get("existing", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(ns, nil),
+		s.mockNamespaces.EXPECT().Update(released).Times(1).
+			Return(released, nil),
+		s.mockStorageClass.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground),
+			v1.ListOptions{LabelSelector: "juju-model==existing"},
+		).Times(1).
+			Return(s.k8sNotFoundError()),
+	)...)
+
+	err := s.broker.Destroy(context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// expectLabelledResources expects the services, deployments and pods
+// with the given label to be listed, returning the given ones.
+func (s *K8sBrokerSuite) expectLabelledResources(label string, services []core.Service, pods []core.Pod) []*gomock.Call {
+	opts := v1.ListOptions{LabelSelector: label}
+	return []*gomock.Call{
+		s.mockServices.EXPECT().List(opts).Times(1).
+			Return(&core.ServiceList{Items: services}, nil),
+		s.mockDeployments.EXPECT().List(opts).Times(1).
+			Return(&appsv1.DeploymentList{}, nil),
+		s.mockPods.EXPECT().List(opts).Times(1).
+			Return(&core.PodList{Items: pods}, nil),
+	}
+}
+
+func (s *K8sBrokerSuite) TestDestroyExistingNamespaceDeletesModelResources(c *gc.C) {
+	ctrl := s.setupExistingNamespace(c)
+	defer ctrl.Finish()
+
+	appService := core.Service{ObjectMeta: v1.ObjectMeta{
+		Name:   "gitlab",
+		Labels: map[string]string{"juju-app": "gitlab"},
+	}}
+	appPod := core.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "gitlab-0",
+			Labels: map[string]string{"juju-app": "gitlab"},
+		},
+		Spec: core.PodSpec{
+			Containers: []core.Container{{
+				Name: "gitlab",
+				VolumeMounts: []core.VolumeMount{
+					{Name: "database-appuuid"},
+					{Name: "gitlab-files"},
+				},
+			}},
+			Volumes: []core.Volume{{
+				Name: "database-appuuid", VolumeSource: core.VolumeSource{
+					PersistentVolumeClaim: &core.PersistentVolumeClaimVolumeSource{
+						ClaimName: "database-appuuid-gitlab-0"}},
+			}, {
+				Name: "gitlab-files", VolumeSource: core.VolumeSource{
+					ConfigMap: &core.ConfigMapVolumeSource{
+						LocalObjectReference: core.LocalObjectReference{Name: "gitlab-files-config"}}},
+			}, {
+				Name: "user-config", VolumeSource: core.VolumeSource{
+					ConfigMap: &core.ConfigMapVolumeSource{
+						LocalObjectReference: core.LocalObjectReference{Name: "site-settings"}}},
+			}},
+		},
+	}
+	operatorPod := core.Pod{ObjectMeta: v1.ObjectMeta{
+		Name:   "gitlab-operator-0",
+		Labels: map[string]string{"juju-operator": "gitlab"},
+	}}
+
+	ns := s.ensureJujuNamespaceAnnotations(false, &core.Namespace{ObjectMeta: v1.ObjectMeta{
+		Name:   "existing",
+		Labels: map[string]string{"juju-model-uuid": s.cfg.UUID()},
+	}})
+	released := &core.Namespace{ObjectMeta: v1.ObjectMeta{
+		Name:        "existing",
+		Labels:      map[string]string{},
+		Annotations: map[string]string{},
+	}}
+	notLegacy := func() *gomock.Call {
+		return s.mockStatefulSets.EXPECT().Get("juju-operator-gitlab", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError())
+	}
+	calls := s.expectLabelledResources("juju-app", []core.Service{appService}, []core.Pod{appPod})
+	calls = append(calls,
+		// The application.
+		notLegacy(),
+		s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-app==gitlab"}).Times(1).
+			Return(&core.PodList{Items: []core.Pod{appPod}}, nil),
+		s.mockPersistentVolumeClaims.EXPECT().Delete("database-appuuid-gitlab-0", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockConfigMaps.EXPECT().Delete("gitlab-files-config", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		notLegacy(),
+		s.mockIngressInterface.EXPECT().Delete("gitlab", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Delete("gitlab-ingress-tls", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		notLegacy(),
+		s.mockServices.EXPECT().Delete("gitlab", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockStatefulSets.EXPECT().Delete("gitlab", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockDeployments.EXPECT().Delete("gitlab", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("gitlab", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-app==gitlab"}).Times(1).
+			Return(&core.SecretList{}, nil),
+	)
+	calls = append(calls, s.expectLabelledResources("juju-operator", nil, []core.Pod{operatorPod})...)
+	calls = append(calls,
+		// The operator.
+		notLegacy(),
+		s.mockConfigMaps.EXPECT().Delete("gitlab-operator-config", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockConfigMaps.EXPECT().Delete("gitlab-configurations-config", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Delete("gitlab-operator", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-operator==gitlab"}).Times(1).
+			Return(&core.PodList{}, nil),
+		s.mockDeployments.EXPECT().Delete("gitlab-operator", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+
+		// The namespace is released rather than deleted.
+		s.mockSecrets.EXPECT().Delete("juju-image-registry-credentials", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockNamespaces.EXPECT().Get("existing", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(ns, nil),
+		s.mockNamespaces.EXPECT().Update(released).Times(1).
+			Return(released, nil),
+		s.mockStorageClass.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground),
+			v1.ListOptions{LabelSelector: "juju-model==existing"},
+		).Times(1).
+			Return(s.k8sNotFoundError()),
+	)
+	gomock.InOrder(calls...)
+
+	err := s.broker.Destroy(context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestPrepareForBootstrapExistingNamespace(c *gc.C) {
+	ctrl := s.setupExistingNamespace(c)
+	defer ctrl.Finish()
+
+	ctx := envtesting.BootstrapContext(c)
+	err := s.broker.PrepareForBootstrap(ctx, "ctrl-1")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *K8sBrokerSuite) TestDeleteOperator(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
@@ -869,6 +1132,8 @@ func (s *K8sBrokerSuite) TestEnsureOperator(c *gc.C) {
 		s.mockConfigMaps.EXPECT().Update(configMapArg).Times(1),
 		s.mockStorageClass.EXPECT().Get("test-operator-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
 			Return(&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "test-operator-storage"}}, nil),
+		s.mockSecrets.EXPECT().Delete("juju-image-registry-credentials", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Update(statefulSetArg).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
@@ -890,6 +1155,73 @@ func (s *K8sBrokerSuite) TestEnsureOperator(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *K8sBrokerSuite) TestEnsureOperatorImagePullSecrets(c *gc.C) {
+	cfg, err := s.cfg.Apply(map[string]interface{}{
+		provider.ImageRegistryCredentialsKey: "registry.example.com=fred:secret",
+		provider.ImagePullSecretsKey:         "team-pull-secret, other-pull-secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.cfg = cfg
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	secretData, err := json.Marshal(provider.DockerConfigJson{
+		Auths: provider.DockerConfig{
+			"registry.example.com": {Username: "fred", Password: "secret"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	secretArg := &core.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-image-registry-credentials",
+			Namespace: s.getNamespace(),
+			Annotations: map[string]string{
+				"juju.io/model":      s.cfg.UUID(),
+				"juju.io/controller": s.controllerUUID,
+			},
+		},
+		Type: core.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			core.DockerConfigJsonKey: secretData,
+		},
+	}
+	statefulSetArg := operatorStatefulSetArg(1, "test-operator-storage")
+	statefulSetArg.Spec.Template.Spec.ImagePullSecrets = []core.LocalObjectReference{
+		{Name: "juju-image-registry-credentials"},
+		{Name: "team-pull-secret"},
+		{Name: "other-pull-secret"},
+	}
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockConfigMaps.EXPECT().Get("test-operator-config", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, nil),
+		s.mockStorageClass.EXPECT().Get("test-operator-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
+			Return(&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "test-operator-storage"}}, nil),
+		s.mockSecrets.EXPECT().Update(secretArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Create(secretArg).Times(1).
+			Return(secretArg, nil),
+		s.mockStatefulSets.EXPECT().Update(statefulSetArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
+			Return(nil, nil),
+	)
+
+	err = s.broker.EnsureOperator("test", "path/to/agent", &caas.OperatorConfig{
+		OperatorImagePath: "/path/to/image",
+		Version:           version.MustParse("2.99.0"),
+		CharmStorage: caas.CharmStorageParams{
+			Size:         uint64(10),
+			Provider:     "kubernetes",
+			Attributes:   map[string]interface{}{"storage-class": "operator-storage"},
+			ResourceTags: map[string]string{"foo": "bar"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *K8sBrokerSuite) TestEnsureOperatorNoAgentConfig(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
@@ -902,6 +1234,8 @@ func (s *K8sBrokerSuite) TestEnsureOperatorNoAgentConfig(c *gc.C) {
 			Return(nil, nil),
 		s.mockStorageClass.EXPECT().Get("test-operator-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
 			Return(&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "test-operator-storage"}}, nil),
+		s.mockSecrets.EXPECT().Delete("juju-image-registry-credentials", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Update(statefulSetArg).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
@@ -958,6 +1292,8 @@ func (s *K8sBrokerSuite) TestDeleteServiceForApplication(c *gc.C) {
 			Return(s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Delete("test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
 			Return(s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-app==test"}).Times(1).
 			Return(&core.SecretList{Items: []core.Secret{{
 				ObjectMeta: v1.ObjectMeta{Name: "secret"},
@@ -1058,12 +1394,16 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockSecrets.EXPECT().Update(secretArg).Times(1).
 			Return(nil, nil),
+		s.mockSecrets.EXPECT().Delete("juju-image-registry-credentials", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(deploymentArg).Times(1).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(serviceArg).Times(1).
@@ -1336,6 +1676,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithStorage(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
 			Return(nil, nil),
+		s.mockSecrets.EXPECT().Delete("juju-image-registry-credentials", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(&appsv1.StatefulSet{ObjectMeta: v1.ObjectMeta{Annotations: map[string]string{"juju-app-uuid": "appuuid"}}}, nil),
 		s.mockStorageClass.EXPECT().Get("test-workload-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
@@ -1346,6 +1688,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithStorage(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
@@ -1432,12 +1776,16 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDeploymentWithDevices(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
 			Return(nil, nil),
+		s.mockSecrets.EXPECT().Delete("juju-image-registry-credentials", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(deploymentArg).Times(1).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
@@ -1493,6 +1841,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForStatefulSetWithDevices(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
 			Return(nil, nil),
+		s.mockSecrets.EXPECT().Delete("juju-image-registry-credentials", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(&appsv1.StatefulSet{ObjectMeta: v1.ObjectMeta{Annotations: map[string]string{"juju-app-uuid": "appuuid"}}}, nil),
 		s.mockStorageClass.EXPECT().Get("test-workload-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
@@ -1503,6 +1853,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForStatefulSetWithDevices(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
@@ -1565,6 +1917,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithConstraints(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
 			Return(nil, nil),
+		s.mockSecrets.EXPECT().Delete("juju-image-registry-credentials", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(&appsv1.StatefulSet{ObjectMeta: v1.ObjectMeta{Annotations: map[string]string{"juju-app-uuid": "appuuid"}}}, nil),
 		s.mockStorageClass.EXPECT().Get("test-workload-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
@@ -1575,6 +1929,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithConstraints(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
@@ -1644,6 +2000,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithNodeAffinity(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
 			Return(nil, nil),
+		s.mockSecrets.EXPECT().Delete("juju-image-registry-credentials", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(&appsv1.StatefulSet{ObjectMeta: v1.ObjectMeta{Annotations: map[string]string{"juju-app-uuid": "appuuid"}}}, nil),
 		s.mockStorageClass.EXPECT().Get("test-workload-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
@@ -1654,6 +2012,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithNodeAffinity(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
@@ -1715,6 +2075,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithZones(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
 			Return(nil, nil),
+		s.mockSecrets.EXPECT().Delete("juju-image-registry-credentials", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(&appsv1.StatefulSet{ObjectMeta: v1.ObjectMeta{Annotations: map[string]string{"juju-app-uuid": "appuuid"}}}, nil),
 		s.mockStorageClass.EXPECT().Get("test-workload-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
@@ -1725,6 +2087,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithZones(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
@@ -1755,6 +2119,497 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithZones(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *K8sBrokerSuite) TestEnsureServiceWithUpdateStrategyAndDisruptionBudget(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	numUnits := int32(2)
+	unitSpec, err := provider.MakeUnitSpec("app-name", "app-name", basicPodspec)
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(unitSpec)
+
+	maxSurge := intstr.FromString("25%")
+	maxUnavailable := intstr.FromInt(1)
+	deploymentArg := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "app-name",
+			Labels:      map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{}},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numUnits,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-app": "app-name"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "app-name-",
+					Labels:       map[string]string{"juju-app": "app-name"},
+					Annotations: map[string]string{
+						"apparmor.security.beta.kubernetes.io/pod": "runtime/default",
+						"seccomp.security.beta.kubernetes.io/pod":  "docker/default",
+					},
+				},
+				Spec: podSpec,
+			},
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxSurge:       &maxSurge,
+					MaxUnavailable: &maxUnavailable,
+				},
+			},
+		},
+	}
+	minAvailable := intstr.FromInt(2)
+	pdbArg := &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "app-name",
+			Labels:      map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{},
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-app": "app-name"},
+			},
+		},
+	}
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
+			Return(nil, nil),
+		s.mockSecrets.EXPECT().Delete("juju-image-registry-credentials", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Create(deploymentArg).Times(1).
+			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Update(pdbArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Create(pdbArg).Times(1).
+			Return(nil, nil),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(basicServiceArg).Times(1).
+			Return(nil, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: basicPodspec,
+	}
+	err = s.broker.EnsureService("app-name", nil, params, 2, application.ConfigAttributes{
+		"kubernetes-service-type":            "nodeIP",
+		"kubernetes-service-loadbalancer-ip": "10.0.0.1",
+		"kubernetes-service-externalname":    "ext-name",
+		"kubernetes-update-max-surge":        "25%",
+		"kubernetes-update-max-unavailable":  "1",
+		"kubernetes-pdb-min-available":       "2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceWithStatefulSetPartition(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	unitSpec, err := provider.MakeUnitSpec("app-name", "app-name", basicPodspec)
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(unitSpec)
+	podSpec.Containers[0].VolumeMounts = []core.VolumeMount{{
+		Name:      "database-appuuid",
+		MountPath: "path/to/here",
+	}}
+	statefulSetArg := unitStatefulSetArg(2, "workload-storage", podSpec)
+	partition := int32(1)
+	statefulSetArg.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+		Type: appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+			Partition: &partition,
+		},
+	}
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
+			Return(nil, nil),
+		s.mockSecrets.EXPECT().Delete("juju-image-registry-credentials", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(&appsv1.StatefulSet{ObjectMeta: v1.ObjectMeta{Annotations: map[string]string{"juju-app-uuid": "appuuid"}}}, nil),
+		s.mockStorageClass.EXPECT().Get("test-workload-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStorageClass.EXPECT().Get("workload-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
+			Return(&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "workload-storage"}}, nil),
+		s.mockStatefulSets.EXPECT().Update(statefulSetArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
+			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(basicServiceArg).Times(1).
+			Return(nil, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: basicPodspec,
+		Filesystems: []storage.KubernetesFilesystemParams{{
+			StorageName: "database",
+			Size:        100,
+			Provider:    "kubernetes",
+			Attachment: &storage.KubernetesFilesystemAttachmentParams{
+				Path: "path/to/here",
+			},
+			Attributes:   map[string]interface{}{"storage-class": "workload-storage"},
+			ResourceTags: map[string]string{"foo": "bar"},
+		}},
+	}
+	err = s.broker.EnsureService("app-name", nil, params, 2, application.ConfigAttributes{
+		"kubernetes-service-type":            "nodeIP",
+		"kubernetes-service-loadbalancer-ip": "10.0.0.1",
+		"kubernetes-service-externalname":    "ext-name",
+		"kubernetes-update-partition":        1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceClearsStatefulSetPartition(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	unitSpec, err := provider.MakeUnitSpec("app-name", "app-name", basicPodspec)
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(unitSpec)
+	podSpec.Containers[0].VolumeMounts = []core.VolumeMount{{
+		Name:      "database-appuuid",
+		MountPath: "path/to/here",
+	}}
+	statefulSetArg := unitStatefulSetArg(2, "workload-storage", podSpec)
+
+	// The existing stateful set was left with the partition
+	// of an earlier rollout.
+	existing := unitStatefulSetArg(2, "workload-storage", podSpec)
+	partition := int32(1)
+	existing.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+		Type: appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+			Partition: &partition,
+		},
+	}
+	updated := unitStatefulSetArg(2, "workload-storage", podSpec)
+	updated.Spec.Template.Spec.Containers = provider.PodSpec(unitSpec).Containers
+	updated.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+		Type: appsv1.RollingUpdateStatefulSetStrategyType,
+	}
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
+			Return(nil, nil),
+		s.mockSecrets.EXPECT().Delete("juju-image-registry-credentials", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(&appsv1.StatefulSet{ObjectMeta: v1.ObjectMeta{Annotations: map[string]string{"juju-app-uuid": "appuuid"}}}, nil),
+		s.mockStorageClass.EXPECT().Get("test-workload-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStorageClass.EXPECT().Get("workload-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
+			Return(&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "workload-storage"}}, nil),
+		s.mockStatefulSets.EXPECT().Update(statefulSetArg).Times(1).
+			Return(nil, s.k8sInvalidError()),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(existing, nil),
+		s.mockStatefulSets.EXPECT().Update(updated).Times(1).
+			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(basicServiceArg).Times(1).
+			Return(nil, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: basicPodspec,
+		Filesystems: []storage.KubernetesFilesystemParams{{
+			StorageName: "database",
+			Size:        100,
+			Provider:    "kubernetes",
+			Attachment: &storage.KubernetesFilesystemAttachmentParams{
+				Path: "path/to/here",
+			},
+			Attributes:   map[string]interface{}{"storage-class": "workload-storage"},
+			ResourceTags: map[string]string{"foo": "bar"},
+		}},
+	}
+	err = s.broker.EnsureService("app-name", nil, params, 2, application.ConfigAttributes{
+		"kubernetes-service-type":            "nodeIP",
+		"kubernetes-service-loadbalancer-ip": "10.0.0.1",
+		"kubernetes-service-externalname":    "ext-name",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceInvalidUpdateStrategy(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: basicPodspec,
+	}
+	statusCallback := func(appName string, settableStatus status.Status, info string, data map[string]interface{}) error {
+		return nil
+	}
+	err := s.broker.EnsureService("app-name", statusCallback, params, 2, application.ConfigAttributes{
+		"kubernetes-update-max-surge": "lots",
+	})
+	c.Assert(err, gc.ErrorMatches, `configuring update strategy for app-name: kubernetes-update-max-surge value "lots" \(expected a number or percentage\) not valid`)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceNegativeUpdateStrategy(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: basicPodspec,
+	}
+	statusCallback := func(appName string, settableStatus status.Status, info string, data map[string]interface{}) error {
+		return nil
+	}
+	err := s.broker.EnsureService("app-name", statusCallback, params, 2, application.ConfigAttributes{
+		"kubernetes-update-max-unavailable": "-10%",
+	})
+	c.Assert(err, gc.ErrorMatches, `configuring update strategy for app-name: negative kubernetes-update-max-unavailable "-10%" not valid`)
+}
+
+func (s *K8sBrokerSuite) assertExposeService(c *gc.C, config application.ConfigAttributes, secretCalls []*gomock.Call, ingressArg *extensionsv1beta1.Ingress) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	svc := &core.Service{
+		ObjectMeta: v1.ObjectMeta{Name: "app-name"},
+		Spec: core.ServiceSpec{
+			Ports: []core.ServicePort{{Port: 80, TargetPort: intstr.FromInt(8080)}},
+		},
+	}
+	calls := []*gomock.Call{
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{}).Times(1).
+			Return(svc, nil),
+	}
+	calls = append(calls, secretCalls...)
+	calls = append(calls,
+		s.mockIngressInterface.EXPECT().Update(ingressArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockIngressInterface.EXPECT().Create(ingressArg).Times(1).
+			Return(nil, nil),
+	)
+	gomock.InOrder(calls...)
+
+	err := s.broker.ExposeService("app-name", map[string]string{"juju-app": "app-name"}, config)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func ingressArg(hosts []string, paths []string, annotations map[string]string) *extensionsv1beta1.Ingress {
+	ingressAnnotations := map[string]string{
+		"ingress.kubernetes.io/rewrite-target":  "",
+		"ingress.kubernetes.io/ssl-redirect":    "false",
+		"kubernetes.io/ingress.class":           "nginx",
+		"kubernetes.io/ingress.allow-http":      "false",
+		"ingress.kubernetes.io/ssl-passthrough": "false",
+	}
+	for k, v := range annotations {
+		ingressAnnotations[k] = v
+	}
+	var httpPaths []extensionsv1beta1.HTTPIngressPath
+	for _, p := range paths {
+		httpPaths = append(httpPaths, extensionsv1beta1.HTTPIngressPath{
+			Path: p,
+			Backend: extensionsv1beta1.IngressBackend{
+				ServiceName: "app-name", ServicePort: intstr.FromInt(8080)},
+		})
+	}
+	var rules []extensionsv1beta1.IngressRule
+	for _, h := range hosts {
+		rules = append(rules, extensionsv1beta1.IngressRule{
+			Host: h,
+			IngressRuleValue: extensionsv1beta1.IngressRuleValue{
+				HTTP: &extensionsv1beta1.HTTPIngressRuleValue{Paths: httpPaths},
+			},
+		})
+	}
+	return &extensionsv1beta1.Ingress{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "app-name",
+			Labels:      map[string]string{"juju-app": "app-name"},
+			Annotations: ingressAnnotations,
+		},
+		Spec: extensionsv1beta1.IngressSpec{Rules: rules},
+	}
+}
+
+func (s *K8sBrokerSuite) TestExposeService(c *gc.C) {
+	s.assertExposeService(c, application.ConfigAttributes{
+		"juju-external-hostname": "example.com",
+		"juju-application-path":  "$appname",
+	}, []*gomock.Call{
+		s.mockSecrets.EXPECT().Delete("app-name-ingress-tls", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+	}, ingressArg([]string{"example.com"}, []string{"/app-name"}, nil))
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceMultipleHostsAndPaths(c *gc.C) {
+	s.assertExposeService(c, application.ConfigAttributes{
+		"juju-external-hostname":         "example.com",
+		"kubernetes-ingress-hostnames":   "www.example.com, example.com api.example.com",
+		"kubernetes-ingress-paths":       "/ v1",
+		"kubernetes-ingress-annotations": map[string]interface{}{"foo": "bar", "kubernetes.io/ingress.class": "traefik"},
+	}, []*gomock.Call{
+		s.mockSecrets.EXPECT().Delete("app-name-ingress-tls", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+	}, ingressArg(
+		[]string{"example.com", "www.example.com", "api.example.com"},
+		[]string{"/", "/v1"},
+		map[string]string{"foo": "bar", "kubernetes.io/ingress.class": "traefik"},
+	))
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceWithTLSSecret(c *gc.C) {
+	ingress := ingressArg([]string{"example.com"}, []string{"/"}, nil)
+	ingress.Spec.TLS = []extensionsv1beta1.IngressTLS{{
+		Hosts:      []string{"example.com"},
+		SecretName: "my-tls",
+	}}
+	s.assertExposeService(c, application.ConfigAttributes{
+		"juju-external-hostname":        "example.com",
+		"kubernetes-ingress-tls-secret": "my-tls",
+	}, []*gomock.Call{
+		s.mockSecrets.EXPECT().Delete("app-name-ingress-tls", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+	}, ingress)
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceWithTLSCertificate(c *gc.C) {
+	secretArg := &core.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "app-name-ingress-tls",
+			Namespace: "test",
+			Labels:    map[string]string{"juju-app": "app-name"},
+		},
+		Type: core.SecretTypeTLS,
+		Data: map[string][]byte{
+			"tls.crt": []byte(testing.ServerCert),
+			"tls.key": []byte(testing.ServerKey),
+		},
+	}
+	ingress := ingressArg([]string{"example.com", "www.example.com"}, []string{"/"}, nil)
+	ingress.Spec.TLS = []extensionsv1beta1.IngressTLS{{
+		Hosts:      []string{"example.com", "www.example.com"},
+		SecretName: "app-name-ingress-tls",
+	}}
+	s.assertExposeService(c, application.ConfigAttributes{
+		"juju-external-hostname":             "example.com",
+		"kubernetes-ingress-hostnames":       "www.example.com",
+		"kubernetes-ingress-tls-certificate": testing.ServerCert,
+		"kubernetes-ingress-tls-key":         testing.ServerKey,
+	}, []*gomock.Call{
+		s.mockSecrets.EXPECT().Update(secretArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Create(secretArg).Times(1).
+			Return(nil, nil),
+	}, ingress)
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceInvalidTLSConfig(c *gc.C) {
+	for i, t := range []struct {
+		config application.ConfigAttributes
+		err    string
+	}{{
+		config: application.ConfigAttributes{
+			"kubernetes-ingress-tls-secret":      "my-tls",
+			"kubernetes-ingress-tls-certificate": testing.ServerCert,
+			"kubernetes-ingress-tls-key":         testing.ServerKey,
+		},
+		err: `configuring TLS for app-name: specifying both "kubernetes-ingress-tls-secret" and a TLS certificate not valid`,
+	}, {
+		config: application.ConfigAttributes{
+			"kubernetes-ingress-tls-certificate": testing.ServerCert,
+		},
+		err: `configuring TLS for app-name: "kubernetes-ingress-tls-certificate" without "kubernetes-ingress-tls-key" not valid`,
+	}, {
+		config: application.ConfigAttributes{
+			"kubernetes-ingress-tls-certificate": testing.ServerCert,
+			"kubernetes-ingress-tls-key":         testing.CAKey,
+		},
+		err: `configuring TLS for app-name: invalid TLS certificate or key: .*`,
+	}} {
+		c.Logf("test %d", i)
+		ctrl := s.setupController(c)
+
+		gomock.InOrder(
+			s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+				Return(nil, s.k8sNotFoundError()),
+			s.mockServices.EXPECT().Get("app-name", v1.GetOptions{}).Times(1).
+				Return(&core.Service{
+					ObjectMeta: v1.ObjectMeta{Name: "app-name"},
+					Spec:       core.ServiceSpec{Ports: []core.ServicePort{{Port: 80}}},
+				}, nil),
+		)
+		t.config["juju-external-hostname"] = "example.com"
+		err := s.broker.ExposeService("app-name", nil, t.config)
+		c.Check(err, gc.ErrorMatches, t.err)
+		ctrl.Finish()
+	}
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceNoHostname(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	err := s.broker.ExposeService("app-name", nil, application.ConfigAttributes{})
+	c.Assert(err, gc.ErrorMatches, "external hostname required")
+}
+
+func (s *K8sBrokerSuite) TestUnexposeService(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockIngressInterface.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(nil),
+		s.mockSecrets.EXPECT().Delete("app-name-ingress-tls", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+	)
+
+	err := s.broker.UnexposeService("app-name")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *K8sBrokerSuite) TestOperator(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
@@ -1768,9 +2623,19 @@ func (s *K8sBrokerSuite) TestOperator(c *gc.C) {
 			Message: "test message.",
 		},
 	}
+	failedMount := time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC)
+	events := []core.Event{{
+		InvolvedObject: core.ObjectReference{Kind: "Pod", Name: "test-operator"},
+		Type:           core.EventTypeWarning,
+		Reason:         "FailedMount",
+		Message:        "Unable to mount volumes",
+		LastTimestamp:  v1.NewTime(failedMount),
+	}}
 	gomock.InOrder(
 		s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-operator==test"}).Times(1).
 			Return(&core.PodList{Items: []core.Pod{opPod}}, nil),
+		s.mockEvents.EXPECT().List(eventListOptions("Pod", "test-operator")).Times(1).
+			Return(&core.EventList{Items: events}, nil),
 	)
 
 	operator, err := s.broker.Operator("test")
@@ -1778,6 +2643,100 @@ func (s *K8sBrokerSuite) TestOperator(c *gc.C) {
 
 	c.Assert(operator.Status.Status, gc.Equals, status.Allocating)
 	c.Assert(operator.Status.Message, gc.Equals, "test message.")
+	c.Assert(operator.Events, jc.DeepEquals, []status.StatusInfo{{
+		Status:  "FailedMount",
+		Message: "Unable to mount volumes",
+		Data:    map[string]interface{}{"type": "Warning", "object": "Pod/test-operator"},
+		Since:   &failedMount,
+	}})
+}
+
+func (s *K8sBrokerSuite) TestUnitsEvents(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	pod := core.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "app-name-0",
+			UID:  "uuid",
+		},
+		Spec: core.PodSpec{
+			Containers: []core.Container{{Name: "app-name"}},
+			Volumes: []core.Volume{{
+				Name: "database",
+				VolumeSource: core.VolumeSource{
+					PersistentVolumeClaim: &core.PersistentVolumeClaimVolumeSource{ClaimName: "database-app-name-0"},
+				},
+			}},
+		},
+		Status: core.PodStatus{
+			Phase:   core.PodPending,
+			Message: "pulling image",
+		},
+	}
+	t0 := time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Minute)
+	t2 := t1.Add(time.Minute)
+	podEvent := core.Event{
+		InvolvedObject: core.ObjectReference{Kind: "Pod", Name: "app-name-0"},
+		Type:           core.EventTypeWarning,
+		Reason:         "BackOff",
+		Message:        "Back-off pulling image",
+		LastTimestamp:  v1.NewTime(t2),
+	}
+	pvcEvent := core.Event{
+		InvolvedObject: core.ObjectReference{Kind: "PersistentVolumeClaim", Name: "database-app-name-0"},
+		Type:           core.EventTypeNormal,
+		Reason:         "ProvisioningSucceeded",
+		Message:        "Successfully provisioned volume",
+		FirstTimestamp: v1.NewTime(t0),
+	}
+	serviceEvent := core.Event{
+		InvolvedObject: core.ObjectReference{Kind: "Service", Name: "app-name"},
+		Type:           core.EventTypeNormal,
+		Reason:         "EnsuringLoadBalancer",
+		Message:        "Ensuring load balancer",
+		LastTimestamp:  v1.NewTime(t1),
+	}
+	gomock.InOrder(
+		s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-app==app-name"}).Times(1).
+			Return(&core.PodList{Items: []core.Pod{pod}}, nil),
+		s.mockEvents.EXPECT().List(eventListOptions("PersistentVolumeClaim", "database-app-name-0")).Times(1).
+			Return(&core.EventList{Items: []core.Event{pvcEvent}}, nil),
+		s.mockEvents.EXPECT().List(eventListOptions("Pod", "app-name-0")).Times(1).
+			Return(&core.EventList{Items: []core.Event{podEvent}}, nil),
+		s.mockEvents.EXPECT().List(eventListOptions("Service", "app-name")).Times(1).
+			Return(&core.EventList{Items: []core.Event{serviceEvent}}, nil),
+		s.mockEvents.EXPECT().List(eventListOptions("Service", "juju-app-name")).Times(1).
+			Return(&core.EventList{}, nil),
+	)
+
+	units, err := s.broker.Units("app-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+	c.Assert(units[0].Events, jc.DeepEquals, []status.StatusInfo{{
+		Status:  "ProvisioningSucceeded",
+		Message: "Successfully provisioned volume",
+		Data:    map[string]interface{}{"type": "Normal", "object": "PersistentVolumeClaim/database-app-name-0"},
+		Since:   &t0,
+	}, {
+		Status:  "EnsuringLoadBalancer",
+		Message: "Ensuring load balancer",
+		Data:    map[string]interface{}{"type": "Normal", "object": "Service/app-name"},
+		Since:   &t1,
+	}, {
+		Status:  "BackOff",
+		Message: "Back-off pulling image",
+		Data:    map[string]interface{}{"type": "Warning", "object": "Pod/app-name-0"},
+		Since:   &t2,
+	}})
+}
+
+func eventListOptions(kind, name string) v1.ListOptions {
+	return v1.ListOptions{
+		IncludeUninitialized: true,
+		FieldSelector:        "involvedObject.kind=" + kind + ",involvedObject.name=" + name,
+	}
 }
 
 func (s *K8sBrokerSuite) TestOperatorNoPodFound(c *gc.C) {
@@ -12,6 +12,7 @@ import (
 	"gopkg.in/yaml.v2"
 	core "k8s.io/api/core/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
 
 	"github.com/juju/juju/caas"
@@ -57,6 +58,24 @@ type K8sServiceSpec struct {
 	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
+// K8sUpdateStrategySpec defines how pods are replaced
+// when the pod spec of an application changes.
+type K8sUpdateStrategySpec struct {
+	// MaxSurge and MaxUnavailable apply to applications
+	// deployed as a deployment.
+	MaxSurge       *intstr.IntOrString `json:"maxSurge,omitempty"`
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// Partition applies to applications deployed as a stateful set.
+	Partition *int32 `json:"partition,omitempty"`
+}
+
+// K8sDisruptionBudgetSpec defines the pod disruption budget
+// created for an application.
+type K8sDisruptionBudgetSpec struct {
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+}
+
 // K8sPodSpec is a subset of v1.PodSpec which defines
 // attributes we expose for charms to set.
 type K8sPodSpec struct {
@@ -74,10 +93,17 @@ type K8sPodSpec struct {
 	DNSConfig                     *core.PodDNSConfig       `json:"dnsConfig,omitempty"`
 	ReadinessGates                []core.PodReadinessGate  `json:"readinessGates,omitempty"`
 	Service                       *K8sServiceSpec          `json:"service,omitempty"`
+	UpdateStrategy                *K8sUpdateStrategySpec   `json:"updateStrategy,omitempty"`
+	DisruptionBudget              *K8sDisruptionBudgetSpec `json:"disruptionBudget,omitempty"`
 }
 
 // Validate is defined on ProviderPod.
-func (*K8sPodSpec) Validate() error {
+func (spec *K8sPodSpec) Validate() error {
+	if spec.UpdateStrategy != nil {
+		if spec.UpdateStrategy.Partition != nil && *spec.UpdateStrategy.Partition < 0 {
+			return errors.NotValidf("negative update strategy partition %d", *spec.UpdateStrategy.Partition)
+		}
+	}
 	return nil
 }
 
@@ -113,6 +113,12 @@ initContainers:
 service:
   annotations:
     foo: bar
+updateStrategy:
+  maxSurge: 25%
+  maxUnavailable: 1
+  partition: 2
+disruptionBudget:
+  minAvailable: 50%
 customResourceDefinitions:
   tfjobs.kubeflow.org:
     group: kubeflow.org
@@ -178,6 +184,14 @@ foo: bar
 			Service: &provider.K8sServiceSpec{
 				Annotations: map[string]string{"foo": "bar"},
 			},
+			UpdateStrategy: &provider.K8sUpdateStrategySpec{
+				MaxSurge:       intOrStringPtr(intstr.FromString("25%")),
+				MaxUnavailable: intOrStringPtr(intstr.FromInt(1)),
+				Partition:      int32Ptr(2),
+			},
+			DisruptionBudget: &provider.K8sDisruptionBudgetSpec{
+				MinAvailable: intOrStringPtr(intstr.FromString("50%")),
+			},
 		},
 		Containers: []caas.ContainerSpec{{
 			Name:       "gitlab",
@@ -332,6 +346,10 @@ func boolPtr(b bool) *bool {
 	return &b
 }
 
+func intOrStringPtr(v intstr.IntOrString) *intstr.IntOrString {
+	return &v
+}
+
 func (s *ContainersSuite) TestValidateMissingContainers(c *gc.C) {
 
 	specStr := `
@@ -406,3 +424,19 @@ containers:
 	err = spec.Validate()
 	c.Assert(err, gc.ErrorMatches, `mount path is missing for file set "configuration"`)
 }
+
+func (s *ContainersSuite) TestValidateNegativePartition(c *gc.C) {
+
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+updateStrategy:
+  partition: -1
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	err = spec.Validate()
+	c.Assert(err, gc.ErrorMatches, `negative update strategy partition -1 not valid`)
+}
@@ -4,6 +4,10 @@
 package provider
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	core "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -12,6 +16,7 @@ import (
 
 	k8sannotations "github.com/juju/juju/core/annotations"
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/tags"
 )
 
 var requireAnnotationsForNameSpace = []string{
@@ -132,6 +137,151 @@ func (k *kubernetesClient) createNamespace(name string) error {
 	return errors.Trace(err)
 }
 
+// adoptNamespace claims an existing namespace for the model. The namespace
+// is labelled with the model UUID so that it cannot be used by another model.
+func (k *kubernetesClient) adoptNamespace(name string) error {
+	ns, err := k.getNamespaceByName(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	owner := ns.GetLabels()[tags.JujuModel]
+	if owner == "" {
+		owner = ns.GetAnnotations()[annotationModelUUIDKey]
+	}
+	if owner != "" && owner != k.modelUUID {
+		return errors.NewNotValid(nil, fmt.Sprintf("namespace %q is already used by model %q", name, owner))
+	}
+	labels := ns.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[tags.JujuModel] = k.modelUUID
+	ns.SetLabels(labels)
+	if err := k.ensureNamespaceAnnotations(ns); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = k.CoreV1().Namespaces().Update(ns)
+	return errors.Annotatef(err, "adopting namespace %q", name)
+}
+
+// releaseNamespace removes the model's ownership label and
+// annotations from an adopted namespace, leaving it in place.
+func (k *kubernetesClient) releaseNamespace() error {
+	ns, err := k.GetNamespace(k.namespace)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	labels := ns.GetLabels()
+	delete(labels, tags.JujuModel)
+	ns.SetLabels(labels)
+	annotations := ns.GetAnnotations()
+	for key := range k.annotations {
+		delete(annotations, key)
+	}
+	ns.SetAnnotations(annotations)
+	_, err = k.CoreV1().Namespaces().Update(ns)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// deleteModelResources deletes the applications and operators juju created
+// in an adopted namespace, along with their storage and configuration. The
+// namespace itself is left in place when the model is destroyed, so these
+// are not deleted with it.
+func (k *kubernetesClient) deleteModelResources() error {
+	appNames, err := k.labelValues(labelApplication)
+	if err != nil {
+		return errors.Annotate(err, "finding applications")
+	}
+	for _, appName := range appNames.SortedValues() {
+		if err := k.deleteApplicationResources(appName); err != nil {
+			return errors.Annotatef(err, "deleting application %q", appName)
+		}
+	}
+	operatorNames, err := k.labelValues(labelOperator)
+	if err != nil {
+		return errors.Annotate(err, "finding operators")
+	}
+	for _, appName := range operatorNames.SortedValues() {
+		if err := k.DeleteOperator(appName); err != nil {
+			return errors.Annotatef(err, "deleting operator for %q", appName)
+		}
+	}
+	return nil
+}
+
+// labelValues returns the values of the given label on the services,
+// deployments and pods in the namespace.
+func (k *kubernetesClient) labelValues(label string) (set.Strings, error) {
+	opts := v1.ListOptions{LabelSelector: label}
+	values := set.NewStrings()
+	services, err := k.CoreV1().Services(k.namespace).List(opts)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, s := range services.Items {
+		values.Add(s.Labels[label])
+	}
+	deployments, err := k.AppsV1().Deployments(k.namespace).List(opts)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, d := range deployments.Items {
+		values.Add(d.Labels[label])
+	}
+	pods, err := k.CoreV1().Pods(k.namespace).List(opts)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, p := range pods.Items {
+		values.Add(p.Labels[label])
+	}
+	values.Remove("")
+	return values, nil
+}
+
+// deleteApplicationResources deletes the workload, service, ingress,
+// secrets, volume claims and file config maps of the application.
+func (k *kubernetesClient) deleteApplicationResources(appName string) error {
+	deploymentName := k.deploymentName(appName)
+	pods, err := k.CoreV1().Pods(k.namespace).List(v1.ListOptions{
+		LabelSelector: applicationSelector(appName),
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, p := range pods.Items {
+		if len(p.Spec.Containers) > 0 {
+			if _, err := k.deleteVolumeClaims(appName, &p); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		for _, vol := range p.Spec.Volumes {
+			if vol.ConfigMap == nil || !isApplicationConfigMap(deploymentName, vol.ConfigMap.Name) {
+				continue
+			}
+			if err := k.deleteConfigMap(vol.ConfigMap.Name); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	if err := k.UnexposeService(appName); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(k.DeleteService(appName))
+}
+
+// isApplicationConfigMap reports whether the config map was created by
+// juju for one of the application's file sets.
+func isApplicationConfigMap(deploymentName, configMapName string) bool {
+	return strings.HasPrefix(configMapName, deploymentName+"-") && strings.HasSuffix(configMapName, "-config")
+}
+
 func (k *kubernetesClient) deleteNamespace() error {
 	// deleteNamespace is used as a means to implement Destroy().
 	// All model resources are provisioned in the namespace;
@@ -127,3 +127,35 @@ func (s *providerSuite) TestValidate(c *gc.C) {
 	validAttrs := validCfg.AllAttrs()
 	c.Assert(config.AllAttrs(), gc.DeepEquals, validAttrs)
 }
+
+func (s *providerSuite) TestValidateExistingNamespace(c *gc.C) {
+	config := fakeConfig(c, coretesting.Attrs{"namespace": "team-a"})
+	validCfg, err := s.provider.Validate(config, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(validCfg.AllAttrs()["namespace"], gc.Equals, "team-a")
+}
+
+func (s *providerSuite) TestValidateImageRegistryCredentials(c *gc.C) {
+	config := fakeConfig(c, coretesting.Attrs{
+		"image-registry-credentials": "registry.example.com=fred:secret",
+		"image-pull-secrets":         "team-a-pull-secret",
+	})
+	validCfg, err := s.provider.Validate(config, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(validCfg.AllAttrs()["image-registry-credentials"], gc.Equals, "registry.example.com=fred:secret")
+	c.Assert(validCfg.AllAttrs()["image-pull-secrets"], gc.Equals, "team-a-pull-secret")
+}
+
+func (s *providerSuite) TestValidateInvalidImageRegistryCredentials(c *gc.C) {
+	config := fakeConfig(c, coretesting.Attrs{"image-registry-credentials": "registry.example.com=fred"})
+	_, err := s.provider.Validate(config, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid k8s provider config: credentials for registry "registry.example.com", expected username:password not valid`)
+}
+
+func (s *providerSuite) TestValidateChangeNamespace(c *gc.C) {
+	oldCfg := fakeConfig(c, coretesting.Attrs{"namespace": "team-a"})
+	newCfg, err := oldCfg.Apply(map[string]interface{}{"namespace": "team-b"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.provider.Validate(newCfg, oldCfg)
+	c.Assert(err, gc.ErrorMatches, `invalid k8s provider config: cannot change namespace from "team-a" to "team-b"`)
+}
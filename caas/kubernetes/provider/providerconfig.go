@@ -5,6 +5,7 @@ package provider
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/schema"
 	"gopkg.in/juju/environschema.v1"
@@ -15,6 +16,19 @@ import (
 const (
 	WorkloadStorageKey = "workload-storage"
 	OperatorStorageKey = "operator-storage"
+
+	// NamespaceKey is the name of an existing namespace to be
+	// used by a model instead of creating one for it.
+	NamespaceKey = "namespace"
+
+	// ImageRegistryCredentialsKey holds the credentials used to pull
+	// images from private registries, for both workload and operator
+	// pods, as space separated "registry=username:password" values.
+	ImageRegistryCredentialsKey = "image-registry-credentials"
+
+	// ImagePullSecretsKey holds a comma separated list of existing
+	// image pull secrets in the model namespace used by all pods.
+	ImagePullSecretsKey = "image-pull-secrets"
 )
 
 var configSchema = environschema.Fields{
@@ -29,6 +43,23 @@ var configSchema = environschema.Fields{
 		Group:       environschema.AccountGroup,
 		Immutable:   true,
 	},
+	NamespaceKey: {
+		Description: "The name of an existing namespace to use for the model instead of creating one.",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+		Immutable:   true,
+	},
+	ImageRegistryCredentialsKey: {
+		Description: "Credentials for private image registries as space separated registry=username:password values.",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+		Secret:      true,
+	},
+	ImagePullSecretsKey: {
+		Description: "A comma separated list of existing image pull secrets in the model namespace to use for all pods.",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 }
 
 var providerConfigFields = func() schema.Fields {
@@ -40,8 +71,11 @@ var providerConfigFields = func() schema.Fields {
 }()
 
 var providerConfigDefaults = schema.Defaults{
-	WorkloadStorageKey: "",
-	OperatorStorageKey: "",
+	WorkloadStorageKey:          "",
+	OperatorStorageKey:          "",
+	NamespaceKey:                schema.Omit,
+	ImageRegistryCredentialsKey: schema.Omit,
+	ImagePullSecretsKey:         schema.Omit,
 }
 
 type brokerConfig struct {
@@ -57,6 +91,27 @@ func (c *brokerConfig) operatorStorage() string {
 	return c.attrs[OperatorStorageKey].(string)
 }
 
+func (c *brokerConfig) existingNamespace() string {
+	namespace, _ := c.attrs[NamespaceKey].(string)
+	return namespace
+}
+
+func (c *brokerConfig) registryCredentials() (DockerConfig, error) {
+	value, _ := c.attrs[ImageRegistryCredentialsKey].(string)
+	return parseRegistryCredentials(value)
+}
+
+func (c *brokerConfig) imagePullSecrets() []string {
+	value, _ := c.attrs[ImagePullSecretsKey].(string)
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 func (p kubernetesEnvironProvider) Validate(cfg, old *config.Config) (*config.Config, error) {
 	newCfg, err := validateConfig(cfg, old)
 	if err != nil {
@@ -105,5 +160,14 @@ func validateConfig(cfg, old *config.Config) (*brokerConfig, error) {
 	}
 
 	bcfg := &brokerConfig{cfg, validated}
+	if _, err := bcfg.registryCredentials(); err != nil {
+		return nil, err
+	}
+	if old != nil {
+		oldNamespace, _ := old.UnknownAttrs()[NamespaceKey].(string)
+		if newNamespace := bcfg.existingNamespace(); oldNamespace != newNamespace {
+			return nil, fmt.Errorf("cannot change %s from %q to %q", NamespaceKey, oldNamespace, newNamespace)
+		}
+	}
 	return bcfg, nil
 }
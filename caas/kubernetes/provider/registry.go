@@ -0,0 +1,89 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"encoding/json"
+
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// registryCredentialsSecretName is the name of the secret holding
+// the image registry credentials configured for the model.
+const registryCredentialsSecretName = "juju-image-registry-credentials"
+
+// ensureModelImagePullSecrets creates or updates the secret holding any
+// image registry credentials configured for the model, and returns
+// references to it and to any existing pull secrets named in model config.
+// If no credentials are configured, any secret left over from an earlier
+// configuration is deleted.
+func (k *kubernetesClient) ensureModelImagePullSecrets() ([]core.LocalObjectReference, error) {
+	cfg := k.Config()
+	bcfg := &brokerConfig{cfg, cfg.UnknownAttrs()}
+	auths, err := bcfg.registryCredentials()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var result []core.LocalObjectReference
+	if len(auths) == 0 {
+		if err := k.deleteSecret(registryCredentialsSecretName); err != nil {
+			return nil, errors.Annotate(err, "deleting image registry credentials secret")
+		}
+	} else {
+		secretData, err := json.Marshal(DockerConfigJson{Auths: auths})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		secret := &core.Secret{
+			ObjectMeta: v1.ObjectMeta{
+				Name:        registryCredentialsSecretName,
+				Namespace:   k.namespace,
+				Annotations: k.annotations.ToMap(),
+			},
+			Type: core.SecretTypeDockerConfigJson,
+			Data: map[string][]byte{
+				core.DockerConfigJsonKey: secretData,
+			},
+		}
+		if err := k.ensureSecret(secret); err != nil {
+			return nil, errors.Annotate(err, "creating image registry credentials secret")
+		}
+		result = append(result, core.LocalObjectReference{Name: registryCredentialsSecretName})
+	}
+	for _, name := range bcfg.imagePullSecrets() {
+		result = append(result, core.LocalObjectReference{Name: name})
+	}
+	return result, nil
+}
+
+// addImagePullSecrets adds the specified pull secrets to the pod
+// spec, ignoring any which are already used by the pod. A reference
+// to the model's registry credentials secret is removed if that
+// secret is not one of those specified.
+func addImagePullSecrets(pod *core.PodSpec, secrets []core.LocalObjectReference) {
+	wanted := make(map[string]bool)
+	for _, secret := range secrets {
+		wanted[secret.Name] = true
+	}
+	existing := make(map[string]bool)
+	var kept []core.LocalObjectReference
+	for _, secret := range pod.ImagePullSecrets {
+		if secret.Name == registryCredentialsSecretName && !wanted[secret.Name] {
+			continue
+		}
+		existing[secret.Name] = true
+		kept = append(kept, secret)
+	}
+	pod.ImagePullSecrets = kept
+	for _, secret := range secrets {
+		if existing[secret.Name] {
+			continue
+		}
+		existing[secret.Name] = true
+		pod.ImagePullSecrets = append(pod.ImagePullSecrets, secret)
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	apps "k8s.io/api/apps/v1"
+	"k8s.io/api/policy/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	k8sannotations "github.com/juju/juju/core/annotations"
+	"github.com/juju/juju/core/application"
+)
+
+// parseIntOrPercent parses a config value which may be either
+// an absolute number or a percentage, eg "2" or "25%".
+func parseIntOrPercent(key, value string) (*intstr.IntOrString, error) {
+	v := intstr.Parse(strings.TrimSpace(value))
+	if v.Type == intstr.String && !strings.HasSuffix(v.StrVal, "%") {
+		return nil, errors.NotValidf("%s value %q (expected a number or percentage)", key, value)
+	}
+	n, err := intstr.GetValueFromIntOrPercent(&v, 100, false)
+	if err != nil {
+		return nil, errors.NotValidf("%s value %q (expected a number or percentage)", key, value)
+	}
+	if n < 0 {
+		return nil, errors.NotValidf("negative %s %q", key, value)
+	}
+	return &v, nil
+}
+
+// applyRolloutConfig merges any update strategy and disruption budget
+// settings from the application config into the unit spec. Settings in
+// the application config take precedence over those from the charm.
+func applyRolloutConfig(unitSpec *unitSpec, config application.ConfigAttributes) error {
+	// Copy the charm's strategy so the pod spec is not modified.
+	strategy := &K8sUpdateStrategySpec{}
+	if unitSpec.UpdateStrategy != nil {
+		*strategy = *unitSpec.UpdateStrategy
+	}
+	if v := config.GetString(updateMaxSurgeKey, ""); v != "" {
+		maxSurge, err := parseIntOrPercent(updateMaxSurgeKey, v)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		strategy.MaxSurge = maxSurge
+	}
+	if v := config.GetString(updateMaxUnavailableKey, ""); v != "" {
+		maxUnavailable, err := parseIntOrPercent(updateMaxUnavailableKey, v)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		strategy.MaxUnavailable = maxUnavailable
+	}
+	if _, ok := config[updatePartitionKey]; ok {
+		partition := int32(config.GetInt(updatePartitionKey, 0))
+		if partition < 0 {
+			return errors.NotValidf("negative %s %d", updatePartitionKey, partition)
+		}
+		strategy.Partition = &partition
+	}
+	if strategy.Partition != nil && *strategy.Partition == 0 {
+		// Lowering the partition to 0 finishes a staged rollout,
+		// after which no pods are held back.
+		strategy.Partition = nil
+	}
+	if strategy.MaxSurge != nil || strategy.MaxUnavailable != nil || strategy.Partition != nil {
+		unitSpec.UpdateStrategy = strategy
+	}
+
+	if v := config.GetString(pdbMinAvailableKey, ""); v != "" {
+		minAvailable, err := parseIntOrPercent(pdbMinAvailableKey, v)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		unitSpec.DisruptionBudget = &K8sDisruptionBudgetSpec{MinAvailable: minAvailable}
+	}
+	return nil
+}
+
+// deploymentStrategy returns the deployment strategy to use for
+// the specified update strategy, or nil to use the k8s default.
+func deploymentStrategy(strategy *K8sUpdateStrategySpec) *apps.DeploymentStrategy {
+	if strategy == nil || (strategy.MaxSurge == nil && strategy.MaxUnavailable == nil) {
+		return nil
+	}
+	return &apps.DeploymentStrategy{
+		Type: apps.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &apps.RollingUpdateDeployment{
+			MaxSurge:       strategy.MaxSurge,
+			MaxUnavailable: strategy.MaxUnavailable,
+		},
+	}
+}
+
+// statefulSetUpdateStrategy returns the stateful set update strategy to use
+// for the specified update strategy, or nil to use the k8s default.
+func statefulSetUpdateStrategy(strategy *K8sUpdateStrategySpec) *apps.StatefulSetUpdateStrategy {
+	if strategy == nil || strategy.Partition == nil {
+		return nil
+	}
+	return &apps.StatefulSetUpdateStrategy{
+		Type: apps.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &apps.RollingUpdateStatefulSetStrategy{
+			Partition: strategy.Partition,
+		},
+	}
+}
+
+// ensurePodDisruptionBudget creates or updates the pod disruption budget
+// for the specified application. If no budget is required, any existing
+// budget is deleted.
+func (k *kubernetesClient) ensurePodDisruptionBudget(
+	appName, deploymentName string, annotations k8sannotations.Annotation, budget *K8sDisruptionBudgetSpec,
+) error {
+	if budget == nil || budget.MinAvailable == nil {
+		return k.deletePodDisruptionBudget(deploymentName)
+	}
+	logger.Debugf("creating/updating pod disruption budget for %s", appName)
+	spec := &v1beta1.PodDisruptionBudget{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        deploymentName,
+			Labels:      map[string]string{labelApplication: appName},
+			Annotations: annotations.ToMap(),
+		},
+		Spec: v1beta1.PodDisruptionBudgetSpec{
+			MinAvailable: budget.MinAvailable,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{labelApplication: appName},
+			},
+		},
+	}
+	budgets := k.PolicyV1beta1().PodDisruptionBudgets(k.namespace)
+	_, err := budgets.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = budgets.Create(spec)
+	}
+	if !k8serrors.IsInvalid(err) {
+		return errors.Trace(err)
+	}
+	// Older clusters do not allow the spec of an existing
+	// budget to be changed, so it needs to be replaced.
+	if err := k.deletePodDisruptionBudget(deploymentName); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = budgets.Create(spec)
+	return errors.Trace(err)
+}
+
+// deletePodDisruptionBudget deletes a pod disruption budget resource.
+func (k *kubernetesClient) deletePodDisruptionBudget(name string) error {
+	err := k.PolicyV1beta1().PodDisruptionBudgets(k.namespace).Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
@@ -99,6 +99,11 @@ type InstanceConfig struct {
 	// specified by the user.
 	CloudInitUserData map[string]interface{}
 
+	// ApplicationCloudInitUserData defines key/value pairs from the
+	// config of the applications the machine is provisioned for. They
+	// are merged into CloudInitUserData when the config is populated.
+	ApplicationCloudInitUserData map[string]interface{}
+
 	// MachineId identifies the new machine.
 	MachineId string
 
@@ -848,11 +853,48 @@ func PopulateInstanceConfig(icfg *InstanceConfig,
 	icfg.AptMirror = aptMirror
 	icfg.EnableOSRefreshUpdate = enableOSRefreshUpdates
 	icfg.EnableOSUpgrade = enableOSUpgrade
-	icfg.CloudInitUserData = cloudInitUserData
+	icfg.CloudInitUserData = MergeCloudInitUserData(cloudInitUserData, icfg.ApplicationCloudInitUserData)
 	icfg.Profiles = profiles
 	return nil
 }
 
+// mergedCloudInitListKeys holds the cloud-init user data keys whose list
+// values are appended to one another when user data is merged, rather
+// than replaced.
+var mergedCloudInitListKeys = []string{"packages", "preruncmd", "postruncmd"}
+
+// MergeCloudInitUserData returns the cloud-init user data resulting from
+// adding the extra user data to the base user data. The packages, preruncmd
+// and postruncmd lists of the extra user data are appended to those of the
+// base; any other key in the extra user data replaces that in the base.
+// Neither input is modified.
+func MergeCloudInitUserData(base, extra map[string]interface{}) map[string]interface{} {
+	if len(extra) == 0 {
+		return base
+	}
+	result := make(map[string]interface{})
+	for k, v := range base {
+		result[k] = v
+	}
+	for k, v := range extra {
+		result[k] = v
+	}
+	for _, k := range mergedCloudInitListKeys {
+		baseList, ok := base[k].([]interface{})
+		if !ok {
+			continue
+		}
+		extraList, ok := extra[k].([]interface{})
+		if !ok {
+			continue
+		}
+		merged := make([]interface{}, 0, len(baseList)+len(extraList))
+		merged = append(merged, baseList...)
+		result[k] = append(merged, extraList...)
+	}
+	return result
+}
+
 // FinishInstanceConfig sets fields on a InstanceConfig that can be determined by
 // inspecting a plain config.Config and the machine constraints at the last
 // moment before creating the user-data. It assumes that the supplied Config comes
@@ -4,6 +4,7 @@
 package instancecfg_test
 
 import (
+	"github.com/juju/proxy"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
@@ -111,3 +112,51 @@ func (*instancecfgSuite) TestGUITools(c *gc.C) {
 	}
 	c.Assert(icfg.GUITools(), gc.Equals, "/path/to/datadir/gui")
 }
+
+func (*instancecfgSuite) TestMergeCloudInitUserData(c *gc.C) {
+	base := map[string]interface{}{
+		"packages":        []interface{}{"python-keystoneclient"},
+		"preruncmd":       []interface{}{"mkdir /tmp/preruncmd"},
+		"package_upgrade": false,
+	}
+	extra := map[string]interface{}{
+		"packages":        []interface{}{"htop"},
+		"postruncmd":      []interface{}{"mkdir /tmp/postruncmd"},
+		"package_upgrade": true,
+	}
+	merged := instancecfg.MergeCloudInitUserData(base, extra)
+	c.Assert(merged, jc.DeepEquals, map[string]interface{}{
+		"packages":        []interface{}{"python-keystoneclient", "htop"},
+		"preruncmd":       []interface{}{"mkdir /tmp/preruncmd"},
+		"postruncmd":      []interface{}{"mkdir /tmp/postruncmd"},
+		"package_upgrade": true,
+	})
+	// The inputs are not modified.
+	c.Assert(base["packages"], jc.DeepEquals, []interface{}{"python-keystoneclient"})
+	c.Assert(base["package_upgrade"], jc.IsFalse)
+}
+
+func (*instancecfgSuite) TestMergeCloudInitUserDataNoExtra(c *gc.C) {
+	base := map[string]interface{}{"package_upgrade": false}
+	c.Assert(instancecfg.MergeCloudInitUserData(base, nil), jc.DeepEquals, base)
+	c.Assert(instancecfg.MergeCloudInitUserData(nil, nil), gc.IsNil)
+}
+
+func (*instancecfgSuite) TestPopulateInstanceConfigMergesApplicationCloudInitUserData(c *gc.C) {
+	icfg := instancecfg.InstanceConfig{
+		ApplicationCloudInitUserData: map[string]interface{}{
+			"packages": []interface{}{"htop"},
+		},
+	}
+	err := instancecfg.PopulateInstanceConfig(
+		&icfg, "dummy", "", true,
+		proxy.Settings{}, proxy.Settings{}, proxy.Settings{},
+		"", false, false,
+		map[string]interface{}{"packages": []interface{}{"python-keystoneclient"}},
+		nil,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(icfg.CloudInitUserData, jc.DeepEquals, map[string]interface{}{
+		"packages": []interface{}{"python-keystoneclient", "htop"},
+	})
+}
@@ -113,6 +113,7 @@ func deployBundle(
 	bundleURL *charm.URL,
 	bundleOverlayFile []string,
 	channel csparams.Channel,
+	branchName string,
 	apiRoot DeployAPI,
 	ctx *cmd.Context,
 	bundleStorage map[string]map[string]storage.Constraints,
@@ -130,7 +131,7 @@ func deployBundle(
 	}
 
 	// TODO: move bundle parsing and checking into the handler.
-	h := makeBundleHandler(dryRun, bundleDir, channel, apiRoot, ctx, data, bundleURL, bundleStorage, bundleDevices)
+	h := makeBundleHandler(dryRun, bundleDir, channel, branchName, apiRoot, ctx, data, bundleURL, bundleStorage, bundleDevices)
 	if err := h.makeModel(useExistingMachines, bundleMachines); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -176,6 +177,11 @@ type bundleHandler struct {
 	// channel identifies the default channel to use for the bundle.
 	channel csparams.Channel
 
+	// branchName identifies the active model branch. Charm upgrades,
+	// options and constraints for existing applications are applied
+	// under this branch.
+	branchName string
+
 	// api is used to interact with the environment.
 	api DeployAPI
 
@@ -230,6 +236,7 @@ func makeBundleHandler(
 	dryRun bool,
 	bundleDir string,
 	channel csparams.Channel,
+	branchName string,
 	api DeployAPI,
 	ctx *cmd.Context,
 	data *charm.BundleData,
@@ -247,6 +254,7 @@ func makeBundleHandler(
 		applications:  applications,
 		results:       make(map[string]string),
 		channel:       channel,
+		branchName:    branchName,
 		api:           api,
 		bundleStorage: bundleStorage,
 		bundleDevices: bundleDevices,
@@ -919,8 +927,7 @@ func (h *bundleHandler) upgradeCharm(change *bundlechanges.UpgradeCharmChange) e
 		CharmID:         chID,
 		ResourceIDs:     resNames2IDs,
 	}
-	// Bundles only ever deal with the current generation.
-	if err := h.api.SetCharm(model.GenerationMaster, cfg); err != nil {
+	if err := h.api.SetCharm(h.branchName, cfg); err != nil {
 		return errors.Trace(err)
 	}
 	h.writeAddedResources(resNames2IDs)
@@ -953,7 +960,7 @@ func (h *bundleHandler) setOptions(change *bundlechanges.SetOptionsChange) error
 	if err := h.api.Update(params.ApplicationUpdate{
 		ApplicationName: p.Application,
 		SettingsYAML:    string(cfg),
-		Generation:      model.GenerationMaster,
+		Generation:      h.branchName,
 	}); err != nil {
 		return errors.Annotatef(err, "cannot update options for application %q", p.Application)
 	}
@@ -969,7 +976,7 @@ func (h *bundleHandler) setConstraints(change *bundlechanges.SetConstraintsChang
 	p := change.Params
 	// We know that p.Constraints is a valid constraints type due to the validation.
 	cons, _ := constraints.Parse(p.Constraints)
-	if err := h.api.SetConstraints(p.Application, cons); err != nil {
+	if err := h.api.SetBranchConstraints(h.branchName, p.Application, cons); err != nil {
 		// This should never happen, as the bundle is already verified.
 		return errors.Annotatef(err, "cannot update constraints for application %q", p.Application)
 	}
@@ -54,6 +54,8 @@ Application constraints are combined with model constraints, set with ` +
 provision machines for applications. Where model and application constraints
 overlap, the application constraints take precedence.
 Constraints for a specific model can be viewed with ` + "`juju get-model-\nconstraints`" + `.
+If a branch is active, the constraints only apply to new machines provisioned
+for units tracking that branch.
 This command requires that the application to have at least one unit. To apply 
 constraints to
 the first unit set them at the model level or pass them as an argument
@@ -76,7 +78,7 @@ func NewApplicationGetConstraintsCommand() modelcmd.ModelCommand {
 type applicationConstraintsAPI interface {
 	Close() error
 	GetConstraints(...string) ([]constraints.Value, error)
-	SetConstraints(string, constraints.Value) error
+	SetBranchConstraints(string, string, constraints.Value) error
 }
 
 type applicationConstraintsCommand struct {
@@ -190,6 +192,10 @@ func (c *applicationSetConstraintsCommand) Run(_ *cmd.Context) (err error) {
 	}
 	defer apiclient.Close()
 
-	err = apiclient.SetConstraints(c.ApplicationName, c.Constraints)
+	branchName, err := c.ActiveBranch()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = apiclient.SetBranchConstraints(branchName, c.ApplicationName, c.Constraints)
 	return block.ProcessBlockedError(err, block.BlockChange)
 }
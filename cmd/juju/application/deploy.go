@@ -30,6 +30,7 @@ import (
 	"github.com/juju/juju/api/application"
 	apicharms "github.com/juju/juju/api/charms"
 	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/api/machinemanager"
 	"github.com/juju/juju/api/modelconfig"
 	app "github.com/juju/juju/apiserver/facades/client/application"
 	apiparams "github.com/juju/juju/apiserver/params"
@@ -44,6 +45,7 @@ import (
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/resource/resourceadapters"
+	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/storage"
 )
 
@@ -64,7 +66,7 @@ type ApplicationAPI interface {
 	GetConstraints(appNames ...string) ([]constraints.Value, error)
 	SetAnnotation(annotations map[string]map[string]string) ([]apiparams.ErrorResult, error)
 	SetCharm(string, application.SetCharmConfig) error
-	SetConstraints(application string, constraints constraints.Value) error
+	SetBranchConstraints(branchName, application string, constraints constraints.Value) error
 	Update(apiparams.ApplicationUpdate) error
 	ScaleApplication(application.ScaleApplicationParams) (apiparams.ScaleApplicationResult, error)
 }
@@ -108,8 +110,15 @@ type DeployAPI interface {
 
 	GetBundle(*charm.URL) (charm.Bundle, error)
 
+	// GetCharmMeta returns the metadata of a charm in the charm store.
+	GetCharmMeta(*charm.URL) (*charm.Meta, error)
+
 	WatchAll() (*api.AllWatcher, error)
 
+	// PreviewMachines returns the instance type, image and availability
+	// zone that would be used for each of the machines, without adding them.
+	PreviewMachines([]apiparams.AddMachineParams) ([]apiparams.MachinePreviewResult, error)
+
 	// PlanURL returns the configured URL prefix for the metering plan API.
 	PlanURL() string
 }
@@ -174,6 +183,10 @@ func (a *charmstoreClient) AuthorizeCharmstoreEntity(url *charm.URL) (*macaroon.
 	return authorizeCharmStoreEntity(a, url)
 }
 
+func (a *charmstoreClient) GetCharmMeta(url *charm.URL) (*charm.Meta, error) {
+	return charmStoreCharmMeta(a, url)
+}
+
 func (c *plansClient) PlanURL() string {
 	return c.planURL
 }
@@ -188,6 +201,10 @@ type deployAPIAdapter struct {
 	*charmstoreClient
 	*annotationsClient
 	*plansClient
+
+	// machineManagerClient is not embedded, as its AddMachines
+	// method would hide the one provided by apiClient.
+	machineManagerClient *machinemanager.Client
 }
 
 func (a *deployAPIAdapter) Client() *api.Client {
@@ -230,6 +247,10 @@ func (a *deployAPIAdapter) GetAnnotations(tags []string) ([]apiparams.Annotation
 	return a.annotationsClient.Get(tags)
 }
 
+func (a *deployAPIAdapter) PreviewMachines(machines []apiparams.AddMachineParams) ([]apiparams.MachinePreviewResult, error) {
+	return a.machineManagerClient.PreviewMachines(machines)
+}
+
 // NewDeployCommand returns a command to deploy applications.
 func NewDeployCommand() modelcmd.ModelCommand {
 	steps := []DeployStep{
@@ -277,6 +298,8 @@ func NewDeployCommand() modelcmd.ModelCommand {
 			annotationsClient: &annotationsClient{Client: annotations.NewClient(apiRoot)},
 			charmRepoClient:   &charmRepoClient{charmrepo.NewCharmStoreFromClient(cstoreClient)},
 			plansClient:       &plansClient{planURL: mURL},
+
+			machineManagerClient: machinemanager.NewClient(apiRoot),
 		}, nil
 	}
 
@@ -307,7 +330,8 @@ type DeployCommand struct {
 	Force bool
 
 	// DryRun is used to specify that the bundle shouldn't actually be
-	// deployed but just output the changes.
+	// deployed but just output the changes. For a charm, the machines
+	// that would be started for its units are shown instead.
 	DryRun bool
 
 	ApplicationName string
@@ -522,6 +546,12 @@ for security purposes by allowing only certain configurations and devices. Use
 the '--force' option to bypass this check. Doing so is not recommended as it
 can lead to unexpected behaviour.
 
+Use the '--dry-run' option to see what would be deployed without deploying
+anything. For a bundle, the changes that would be made to the model are shown.
+For a charm, the instance type, image and availability zone of each machine
+that would be started for its units are shown; units placed on existing
+machines or in containers are not included.
+
 Further reading: https://docs.jujucharms.com/stable/charms-deploying
 
 Examples:
@@ -558,6 +588,10 @@ Deploy to a machine with at least 8 GiB of memory:
 
     juju deploy postgresql --constraints mem=8G
 
+Show the instances that would be started for 3 units, without deploying:
+
+    juju deploy postgresql -n 3 --constraints mem=8G --dry-run
+
 Deploy to a specific availability zone (provider-dependent):
 
     juju deploy mysql --to zone=us-east-1a
@@ -636,9 +670,8 @@ func (c *DeployCommand) Info() *cmd.Info {
 }
 
 var (
-	// TODO(thumper): support dry-run for apps as well as bundles.
 	bundleOnlyFlags = []string{
-		"overlay", "dry-run", "map-machines",
+		"overlay", "map-machines",
 	}
 )
 
@@ -670,7 +703,7 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.BundleOverlayFile), "overlay", "Bundles to overlay on the primary bundle, applied in order")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Set application constraints")
 	f.StringVar(&c.Series, "series", "", "The series on which to deploy")
-	f.BoolVar(&c.DryRun, "dry-run", false, "Just show what the bundle deploy would do")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Just show what the deploy would do")
 	f.BoolVar(&c.Force, "force", false, "Allow a charm to be deployed which bypasses checks such as supported series or LXD profile allow list")
 	f.Var(storageFlag{&c.Storage, &c.BundleStorage}, "storage", "Charm storage constraints")
 	f.Var(devicesFlag{&c.Devices, &c.BundleDevices}, "device", "Charm device constraints")
@@ -864,6 +897,14 @@ func (c *DeployCommand) deployBundle(
 		}
 	}
 
+	branchName, err := c.ActiveBranch()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if branchName == "" {
+		branchName = model.GenerationMaster
+	}
+
 	// TODO(ericsnow) Do something with the CS macaroons that were returned?
 	// Deploying bundles does not allow the use force, it's expected that the
 	// bundle is correct and therefore the charms are also.
@@ -873,6 +914,7 @@ func (c *DeployCommand) deployBundle(
 		bundleURL,
 		c.BundleOverlayFile,
 		channel,
+		branchName,
 		apiRoot,
 		ctx,
 		bundleStorage,
@@ -1232,6 +1274,9 @@ func (c *DeployCommand) maybePredeployedLocalCharm() (deployFn, error) {
 		if err := c.validateResourcesNeededForLocalDeploy(charmInfo.Meta); err != nil {
 			return errors.Trace(err)
 		}
+		if c.DryRun {
+			return errors.Trace(c.previewDeploy(ctx, api, userCharmURL.Series, charmInfo.Meta.Subordinate))
+		}
 		formattedCharmURL := userCharmURL.String()
 		ctx.Infof("Located charm %q.", formattedCharmURL)
 		ctx.Infof("Deploying charm %q.", formattedCharmURL)
@@ -1398,6 +1443,9 @@ func (c *DeployCommand) maybeReadLocalCharm(apiRoot DeployAPI) (deployFn, error)
 		if err := c.validateCharmFlags(); err != nil {
 			return errors.Trace(err)
 		}
+		if c.DryRun {
+			return errors.Trace(c.previewDeploy(ctx, apiRoot, curl.Series, ch.Meta().Subordinate))
+		}
 
 		if curl, err = apiRoot.AddLocalCharm(curl, ch, c.Force); err != nil {
 			return errors.Trace(err)
@@ -1553,6 +1601,16 @@ func (c *DeployCommand) charmStoreCharm() (deployFn, error) {
 			return errors.Errorf("%v. Use --force to deploy the charm anyway.", err)
 		}
 
+		if c.DryRun {
+			// The charm isn't added to the controller for a dry
+			// run, so ask the charm store whether it's a subordinate.
+			meta, err := apiRoot.GetCharmMeta(storeCharmOrBundleURL)
+			if err != nil {
+				return errors.Annotatef(err, "getting metadata for charm %q", storeCharmOrBundleURL)
+			}
+			return errors.Trace(c.previewDeploy(ctx, apiRoot, series, meta.Subordinate))
+		}
+
 		// Store the charm in the controller
 		curl, csMac, err := addCharmFromURL(apiRoot, storeCharmOrBundleURL, channel, c.Force)
 		if err != nil {
@@ -1579,6 +1637,62 @@ func (c *DeployCommand) charmStoreCharm() (deployFn, error) {
 	}, nil
 }
 
+// previewDeploy writes the instance type, image and availability zone
+// of each new machine that would be started for the application's units,
+// without adding any machines or deploying the charm. Units placed on
+// existing machines or in containers are not previewed.
+func (c *DeployCommand) previewDeploy(ctx *cmd.Context, apiRoot DeployAPI, series string, subordinate bool) error {
+	modelType, err := c.ModelType()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if modelType == model.CAAS {
+		return errors.New("--dry-run cannot be used with charms on kubernetes models")
+	}
+	if subordinate {
+		ctx.Infof("Subordinate applications are not deployed to machines of their own.")
+		return nil
+	}
+
+	modelUUID, ok := apiRoot.ModelUUID()
+	if !ok {
+		return errors.New("API connection is controller-only (should never happen)")
+	}
+	var machines []apiparams.AddMachineParams
+	var skipped int
+	for i := 0; i < c.NumUnits; i++ {
+		var placement *instance.Placement
+		if i < len(c.Placement) {
+			placement = c.Placement[i]
+		}
+		if placement != nil && placement.Scope != "model-uuid" {
+			skipped++
+			continue
+		}
+		if placement != nil {
+			placement = &instance.Placement{Scope: modelUUID, Directive: placement.Directive}
+		}
+		machines = append(machines, apiparams.AddMachineParams{
+			Placement:   placement,
+			Series:      series,
+			Constraints: c.Constraints,
+			Jobs:        []multiwatcher.MachineJob{multiwatcher.JobHostUnits},
+		})
+	}
+	if skipped > 0 {
+		ctx.Infof("%d unit(s) placed on existing machines or in containers are not shown.", skipped)
+	}
+	if len(machines) == 0 {
+		return nil
+	}
+
+	results, err := apiRoot.PreviewMachines(machines)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return common.FormatMachinePreviews(ctx.Stdout, results)
+}
+
 // getFlags returns the flags with the given names. Only flags that are set and
 // whose name is included in flagNames are included.
 func getFlags(flagSet *gnuflag.FlagSet, flagNames []string) []string {
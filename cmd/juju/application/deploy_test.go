@@ -60,6 +60,7 @@ import (
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/testcharms"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -893,7 +894,7 @@ func (s *DeploySuite) TestDeployFlags(c *gc.C) {
 	c.Assert(command.flagSet, jc.DeepEquals, flagSet)
 	// Add to the slice below if a new flag is introduced which is valid for
 	// both charms and bundles.
-	charmAndBundleFlags := []string{"channel", "storage", "device"}
+	charmAndBundleFlags := []string{"channel", "storage", "device", "dry-run"}
 	var allFlags []string
 	flagSet.VisitAll(func(flag *gnuflag.Flag) {
 		allFlags = append(allFlags, flag.Name)
@@ -1923,6 +1924,67 @@ func (s *DeployUnitTestSuite) TestAddMetricCredentialsDefaultForUnmeteredCharm(c
 	}
 }
 
+func (s *DeployUnitTestSuite) TestDeployLocalCharmDryRun(c *gc.C) {
+	charmDir := s.makeCharmDir(c, "multi-series")
+	fakeAPI := s.fakeAPI()
+	cons := constraints.MustParse("mem=8G")
+	jobs := []multiwatcher.MachineJob{multiwatcher.JobHostUnits}
+	fakeAPI.Call("PreviewMachines", []params.AddMachineParams{{
+		Placement:   &instance.Placement{Scope: "deadbeef-0bad-400d-8000-4b1d0d06f00d", Directive: "zone=nz"},
+		Series:      "trusty",
+		Constraints: cons,
+		Jobs:        jobs,
+	}, {
+		Series:      "trusty",
+		Constraints: cons,
+		Jobs:        jobs,
+	}}).Returns([]params.MachinePreviewResult{{
+		Series:           "trusty",
+		Arch:             "amd64",
+		InstanceType:     "m5.large",
+		ImageId:          "ami-0123",
+		AvailabilityZone: "nz",
+	}, {
+		Series:       "trusty",
+		Arch:         "amd64",
+		InstanceType: "m5.large",
+		ImageId:      "ami-0123",
+	}}, error(nil))
+
+	context, err := s.runDeploy(c, fakeAPI, charmDir.Path,
+		"--series", "trusty", "-n", "3", "--to", "zone=nz,lxd", "--constraints", "mem=8G", "--dry-run",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, ""+
+		"Series  Arch   Instance type  Image     Zone\n"+
+		"trusty  amd64  m5.large       ami-0123  nz\n"+
+		"trusty  amd64  m5.large       ami-0123  -\n",
+	)
+	c.Check(cmdtesting.Stderr(context), gc.Equals,
+		"1 unit(s) placed on existing machines or in containers are not shown.\n",
+	)
+	for _, call := range fakeAPI.Calls() {
+		c.Check(call.FuncName, gc.Not(gc.Matches), "AddLocalCharm|Deploy")
+	}
+}
+
+func (s *DeployUnitTestSuite) TestDeployCharmStoreSubordinateDryRun(c *gc.C) {
+	fakeAPI := s.fakeAPI()
+	loggingURL := charm.MustParseURL("cs:quantal/logging-1")
+	withCharmRepoResolvable(fakeAPI, loggingURL)
+	fakeAPI.Call("GetCharmMeta", loggingURL).Returns(&charm.Meta{Name: "logging", Subordinate: true}, error(nil))
+
+	context, err := s.runDeploy(c, fakeAPI, loggingURL.String(), "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(context), gc.Equals,
+		"Subordinate applications are not deployed to machines of their own.\n",
+	)
+	for _, call := range fakeAPI.Calls() {
+		c.Check(call.FuncName, gc.Not(gc.Matches), "AddCharm.*|PreviewMachines|Deploy")
+	}
+}
+
 func (s *DeployUnitTestSuite) TestRedeployLocalCharmSucceedsWhenDeployed(c *gc.C) {
 	charmDir := s.makeCharmDir(c, "dummy")
 	fakeAPI := s.fakeAPI()
@@ -2218,6 +2280,11 @@ func (f *fakeDeployAPI) GetBundle(url *charm.URL) (charm.Bundle, error) {
 	return results[0].(charm.Bundle), jujutesting.TypeAssertError(results[1])
 }
 
+func (f *fakeDeployAPI) GetCharmMeta(url *charm.URL) (*charm.Meta, error) {
+	results := f.MethodCall(f, "GetCharmMeta", url)
+	return results[0].(*charm.Meta), jujutesting.TypeAssertError(results[1])
+}
+
 func (f *fakeDeployAPI) Status(patterns []string) (*params.FullStatus, error) {
 	results := f.MethodCall(f, "Status", patterns)
 	return results[0].(*params.FullStatus), jujutesting.TypeAssertError(results[1])
@@ -2258,8 +2325,8 @@ func (f *fakeDeployAPI) Update(args params.ApplicationUpdate) error {
 	return jujutesting.TypeAssertError(results[0])
 }
 
-func (f *fakeDeployAPI) SetConstraints(application string, constraints constraints.Value) error {
-	results := f.MethodCall(f, "SetConstraints", application, constraints)
+func (f *fakeDeployAPI) SetBranchConstraints(branchName, application string, constraints constraints.Value) error {
+	results := f.MethodCall(f, "SetBranchConstraints", branchName, application, constraints)
 	return jujutesting.TypeAssertError(results[0])
 }
 
@@ -2268,6 +2335,11 @@ func (f *fakeDeployAPI) AddMachines(machineParams []params.AddMachineParams) ([]
 	return results[0].([]params.AddMachinesResult), jujutesting.TypeAssertError(results[0])
 }
 
+func (f *fakeDeployAPI) PreviewMachines(machineParams []params.AddMachineParams) ([]params.MachinePreviewResult, error) {
+	results := f.MethodCall(f, "PreviewMachines", machineParams)
+	return results[0].([]params.MachinePreviewResult), jujutesting.TypeAssertError(results[1])
+}
+
 func (f *fakeDeployAPI) PlanURL() string {
 	return f.planURL
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/api/base"
 	apicharms "github.com/juju/juju/api/charms"
+	"github.com/juju/juju/api/machinemanager"
 	"github.com/juju/juju/api/modelconfig"
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -64,6 +65,8 @@ func NewDeployCommandForTest(newAPIRoot func() (DeployAPI, error), steps []Deplo
 				annotationsClient: &annotationsClient{Client: annotations.NewClient(apiRoot)},
 				charmRepoClient:   &charmRepoClient{charmrepo.NewCharmStoreFromClient(cstoreClient)},
 				plansClient:       &plansClient{planURL: mURL},
+
+				machineManagerClient: machinemanager.NewClient(apiRoot),
 			}, nil
 		}
 	}
@@ -117,6 +120,8 @@ func NewDeployCommandForTest2(charmstore charmstoreForDeploy, charmrepo *charmst
 			annotationsClient: &annotationsClient{Client: annotations.NewClient(apiRoot)},
 			charmRepoClient:   &charmRepoClient{charmrepo},
 			plansClient:       &plansClient{planURL: mURL},
+
+			machineManagerClient: machinemanager.NewClient(apiRoot),
 		}, nil
 	}
 
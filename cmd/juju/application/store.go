@@ -96,3 +96,13 @@ func authorizeCharmStoreEntity(csClient charmstoreForDeploy, curl *charm.URL) (*
 	}
 	return m, nil
 }
+
+// charmStoreCharmMeta returns the metadata of the charm with the given
+// URL, as held by the charm store, without fetching the charm archive.
+func charmStoreCharmMeta(csClient charmstoreForDeploy, curl *charm.URL) (*charm.Meta, error) {
+	var meta charm.Meta
+	if err := csClient.Get("/"+curl.Path()+"/meta/charm-metadata", &meta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &meta, nil
+}
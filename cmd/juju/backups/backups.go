@@ -30,6 +30,9 @@ type APIClient interface {
 	io.Closer
 	// Create sends an RPC request to create a new backup.
 	Create(notes string, keepCopy, noDownload bool) (*params.BackupsMetadataResult, error)
+	// CreateEncrypted sends an RPC request to create a new backup,
+	// encrypting the archive with the given key.
+	CreateEncrypted(notes string, keepCopy, noDownload bool, key params.BackupsEncryptionKey) (*params.BackupsMetadataResult, error)
 	// Info gets the backup's metadata.
 	Info(id string) (*params.BackupsMetadataResult, error)
 	// List gets all stored metadata.
@@ -44,6 +47,9 @@ type APIClient interface {
 	Restore(string, backups.ClientConnection) error
 	// RestoreReader will restore a backup file into the controller.
 	RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, backups.ClientConnection) error
+	// Schedule gets the scheduled backup configuration and the
+	// outcome of the last scheduled backup.
+	Schedule() (*params.BackupsScheduleResult, error)
 }
 
 // CommandBase is the base type for backups sub-commands.
@@ -112,6 +118,9 @@ func (c *CommandBase) dumpMetadata(ctx *cmd.Context, result *params.BackupsMetad
 	fmt.Fprintf(ctx.Stdout, "started:         %v\n", result.Started)
 	fmt.Fprintf(ctx.Stdout, "finished:        %v\n", result.Finished)
 	fmt.Fprintf(ctx.Stdout, "notes:           %q\n", result.Notes)
+	if result.Encryption != "" {
+		fmt.Fprintf(ctx.Stdout, "encryption:      %q\n", result.Encryption)
+	}
 
 	fmt.Fprintf(ctx.Stdout, "model ID:        %q\n", result.Model)
 	fmt.Fprintf(ctx.Stdout, "machine ID:      %q\n", result.Machine)
@@ -141,6 +150,20 @@ var getArchive = func(filename string) (rc ArchiveReader, metaResult *params.Bac
 		return nil, nil, errors.Trace(err)
 	}
 
+	// Encrypted archives must be decrypted before their
+	// metadata can be read.
+	encrypted, _, err := statebackups.IsEncryptedArchive(archive)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if encrypted {
+		return nil, nil, errors.Errorf("backup archive %q is encrypted; use --passphrase-file or --private-key-file to decrypt it", filename)
+	}
+	_, err = archive.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
 	// Extract the metadata.
 	ad, err := statebackups.NewArchiveDataReader(archive)
 	if err != nil {
@@ -35,6 +35,19 @@ at the end of the backup process.
 
 Use --keep-copy option to store a copy of backup remotely on the controller.
 
+Remote copies are kept in the controller's database unless the
+backup-storage controller config option selects a directory (such as an
+NFS mount) or an S3-compatible object store, so that they survive the
+loss of the controller. For example:
+
+    juju controller-config backup-storage=directory backup-storage-path=/srv/backups
+
+Use --passphrase-file or --public-key-file to encrypt the backup archive
+(and the metadata it contains) with OpenPGP, using a passphrase or an
+armored public key read from the given file. An encrypted archive can be
+decrypted with 'juju download-backup --decrypt', restored with
+'juju restore-backup', or decrypted with gpg.
+
 Use --verbose to see extra information about backup.
 
 To access remote backups stored on the controller, see 'juju download-backup'.
@@ -45,6 +58,8 @@ Examples:
     juju create-backup --no-download --keep-copy=false // ignores --keep-copy
     juju create-backup --keep-copy
     juju create-backup --verbose
+    juju create-backup --passphrase-file ~/backup-passphrase
+    juju create-backup --public-key-file ~/backup-key.asc
 
 See also:
     backups
@@ -67,7 +82,10 @@ type createCommand struct {
 	Notes string
 	// KeepCopy means the backup archive should be stored in the controller db.
 	KeepCopy bool
-	fs       *gnuflag.FlagSet
+	// Encryption holds the flags naming the key with which to
+	// encrypt the backup archive.
+	Encryption encryptionFlags
+	fs         *gnuflag.FlagSet
 }
 
 // Info implements Command.Info.
@@ -86,6 +104,7 @@ func (c *createCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.NoDownload, "no-download", false, "Do not download the archive, implies keep-copy")
 	f.BoolVar(&c.KeepCopy, "keep-copy", false, "Keep a copy of the archive on the controller")
 	f.StringVar(&c.Filename, "filename", notset, "Download to this file")
+	c.Encryption.addFlags(f)
 	c.fs = f
 }
 
@@ -119,7 +138,7 @@ func (c *createCommand) Init(args []string) error {
 	if c.Filename == "" {
 		return errors.Errorf("missing filename")
 	}
-	return nil
+	return errors.Trace(c.Encryption.validate())
 }
 
 // Run implements Command.Run.
@@ -152,7 +171,19 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 		c.KeepCopy = true
 	}
 
-	metadataResult, copyFrom, err := c.create(client, apiVersion)
+	var key *params.BackupsEncryptionKey
+	if c.Encryption.isSet() {
+		if apiVersion < 3 {
+			return errors.New("backup encryption is not supported by this controller")
+		}
+		k, err := c.Encryption.key(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		key = &k
+	}
+
+	metadataResult, copyFrom, err := c.create(client, apiVersion, key)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -209,8 +240,14 @@ func (c *createCommand) download(ctx *cmd.Context, client APIClient, copyFrom st
 	return nil
 }
 
-func (c *createCommand) create(client APIClient, apiVersion int) (*params.BackupsMetadataResult, string, error) {
-	result, err := client.Create(c.Notes, c.KeepCopy, c.NoDownload)
+func (c *createCommand) create(client APIClient, apiVersion int, key *params.BackupsEncryptionKey) (*params.BackupsMetadataResult, string, error) {
+	var result *params.BackupsMetadataResult
+	var err error
+	if key != nil {
+		result, err = client.CreateEncrypted(c.Notes, c.KeepCopy, c.NoDownload, *key)
+	} else {
+		result, err = client.Create(c.Notes, c.KeepCopy, c.NoDownload)
+	}
 	if err != nil {
 		return nil, "", errors.Trace(err)
 	}
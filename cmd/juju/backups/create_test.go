@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -16,6 +17,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/backups"
 )
 
@@ -281,3 +283,55 @@ func (s *createSuite) TestError(c *gc.C) {
 
 	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
 }
+
+func (s *createSuite) TestPassphraseFile(c *gc.C) {
+	s.apiVersion = 3
+	passphraseFile := filepath.Join(c.MkDir(), "passphrase")
+	err := ioutil.WriteFile(passphraseFile, []byte("sekrit\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.setSuccess()
+	_, err = cmdtesting.RunCommand(c, s.wrappedCommand, "--no-download", "--passphrase-file", passphraseFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.CheckCalls(c, "CreateEncrypted")
+	client.CheckArgs(c, "", "true", "true")
+	c.Check(client.key, jc.DeepEquals, &params.BackupsEncryptionKey{Passphrase: "sekrit"})
+}
+
+func (s *createSuite) TestPublicKeyFile(c *gc.C) {
+	s.apiVersion = 3
+	keyFile := filepath.Join(c.MkDir(), "key.asc")
+	err := ioutil.WriteFile(keyFile, []byte("<public key>"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.setSuccess()
+	_, err = cmdtesting.RunCommand(c, s.wrappedCommand, "--no-download", "--public-key-file", keyFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.CheckCalls(c, "CreateEncrypted")
+	c.Check(client.key, jc.DeepEquals, &params.BackupsEncryptionKey{PublicKey: "<public key>"})
+}
+
+func (s *createSuite) TestEmptyPassphraseFile(c *gc.C) {
+	s.apiVersion = 3
+	passphraseFile := filepath.Join(c.MkDir(), "passphrase")
+	err := ioutil.WriteFile(passphraseFile, []byte("\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.setSuccess()
+	_, err = cmdtesting.RunCommand(c, s.wrappedCommand, "--no-download", "--passphrase-file", passphraseFile)
+	c.Check(err, gc.ErrorMatches, `passphrase file ".*" is empty`)
+}
+
+func (s *createSuite) TestPassphraseAndPublicKeyFile(c *gc.C) {
+	s.setSuccess()
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--passphrase-file", "a", "--public-key-file", "b")
+	c.Check(err, gc.ErrorMatches, "cannot specify both --passphrase-file and --public-key-file")
+}
+
+func (s *createSuite) TestEncryptionV2Fail(c *gc.C) {
+	s.setSuccess()
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--no-download", "--passphrase-file", "a")
+	c.Check(err, gc.ErrorMatches, "backup encryption is not supported by this controller")
+}
@@ -22,6 +22,16 @@ download-backup retrieves a backup archive file.
 
 If --filename is not used, the archive is downloaded to a temporary
 location and the filename is printed to stdout.
+
+Use --decrypt to decrypt an archive created with 'juju create-backup
+--passphrase-file' or '--public-key-file', giving the passphrase or the
+armored OpenPGP private key with --passphrase-file or --private-key-file.
+If the private key is itself protected with a passphrase, give both.
+
+Examples:
+    juju download-backup <ID>
+    juju download-backup <ID> --decrypt --passphrase-file ~/backup-passphrase
+    juju download-backup <ID> --decrypt --private-key-file ~/backup-key.asc
 `
 
 // NewDownloadCommand returns a commant used to download backups.
@@ -36,6 +46,11 @@ type downloadCommand struct {
 	Filename string
 	// ID is the backup ID to download.
 	ID string
+	// Decrypt means the downloaded archive should be decrypted.
+	Decrypt bool
+	// Decryption holds the flags naming the key with which to
+	// decrypt the archive.
+	Decryption decryptionFlags
 }
 
 // Info implements Command.Info.
@@ -52,6 +67,8 @@ func (c *downloadCommand) Info() *cmd.Info {
 func (c *downloadCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.StringVar(&c.Filename, "filename", "", "Download target")
+	f.BoolVar(&c.Decrypt, "decrypt", false, "Decrypt the downloaded archive")
+	c.Decryption.addFlags(f)
 }
 
 // Init implements Command.Init.
@@ -64,6 +81,12 @@ func (c *downloadCommand) Init(args []string) error {
 		return errors.Trace(err)
 	}
 	c.ID = id
+	if c.Decrypt && !c.Decryption.isSet() {
+		return errors.New("--decrypt requires --passphrase-file or --private-key-file")
+	}
+	if !c.Decrypt && c.Decryption.isSet() {
+		return errors.New("--passphrase-file and --private-key-file require --decrypt")
+	}
 	return nil
 }
 
@@ -77,6 +100,14 @@ func (c *downloadCommand) Run(ctx *cmd.Context) error {
 			return err
 		}
 	}
+	var key backups.DecryptionKey
+	if c.Decrypt {
+		var err error
+		if key, err = c.Decryption.key(ctx); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	client, err := c.NewAPIClient()
 	if err != nil {
 		return errors.Trace(err)
@@ -99,9 +130,18 @@ func (c *downloadCommand) Run(ctx *cmd.Context) error {
 	defer archive.Close()
 
 	// Write out the archive.
-	_, err = io.Copy(archive, resultArchive)
-	if err != nil {
-		return errors.Annotate(err, "while copying local archive file")
+	if c.Decrypt {
+		if err := decryptArchive(resultArchive, archive, key); err != nil {
+			// Don't leave a partially decrypted archive around.
+			archive.Close()
+			os.Remove(filename)
+			return errors.Trace(err)
+		}
+	} else {
+		_, err = io.Copy(archive, resultArchive)
+		if err != nil {
+			return errors.Annotate(err, "while copying local archive file")
+		}
 	}
 
 	// Print the local filename.
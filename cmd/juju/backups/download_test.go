@@ -4,6 +4,11 @@
 package backups_test
 
 import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
@@ -11,6 +16,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/cmd/juju/backups"
+	statebackups "github.com/juju/juju/state/backups"
 )
 
 type downloadSuite struct {
@@ -71,3 +77,59 @@ func (s *downloadSuite) TestError(c *gc.C) {
 	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, s.metaresult.ID)
 	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
 }
+
+func (s *downloadSuite) setEncrypted(c *gc.C) string {
+	var encrypted bytes.Buffer
+	w, err := statebackups.EncryptArchive(&encrypted, statebackups.EncryptionKey{Passphrase: "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = w.Write([]byte(s.data))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+
+	client := s.setSuccess()
+	client.archive = ioutil.NopCloser(&encrypted)
+	return filepath.Join(c.MkDir(), "passphrase")
+}
+
+func (s *downloadSuite) TestDecrypt(c *gc.C) {
+	passphraseFile := s.setEncrypted(c)
+	err := ioutil.WriteFile(passphraseFile, []byte("sekrit\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, s.metaresult.ID, "--decrypt", "--passphrase-file", passphraseFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.filename = "juju-backup-" + s.metaresult.ID + ".tar.gz"
+	s.checkStd(c, ctx, s.filename+"\n", "")
+	s.checkArchive(c)
+}
+
+func (s *downloadSuite) TestDecryptWrongPassphrase(c *gc.C) {
+	passphraseFile := s.setEncrypted(c)
+	err := ioutil.WriteFile(passphraseFile, []byte("wrong"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cmdtesting.RunCommand(c, s.wrappedCommand, s.metaresult.ID, "--decrypt", "--passphrase-file", passphraseFile)
+	c.Check(err, gc.ErrorMatches, "cannot decrypt backup archive: wrong key or passphrase")
+
+	s.filename = "juju-backup-" + s.metaresult.ID + ".tar.gz"
+	_, err = os.Stat(s.filename)
+	c.Check(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *downloadSuite) TestDecryptNotEncrypted(c *gc.C) {
+	s.setSuccess()
+	passphraseFile := filepath.Join(c.MkDir(), "passphrase")
+	err := ioutil.WriteFile(passphraseFile, []byte("sekrit"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cmdtesting.RunCommand(c, s.wrappedCommand, s.metaresult.ID, "--decrypt", "--passphrase-file", passphraseFile)
+	c.Check(err, gc.ErrorMatches, "backup archive is not encrypted")
+}
+
+func (s *downloadSuite) TestDecryptFlags(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, s.metaresult.ID, "--decrypt")
+	c.Check(err, gc.ErrorMatches, "--decrypt requires --passphrase-file or --private-key-file")
+	_, err = cmdtesting.RunCommand(c, s.wrappedCommand, s.metaresult.ID, "--passphrase-file", "a")
+	c.Check(err, gc.ErrorMatches, "--passphrase-file and --private-key-file require --decrypt")
+}
@@ -0,0 +1,125 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	statebackups "github.com/juju/juju/state/backups"
+)
+
+// encryptionFlags holds the flags used to encrypt a new backup archive.
+type encryptionFlags struct {
+	PassphraseFile string
+	PublicKeyFile  string
+}
+
+func (f *encryptionFlags) addFlags(fs *gnuflag.FlagSet) {
+	fs.StringVar(&f.PassphraseFile, "passphrase-file", "", "Encrypt the archive with the passphrase in this file")
+	fs.StringVar(&f.PublicKeyFile, "public-key-file", "", "Encrypt the archive to the armored OpenPGP public key in this file")
+}
+
+func (f *encryptionFlags) validate() error {
+	if f.PassphraseFile != "" && f.PublicKeyFile != "" {
+		return errors.New("cannot specify both --passphrase-file and --public-key-file")
+	}
+	return nil
+}
+
+func (f *encryptionFlags) isSet() bool {
+	return f.PassphraseFile != "" || f.PublicKeyFile != ""
+}
+
+// key returns the encryption key read from the files named by the flags.
+func (f *encryptionFlags) key(ctx *cmd.Context) (params.BackupsEncryptionKey, error) {
+	var key params.BackupsEncryptionKey
+	var err error
+	if f.PassphraseFile != "" {
+		key.Passphrase, err = readPassphraseFile(ctx, f.PassphraseFile)
+	} else {
+		key.PublicKey, err = readKeyFile(ctx, f.PublicKeyFile)
+	}
+	return key, errors.Trace(err)
+}
+
+// decryptionFlags holds the flags used to decrypt a backup archive.
+type decryptionFlags struct {
+	PassphraseFile string
+	PrivateKeyFile string
+}
+
+func (f *decryptionFlags) addFlags(fs *gnuflag.FlagSet) {
+	fs.StringVar(&f.PassphraseFile, "passphrase-file", "", "Decrypt the archive (or unlock the private key) with the passphrase in this file")
+	fs.StringVar(&f.PrivateKeyFile, "private-key-file", "", "Decrypt the archive with the armored OpenPGP private key in this file")
+}
+
+func (f *decryptionFlags) isSet() bool {
+	return f.PassphraseFile != "" || f.PrivateKeyFile != ""
+}
+
+// key returns the decryption key read from the files named by the flags.
+func (f *decryptionFlags) key(ctx *cmd.Context) (statebackups.DecryptionKey, error) {
+	var key statebackups.DecryptionKey
+	var err error
+	if f.PassphraseFile != "" {
+		if key.Passphrase, err = readPassphraseFile(ctx, f.PassphraseFile); err != nil {
+			return key, errors.Trace(err)
+		}
+	}
+	if f.PrivateKeyFile != "" {
+		if key.PrivateKey, err = readKeyFile(ctx, f.PrivateKeyFile); err != nil {
+			return key, errors.Trace(err)
+		}
+	}
+	return key, nil
+}
+
+func readKeyFile(ctx *cmd.Context, filename string) (string, error) {
+	data, err := ioutil.ReadFile(ctx.AbsPath(filename))
+	if err != nil {
+		return "", errors.Annotate(err, "cannot read key file")
+	}
+	return string(data), nil
+}
+
+func readPassphraseFile(ctx *cmd.Context, filename string) (string, error) {
+	data, err := ioutil.ReadFile(ctx.AbsPath(filename))
+	if err != nil {
+		return "", errors.Annotate(err, "cannot read passphrase file")
+	}
+	// Editors usually end the file with a newline,
+	// which is not part of the passphrase.
+	passphrase := strings.TrimRight(string(data), "\r\n")
+	if passphrase == "" {
+		return "", errors.Errorf("passphrase file %q is empty", filename)
+	}
+	return passphrase, nil
+}
+
+// decryptArchive writes the decrypted content of the encrypted backup
+// archive read from r to w.
+func decryptArchive(r io.Reader, w io.Writer, key statebackups.DecryptionKey) error {
+	encrypted, r, err := statebackups.IsEncryptedArchive(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !encrypted {
+		return errors.New("backup archive is not encrypted")
+	}
+	decrypted, err := statebackups.DecryptArchive(r, key)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := io.Copy(w, decrypted); err != nil {
+		return errors.Annotate(err, "while decrypting backup archive")
+	}
+	return nil
+}
@@ -61,6 +61,13 @@ func NewShowCommandForTest(store jujuclient.ClientStore) cmd.Command {
 	return modelcmd.Wrap(c)
 }
 
+func NewShowScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &showScheduleCommand{}
+	c.Log = &cmd.Log{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
 func NewUploadCommandForTest(store jujuclient.ClientStore) cmd.Command {
 	c := &uploadCommand{}
 	c.Log = &cmd.Log{}
@@ -122,6 +122,19 @@ func (mr *MockAPIClientMockRecorder) Create(arg0, arg1, arg2 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPIClient)(nil).Create), arg0, arg1, arg2)
 }
 
+// CreateEncrypted mocks base method
+func (m *MockAPIClient) CreateEncrypted(arg0 string, arg1, arg2 bool, arg3 params.BackupsEncryptionKey) (*params.BackupsMetadataResult, error) {
+	ret := m.ctrl.Call(m, "CreateEncrypted", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*params.BackupsMetadataResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEncrypted indicates an expected call of CreateEncrypted
+func (mr *MockAPIClientMockRecorder) CreateEncrypted(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEncrypted", reflect.TypeOf((*MockAPIClient)(nil).CreateEncrypted), arg0, arg1, arg2, arg3)
+}
+
 // Download mocks base method
 func (m *MockAPIClient) Download(arg0 string) (io.ReadCloser, error) {
 	ret := m.ctrl.Call(m, "Download", arg0)
@@ -202,6 +215,19 @@ func (mr *MockAPIClientMockRecorder) RestoreReader(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreReader", reflect.TypeOf((*MockAPIClient)(nil).RestoreReader), arg0, arg1, arg2)
 }
 
+// Schedule mocks base method
+func (m *MockAPIClient) Schedule() (*params.BackupsScheduleResult, error) {
+	ret := m.ctrl.Call(m, "Schedule")
+	ret0, _ := ret[0].(*params.BackupsScheduleResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Schedule indicates an expected call of Schedule
+func (mr *MockAPIClientMockRecorder) Schedule() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schedule", reflect.TypeOf((*MockAPIClient)(nil).Schedule))
+}
+
 // Upload mocks base method
 func (m *MockAPIClient) Upload(arg0 io.ReadSeeker, arg1 params.BackupsMetadataResult) (string, error) {
 	ret := m.ctrl.Call(m, "Upload", arg0, arg1)
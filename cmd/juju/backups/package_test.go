@@ -152,14 +152,16 @@ func (s *BaseBackupsSuite) checkStd(c *gc.C, ctx *cmd.Context, out, err string)
 // TODO (hml) 2018-05-01
 // Replace this fakeAPIClient with MockAPIClient for all tests.
 type fakeAPIClient struct {
-	metaresult *params.BackupsMetadataResult
-	archive    io.ReadCloser
-	err        error
+	metaresult     *params.BackupsMetadataResult
+	scheduleresult *params.BackupsScheduleResult
+	archive        io.ReadCloser
+	err            error
 
 	calls []string
 	args  []string
 	idArg string
 	notes string
+	key   *params.BackupsEncryptionKey
 }
 
 func (f *fakeAPIClient) Check(c *gc.C, id, notes string, calls ...string) {
@@ -188,6 +190,17 @@ func (c *fakeAPIClient) Create(notes string, keepCopy, noDownload bool) (*params
 	return createResult, nil
 }
 
+func (c *fakeAPIClient) CreateEncrypted(notes string, keepCopy, noDownload bool, key params.BackupsEncryptionKey) (*params.BackupsMetadataResult, error) {
+	c.calls = append(c.calls, "CreateEncrypted")
+	c.args = append(c.args, notes, fmt.Sprintf("%t", keepCopy), fmt.Sprintf("%t", noDownload))
+	c.notes = notes
+	c.key = &key
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.metaresult, nil
+}
+
 func (c *fakeAPIClient) Info(id string) (*params.BackupsMetadataResult, error) {
 	c.calls = append(c.calls, "Info")
 	c.args = append(c.args, id)
@@ -237,6 +250,14 @@ func (c *fakeAPIClient) Remove(id ...string) ([]params.ErrorResult, error) {
 	return nil, nil
 }
 
+func (c *fakeAPIClient) Schedule() (*params.BackupsScheduleResult, error) {
+	c.calls = append(c.calls, "Schedule")
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.scheduleresult, nil
+}
+
 func (c *fakeAPIClient) Close() error {
 	return nil
 }
@@ -6,10 +6,13 @@ package backups
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/juju/cmd"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"gopkg.in/juju/names.v2"
@@ -40,6 +43,10 @@ type restoreCommand struct {
 
 	Filename string
 	BackupId string
+
+	// Decryption holds the flags naming the key with which to
+	// decrypt an encrypted backup archive.
+	Decryption decryptionFlags
 }
 
 // RestoreAPI is used to invoke various API calls.
@@ -74,6 +81,11 @@ machines will be added or removed during the restore process.
 Note: Extra care is needed to restore in an HA environment, please see
 https://docs.jujucharms.com/stable/controllers-backup for more information.
 
+If the backup archive was encrypted when it was created, give the
+passphrase or the armored OpenPGP private key with which to decrypt it
+using --passphrase-file or --private-key-file. The archive is decrypted
+locally, and the decrypted archive is uploaded to the controller.
+
 If the provided state cannot be restored, this command will fail with
 an explanation.
 `
@@ -93,6 +105,7 @@ func (c *restoreCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.StringVar(&c.Filename, "file", "", "Provide a file to be used as the backup")
 	f.StringVar(&c.BackupId, "id", "", "Provide the name of the backup to be restored")
+	c.Decryption.addFlags(f)
 }
 
 // Init is where the preconditions for this command can be checked.
@@ -177,14 +190,28 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("unable to restore backup in HA configuration.  For help see https://docs.jujucharms.com/stable/controllers-backup")
 	}
 
-	var archive ArchiveReader
-	var meta *params.BackupsMetadataResult
 	target := c.BackupId
+	archiveFilename := c.Filename
 	if c.Filename != "" {
-		// Read archive specified by the Filename
 		target = c.Filename
+	}
+	if c.Decryption.isSet() {
+		// Encrypted archives are decrypted locally, and then
+		// restored from the decrypted file.
+		decrypted, err := c.decryptArchive(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer os.Remove(decrypted)
+		archiveFilename = decrypted
+	}
+
+	var archive ArchiveReader
+	var meta *params.BackupsMetadataResult
+	if archiveFilename != "" {
+		// Read archive specified by the Filename
 		var err error
-		archive, meta, err = getArchive(c.Filename)
+		archive, meta, err = getArchive(archiveFilename)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -197,16 +224,122 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	var existing set.Strings
+	if c.Decryption.isSet() {
+		existing, err = backupsWithChecksum(client, meta.Checksum)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	// We have a backup client, now use the relevant method
 	// to restore the backup.
-	if c.Filename != "" {
+	if archiveFilename != "" {
 		err = client.RestoreReader(archive, meta, c.newClient)
 	} else {
 		err = client.Restore(c.BackupId, c.newClient)
 	}
+	if c.Decryption.isSet() {
+		// The decrypted archive is uploaded to the controller to be
+		// restored. Remove it again, whether or not the restore
+		// succeeded, so that no plaintext copy is kept there.
+		if removeErr := c.removeUploadedBackup(meta.Checksum, existing); removeErr != nil {
+			ctx.Warningf("cannot remove decrypted backup from the controller: %v", removeErr)
+		}
+	}
 	if err != nil {
 		return errors.Trace(err)
 	}
 	fmt.Fprintf(ctx.Stdout, "restore from %q completed\n", target)
 	return nil
 }
+
+// backupsWithChecksum returns the IDs of the backups stored on the
+// controller whose archives have the given checksum.
+func backupsWithChecksum(client APIClient, checksum string) (set.Strings, error) {
+	results, err := client.List()
+	if err != nil {
+		return nil, errors.Annotate(err, "listing backups")
+	}
+	ids := set.NewStrings()
+	for _, result := range results.List {
+		if result.Checksum == checksum {
+			ids.Add(result.ID)
+		}
+	}
+	return ids, nil
+}
+
+// removeUploadedBackup removes the backup with the given checksum
+// which was uploaded to the controller for the restore. Backups
+// which were already stored on the controller are not removed.
+func (c *restoreCommand) removeUploadedBackup(checksum string, existing set.Strings) error {
+	// Restoring restarts the controller, so a new connection is needed.
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	ids, err := backupsWithChecksum(client, checksum)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	uploaded := ids.Difference(existing)
+	if uploaded.IsEmpty() {
+		return nil
+	}
+	results, err := client.Remove(uploaded.SortedValues()...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, result := range results {
+		if result.Error != nil {
+			return errors.Trace(result.Error)
+		}
+	}
+	return nil
+}
+
+// decryptArchive decrypts the backup archive, read either from the
+// file or downloaded from the controller, to a temporary file and
+// returns the name of that file.
+func (c *restoreCommand) decryptArchive(ctx *cmd.Context) (_ string, err error) {
+	key, err := c.Decryption.key(ctx)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	var encrypted io.ReadCloser
+	if c.Filename != "" {
+		encrypted, err = os.Open(c.Filename)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+	} else {
+		client, err := c.NewAPIClient()
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		defer client.Close()
+		encrypted, err = client.Download(c.BackupId)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+	}
+	defer encrypted.Close()
+
+	decrypted, err := ioutil.TempFile("", "juju-backup-")
+	if err != nil {
+		return "", errors.Annotate(err, "while creating decrypted archive file")
+	}
+	defer func() {
+		decrypted.Close()
+		if err != nil {
+			os.Remove(decrypted.Name())
+		}
+	}()
+	if err := decryptArchive(encrypted, decrypted, key); err != nil {
+		return "", errors.Trace(err)
+	}
+	return decrypted.Name(), nil
+}
@@ -4,7 +4,10 @@
 package backups_test
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"path/filepath"
 
 	"github.com/golang/mock/gomock"
@@ -23,6 +26,7 @@ import (
 	"github.com/juju/juju/jujuclient"
 	_ "github.com/juju/juju/provider/dummy"
 	_ "github.com/juju/juju/provider/lxd"
+	statebackups "github.com/juju/juju/state/backups"
 	"github.com/juju/juju/testing"
 )
 
@@ -262,3 +266,107 @@ func (s *restoreSuite) TestRestoreFromBackupHAFail(c *gc.C) {
 	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--id", "an_id")
 	c.Assert(err, gc.ErrorMatches, "unable to restore backup in HA configuration.  For help see https://docs.jujucharms.com/stable/controllers-backup")
 }
+
+func (s *restoreSuite) encryptedArchive(c *gc.C) (io.ReadCloser, string) {
+	var encrypted bytes.Buffer
+	w, err := statebackups.EncryptArchive(&encrypted, statebackups.EncryptionKey{Passphrase: "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = w.Write([]byte("<archive>"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+
+	passphraseFile := filepath.Join(c.MkDir(), "passphrase")
+	err = ioutil.WriteFile(passphraseFile, []byte("sekrit"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	return ioutil.NopCloser(&encrypted), passphraseFile
+}
+
+func (s *restoreSuite) TestRestoreEncryptedFromBackupId(c *gc.C) {
+	ctlr, apiClient, archiveReader, modelStatusClient := s.patch(c, nil)
+	defer ctlr.Finish()
+	expectModelStatus(modelStatusClient)
+	var decrypted string
+	meta := &params.BackupsMetadataResult{Checksum: "decrypted-checksum"}
+	s.PatchValue(backups.GetArchive,
+		func(filename string) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
+			data, err := ioutil.ReadFile(filename)
+			c.Assert(err, jc.ErrorIsNil)
+			decrypted = string(data)
+			return archiveReader, meta, nil
+		},
+	)
+	encrypted, passphraseFile := s.encryptedArchive(c)
+	gomock.InOrder(
+		apiClient.EXPECT().Download("an_id").Return(encrypted, nil),
+		apiClient.EXPECT().Close(),
+		apiClient.EXPECT().List().Return(s.encryptedBackupList(false), nil),
+		apiClient.EXPECT().RestoreReader(archiveReader, meta, gomock.Any()).Return(
+			nil,
+		),
+		apiClient.EXPECT().List().Return(s.encryptedBackupList(true), nil),
+		apiClient.EXPECT().Remove("uploaded").Return([]params.ErrorResult{{}}, nil),
+		apiClient.EXPECT().Close(),
+		apiClient.EXPECT().Close(),
+		archiveReader.EXPECT().Close(),
+	)
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--id", "an_id", "--passphrase-file", passphraseFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(decrypted, gc.Equals, "<archive>")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "restore from \"an_id\" completed\n")
+}
+
+func (s *restoreSuite) TestRestoreEncryptedFailRemovesUpload(c *gc.C) {
+	ctlr, apiClient, archiveReader, modelStatusClient := s.patch(c, nil)
+	defer ctlr.Finish()
+	expectModelStatus(modelStatusClient)
+	meta := &params.BackupsMetadataResult{Checksum: "decrypted-checksum"}
+	s.PatchValue(backups.GetArchive,
+		func(filename string) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
+			return archiveReader, meta, nil
+		},
+	)
+	encrypted, passphraseFile := s.encryptedArchive(c)
+	gomock.InOrder(
+		apiClient.EXPECT().Download("an_id").Return(encrypted, nil),
+		apiClient.EXPECT().Close(),
+		apiClient.EXPECT().List().Return(s.encryptedBackupList(false), nil),
+		apiClient.EXPECT().RestoreReader(archiveReader, meta, gomock.Any()).Return(
+			errors.New("failed"),
+		),
+		apiClient.EXPECT().List().Return(s.encryptedBackupList(true), nil),
+		apiClient.EXPECT().Remove("uploaded").Return([]params.ErrorResult{{}}, nil),
+		apiClient.EXPECT().Close(),
+		apiClient.EXPECT().Close(),
+		archiveReader.EXPECT().Close(),
+	)
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--id", "an_id", "--passphrase-file", passphraseFile)
+	c.Assert(err, gc.ErrorMatches, "failed")
+}
+
+// encryptedBackupList returns the backups stored on the controller
+// before and after the decrypted archive is uploaded for a restore.
+func (s *restoreSuite) encryptedBackupList(uploaded bool) *params.BackupsListResult {
+	result := &params.BackupsListResult{
+		List: []params.BackupsMetadataResult{{ID: "an_id", Checksum: "encrypted-checksum"}},
+	}
+	if uploaded {
+		result.List = append(result.List, params.BackupsMetadataResult{ID: "uploaded", Checksum: "decrypted-checksum"})
+	}
+	return result
+}
+
+func (s *restoreSuite) TestRestoreEncryptedWrongPassphrase(c *gc.C) {
+	ctlr, apiClient, _, modelStatusClient := s.patch(c, nil)
+	defer ctlr.Finish()
+	expectModelStatus(modelStatusClient)
+	encrypted, _ := s.encryptedArchive(c)
+	passphraseFile := filepath.Join(c.MkDir(), "wrong")
+	err := ioutil.WriteFile(passphraseFile, []byte("wrong"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	gomock.InOrder(
+		apiClient.EXPECT().Download("an_id").Return(encrypted, nil),
+		apiClient.EXPECT().Close(),
+	)
+	_, err = cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--id", "an_id", "--passphrase-file", passphraseFile)
+	c.Assert(err, gc.ErrorMatches, "cannot decrypt backup archive: wrong key or passphrase")
+}
@@ -0,0 +1,99 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const showScheduleDoc = `
+show-backup-schedule displays the scheduled backup configuration of the
+controller, and the outcome of the most recent scheduled backup.
+
+Scheduled backups are configured using controller config, e.g.
+
+    juju controller-config backup-schedule-interval=24h backup-retention-count=7
+
+Scheduled backups whose age falls outside the retention policy are
+removed automatically; backups created with create-backup are never
+removed by the scheduler.
+
+See also:
+    create-backup
+    backups
+    controller-config
+`
+
+// NewShowScheduleCommand returns a command used to show the scheduled
+// backup configuration of the controller.
+func NewShowScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&showScheduleCommand{})
+}
+
+// showScheduleCommand is the sub-command for showing the backup schedule.
+type showScheduleCommand struct {
+	CommandBase
+}
+
+// Info implements Command.Info.
+func (c *showScheduleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-backup-schedule",
+		Args:    "",
+		Purpose: "Show the scheduled backup configuration of the controller.",
+		Doc:     showScheduleDoc,
+	})
+}
+
+// Init implements Command.Init.
+func (c *showScheduleCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *showScheduleCommand) Run(ctx *cmd.Context) error {
+	if err := c.validateIaasController(c.Info().Name); err != nil {
+		return errors.Trace(err)
+	}
+	if c.Log != nil {
+		if err := c.Log.Start(ctx); err != nil {
+			return err
+		}
+	}
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	result, err := client.Schedule()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if result.Interval == 0 {
+		fmt.Fprintln(ctx.Stdout, "interval:         disabled")
+	} else {
+		fmt.Fprintf(ctx.Stdout, "interval:         %v\n", result.Interval)
+	}
+	fmt.Fprintf(ctx.Stdout, "retention count:  %d\n", result.RetentionCount)
+	fmt.Fprintf(ctx.Stdout, "retention daily:  %d\n", result.RetentionDaily)
+	fmt.Fprintf(ctx.Stdout, "retention weekly: %d\n", result.RetentionWeekly)
+	if result.LastStatus == nil {
+		fmt.Fprintln(ctx.Stdout, "last backup:      none")
+		return nil
+	}
+	fmt.Fprintf(ctx.Stdout, "last backup:      %s\n", result.LastStatus.Status)
+	fmt.Fprintf(ctx.Stdout, "message:          %s\n", result.LastStatus.Info)
+	if result.LastStatus.Since != nil {
+		fmt.Fprintf(ctx.Stdout, "since:            %v\n", result.LastStatus.Since.UTC())
+	}
+	return nil
+}
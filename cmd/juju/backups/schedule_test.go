@@ -0,0 +1,85 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/backups"
+	"github.com/juju/juju/core/status"
+)
+
+type showScheduleSuite struct {
+	BaseBackupsSuite
+	subcommand cmd.Command
+}
+
+var _ = gc.Suite(&showScheduleSuite{})
+
+func (s *showScheduleSuite) SetUpTest(c *gc.C) {
+	s.BaseBackupsSuite.SetUpTest(c)
+	s.subcommand = backups.NewShowScheduleCommandForTest(s.store)
+}
+
+func (s *showScheduleSuite) TestDisabled(c *gc.C) {
+	client := s.setSuccess()
+	client.scheduleresult = &params.BackupsScheduleResult{RetentionCount: 7}
+	ctx, err := cmdtesting.RunCommand(c, s.subcommand)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.checkStd(c, ctx, `
+interval:         disabled
+retention count:  7
+retention daily:  0
+retention weekly: 0
+last backup:      none
+`[1:], "")
+	client.CheckCalls(c, "Schedule")
+}
+
+func (s *showScheduleSuite) TestScheduled(c *gc.C) {
+	since := time.Date(2019, 4, 1, 12, 0, 0, 0, time.UTC)
+	client := s.setSuccess()
+	client.scheduleresult = &params.BackupsScheduleResult{
+		Interval:        24 * time.Hour,
+		RetentionCount:  3,
+		RetentionDaily:  7,
+		RetentionWeekly: 4,
+		LastStatus: &params.EntityStatus{
+			Status: status.Available,
+			Info:   "created backup spam",
+			Since:  &since,
+		},
+	}
+	ctx, err := cmdtesting.RunCommand(c, s.subcommand)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.checkStd(c, ctx, `
+interval:         24h0m0s
+retention count:  3
+retention daily:  7
+retention weekly: 4
+last backup:      available
+message:          created backup spam
+since:            2019-04-01 12:00:00 +0000 UTC
+`[1:], "")
+}
+
+func (s *showScheduleSuite) TestError(c *gc.C) {
+	s.setFailure("failed!")
+	_, err := cmdtesting.RunCommand(c, s.subcommand)
+	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
+}
+
+func (s *showScheduleSuite) TestTooManyArgs(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.subcommand, "foo")
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
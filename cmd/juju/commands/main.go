@@ -296,6 +296,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(backups.NewCreateCommand())
 	r.Register(backups.NewDownloadCommand())
 	r.Register(backups.NewShowCommand())
+	r.Register(backups.NewShowScheduleCommand())
 	r.Register(backups.NewListCommand())
 	r.Register(backups.NewRemoveCommand())
 	r.Register(backups.NewRestoreCommand())
@@ -326,6 +327,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	// Manage machines
 	r.Register(machine.NewAddCommand())
 	r.Register(machine.NewRemoveCommand())
+	r.Register(machine.NewReplaceCommand())
 	r.Register(machine.NewListMachinesCommand())
 	r.Register(machine.NewShowMachineCommand())
 	r.Register(machine.NewUpgradeSeriesCommand())
@@ -342,13 +344,18 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	if featureflag.Enabled(feature.Generations) {
 		r.Register(model.NewBranchCommand())
 		r.Register(model.NewCommitCommand())
+		r.Register(model.NewAbortCommand())
 		r.Register(model.NewTrackBranchCommand())
 		r.Register(model.NewCheckoutCommand())
 		r.Register(model.NewDiffCommand())
 	}
 
 	r.Register(newMigrateCommand())
+	r.Register(newMigrateModelsCommand())
+	r.Register(newShowMigrationBatchCommand())
 	r.Register(model.NewExportBundleCommand())
+	r.Register(model.NewExportModelCommand())
+	r.Register(model.NewImportModelCommand())
 
 	if featureflag.Enabled(feature.DeveloperMode) {
 		r.Register(model.NewDumpCommand())
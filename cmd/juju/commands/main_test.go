@@ -465,6 +465,7 @@ var commandNames = []string{
 	"enable-ha",
 	"enable-user",
 	"export-bundle",
+	"export-model",
 	"expose",
 	"find-offers",
 	"firewall-rules",
@@ -478,6 +479,7 @@ var commandNames = []string{
 	"hook-tool",
 	"hook-tools",
 	"import-filesystem",
+	"import-model",
 	"import-ssh-key",
 	"kill-controller",
 	"list-actions",
@@ -509,6 +511,7 @@ var commandNames = []string{
 	"machines",
 	"metrics",
 	"migrate",
+	"migrate-models",
 	"model-config",
 	"model-default",
 	"model-defaults",
@@ -537,6 +540,7 @@ var commandNames = []string{
 	"remove-storage-pool",
 	"remove-unit",
 	"remove-user",
+	"replace-machine",
 	"resolved",
 	"resolve",
 	"resources",
@@ -563,11 +567,13 @@ var commandNames = []string{
 	"show-action-status",
 	"show-application",
 	"show-backup",
+	"show-backup-schedule",
 	"show-cloud",
 	"show-controller",
 	"show-credential",
 	"show-credentials",
 	"show-machine",
+	"show-migration-batch",
 	"show-model",
 	"show-offer",
 	"show-status",
@@ -4,8 +4,12 @@
 package commands
 
 import (
+	"io"
+
+	"github.com/dustin/go-humanize"
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/macaroon-bakery.v2-unstable/httpbakery"
 	"gopkg.in/macaroon.v2-unstable"
 
@@ -13,6 +17,7 @@ import (
 	"github.com/juju/juju/api/controller"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/jujuclient"
 )
 
@@ -28,10 +33,13 @@ type migrateCommand struct {
 	newAPIRoot       func(jujuclient.ClientStore, string, string) (api.Connection, error)
 	api              migrateAPI
 	targetController string
+	dryRun           bool
+	resume           bool
 }
 
 type migrateAPI interface {
 	InitiateMigration(spec controller.MigrationSpec) (string, error)
+	MigrationDryRun(spec controller.MigrationSpec) (controller.MigrationDryRunResult, error)
 }
 
 const migrateDoc = `
@@ -57,6 +65,25 @@ This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
 "status" command and by consulting the logs.
 
+With --dry-run, the migration is checked but not started. The prechecks
+are run on both controllers, and the model is exported and validated by
+the target controller without being imported. The charms, agent binaries
+and resources that would be uploaded to the target controller are listed
+with their sizes, to help estimate how long the migration will take.
+
+With --resume, the model's last migration to the target controller is
+tried again. The migration must have been aborted after the model was
+imported into the target controller, for example because uploading the
+model's binaries failed. Charms, agent binaries and resources which the
+target controller already has are not uploaded again. A model kept on the
+target controller by an aborted migration is removed if the migration is
+not resumed within 24 hours.
+
+Examples:
+    juju migrate mymodel target-controller
+    juju migrate --dry-run mymodel target-controller
+    juju migrate --resume mymodel target-controller
+
 See also:
     login
     controllers
@@ -73,6 +100,13 @@ func (c *migrateCommand) Info() *cmd.Info {
 	})
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.dryRun, "dry-run", false, "Check whether the migration would succeed without starting it")
+	f.BoolVar(&c.resume, "resume", false, "Resume the model's last aborted migration to the target controller")
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -84,6 +118,9 @@ func (c *migrateCommand) Init(args []string) error {
 	if len(args) > 2 {
 		return errors.New("too many arguments specified")
 	}
+	if c.dryRun && c.resume {
+		return errors.New("--dry-run and --resume can't be used together")
+	}
 
 	c.SetModelName(args[0], false)
 	c.targetController = args[1]
@@ -91,14 +128,23 @@ func (c *migrateCommand) Init(args []string) error {
 }
 
 func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
-	store := c.ClientStore()
+	return targetControllerSpec(&c.CommandBase, c.ClientStore(), c.newAPIRoot, c.targetController)
+}
 
-	controllerInfo, err := store.ControllerByName(c.targetController)
+// targetControllerSpec returns a migration spec holding the details
+// required to connect to the named target controller.
+func targetControllerSpec(
+	base *modelcmd.CommandBase,
+	store jujuclient.ClientStore,
+	newAPIRoot func(jujuclient.ClientStore, string, string) (api.Connection, error),
+	targetController string,
+) (*controller.MigrationSpec, error) {
+	controllerInfo, err := store.ControllerByName(targetController)
 	if err != nil {
 		return nil, err
 	}
 
-	accountInfo, err := store.AccountDetails(c.targetController)
+	accountInfo, err := store.AccountDetails(targetController)
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +152,7 @@ func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
 	var macs []macaroon.Slice
 	if accountInfo.Password == "" {
 		var err error
-		macs, err = c.getTargetControllerMacaroons()
+		macs, err = targetControllerMacaroons(base, store, newAPIRoot, targetController)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -137,10 +183,19 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 	spec.ModelUUID = uuids[0]
+	spec.Resume = c.resume
 	api, err := c.getAPI()
 	if err != nil {
 		return err
 	}
+	if c.dryRun {
+		result, err := api.MigrationDryRun(*spec)
+		if err != nil {
+			return err
+		}
+		ctx.Infof("Migration of model %q to controller %q passed all checks", modelName, c.targetController)
+		return errors.Trace(printMigrationBinaries(ctx.Stdout, result))
+	}
 	id, err := api.InitiateMigration(*spec)
 	if err != nil {
 		return err
@@ -149,6 +204,34 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// printMigrationBinaries writes a table of the binaries that a migration
+// would transfer to the target controller, with their total size.
+func printMigrationBinaries(writer io.Writer, result controller.MigrationDryRunResult) error {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Type", "Name", "Size")
+	var total uint64
+	for _, group := range []struct {
+		kind     string
+		binaries []controller.MigrationBinary
+	}{
+		{"charm", result.Charms},
+		{"agent", result.Tools},
+		{"resource", result.Resources},
+	} {
+		for _, b := range group.binaries {
+			size := "unknown"
+			if b.Size > 0 {
+				size = humanize.IBytes(uint64(b.Size))
+				total += uint64(b.Size)
+			}
+			w.Println(group.kind, b.Name, size)
+		}
+	}
+	w.Println("total", "", humanize.IBytes(total))
+	return tw.Flush()
+}
+
 func (c *migrateCommand) getAPI() (migrateAPI, error) {
 	if c.api != nil {
 		return c.api, nil
@@ -160,8 +243,13 @@ func (c *migrateCommand) getAPI() (migrateAPI, error) {
 	return controller.NewClient(apiRoot), nil
 }
 
-func (c *migrateCommand) getTargetControllerMacaroons() ([]macaroon.Slice, error) {
-	jar, err := c.CommandBase.CookieJar(c.ClientStore(), c.targetController)
+func targetControllerMacaroons(
+	base *modelcmd.CommandBase,
+	store jujuclient.ClientStore,
+	newAPIRoot func(jujuclient.ClientStore, string, string) (api.Connection, error),
+	targetController string,
+) ([]macaroon.Slice, error) {
+	jar, err := base.CookieJar(store, targetController)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -171,7 +259,7 @@ func (c *migrateCommand) getTargetControllerMacaroons() ([]macaroon.Slice, error
 	//
 	// TODO(axw,mjs) add a controller API that returns a macaroon that
 	// may be used for the sole purpose of migration.
-	api, err := c.newAPIRoot(c.ClientStore(), c.targetController, "")
+	api, err := newAPIRoot(store, targetController, "")
 	if err != nil {
 		return nil, errors.Annotate(err, "connecting to target controller")
 	}
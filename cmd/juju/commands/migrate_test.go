@@ -219,6 +219,53 @@ func (s *MigrateSuite) TestControllerDoesntExist(c *gc.C) {
 	c.Check(s.api.specSeen, gc.IsNil) // API shouldn't have been called
 }
 
+func (s *MigrateSuite) TestDryRun(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Migration of model \"model\" to controller \"target\" passed all checks\n")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Type      Name                Size
+charm     cs:wordpress-1      1.0 KiB
+agent     2.6.0-bionic-amd64  4.0 KiB
+agent     2.6.0-xenial-amd64  unknown
+resource  wordpress/data      2.0 KiB
+total                         7.0 KiB
+`[1:])
+	c.Check(s.api.specSeen, gc.IsNil) // The migration shouldn't have been started.
+	c.Check(s.api.dryRunSpecSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:            modelUUID,
+		TargetControllerUUID: targetControllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "targetuser",
+		TargetPassword:       "secret",
+	})
+}
+
+func (s *MigrateSuite) TestResume(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "--resume", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Matches, "Migration started with ID \"uuid:0\"\n")
+	c.Check(s.api.specSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:            modelUUID,
+		TargetControllerUUID: targetControllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "targetuser",
+		TargetPassword:       "secret",
+		Resume:               true,
+	})
+}
+
+func (s *MigrateSuite) TestResumeDryRun(c *gc.C) {
+	_, err := s.makeAndRun(c, "--resume", "--dry-run", "model", "target")
+	c.Assert(err, gc.ErrorMatches, "--dry-run and --resume can't be used together")
+	c.Check(s.api.specSeen, gc.IsNil)
+	c.Check(s.api.dryRunSpecSeen, gc.IsNil)
+}
+
 func (s *MigrateSuite) makeAndRun(c *gc.C, args ...string) (*cmd.Context, error) {
 	return cmdtesting.RunCommand(c, s.makeCommand(), args...)
 }
@@ -236,7 +283,8 @@ func (s *MigrateSuite) makeCommand() modelcmd.ModelCommand {
 }
 
 type fakeMigrateAPI struct {
-	specSeen *controller.MigrationSpec
+	specSeen       *controller.MigrationSpec
+	dryRunSpecSeen *controller.MigrationSpec
 }
 
 func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (string, error) {
@@ -244,6 +292,18 @@ func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (strin
 	return "uuid:0", nil
 }
 
+func (a *fakeMigrateAPI) MigrationDryRun(spec controller.MigrationSpec) (controller.MigrationDryRunResult, error) {
+	a.dryRunSpecSeen = &spec
+	return controller.MigrationDryRunResult{
+		Charms: []controller.MigrationBinary{{Name: "cs:wordpress-1", Size: 1024}},
+		Tools: []controller.MigrationBinary{
+			{Name: "2.6.0-bionic-amd64", Size: 4096},
+			{Name: "2.6.0-xenial-amd64"},
+		},
+		Resources: []controller.MigrationBinary{{Name: "wordpress/data", Size: 2048}},
+	}, nil
+}
+
 type fakeModelAPI struct {
 	models []base.UserModel
 }
@@ -0,0 +1,342 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/controller"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/jujuclient"
+)
+
+func newMigrateModelsCommand() cmd.Command {
+	var cmd migrateModelsCommand
+	cmd.newAPIRoot = cmd.CommandBase.NewAPIRoot
+	return modelcmd.WrapController(&cmd)
+}
+
+// migrateModelsCommand starts a batch of model migrations.
+type migrateModelsCommand struct {
+	modelcmd.ControllerCommandBase
+	newAPIRoot       func(jujuclient.ClientStore, string, string) (api.Connection, error)
+	api              migrateModelsAPI
+	out              cmd.Output
+	targetController string
+	owner            string
+	cloud            string
+	all              bool
+	concurrency      int
+}
+
+type migrateModelsAPI interface {
+	InitiateMigrationBatch(spec controller.MigrationBatchSpec) (string, error)
+	MigrationBatchStatus(id string) (controller.MigrationBatchStatus, error)
+}
+
+const migrateModelsDoc = `
+migrate-models begins the migration of a set of hosted models from the
+current controller to another controller. This is useful for evacuating
+a controller. The models to migrate are chosen with --owner and --cloud,
+which may be combined. All the hosted models are migrated with --all.
+
+The migrations are queued by the controller, which starts no more than
+the number given by --concurrency at once. Queued migrations are started
+as earlier ones finish. The migration of each model otherwise behaves as
+for the "migrate" command; the prechecks for a model are run before its
+migration starts, and a model which fails them, or whose migration fails,
+stays on the current controller without affecting the rest of the batch.
+
+This command only queues the migrations - it does not wait for their
+completion. The progress of the batch, including any failed migrations,
+can be tracked using the "show-migration-batch" command.
+
+Examples:
+    juju migrate-models --all target-controller
+    juju migrate-models --owner bob --concurrency 3 target-controller
+    juju migrate-models --cloud aws target-controller
+
+See also:
+    migrate
+    show-migration-batch
+    login
+`
+
+// Info implements cmd.Command.
+func (c *migrateModelsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "migrate-models",
+		Args:    "<target-controller-name>",
+		Purpose: "Migrate a set of hosted models to another controller.",
+		Doc:     migrateModelsDoc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *migrateModelsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.owner, "owner", "", "Migrate the models owned by this user")
+	f.StringVar(&c.cloud, "cloud", "", "Migrate the models deployed to this cloud")
+	f.BoolVar(&c.all, "all", false, "Migrate all the hosted models")
+	f.IntVar(&c.concurrency, "concurrency", 1, "The maximum number of migrations in progress at once")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatMigrationBatchTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *migrateModelsCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("target controller not specified")
+	}
+	if len(args) > 1 {
+		return errors.New("too many arguments specified")
+	}
+	c.targetController = args[0]
+
+	if c.all == (c.owner != "" || c.cloud != "") {
+		return errors.New("specify either --all, or --owner and/or --cloud")
+	}
+	if c.concurrency < 1 {
+		return errors.Errorf("--concurrency must be at least 1, got %d", c.concurrency)
+	}
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *migrateModelsCommand) Run(ctx *cmd.Context) error {
+	target, err := targetControllerSpec(&c.CommandBase, c.ClientStore(), c.newAPIRoot, c.targetController)
+	if err != nil {
+		return err
+	}
+	api, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	id, err := api.InitiateMigrationBatch(controller.MigrationBatchSpec{
+		Owner:                c.owner,
+		Cloud:                c.cloud,
+		Concurrency:          c.concurrency,
+		TargetControllerUUID: target.TargetControllerUUID,
+		TargetAddrs:          target.TargetAddrs,
+		TargetCACert:         target.TargetCACert,
+		TargetUser:           target.TargetUser,
+		TargetPassword:       target.TargetPassword,
+		TargetMacaroons:      target.TargetMacaroons,
+	})
+	if err != nil {
+		return err
+	}
+	ctx.Infof("Migration batch %q started", id)
+
+	status, err := api.MigrationBatchStatus(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(c.out.Write(ctx, makeMigrationBatchStatus(status, c.targetController)))
+}
+
+func (c *migrateModelsCommand) getAPI() (migrateModelsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
+func newShowMigrationBatchCommand() cmd.Command {
+	return modelcmd.WrapController(&showMigrationBatchCommand{})
+}
+
+// showMigrationBatchCommand shows the progress of a batch of model
+// migrations.
+type showMigrationBatchCommand struct {
+	modelcmd.ControllerCommandBase
+	api     migrateModelsAPI
+	out     cmd.Output
+	batchId string
+}
+
+const showMigrationBatchDoc = `
+Shows the progress of the migrations started by "migrate-models". Each
+model in the batch is listed with the status of its migration, which
+is one of:
+
+    queued     the migration is yet to start
+    migrated   the model now lives on the target controller
+    failed     the migration couldn't be started, or was aborted
+               and the model returned to the current controller
+
+Migrations in progress are shown with their current phase. A summary
+of the batch's progress is given at the end of the tabular output.
+
+Examples:
+    juju show-migration-batch 1
+    juju show-migration-batch 1 --format yaml
+
+See also:
+    migrate-models
+`
+
+// Info implements cmd.Command.
+func (c *showMigrationBatchCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-migration-batch",
+		Args:    "<batch-id>",
+		Purpose: "Show the progress of a batch of model migrations.",
+		Doc:     showMigrationBatchDoc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *showMigrationBatchCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatMigrationBatchTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *showMigrationBatchCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("migration batch id not specified")
+	}
+	if len(args) > 1 {
+		return errors.New("too many arguments specified")
+	}
+	c.batchId = args[0]
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *showMigrationBatchCommand) Run(ctx *cmd.Context) error {
+	api, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	status, err := api.MigrationBatchStatus(c.batchId)
+	if err != nil {
+		return err
+	}
+	target := status.TargetControllerUUID
+	if name, err := c.controllerName(target); err == nil {
+		target = name
+	}
+	return errors.Trace(c.out.Write(ctx, makeMigrationBatchStatus(status, target)))
+}
+
+// controllerName returns the name by which the client knows the
+// controller with the given UUID.
+func (c *showMigrationBatchCommand) controllerName(uuid string) (string, error) {
+	controllers, err := c.ClientStore().AllControllers()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	for name, details := range controllers {
+		if details.ControllerUUID == uuid {
+			return name, nil
+		}
+	}
+	return "", errors.NotFoundf("controller %q", uuid)
+}
+
+func (c *showMigrationBatchCommand) getAPI() (migrateModelsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
+// migrationBatchStatus holds the progress of a batch of model
+// migrations for output.
+type migrationBatchStatus struct {
+	Id          string                      `yaml:"id" json:"id"`
+	Target      string                      `yaml:"target-controller" json:"target-controller"`
+	InitiatedBy string                      `yaml:"initiated-by" json:"initiated-by"`
+	Started     time.Time                   `yaml:"started" json:"started"`
+	Concurrency int                         `yaml:"concurrency" json:"concurrency"`
+	Models      []migrationBatchModelStatus `yaml:"models" json:"models"`
+}
+
+type migrationBatchModelStatus struct {
+	Model       string `yaml:"model" json:"model"`
+	Status      string `yaml:"status" json:"status"`
+	MigrationId string `yaml:"migration-id,omitempty" json:"migration-id,omitempty"`
+	Message     string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+func makeMigrationBatchStatus(in controller.MigrationBatchStatus, target string) migrationBatchStatus {
+	out := migrationBatchStatus{
+		Id:          in.Id,
+		Target:      target,
+		InitiatedBy: in.InitiatedBy,
+		Started:     in.StartTime,
+		Concurrency: in.Concurrency,
+	}
+	for _, m := range in.Models {
+		model := migrationBatchModelStatus{
+			Model:       m.Owner + "/" + m.Name,
+			MigrationId: m.MigrationId,
+			Message:     m.Message,
+		}
+		switch {
+		case m.StartError != "":
+			model.Status = "failed"
+			model.Message = m.StartError
+		case m.Phase == "":
+			model.Status = "queued"
+		case m.Phase == coremigration.DONE.String():
+			model.Status = "migrated"
+		case m.Phase == coremigration.ABORTDONE.String():
+			model.Status = "failed"
+		default:
+			model.Status = m.Phase
+		}
+		out.Models = append(out.Models, model)
+	}
+	return out
+}
+
+// formatMigrationBatchTabular writes a table of the models in a
+// migration batch, followed by a summary of the batch's progress.
+func formatMigrationBatchTabular(writer io.Writer, value interface{}) error {
+	status, ok := value.(migrationBatchStatus)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", status, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Model", "Status", "Message")
+	counts := make(map[string]int)
+	for _, m := range status.Models {
+		w.Println(m.Model, m.Status, m.Message)
+		switch m.Status {
+		case "queued", "migrated", "failed":
+			counts[m.Status]++
+		default:
+			counts["in progress"]++
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Trace(err)
+	}
+	_, err := fmt.Fprintf(writer, "\n%d of %d models migrated to %q, %d in progress, %d queued, %d failed\n",
+		counts["migrated"], len(status.Models), status.Target,
+		counts["in progress"], counts["queued"], counts["failed"],
+	)
+	return errors.Trace(err)
+}
@@ -0,0 +1,220 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type MigrateModelsSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   *fakeMigrateModelsAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&MigrateModelsSuite{})
+
+func (s *MigrateModelsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+
+	s.store = jujuclient.NewMemStore()
+	err := s.store.AddController("source", jujuclient.ControllerDetails{
+		ControllerUUID: "eeeeeeee-0bad-400d-8000-4b1d0d06f00d",
+		CACert:         "somecert",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.SetCurrentController("source")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateAccount("source", jujuclient.AccountDetails{
+		User: "sourceuser",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.store.AddController("target", jujuclient.ControllerDetails{
+		ControllerUUID: targetControllerUUID,
+		APIEndpoints:   []string{"1.2.3.4:5"},
+		CACert:         "cert",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateAccount("target", jujuclient.AccountDetails{
+		User:     "targetuser",
+		Password: "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.api = &fakeMigrateModelsAPI{
+		status: controller.MigrationBatchStatus{
+			Id:                   "1",
+			InitiatedBy:          "sourceuser",
+			StartTime:            time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC),
+			TargetControllerUUID: targetControllerUUID,
+			Concurrency:          2,
+			Models: []controller.MigrationBatchModel{{
+				Name:        "one",
+				Owner:       "bob",
+				MigrationId: "uuid1:0",
+				Phase:       "DONE",
+			}, {
+				Name:        "two",
+				Owner:       "bob",
+				MigrationId: "uuid2:0",
+				Phase:       "IMPORT",
+				Message:     "importing",
+			}, {
+				Name:        "three",
+				Owner:       "bob",
+				MigrationId: "uuid3:0",
+				Phase:       "ABORTDONE",
+				Message:     "aborted: target prechecks failed",
+			}, {
+				Name:       "four",
+				Owner:      "bob",
+				StartError: "already in progress",
+			}, {
+				Name:  "five",
+				Owner: "bob",
+			}},
+		},
+	}
+}
+
+func (s *MigrateModelsSuite) makeMigrateModelsCommand() cmd.Command {
+	command := &migrateModelsCommand{api: s.api}
+	command.newAPIRoot = func(jujuclient.ClientStore, string, string) (api.Connection, error) {
+		return nil, nil
+	}
+	command.SetClientStore(s.store)
+	return modelcmd.WrapController(command)
+}
+
+func (s *MigrateModelsSuite) makeShowCommand() cmd.Command {
+	command := &showMigrationBatchCommand{api: s.api}
+	command.SetClientStore(s.store)
+	return modelcmd.WrapController(command)
+}
+
+const expectedBatchTable = `
+Model      Status    Message
+bob/one    migrated  
+bob/two    IMPORT    importing
+bob/three  failed    aborted: target prechecks failed
+bob/four   failed    already in progress
+bob/five   queued    
+
+1 of 5 models migrated to "target", 1 in progress, 1 queued, 2 failed
+`
+
+func (s *MigrateModelsSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "target controller not specified",
+	}, {
+		args: []string{"--all", "target", "extra"},
+		err:  "too many arguments specified",
+	}, {
+		args: []string{"target"},
+		err:  "specify either --all, or --owner and/or --cloud",
+	}, {
+		args: []string{"--all", "--owner", "bob", "target"},
+		err:  "specify either --all, or --owner and/or --cloud",
+	}, {
+		args: []string{"--all", "--concurrency", "0", "target"},
+		err:  "--concurrency must be at least 1, got 0",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, s.makeMigrateModelsCommand(), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	c.Check(s.api.specSeen, gc.IsNil)
+}
+
+func (s *MigrateModelsSuite) TestMigrateModels(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.makeMigrateModelsCommand(),
+		"--owner", "bob", "--cloud", "aws", "--concurrency", "2", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.api.specSeen, jc.DeepEquals, &controller.MigrationBatchSpec{
+		Owner:                "bob",
+		Cloud:                "aws",
+		Concurrency:          2,
+		TargetControllerUUID: targetControllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "targetuser",
+		TargetPassword:       "secret",
+	})
+	c.Check(s.api.idSeen, gc.Equals, "1")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Migration batch \"1\" started\n")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, expectedBatchTable[1:])
+}
+
+func (s *MigrateModelsSuite) TestMigrateModelsAll(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.makeMigrateModelsCommand(), "--all", "target")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.specSeen.Owner, gc.Equals, "")
+	c.Check(s.api.specSeen.Cloud, gc.Equals, "")
+	c.Check(s.api.specSeen.Concurrency, gc.Equals, 1)
+}
+
+func (s *MigrateModelsSuite) TestShowMigrationBatch(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.makeShowCommand(), "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.idSeen, gc.Equals, "1")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, expectedBatchTable[1:])
+}
+
+func (s *MigrateModelsSuite) TestShowMigrationBatchYAML(c *gc.C) {
+	s.api.status.Models = s.api.status.Models[1:2]
+	ctx, err := cmdtesting.RunCommand(c, s.makeShowCommand(), "1", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+id: "1"
+target-controller: target
+initiated-by: sourceuser
+started: 2019-05-01T12:00:00Z
+concurrency: 2
+models:
+- model: bob/two
+  status: IMPORT
+  migration-id: uuid2:0
+  message: importing
+`[1:])
+}
+
+func (s *MigrateModelsSuite) TestShowMigrationBatchInitErrors(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.makeShowCommand())
+	c.Check(err, gc.ErrorMatches, "migration batch id not specified")
+	_, err = cmdtesting.RunCommand(c, s.makeShowCommand(), "1", "2")
+	c.Check(err, gc.ErrorMatches, "too many arguments specified")
+}
+
+type fakeMigrateModelsAPI struct {
+	specSeen *controller.MigrationBatchSpec
+	idSeen   string
+	status   controller.MigrationBatchStatus
+}
+
+func (a *fakeMigrateModelsAPI) InitiateMigrationBatch(spec controller.MigrationBatchSpec) (string, error) {
+	a.specSeen = &spec
+	return "1", nil
+}
+
+func (a *fakeMigrateModelsAPI) MigrationBatchStatus(id string) (controller.MigrationBatchStatus, error) {
+	a.idSeen = id
+	return a.status, nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/output"
+)
+
+// FormatMachinePreviews writes a table describing the instances that would
+// be started for new machines, as returned by the PreviewMachines API call.
+// An error describing any machines that could not be previewed is returned
+// after the table is written.
+func FormatMachinePreviews(writer io.Writer, results []params.MachinePreviewResult) error {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	var errs []string
+	printed := false
+	for _, result := range results {
+		if result.Error != nil {
+			errs = append(errs, result.Error.Error())
+			continue
+		}
+		if !printed {
+			w.Println("Series", "Arch", "Instance type", "Image", "Zone")
+			printed = true
+		}
+		zone := result.AvailabilityZone
+		if zone == "" {
+			zone = "-"
+		}
+		w.Println(result.Series, result.Arch, result.InstanceType, result.ImageId, zone)
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Trace(err)
+	}
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errors.Errorf("cannot preview machine: %s", errs[0])
+	}
+	return errors.Errorf("cannot preview %d machines: %s", len(errs), strings.Join(errs, ", "))
+}
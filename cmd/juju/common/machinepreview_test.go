@@ -0,0 +1,65 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"bytes"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+)
+
+type machinePreviewSuite struct{}
+
+var _ = gc.Suite(&machinePreviewSuite{})
+
+func (s *machinePreviewSuite) TestFormatMachinePreviews(c *gc.C) {
+	var buf bytes.Buffer
+	err := common.FormatMachinePreviews(&buf, []params.MachinePreviewResult{{
+		Series:           "bionic",
+		Arch:             "amd64",
+		InstanceType:     "m5.large",
+		ImageId:          "ami-0123",
+		AvailabilityZone: "us-east-1a",
+	}, {
+		Series:       "bionic",
+		Arch:         "arm64",
+		InstanceType: "a1.large",
+		ImageId:      "ami-4567",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(buf.String(), gc.Equals, ""+
+		"Series  Arch   Instance type  Image     Zone\n"+
+		"bionic  amd64  m5.large       ami-0123  us-east-1a\n"+
+		"bionic  arm64  a1.large       ami-4567  -\n",
+	)
+}
+
+func (s *machinePreviewSuite) TestFormatMachinePreviewsErrors(c *gc.C) {
+	var buf bytes.Buffer
+	err := common.FormatMachinePreviews(&buf, []params.MachinePreviewResult{{
+		Error: &params.Error{Message: "boom"},
+	}})
+	c.Assert(err, gc.ErrorMatches, "cannot preview machine: boom")
+	c.Assert(buf.String(), gc.Equals, "")
+
+	err = common.FormatMachinePreviews(&buf, []params.MachinePreviewResult{{
+		Error: &params.Error{Message: "boom"},
+	}, {
+		Series:       "bionic",
+		Arch:         "amd64",
+		InstanceType: "m5.large",
+		ImageId:      "ami-0123",
+	}, {
+		Error: &params.Error{Message: "bang"},
+	}})
+	c.Assert(err, gc.ErrorMatches, "cannot preview 2 machines: boom, bang")
+	c.Assert(buf.String(), gc.Equals, ""+
+		"Series  Arch   Instance type  Image     Zone\n"+
+		"bionic  amd64  m5.large       ami-0123  -\n",
+	)
+}
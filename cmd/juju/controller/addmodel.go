@@ -99,6 +99,11 @@ to manage multiple clouds, so the only valid cloud is the same cloud
 as the controller model is deployed to. This may change in a future
 release.
 
+Kubernetes models are created in a new namespace named after the model.
+To use a namespace which already exists in the cluster instead, set the
+"namespace" config option. Juju will not delete such a namespace when
+the model is destroyed.
+
 Examples:
 
     juju add-model mymodel
@@ -106,6 +111,7 @@ Examples:
     juju add-model mymodel aws/us-east-1
     juju add-model mymodel --config my-config.yaml --config image-stream=daily
     juju add-model mymodel --credential credential_name --config authorized-keys="ssh-rsa ..."
+    juju add-model mymodel --config namespace=team-a
 `
 
 func (c *addModelCommand) Info() *cmd.Info {
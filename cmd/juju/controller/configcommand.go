@@ -44,6 +44,7 @@ const configCommandHelpDoc = `
 By default, all configuration (keys and values) for the controller are
 displayed if a key is not specified. Supplying one key name returns
 only the value for that key.
+Secret values, such as backup-s3-secret-key, are never displayed.
 
 Supplying key=value will set the supplied key to the supplied value;
 this can be repeated for multiple keys. You can also specify a yaml
@@ -182,6 +183,12 @@ func (c *configCommand) getConfig(client controllerAPI, ctx *cmd.Context) error
 	if err != nil {
 		return err
 	}
+	// Older controllers return the values of secret attributes.
+	for key := range attrs {
+		if controller.SecretAttributes.Contains(key) {
+			delete(attrs, key)
+		}
+	}
 
 	if c.key != "" {
 		if value, found := attrs[c.key]; found {
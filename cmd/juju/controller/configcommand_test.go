@@ -128,6 +128,22 @@ audit-log-exclude-methods  Actual.Size`[1:]
 	c.Assert(output, gc.Equals, expected)
 }
 
+func (s *ConfigSuite) TestSecretValuesOmitted(c *gc.C) {
+	var api fakeControllerAPI
+	api.config = map[string]interface{}{
+		"backup-s3-access-key": "access",
+		"backup-s3-secret-key": "secret",
+	}
+	context, err := s.runWithAPI(c, &api)
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := strings.TrimSpace(cmdtesting.Stdout(context))
+	expected := `
+Attribute             Value
+backup-s3-access-key  access`[1:]
+	c.Assert(output, gc.Equals, expected)
+}
+
 func (s *ConfigSuite) TestAllValuesJSON(c *gc.C) {
 	context, err := s.run(c, "--format=json")
 	c.Assert(err, jc.ErrorIsNil)
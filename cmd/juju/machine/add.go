@@ -58,6 +58,30 @@ and bringing it under Juju's management; currently this requires that the
 machine be running Ubuntu, that it be accessible via SSH, and be running on
 the same network as the API server.
 
+Many machines can be manually provisioned at once from an inventory file,
+given with --inventory. The file lists the hosts to enlist, each with an
+optional login user, SSH private key, expected series and constraints:
+
+    hosts:
+      - host: 10.10.0.3
+        user: admin
+        key: ~/.ssh/enlist_rsa
+        series: bionic
+        constraints: tags=db
+      - host: ubuntu@10.10.0.4
+
+Hosts are provisioned in parallel, at most --concurrency at a time, so the
+login user must be able to use sudo without a password. Hosts that have
+already been provisioned are skipped, so the command may be run again after
+adding hosts to the inventory. The --series and --constraints options apply
+to hosts that do not specify their own.
+
+With --dry-run, the instance type, image and availability zone that would be
+used for each new machine are shown, and nothing is added or started. The
+provider's instance types are matched against the model and command line
+constraints, and its image metadata is looked up, just as when the machine
+is provisioned.
+
 It is possible to override or augment constraints by passing provider-specific
 "placement directives" as an argument; these give the provider additional
 information about how to allocate the machine. For example, one can direct the
@@ -70,8 +94,12 @@ Examples:
    juju add-machine lxd -n 2             (starts 2 new machines with an lxd container)
    juju add-machine lxd:4                (starts a new lxd container on machine 4)
    juju add-machine --constraints mem=8G (starts a machine with at least 8GB RAM)
+   juju add-machine --constraints mem=8G --dry-run
+                                         (shows the instance that would be started)
    juju add-machine ssh:user@10.10.0.3   (manually provisions machine with ssh)
    juju add-machine winrm:user@10.10.0.3 (manually provisions machine with winrm)
+   juju add-machine --inventory hosts.yaml --concurrency 10
+                                         (manually provisions the machines in hosts.yaml)
    juju add-machine zone=us-east-1a      (start a machine in zone us-east-1a on AWS)
    juju add-machine maas2.name           (acquire machine maas2.name on MAAS)
 
@@ -115,6 +143,14 @@ type addCommand struct {
 	NumMachines int
 	// Disks describes disks that are to be attached to the machine.
 	Disks []storage.Constraints
+	// InventoryFile is the path to a file listing hosts to manually provision.
+	InventoryFile string
+	// Concurrency is the maximum number of hosts from the inventory file
+	// provisioned at once.
+	Concurrency int
+	// DryRun is used to specify that the instances that would be started
+	// should be shown, without adding any machines.
+	DryRun bool
 }
 
 func (c *addCommand) Info() *cmd.Info {
@@ -132,6 +168,9 @@ func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumMachines, "n", 1, "The number of machines to add")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Additional machine constraints")
 	f.Var(disksFlag{&c.Disks}, "disks", "Constraints for disks to attach to the machine")
+	f.StringVar(&c.InventoryFile, "inventory", "", "Manually provision the hosts listed in this file")
+	f.IntVar(&c.Concurrency, "concurrency", 5, "The maximum number of hosts from the inventory provisioned at once")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Show the instance type, image and zone that would be used, without adding a machine")
 }
 
 func (c *addCommand) Init(args []string) error {
@@ -153,6 +192,28 @@ func (c *addCommand) Init(args []string) error {
 	if c.NumMachines > 1 && c.Placement != nil && c.Placement.Directive != "" {
 		return errors.New("cannot use -n when specifying a placement directive")
 	}
+	if c.InventoryFile != "" {
+		if c.Placement != nil {
+			return errors.New("cannot use --inventory when specifying a placement directive")
+		}
+		if c.NumMachines > 1 {
+			return errors.New("cannot use -n with --inventory")
+		}
+		if len(c.Disks) > 0 {
+			return errors.New("cannot use --disks with --inventory")
+		}
+	}
+	if c.Concurrency < 1 {
+		return errors.Errorf("--concurrency must be at least 1, got %d", c.Concurrency)
+	}
+	if c.DryRun {
+		if c.InventoryFile != "" {
+			return errors.New("cannot use --dry-run with --inventory")
+		}
+		if c.Placement != nil && (c.Placement.Scope == sshScope || c.Placement.Scope == winrmScope) {
+			return errors.New("cannot use --dry-run when manually provisioning a machine")
+		}
+	}
 	return nil
 }
 
@@ -172,6 +233,7 @@ type ModelConfigAPI interface {
 
 type MachineManagerAPI interface {
 	AddMachines([]params.AddMachineParams) ([]params.AddMachinesResult, error)
+	PreviewMachines([]params.AddMachineParams) ([]params.MachinePreviewResult, error)
 	BestAPIVersion() int
 	Close() error
 }
@@ -261,6 +323,10 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
+	if c.InventoryFile != "" {
+		return c.enlistInventory(client, config, ctx)
+	}
+
 	if c.Placement != nil {
 		err := c.tryManualProvision(client, config, ctx)
 		if err != errNonManualScope {
@@ -296,6 +362,21 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 		machines[i] = machineParams
 	}
 
+	if c.DryRun {
+		if machineManager == nil {
+			machineManager, err = c.getMachineManagerAPI()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			defer machineManager.Close()
+		}
+		previews, err := machineManager.PreviewMachines(machines)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return common.FormatMachinePreviews(ctx.Stdout, previews)
+	}
+
 	var results []params.AddMachinesResult
 	// If storage is specified, we attempt to use a new API on the application facade.
 	if len(c.Disks) > 0 {
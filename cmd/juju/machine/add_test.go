@@ -90,6 +90,28 @@ func (s *AddMachineSuite) TestInit(c *gc.C) {
 			args:      []string{"something:special"},
 			count:     1,
 			placement: "something:special",
+		}, {
+			args:  []string{"--inventory", "hosts.yaml"},
+			count: 1,
+		}, {
+			args:        []string{"--inventory", "hosts.yaml", "ssh:10.10.0.3"},
+			errorString: "cannot use --inventory when specifying a placement directive",
+		}, {
+			args:        []string{"--inventory", "hosts.yaml", "-n", "2"},
+			errorString: "cannot use -n with --inventory",
+		}, {
+			args:        []string{"--inventory", "hosts.yaml", "--concurrency", "0"},
+			errorString: "--concurrency must be at least 1, got 0",
+		}, {
+			args:      []string{"--dry-run", "zone=us-east-1a"},
+			count:     1,
+			placement: "model-uuid:zone=us-east-1a",
+		}, {
+			args:        []string{"--dry-run", "--inventory", "hosts.yaml"},
+			errorString: "cannot use --dry-run with --inventory",
+		}, {
+			args:        []string{"--dry-run", "ssh:user@10.10.0.3"},
+			errorString: "cannot use --dry-run when manually provisioning a machine",
 		},
 	} {
 		c.Logf("test %d", i)
@@ -206,6 +228,45 @@ func (s *AddMachineSuite) TestAddMachineWithDisks(c *gc.C) {
 	})
 }
 
+func (s *AddMachineSuite) TestAddMachineDryRun(c *gc.C) {
+	s.fakeMachineManager.previews = []params.MachinePreviewResult{{
+		Series:           "special",
+		Arch:             "amd64",
+		InstanceType:     "m5.large",
+		ImageId:          "ami-0123",
+		AvailabilityZone: "nz",
+	}, {
+		Series:           "special",
+		Arch:             "amd64",
+		InstanceType:     "m5.large",
+		ImageId:          "ami-0123",
+		AvailabilityZone: "nz",
+	}}
+	context, err := s.run(c, "--dry-run", "-n", "2", "--constraints", "mem=8G", "--series=special")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
+		"Series   Arch   Instance type  Image     Zone\n"+
+		"special  amd64  m5.large       ami-0123  nz\n"+
+		"special  amd64  m5.large       ami-0123  nz\n",
+	)
+	c.Assert(cmdtesting.Stderr(context), gc.Equals, "")
+	c.Assert(s.fakeAddMachine.args, gc.HasLen, 0)
+	c.Assert(s.fakeMachineManager.args, gc.HasLen, 0)
+	c.Assert(s.fakeMachineManager.previewArgs, gc.HasLen, 2)
+	param := s.fakeMachineManager.previewArgs[0]
+	c.Assert(param.Series, gc.Equals, "special")
+	c.Assert(param.Constraints.String(), gc.Equals, "mem=8192M")
+}
+
+func (s *AddMachineSuite) TestAddMachineDryRunError(c *gc.C) {
+	s.fakeMachineManager.previews = []params.MachinePreviewResult{{
+		Error: &params.Error{Message: "previewing containers not supported"},
+	}}
+	_, err := s.run(c, "--dry-run", "lxd")
+	c.Assert(err, gc.ErrorMatches, "cannot preview machine: previewing containers not supported")
+	c.Assert(s.fakeAddMachine.args, gc.HasLen, 0)
+}
+
 func (s *AddMachineSuite) TestAddMachineWithDisksUnsupported(c *gc.C) {
 	_, err := s.run(c, "--disks", "2,1G", "--disks", "2G")
 	c.Assert(err, gc.ErrorMatches, "cannot add machines with disks: not supported by the API server")
@@ -279,6 +340,14 @@ func (f *fakeAddMachineAPI) ModelGet() (map[string]interface{}, error) {
 type fakeMachineManagerAPI struct {
 	apiVersion int
 	fakeAddMachineAPI
+
+	previewArgs []params.AddMachineParams
+	previews    []params.MachinePreviewResult
+}
+
+func (f *fakeMachineManagerAPI) PreviewMachines(args []params.AddMachineParams) ([]params.MachinePreviewResult, error) {
+	f.previewArgs = append(f.previewArgs, args...)
+	return f.previews, nil
 }
 
 func (f *fakeMachineManagerAPI) BestAPIVersion() int {
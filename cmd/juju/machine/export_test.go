@@ -14,7 +14,9 @@ import (
 )
 
 var (
-	SSHProvisioner = &sshProvisioner
+	SSHProvisioner       = &sshProvisioner
+	ReleaseManualMachine = &releaseManualMachine
+	ReleaseWaitStrategy  = &releaseWaitStrategy
 )
 
 type AddCommand struct {
@@ -51,15 +53,24 @@ type RemoveCommand struct {
 }
 
 // NewRemoveCommand returns an RemoveCommand with the api provided as specified.
-func NewRemoveCommandForTest(apiRoot api.Connection, machineAPI RemoveMachineAPI) (cmd.Command, *RemoveCommand) {
+func NewRemoveCommandForTest(apiRoot api.Connection, machineAPI RemoveMachineAPI, statusAPI statusAPI) (cmd.Command, *RemoveCommand) {
 	command := &removeCommand{
 		apiRoot:    apiRoot,
 		machineAPI: machineAPI,
+		statusAPI:  statusAPI,
 	}
 	command.SetClientStore(jujuclienttesting.MinimalStore())
 	return modelcmd.Wrap(command), &RemoveCommand{command}
 }
 
+// NewReplaceCommandForTest returns a replace-machine command with the api
+// provided as specified.
+func NewReplaceCommandForTest(api ReplaceMachineAPI) cmd.Command {
+	command := &replaceCommand{api: api}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(command)
+}
+
 // NewUpgradeSeriesCommand returns an upgrade series command for test
 func NewUpgradeSeriesCommandForTest(upgradeAPI UpgradeMachineSeriesAPI) cmd.Command {
 	command := &upgradeSeriesCommand{
@@ -0,0 +1,168 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/juju/cmd"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/manual"
+)
+
+// inventoryHost describes a host to be enlisted, as read from an
+// inventory file.
+type inventoryHost struct {
+	Host        string `yaml:"host"`
+	User        string `yaml:"user,omitempty"`
+	Key         string `yaml:"key,omitempty"`
+	Series      string `yaml:"series,omitempty"`
+	Constraints string `yaml:"constraints,omitempty"`
+}
+
+// readInventory reads and validates the hosts in the inventory file at the
+// input path. Relative key paths are resolved against the directory of the
+// inventory file.
+func readInventory(path string) ([]inventoryHost, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var inventory struct {
+		Hosts []inventoryHost `yaml:"hosts"`
+	}
+	if err := yaml.Unmarshal(data, &inventory); err != nil {
+		return nil, errors.Annotatef(err, "parsing inventory %q", path)
+	}
+	if len(inventory.Hosts) == 0 {
+		return nil, errors.Errorf("inventory %q contains no hosts", path)
+	}
+
+	seen := set.NewStrings()
+	for i, h := range inventory.Hosts {
+		if h.User == "" {
+			h.User, h.Host = splitUserHost(h.Host)
+		}
+		if h.Host == "" {
+			return nil, errors.Errorf("inventory entry %d has no host", i+1)
+		}
+		if seen.Contains(h.Host) {
+			return nil, errors.Errorf("host %q is listed more than once", h.Host)
+		}
+		seen.Add(h.Host)
+
+		if h.Constraints != "" {
+			if _, err := constraints.Parse(h.Constraints); err != nil {
+				return nil, errors.Annotatef(err, "host %q", h.Host)
+			}
+		}
+		if h.Key != "" {
+			if h.Key, err = utils.NormalizePath(h.Key); err != nil {
+				return nil, errors.Annotatef(err, "host %q", h.Host)
+			}
+			if !filepath.IsAbs(h.Key) {
+				h.Key = filepath.Join(filepath.Dir(path), h.Key)
+			}
+		}
+		inventory.Hosts[i] = h
+	}
+	return inventory.Hosts, nil
+}
+
+// enlistResult records the outcome of enlisting a single host.
+type enlistResult struct {
+	host      string
+	machineId string
+	err       error
+}
+
+// enlistInventory manually provisions each of the hosts in the inventory
+// file, with at most c.Concurrency in progress at once.
+// Hosts that are already provisioned are skipped, so that the command can
+// be re-run to enlist hosts added to the inventory since.
+func (c *addCommand) enlistInventory(client AddMachineAPI, config *config.Config, ctx *cmd.Context) error {
+	hosts, err := readInventory(ctx.AbsPath(c.InventoryFile))
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	authKeys, err := common.ReadAuthorizedKeys(ctx, "")
+	if err != nil {
+		return errors.Annotatef(err, "cannot reading authorized-keys")
+	}
+
+	results := make([]enlistResult, len(hosts))
+	sem := make(chan struct{}, c.Concurrency)
+	var wg sync.WaitGroup
+	for i, h := range hosts {
+		series := h.Series
+		if series == "" {
+			series = c.Series
+		}
+		cons := c.Constraints
+		if h.Constraints != "" {
+			cons = constraints.MustParse(h.Constraints)
+		}
+
+		// Hosts are provisioned concurrently, so there can be no prompting
+		// for passwords; progress output would only be interleaved.
+		args := manual.ProvisionMachineArgs{
+			Host:           h.Host,
+			User:           h.User,
+			PrivateKey:     h.Key,
+			Series:         series,
+			Constraints:    cons,
+			Client:         client,
+			Stdin:          strings.NewReader(""),
+			Stdout:         ioutil.Discard,
+			Stderr:         ioutil.Discard,
+			AuthorizedKeys: authKeys,
+			UpdateBehavior: &params.UpdateBehavior{
+				EnableOSRefreshUpdate: config.EnableOSRefreshUpdate(),
+				EnableOSUpgrade:       config.EnableOSUpgrade(),
+			},
+		}
+
+		wg.Add(1)
+		go func(i int, args manual.ProvisionMachineArgs) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			ctx.Verbosef("enlisting host %s", args.Host)
+			machineId, err := sshProvisioner(args)
+			results[i] = enlistResult{host: args.Host, machineId: machineId, err: err}
+		}(i, args)
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, result := range results {
+		switch {
+		case result.err == nil:
+			ctx.Infof("created machine %v for host %s", result.machineId, result.host)
+		case errors.Cause(result.err) == manual.ErrProvisioned:
+			ctx.Infof("skipped host %s: already provisioned", result.host)
+		default:
+			failed++
+			fmt.Fprintf(ctx.Stderr, "failed to enlist host %s: %v\n", result.host, result.err)
+		}
+	}
+	if failed > 0 {
+		return errors.Errorf("failed to enlist %d of %d hosts", failed, len(hosts))
+	}
+	return nil
+}
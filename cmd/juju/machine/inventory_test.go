@@ -0,0 +1,143 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs/manual"
+)
+
+func (s *AddMachineSuite) writeInventory(c *gc.C, content string) (string, string) {
+	dir := c.MkDir()
+	path := filepath.Join(dir, "hosts.yaml")
+	err := ioutil.WriteFile(path, []byte(content), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	return dir, path
+}
+
+func (s *AddMachineSuite) TestInventory(c *gc.C) {
+	dir, path := s.writeInventory(c, `
+hosts:
+  - host: 10.0.0.1
+    user: admin
+    key: keys/id_rsa
+    series: bionic
+    constraints: tags=db
+  - host: ubuntu@10.0.0.2
+  - host: 10.0.0.3
+`[1:])
+
+	var mu sync.Mutex
+	provisioned := make(map[string]manual.ProvisionMachineArgs)
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		provisioned[args.Host] = args
+		switch args.Host {
+		case "10.0.0.1":
+			return "1", nil
+		case "10.0.0.2":
+			return "", manual.ErrProvisioned
+		}
+		return "", errors.New("no route to host")
+	})
+
+	ctx, err := s.run(c, "--inventory", path, "--constraints", "mem=8G")
+	c.Assert(err, gc.ErrorMatches, "failed to enlist 1 of 3 hosts")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, ""+
+		"created machine 1 for host 10.0.0.1\n"+
+		"skipped host 10.0.0.2: already provisioned\n"+
+		"failed to enlist host 10.0.0.3: no route to host\n",
+	)
+
+	c.Assert(provisioned, gc.HasLen, 3)
+	args := provisioned["10.0.0.1"]
+	c.Check(args.User, gc.Equals, "admin")
+	c.Check(args.PrivateKey, gc.Equals, filepath.Join(dir, "keys", "id_rsa"))
+	c.Check(args.Series, gc.Equals, "bionic")
+	c.Check(args.Constraints, jc.DeepEquals, constraints.MustParse("tags=db"))
+
+	args = provisioned["10.0.0.2"]
+	c.Check(args.User, gc.Equals, "ubuntu")
+	c.Check(args.PrivateKey, gc.Equals, "")
+	c.Check(args.Constraints, jc.DeepEquals, constraints.MustParse("mem=8G"))
+}
+
+func (s *AddMachineSuite) TestInventoryConcurrency(c *gc.C) {
+	_, path := s.writeInventory(c, `
+hosts:
+  - host: 10.0.0.1
+  - host: 10.0.0.2
+  - host: 10.0.0.3
+  - host: 10.0.0.4
+  - host: 10.0.0.5
+`[1:])
+
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+	var hosts []string
+	s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		hosts = append(hosts, args.Host)
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		return "0", nil
+	})
+
+	_, err := s.run(c, "--inventory", path, "--concurrency", "2")
+	c.Assert(err, jc.ErrorIsNil)
+
+	sort.Strings(hosts)
+	c.Check(hosts, jc.DeepEquals, []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4", "10.0.0.5"})
+	c.Check(maxInFlight <= 2, jc.IsTrue)
+}
+
+func (s *AddMachineSuite) TestInventoryInvalid(c *gc.C) {
+	for i, test := range []struct {
+		content string
+		err     string
+	}{{
+		content: "hosts: []\n",
+		err:     `inventory ".*" contains no hosts`,
+	}, {
+		content: "hosts:\n  - user: admin\n",
+		err:     "inventory entry 1 has no host",
+	}, {
+		content: "hosts:\n  - host: 10.0.0.1\n  - host: admin@10.0.0.1\n",
+		err:     `host "10.0.0.1" is listed more than once`,
+	}, {
+		content: "hosts:\n  - host: 10.0.0.1\n    constraints: bad=1\n",
+		err:     `host "10.0.0.1": unknown constraint "bad"`,
+	}} {
+		c.Logf("test %d", i)
+		_, path := s.writeInventory(c, test.content)
+		s.PatchValue(machine.SSHProvisioner, func(args manual.ProvisionMachineArgs) (string, error) {
+			c.Fatalf("unexpected provisioning of %q", args.Host)
+			return "", nil
+		})
+		_, err := s.run(c, "--inventory", path)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
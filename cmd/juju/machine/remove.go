@@ -4,11 +4,14 @@
 package machine
 
 import (
+	"strings"
 	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
@@ -17,6 +20,22 @@ import (
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs/manual"
+	"github.com/juju/juju/environs/manual/sshprovisioner"
+)
+
+var (
+	// releaseManualMachine removes everything Juju left behind on a
+	// manually provisioned host.
+	releaseManualMachine = sshprovisioner.ReleaseMachine
+
+	// releaseWaitStrategy controls how long remove-machine waits for
+	// manually provisioned machines to be removed from the model before
+	// releasing their hosts.
+	releaseWaitStrategy = utils.AttemptStrategy{
+		Total: 10 * time.Minute,
+		Delay: 5 * time.Second,
+	}
 )
 
 // NewRemoveCommand returns a command used to remove a specified machine.
@@ -29,6 +48,7 @@ type removeCommand struct {
 	baseMachinesCommand
 	apiRoot      api.Connection
 	machineAPI   RemoveMachineAPI
+	statusAPI    statusAPI
 	MachineIds   []string
 	Force        bool
 	KeepInstance bool
@@ -45,6 +65,16 @@ the corresponding cloud instnace by using --keep-instance option.
 
 Machines responsible for the model cannot be removed.
 
+When a manually provisioned machine is removed, Juju waits up to 10 minutes
+for the machine to leave the model and then connects to its host over SSH,
+as the ubuntu user, to remove the machine agent, its services, any LXD
+containers created by Juju for the model and the Juju data directory. The
+host is then checked to ensure that nothing was left behind, so that it can
+be enlisted again with ` + "`juju add-machine ssh:<host>`" + `. If the machine
+hasn't left the model by then, or hasn't left it straight away when
+--no-wait is used, its host is not released. Use --keep-instance to leave
+the host untouched.
+
 Machines running units or containers can be removed using the '--force'
 option; this will also remove those units and containers without giving
 them an opportunity to shut down cleanly.
@@ -181,6 +211,20 @@ func (c *removeCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	// Manually provisioned hosts are released once their machines have
+	// been removed, unless the instances are being kept.
+	var statusClient statusAPI
+	var manualHosts map[string]manualHost
+	if !c.KeepInstance {
+		if statusClient, err = c.getStatusAPI(); err != nil {
+			return errors.Trace(err)
+		}
+		defer statusClient.Close()
+		if manualHosts, err = c.manualMachineHosts(statusClient); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	var results []params.DestroyMachineResult
 
 	if c.KeepInstance || c.Force {
@@ -231,8 +275,107 @@ func (c *removeCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
+	if len(manualHosts) > 0 {
+		root, err := c.getAPIRoot()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		modelTag, ok := root.ModelTag()
+		if !ok {
+			return errors.New("API connection is not for a model")
+		}
+		if !c.releaseManualHosts(ctx, statusClient, modelTag.Id(), manualHosts) {
+			anyFailed = true
+		}
+	}
+
 	if anyFailed {
 		return cmd.ErrSilent
 	}
 	return nil
 }
+
+func (c *removeCommand) getStatusAPI() (statusAPI, error) {
+	if c.statusAPI != nil {
+		return c.statusAPI, nil
+	}
+	return c.NewAPIClient()
+}
+
+// manualHost identifies the host of a manually provisioned machine.
+type manualHost struct {
+	host   string
+	series string
+}
+
+// manualMachineHosts returns the hosts of the manually provisioned machines
+// being removed, keyed by machine id.
+func (c *removeCommand) manualMachineHosts(client statusAPI) (map[string]manualHost, error) {
+	status, err := client.Status(nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting machine status")
+	}
+	hosts := make(map[string]manualHost)
+	for _, id := range c.MachineIds {
+		m, ok := status.Machines[id]
+		if !ok || !strings.HasPrefix(string(m.InstanceId), manual.ManualInstancePrefix) {
+			continue
+		}
+		hosts[id] = manualHost{
+			host:   strings.TrimPrefix(string(m.InstanceId), manual.ManualInstancePrefix),
+			series: m.Series,
+		}
+	}
+	return hosts, nil
+}
+
+// releaseManualHosts waits for the input manually provisioned machines to be
+// removed from the model, then releases their hosts. With --no-wait, the
+// machines are checked only once. It returns false if any host could not be
+// released.
+func (c *removeCommand) releaseManualHosts(ctx *cmd.Context, client statusAPI, modelUUID string, hosts map[string]manualHost) bool {
+	remaining := set.NewStrings()
+	for id := range hosts {
+		remaining.Add(id)
+	}
+	ids := remaining.SortedValues()
+
+	strategy := releaseWaitStrategy
+	if c.NoWait {
+		strategy = utils.AttemptStrategy{}
+	}
+	for a := strategy.Start(); a.Next(); {
+		status, err := client.Status(nil)
+		if err != nil {
+			logger.Warningf("getting machine status: %v", err)
+			continue
+		}
+		remaining = set.NewStrings()
+		for _, id := range ids {
+			if _, ok := status.Machines[id]; ok {
+				remaining.Add(id)
+			}
+		}
+		if remaining.IsEmpty() {
+			break
+		}
+		ctx.Verbosef("waiting for machines %s to be removed", strings.Join(remaining.SortedValues(), ", "))
+	}
+
+	released := true
+	for _, id := range ids {
+		h := hosts[id]
+		if remaining.Contains(id) {
+			released = false
+			ctx.Infof("releasing host %s failed: machine %s was not removed", h.host, id)
+			continue
+		}
+		if err := releaseManualMachine(h.host, h.series, modelUUID); err != nil {
+			released = false
+			ctx.Infof("releasing host %s failed: %v", h.host, err)
+			continue
+		}
+		ctx.Infof("released host %s", h.host)
+	}
+	return released
+}
@@ -4,24 +4,32 @@
 package machine_test
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/testing"
 )
 
 type RemoveMachineSuite struct {
 	testing.FakeJujuXDGDataHomeSuite
 	fake          *fakeRemoveMachineAPI
+	status        *fakeRemoveStatusAPI
 	apiConnection *mockAPIConnection
+	released      []string
+	releasedModel string
 }
 
 var _ = gc.Suite(&RemoveMachineSuite{})
@@ -29,13 +37,22 @@ var _ = gc.Suite(&RemoveMachineSuite{})
 func (s *RemoveMachineSuite) SetUpTest(c *gc.C) {
 	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
 	s.fake = &fakeRemoveMachineAPI{}
+	s.status = &fakeRemoveStatusAPI{}
+	s.released = nil
+	s.releasedModel = ""
+	s.PatchValue(machine.ReleaseManualMachine, func(host, series, modelUUID string) error {
+		s.releasedModel = modelUUID
+		s.released = append(s.released, host+":"+series)
+		return nil
+	})
+	s.PatchValue(machine.ReleaseWaitStrategy, utils.AttemptStrategy{Min: 3})
 	s.apiConnection = &mockAPIConnection{
 		bestFacadeVersion: 4,
 	}
 }
 
 func (s *RemoveMachineSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
-	remove, _ := machine.NewRemoveCommandForTest(s.apiConnection, s.fake)
+	remove, _ := machine.NewRemoveCommandForTest(s.apiConnection, s.fake, s.status)
 	return cmdtesting.RunCommand(c, remove, args...)
 }
 
@@ -76,7 +93,7 @@ func (s *RemoveMachineSuite) TestInit(c *gc.C) {
 		},
 	} {
 		c.Logf("test %d", i)
-		wrappedCommand, removeCmd := machine.NewRemoveCommandForTest(s.apiConnection, s.fake)
+		wrappedCommand, removeCmd := machine.NewRemoveCommandForTest(s.apiConnection, s.fake, s.status)
 		err := cmdtesting.InitCommand(wrappedCommand, test.args)
 		if test.errorString == "" {
 			c.Check(err, jc.ErrorIsNil)
@@ -171,6 +188,125 @@ func (s *RemoveMachineSuite) TestOldFacadeRemoveKeep(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "this version of Juju doesn't support --keep-instance")
 }
 
+func (s *RemoveMachineSuite) setManualMachines(ids ...string) {
+	machines := make(map[string]params.MachineStatus)
+	for i, id := range ids {
+		machines[id] = params.MachineStatus{
+			Id:         id,
+			InstanceId: instance.Id(fmt.Sprintf("manual:10.0.0.%d", i+1)),
+			Series:     "bionic",
+		}
+	}
+	machines["3"] = params.MachineStatus{Id: "3", InstanceId: "i-deadbeef", Series: "bionic"}
+	s.status.results = []*params.FullStatus{{Machines: machines}}
+}
+
+func (s *RemoveMachineSuite) TestRemoveReleasesManualMachines(c *gc.C) {
+	s.setManualMachines("1", "2")
+	// The machines are removed from the model on the second status call.
+	s.status.results = append(s.status.results, s.status.results[0], &params.FullStatus{})
+	ctx, err := s.run(c, "1", "2", "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.released, jc.DeepEquals, []string{"10.0.0.1:bionic", "10.0.0.2:bionic"})
+	c.Assert(s.releasedModel, gc.Equals, testing.ModelTag.Id())
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing machine 1
+removing machine 2
+removing machine 3
+released host 10.0.0.1
+released host 10.0.0.2
+`[1:])
+}
+
+func (s *RemoveMachineSuite) TestRemoveKeepDoesNotReleaseManualMachines(c *gc.C) {
+	s.setManualMachines("1")
+	_, err := s.run(c, "--keep-instance", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.status.calls, gc.Equals, 0)
+	c.Assert(s.released, gc.HasLen, 0)
+}
+
+func (s *RemoveMachineSuite) TestRemoveManualMachineNotRemoved(c *gc.C) {
+	s.setManualMachines("1")
+	ctx, err := s.run(c, "1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(s.released, gc.HasLen, 0)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing machine 1
+releasing host 10.0.0.1 failed: machine 1 was not removed
+`[1:])
+}
+
+func (s *RemoveMachineSuite) TestRemoveForceNoWaitManualMachineNotRemoved(c *gc.C) {
+	s.setManualMachines("1")
+	ctx, err := s.run(c, "--force", "--no-wait", "1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	// The machine is checked once, without waiting for it to be removed.
+	c.Assert(s.status.calls, gc.Equals, 2)
+	c.Assert(s.released, gc.HasLen, 0)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing machine 1
+releasing host 10.0.0.1 failed: machine 1 was not removed
+`[1:])
+}
+
+func (s *RemoveMachineSuite) TestRemoveForceNoWaitReleasesManualMachine(c *gc.C) {
+	s.setManualMachines("1")
+	s.status.results = append(s.status.results, &params.FullStatus{})
+	_, err := s.run(c, "--force", "--no-wait", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.status.calls, gc.Equals, 2)
+	c.Assert(s.released, jc.DeepEquals, []string{"10.0.0.1:bionic"})
+}
+
+func (s *RemoveMachineSuite) TestRemoveManualMachineReleaseFailed(c *gc.C) {
+	s.setManualMachines("1")
+	s.status.results = append(s.status.results, &params.FullStatus{})
+	s.PatchValue(machine.ReleaseManualMachine, func(host, series, modelUUID string) error {
+		return errors.Errorf("host %q is not clean after release: jujud is running", host)
+	})
+	ctx, err := s.run(c, "1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing machine 1
+releasing host 10.0.0.1 failed: host "10.0.0.1" is not clean after release: jujud is running
+`[1:])
+}
+
+func (s *RemoveMachineSuite) TestRemoveManualMachineFailedNotReleased(c *gc.C) {
+	s.setManualMachines("1")
+	s.status.results = append(s.status.results, &params.FullStatus{})
+	s.fake.results = []params.DestroyMachineResult{{
+		Error: &params.Error{Message: "machine 1 has unit \"foo/0\" assigned"},
+	}}
+	_, err := s.run(c, "1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(s.released, gc.HasLen, 0)
+}
+
+// fakeRemoveStatusAPI returns each of its results in turn, repeating the
+// last one once they are exhausted.
+type fakeRemoveStatusAPI struct {
+	calls   int
+	results []*params.FullStatus
+}
+
+func (f *fakeRemoveStatusAPI) Status(pattern []string) (*params.FullStatus, error) {
+	f.calls++
+	if len(f.results) == 0 {
+		return &params.FullStatus{}, nil
+	}
+	result := f.results[0]
+	if len(f.results) > 1 {
+		f.results = f.results[1:]
+	}
+	return result, nil
+}
+
+func (f *fakeRemoveStatusAPI) Close() error {
+	return nil
+}
+
 type fakeRemoveMachineAPI struct {
 	forced      bool
 	keep        bool
@@ -214,3 +350,7 @@ type mockAPIConnection struct {
 func (m *mockAPIConnection) BestFacadeVersion(name string) int {
 	return m.bestFacadeVersion
 }
+
+func (m *mockAPIConnection) ModelTag() (names.ModelTag, bool) {
+	return testing.ModelTag, true
+}
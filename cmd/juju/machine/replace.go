@@ -0,0 +1,148 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewReplaceCommand returns a command used to replace machines.
+func NewReplaceCommand() cmd.Command {
+	return modelcmd.Wrap(&replaceCommand{})
+}
+
+// replaceCommand replaces existing machines with new ones, moving their
+// units across.
+type replaceCommand struct {
+	baseMachinesCommand
+	api        ReplaceMachineAPI
+	MachineIds []string
+}
+
+// ReplaceMachineAPI defines the API methods used by the replace-machine
+// command.
+type ReplaceMachineAPI interface {
+	ReplaceMachines(machines ...string) ([]params.ReplaceMachineResult, error)
+	Close() error
+}
+
+const replaceMachineDoc = `
+Each machine is replaced by a new machine with the same series, constraints
+and placement, which is then provisioned as usual. The machine's units are
+moved to the new machine and keep their names and storage, but are deployed
+afresh there, so their install and relation hooks are run again.
+
+Storage attached to the units is detached from the old machine and attached
+to the new one by the storage provisioner, so it must be detachable. Once its
+units have been moved, the old machine is removed and its cloud instance is
+stopped.
+
+Controller machines, manually provisioned machines and machines hosting
+containers cannot be replaced.
+
+Machines are specified by their numbers, which may be retrieved from the
+output of ` + "`juju status`." + `
+
+Examples:
+
+    juju replace-machine 3
+    juju replace-machine 3 4/lxd/0
+
+See also:
+    add-machine
+    remove-machine
+    storage
+`
+
+// Info implements Command.Info.
+func (c *replaceCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "replace-machine",
+		Args:    "<machine number> ...",
+		Purpose: "Replaces machines with new ones, moving their units.",
+		Doc:     replaceMachineDoc,
+	})
+}
+
+// Init implements Command.Init.
+func (c *replaceCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no machines specified")
+	}
+	for _, id := range args {
+		if !names.IsValidMachine(id) {
+			return errors.Errorf("invalid machine id %q", id)
+		}
+	}
+	c.MachineIds = args
+	return nil
+}
+
+func (c *replaceCommand) getAPI() (ReplaceMachineAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *replaceCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	results, err := client.ReplaceMachines(c.MachineIds...)
+	if err := block.ProcessBlockedError(err, block.BlockRemove); err != nil {
+		return err
+	}
+
+	anyFailed := false
+	for i, id := range c.MachineIds {
+		result := results[i]
+		var newId string
+		if result.Machine != "" {
+			tag, err := names.ParseMachineTag(result.Machine)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			newId = tag.Id()
+		}
+		if result.Error != nil {
+			anyFailed = true
+			ctx.Infof("replacing machine %s failed: %s", id, result.Error)
+			if newId != "" {
+				ctx.Infof("- machine %s was added to replace it", newId)
+			}
+			continue
+		}
+		ctx.Infof("replacing machine %s with machine %s", id, newId)
+		for _, entity := range result.Units {
+			unitTag, err := names.ParseUnitTag(entity.Tag)
+			if err != nil {
+				logger.Warningf("%s", err)
+				continue
+			}
+			ctx.Infof("- will move %s", names.ReadableString(unitTag))
+		}
+	}
+
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
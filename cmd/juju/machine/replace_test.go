@@ -0,0 +1,109 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type ReplaceMachineSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake *fakeReplaceMachineAPI
+}
+
+var _ = gc.Suite(&ReplaceMachineSuite{})
+
+func (s *ReplaceMachineSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeReplaceMachineAPI{}
+}
+
+func (s *ReplaceMachineSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, machine.NewReplaceCommandForTest(s.fake), args...)
+}
+
+func (s *ReplaceMachineSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		errorString string
+	}{{
+		errorString: "no machines specified",
+	}, {
+		args:        []string{"lxd"},
+		errorString: `invalid machine id "lxd"`,
+	}, {
+		args: []string{"1", "2/lxd/1"},
+	}} {
+		c.Logf("test %d", i)
+		err := cmdtesting.InitCommand(machine.NewReplaceCommandForTest(s.fake), test.args)
+		if test.errorString == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.errorString)
+		}
+	}
+}
+
+func (s *ReplaceMachineSuite) TestReplace(c *gc.C) {
+	s.fake.results = []params.ReplaceMachineResult{{
+		Machine: "machine-3",
+		Units:   []params.Entity{{"unit-foo-0"}, {"unit-bar-1"}},
+	}, {
+		Machine: "machine-2-lxd-4",
+	}}
+	ctx, err := s.run(c, "1", "2/lxd/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.machines, jc.DeepEquals, []string{"1", "2/lxd/1"})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+replacing machine 1 with machine 3
+- will move unit foo/0
+- will move unit bar/1
+replacing machine 2/lxd/1 with machine 2/lxd/4
+`[1:])
+}
+
+func (s *ReplaceMachineSuite) TestReplaceFailed(c *gc.C) {
+	s.fake.results = []params.ReplaceMachineResult{{
+		Error: &params.Error{Message: "cannot replace machine 0: machine is a controller"},
+	}, {
+		Machine: "machine-3",
+		Error:   &params.Error{Message: `cannot replace machine 1: cannot reassign unit "foo/0" to machine 3: boom`},
+	}}
+	ctx, err := s.run(c, "0", "1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+replacing machine 0 failed: cannot replace machine 0: machine is a controller
+replacing machine 1 failed: cannot replace machine 1: cannot reassign unit "foo/0" to machine 3: boom
+- machine 3 was added to replace it
+`[1:])
+}
+
+func (s *ReplaceMachineSuite) TestBlockedError(c *gc.C) {
+	s.fake.err = common.OperationBlockedError("TestBlockedError")
+	_, err := s.run(c, "1")
+	testing.AssertOperationWasBlocked(c, err, ".*TestBlockedError.*")
+}
+
+type fakeReplaceMachineAPI struct {
+	machines []string
+	results  []params.ReplaceMachineResult
+	err      error
+}
+
+func (f *fakeReplaceMachineAPI) ReplaceMachines(machines ...string) ([]params.ReplaceMachineResult, error) {
+	f.machines = machines
+	return f.results, f.err
+}
+
+func (f *fakeReplaceMachineAPI) Close() error {
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/modelgeneration"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/model"
+)
+
+const (
+	abortSummary = "Aborts a branch in the model."
+	abortDoc     = `
+Aborting a branch discards the changes made under it. Units tracking the
+branch revert to the charm, configuration, constraints and resources of the
+master generation. An aborted branch can not be committed.
+
+Aborted and committed branches are removed from the model once they are older
+than the "max-branch-age" model configuration value.
+
+Examples:
+    juju abort upgrade-postgresql
+
+See also:
+    branch
+    track
+    checkout
+    commit
+    diff
+`
+)
+
+// NewAbortCommand wraps abortCommand with sane model settings.
+func NewAbortCommand() cmd.Command {
+	return modelcmd.Wrap(&abortCommand{})
+}
+
+// abortCommand supplies the "abort" CLI command used to discard changes made
+// under a branch.
+type abortCommand struct {
+	modelcmd.ModelCommandBase
+
+	api AbortCommandAPI
+
+	branchName string
+}
+
+// AbortCommandAPI defines an API interface to be used during testing.
+//go:generate mockgen -package mocks -destination ./mocks/abort_mock.go github.com/juju/juju/cmd/juju/model AbortCommandAPI
+type AbortCommandAPI interface {
+	Close() error
+
+	// AbortBranch aborts the branch with the input name,
+	// completing it without applying any of its changes.
+	AbortBranch(branchName string) error
+}
+
+// Info implements part of the cmd.Command interface.
+func (c *abortCommand) Info() *cmd.Info {
+	info := &cmd.Info{
+		Name:    "abort",
+		Args:    "<branch name>",
+		Purpose: abortSummary,
+		Doc:     abortDoc,
+	}
+	return jujucmd.Info(info)
+}
+
+// SetFlags implements part of the cmd.Command interface.
+func (c *abortCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+}
+
+// Init implements part of the cmd.Command interface.
+func (c *abortCommand) Init(args []string) error {
+	if len(args) != 1 {
+		return errors.Errorf("must specify a branch name to abort")
+	}
+	if args[0] == model.GenerationMaster {
+		return errors.Errorf("cannot abort the %q branch", model.GenerationMaster)
+	}
+	c.branchName = args[0]
+	return nil
+}
+
+// getAPI returns the API. This allows passing in a test AbortCommandAPI
+// implementation.
+func (c *abortCommand) getAPI() (AbortCommandAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	client := modelgeneration.NewClient(api)
+	return client, nil
+}
+
+// Run implements the meaty part of the cmd.Command interface.
+func (c *abortCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	if err := client.AbortBranch(c.branchName); err != nil {
+		return err
+	}
+	msg := fmt.Sprintf("Branch %q aborted\n", c.branchName)
+
+	// If the aborted branch was the active one, set the master as active.
+	activeBranch, err := c.ActiveBranch()
+	if err != nil {
+		return err
+	}
+	if activeBranch == c.branchName {
+		if err = c.SetActiveBranch(model.GenerationMaster); err != nil {
+			return err
+		}
+		msg = msg + fmt.Sprintf("Active branch set to %q\n", model.GenerationMaster)
+	}
+
+	_, err = ctx.Stdout.Write([]byte(msg))
+	return err
+}
@@ -28,7 +28,10 @@ Details displayed include:
 - user who created the branch
 - when it was created
 - configuration changes made under the branch for each application
+- the charm, resource revisions and constraints set under the branch for
+  each application
 - a summary of how many units are tracking the branch
+- the progress of any rollout of units onto the branch in waves
 
 Supplying the --all flag will show units tracking the branch and those still
 tracking "master".
@@ -54,6 +54,16 @@ func (s *diffSuite) TestRunCommandNextGenExists(c *gc.C) {
 					UnitsPending:  []string{"redis/1"},
 				},
 				ConfigChanges: map[string]interface{}{"databases": 8},
+				CharmURL:      "cs:redis-2",
+				Resources:     map[string]int{"bin": 3},
+				Constraints:   "mem=4096M",
+				Rollout: &coremodel.GenerationRollout{
+					Status:      "running",
+					WaveSize:    "1",
+					WaveTimeout: "10m0s",
+					Wave:        1,
+					WaveUnits:   []string{"redis/0"},
+				},
 			}},
 		},
 	}
@@ -75,6 +85,17 @@ new-branch:
       - redis/1
     config:
       databases: 8
+    charm: cs:redis-2
+    resources:
+      bin: 3
+    constraints: mem=4096M
+    rollout:
+      status: running
+      wave-size: "1"
+      wave-timeout: 10m0s
+      wave: 1
+      wave-units:
+      - redis/0
 `[1:])
 }
 
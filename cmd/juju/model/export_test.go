@@ -59,6 +59,22 @@ func NewDumpCommandForTest(api DumpModelAPI, store jujuclient.ClientStore) cmd.C
 	return modelcmd.Wrap(cmd)
 }
 
+// NewExportModelCommandForTest returns an exportModelCommand with the
+// apis provided as specified.
+func NewExportModelCommandForTest(api ExportModelAPI, binariesAPI ModelBinariesAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &exportModelCommand{api: api, binariesAPI: binariesAPI}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewImportModelCommandForTest returns an importModelCommand with the
+// api provided as specified.
+func NewImportModelCommandForTest(api ImportModelAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &importModelCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd)
+}
+
 // NewDumpDBCommandForTest returns a DumpDBCommand with the api provided as specified.
 func NewDumpDBCommandForTest(api DumpDBAPI, store jujuclient.ClientStore) cmd.Command {
 	cmd := &dumpDBCommand{api: api}
@@ -197,6 +213,14 @@ func NewCommitCommandForTest(api CommitCommandAPI, store jujuclient.ClientStore)
 	return modelcmd.Wrap(cmd)
 }
 
+func NewAbortCommandForTest(api AbortCommandAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &abortCommand{
+		api: api,
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 func NewTrackBranchCommandForTest(api TrackBranchCommandAPI, store jujuclient.ClientStore) cmd.Command {
 	cmd := &trackBranchCommand{
 		api: api,
@@ -0,0 +1,186 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"io"
+	"net/url"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/migration"
+	resourceapi "github.com/juju/juju/resource/api"
+)
+
+// NewExportModelCommand returns a fully constructed export-model command.
+func NewExportModelCommand() cmd.Command {
+	return modelcmd.Wrap(&exportModelCommand{})
+}
+
+type exportModelCommand struct {
+	modelcmd.ModelCommandBase
+	api         ExportModelAPI
+	binariesAPI ModelBinariesAPI
+
+	filename string
+}
+
+const exportModelHelpDoc = `
+Exports the model to a self-contained archive file, which can be
+imported into another controller with "juju import-model". The archive
+holds the model's description along with the charms, agent binaries
+and resources the model uses, so the target controller does not need
+to be able to reach this one. This makes it possible to move models
+between air-gapped environments.
+
+Exporting a model does not change it. The model continues to run on
+this controller, and its agents remain connected here.
+
+Only controller and model administrators may export a model.
+
+Examples:
+
+    juju export-model mymodel.tar.gz
+    juju export-model -m othermodel othermodel.tar.gz
+
+See also:
+    import-model
+    migrate
+`
+
+// Info implements Command.
+func (c *exportModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "export-model",
+		Args:    "<filename>",
+		Purpose: "Exports a model to an archive file.",
+		Doc:     exportModelHelpDoc,
+	})
+}
+
+// Init implements Command.
+func (c *exportModelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no filename specified")
+	}
+	c.filename, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// ExportModelAPI specifies the used function calls of the ModelManager.
+type ExportModelAPI interface {
+	Close() error
+	ExportModel(names.ModelTag) (params.SerializedModel, error)
+}
+
+// ModelBinariesAPI specifies the calls used to download the binaries
+// used by a model.
+type ModelBinariesAPI interface {
+	migration.CharmDownloader
+	migration.ToolsDownloader
+	migration.ResourceDownloader
+	ServerVersion() (version.Number, bool)
+	Close() error
+}
+
+func (c *exportModelCommand) getAPI() (ExportModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.ModelCommandBase.NewModelManagerAPIClient()
+}
+
+func (c *exportModelCommand) getBinariesAPI() (ModelBinariesAPI, error) {
+	if c.binariesAPI != nil {
+		return c.binariesAPI, nil
+	}
+	root, err := c.ModelCommandBase.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &modelBinariesClient{Connection: root, client: root.Client()}, nil
+}
+
+// Run implements Command.
+func (c *exportModelCommand) Run(ctx *cmd.Context) error {
+	modelName, modelDetails, err := c.ModelCommandBase.ModelDetails()
+	if err != nil {
+		return errors.Annotate(err, "getting model details")
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	binaries, err := c.getBinariesAPI()
+	if err != nil {
+		return err
+	}
+	defer binaries.Close()
+	controllerVersion, ok := binaries.ServerVersion()
+	if !ok {
+		return errors.New("cannot determine controller version")
+	}
+
+	serialized, err := client.ExportModel(names.NewModelTag(modelDetails.ModelUUID))
+	if err != nil {
+		return err
+	}
+
+	filename := ctx.AbsPath(c.filename)
+	f, err := os.OpenFile(filename, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Annotate(err, "creating model archive")
+	}
+	err = migration.WriteArchive(f, migration.WriteArchiveConfig{
+		Model:                  serialized,
+		ControllerAgentVersion: controllerVersion,
+		CharmDownloader:        binaries,
+		ToolsDownloader:        binaries,
+		ResourceDownloader:     binaries,
+	})
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// Don't leave a partial archive around.
+		os.Remove(filename)
+		return errors.Annotate(err, "exporting model")
+	}
+	ctx.Infof("Model %q exported to %s", modelName, c.filename)
+	return nil
+}
+
+// modelBinariesClient downloads the charms, agent binaries and
+// resources used by the model it is connected to.
+type modelBinariesClient struct {
+	api.Connection
+	client *api.Client
+}
+
+// OpenCharm is part of the ModelBinariesAPI interface.
+func (c *modelBinariesClient) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	return c.client.OpenCharm(curl)
+}
+
+// OpenURI is part of the ModelBinariesAPI interface.
+func (c *modelBinariesClient) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
+	return c.client.OpenURI(uri, query)
+}
+
+// OpenResource is part of the ModelBinariesAPI interface.
+func (c *modelBinariesClient) OpenResource(application, name string) (io.ReadCloser, error) {
+	return c.client.OpenURI(resourceapi.NewEndpointPath(application, name), nil)
+}
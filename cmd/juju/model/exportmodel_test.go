@@ -0,0 +1,177 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/testing"
+)
+
+type ExportModelCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api      fakeExportModelAPI
+	binaries fakeModelBinariesAPI
+	store    *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&ExportModelCommandSuite{})
+
+type fakeExportModelAPI struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeExportModelAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeExportModelAPI) ExportModel(model names.ModelTag) (params.SerializedModel, error) {
+	f.MethodCall(f, "ExportModel", model)
+	if err := f.NextErr(); err != nil {
+		return params.SerializedModel{}, err
+	}
+	return params.SerializedModel{
+		Bytes:  []byte("model-uuid: fake uuid\n"),
+		Charms: []string{"cs:xenial/mysql-1"},
+		Tools: []params.SerializedModelTools{{
+			Version: "2.6.0-xenial-amd64",
+			URI:     "/tools/2.6.0-xenial-amd64",
+		}},
+	}, nil
+}
+
+type fakeModelBinariesAPI struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeModelBinariesAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeModelBinariesAPI) ServerVersion() (version.Number, bool) {
+	f.MethodCall(f, "ServerVersion")
+	return version.MustParse("2.6.1"), true
+}
+
+func (f *fakeModelBinariesAPI) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenCharm", curl.String())
+	return ioutil.NopCloser(bytes.NewReader([]byte("charm"))), f.NextErr()
+}
+
+func (f *fakeModelBinariesAPI) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenURI", uri)
+	return ioutil.NopCloser(bytes.NewReader([]byte("tools"))), f.NextErr()
+}
+
+func (f *fakeModelBinariesAPI) OpenResource(application, name string) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenResource", application, name)
+	return ioutil.NopCloser(bytes.NewReader([]byte("resource"))), f.NextErr()
+}
+
+func (s *ExportModelCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api.ResetCalls()
+	s.binaries.ResetCalls()
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID: testing.ModelTag.Id(),
+		ModelType: coremodel.IAAS,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *ExportModelCommandSuite) runExport(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, model.NewExportModelCommandForTest(&s.api, &s.binaries, s.store), args...)
+}
+
+func (s *ExportModelCommandSuite) TestInit(c *gc.C) {
+	_, err := s.runExport(c)
+	c.Assert(err, gc.ErrorMatches, "no filename specified")
+	_, err = s.runExport(c, "foo", "bar")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["bar"\]`)
+}
+
+func (s *ExportModelCommandSuite) TestExport(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "model.tar.gz")
+	ctx, err := s.runExport(c, filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `Model "admin/mymodel" exported to `+filename+"\n")
+
+	s.api.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ExportModel", []interface{}{testing.ModelTag}},
+		{"Close", nil},
+	})
+	s.binaries.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ServerVersion", nil},
+		{"OpenCharm", []interface{}{"cs:xenial/mysql-1"}},
+		{"OpenURI", []interface{}{"/tools/2.6.0-xenial-amd64"}},
+		{"Close", nil},
+	})
+
+	f, err := os.Open(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	archive, err := migration.ReadArchive(f, c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(archive.Metadata(), jc.DeepEquals, migration.ArchiveMetadata{
+		ControllerAgentVersion: version.MustParse("2.6.1"),
+		Charms:                 []string{"cs:xenial/mysql-1"},
+		Tools: []params.SerializedModelTools{{
+			Version: "2.6.0-xenial-amd64",
+			URI:     "tools/2.6.0-xenial-amd64.tgz",
+		}},
+	})
+}
+
+func (s *ExportModelCommandSuite) TestExportFileExists(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "model.tar.gz")
+	err := ioutil.WriteFile(filename, []byte("precious"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.runExport(c, filename)
+	c.Assert(err, gc.ErrorMatches, "creating model archive: .* file exists")
+
+	data, err := ioutil.ReadFile(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "precious")
+}
+
+func (s *ExportModelCommandSuite) TestExportDownloadFails(c *gc.C) {
+	s.binaries.SetErrors(errors.New("boom"))
+	filename := filepath.Join(c.MkDir(), "model.tar.gz")
+
+	_, err := s.runExport(c, filename)
+	c.Assert(err, gc.ErrorMatches, "exporting model: cannot open charm: boom")
+	// No partial archive is left behind.
+	_, err = os.Stat(filename)
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
@@ -0,0 +1,245 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/api/migrationtarget"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/tools"
+)
+
+// NewImportModelCommand returns a fully constructed import-model command.
+func NewImportModelCommand() cmd.Command {
+	return modelcmd.WrapController(&importModelCommand{})
+}
+
+type importModelCommand struct {
+	modelcmd.ControllerCommandBase
+	api ImportModelAPI
+
+	filename string
+}
+
+const importModelHelpDoc = `
+Imports a model from an archive file created with "juju export-model"
+into the controller. The charms, agent binaries and resources used by
+the model are uploaded from the archive, so the controller the model
+was exported from does not need to be reachable.
+
+The same checks are made of the controller and the model as when
+migrating a model with "juju migrate". If any of them fail, or the
+import fails part way through, the model is removed from the
+controller again.
+
+Unlike a migration, importing a model does not redirect the model's
+machine and unit agents to this controller, and the model is not
+removed from the controller it was exported from. This is intended
+for moving models between environments where the source controller
+will no longer be used.
+
+Only controller superusers may import a model.
+
+Examples:
+
+    juju import-model mymodel.tar.gz
+    juju import-model -c othercontroller mymodel.tar.gz
+
+See also:
+    export-model
+    migrate
+`
+
+// Info implements Command.
+func (c *importModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "import-model",
+		Args:    "<filename>",
+		Purpose: "Imports a model from an archive file.",
+		Doc:     importModelHelpDoc,
+	})
+}
+
+// Init implements Command.
+func (c *importModelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no filename specified")
+	}
+	c.filename, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// ImportModelAPI specifies the used function calls of the
+// MigrationTarget facade.
+type ImportModelAPI interface {
+	Close() error
+	Prechecks(coremigration.ModelInfo) error
+	Import([]byte) error
+	Abort(modelUUID string) error
+	Activate(modelUUID string) error
+	CheckMachines(modelUUID string) ([]error, error)
+	UploadCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) (*charm.URL, error)
+	UploadTools(modelUUID string, r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error)
+	UploadResource(modelUUID string, res resource.Resource, r io.ReadSeeker) error
+	SetPlaceholderResource(modelUUID string, res resource.Resource) error
+	SetUnitResource(modelUUID, unit string, res resource.Resource) error
+}
+
+type migrationTargetClient struct {
+	*migrationtarget.Client
+	io.Closer
+}
+
+func (c *importModelCommand) getAPI() (ImportModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.ControllerCommandBase.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &migrationTargetClient{migrationtarget.NewClient(root), root}, nil
+}
+
+// Run implements Command.
+func (c *importModelCommand) Run(ctx *cmd.Context) error {
+	f, err := os.Open(ctx.AbsPath(c.filename))
+	if err != nil {
+		return errors.Annotate(err, "opening model archive")
+	}
+	defer f.Close()
+	dir, err := ioutil.TempDir("", "juju-import-model")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.RemoveAll(dir)
+	archive, err := migration.ReadArchive(f, dir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	info, err := archive.ModelInfo()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	serialized, err := archive.SerializedModel()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	ctx.Verbosef("performing target prechecks")
+	if err := client.Prechecks(info); err != nil {
+		return errors.Annotate(err, "target prechecks failed")
+	}
+
+	ctx.Verbosef("importing model %q", info.Name)
+	if err := client.Import(serialized.Bytes); err != nil {
+		return errors.Annotate(err, "failed to import model")
+	}
+	if err := c.completeImport(ctx, client, info.UUID, archive, serialized); err != nil {
+		// Remove the partially imported model so the import
+		// can be tried again.
+		if abortErr := client.Abort(info.UUID); abortErr != nil {
+			logger.Errorf("cannot remove partially imported model: %v", abortErr)
+		}
+		return errors.Trace(err)
+	}
+	ctx.Infof("Model %q imported", info.Name)
+	return nil
+}
+
+func (c *importModelCommand) completeImport(
+	ctx *cmd.Context,
+	client ImportModelAPI,
+	modelUUID string,
+	archive *migration.Archive,
+	serialized coremigration.SerializedModel,
+) error {
+	ctx.Verbosef("uploading model binaries")
+	uploader := &modelUploader{client: client, modelUUID: modelUUID}
+	err := migration.UploadBinaries(migration.UploadBinariesConfig{
+		Charms:          serialized.Charms,
+		CharmDownloader: archive,
+		CharmUploader:   uploader,
+
+		Tools:           serialized.Tools,
+		ToolsDownloader: archive,
+		ToolsUploader:   uploader,
+
+		Resources:          serialized.Resources,
+		ResourceDownloader: archive,
+		ResourceUploader:   uploader,
+	})
+	if err != nil {
+		return errors.Annotate(err, "failed to upload model binaries")
+	}
+
+	ctx.Verbosef("checking machines in imported model")
+	machineErrs, err := client.CheckMachines(modelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(machineErrs) > 0 {
+		for _, machineErr := range machineErrs {
+			ctx.Warningf("%v", machineErr)
+		}
+		plural := "s"
+		if len(machineErrs) == 1 {
+			plural = ""
+		}
+		return errors.Errorf("machine sanity check failed, %d error%s found", len(machineErrs), plural)
+	}
+
+	ctx.Verbosef("activating model")
+	return errors.Annotate(client.Activate(modelUUID), "failed to activate model")
+}
+
+// modelUploader adds the model UUID to the upload calls made to the
+// target controller.
+type modelUploader struct {
+	client    ImportModelAPI
+	modelUUID string
+}
+
+// UploadTools is part of the migration.ToolsUploader interface.
+func (u *modelUploader) UploadTools(r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error) {
+	return u.client.UploadTools(u.modelUUID, r, vers, additionalSeries...)
+}
+
+// UploadCharm is part of the migration.CharmUploader interface.
+func (u *modelUploader) UploadCharm(curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	return u.client.UploadCharm(u.modelUUID, curl, content)
+}
+
+// UploadResource is part of the migration.ResourceUploader interface.
+func (u *modelUploader) UploadResource(res resource.Resource, content io.ReadSeeker) error {
+	return u.client.UploadResource(u.modelUUID, res, content)
+}
+
+// SetPlaceholderResource is part of the migration.ResourceUploader interface.
+func (u *modelUploader) SetPlaceholderResource(res resource.Resource) error {
+	return u.client.SetPlaceholderResource(u.modelUUID, res)
+}
+
+// SetUnitResource is part of the migration.ResourceUploader interface.
+func (u *modelUploader) SetUnitResource(unitName string, res resource.Resource) error {
+	return u.client.SetUnitResource(u.modelUUID, unitName, res)
+}
@@ -0,0 +1,224 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
+)
+
+type ImportModelCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api      fakeImportModelAPI
+	store    *jujuclient.MemStore
+	filename string
+}
+
+var _ = gc.Suite(&ImportModelCommandSuite{})
+
+type fakeImportModelAPI struct {
+	gitjujutesting.Stub
+	machineErrs []error
+}
+
+func (f *fakeImportModelAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) Prechecks(info coremigration.ModelInfo) error {
+	f.MethodCall(f, "Prechecks", info)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) Import(bytes []byte) error {
+	f.MethodCall(f, "Import", string(bytes))
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) Abort(modelUUID string) error {
+	f.MethodCall(f, "Abort", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) Activate(modelUUID string) error {
+	f.MethodCall(f, "Activate", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) CheckMachines(modelUUID string) ([]error, error) {
+	f.MethodCall(f, "CheckMachines", modelUUID)
+	return f.machineErrs, f.NextErr()
+}
+
+func (f *fakeImportModelAPI) UploadCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	data, err := ioutil.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+	f.MethodCall(f, "UploadCharm", modelUUID, curl.String(), string(data))
+	return curl, f.NextErr()
+}
+
+func (f *fakeImportModelAPI) UploadTools(modelUUID string, r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	f.MethodCall(f, "UploadTools", modelUUID, vers.String(), string(data))
+	return tools.List{&tools.Tools{Version: vers}}, f.NextErr()
+}
+
+func (f *fakeImportModelAPI) UploadResource(modelUUID string, res resource.Resource, r io.ReadSeeker) error {
+	f.MethodCall(f, "UploadResource", modelUUID, res.Name)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) SetPlaceholderResource(modelUUID string, res resource.Resource) error {
+	f.MethodCall(f, "SetPlaceholderResource", modelUUID, res.Name)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) SetUnitResource(modelUUID, unit string, res resource.Resource) error {
+	f.MethodCall(f, "SetUnitResource", modelUUID, unit, res.Name)
+	return f.NextErr()
+}
+
+func (s *ImportModelCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = fakeImportModelAPI{}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	s.filename = s.writeArchive(c)
+}
+
+func (s *ImportModelCommandSuite) writeArchive(c *gc.C) string {
+	cfg := testing.FakeConfig().Merge(testing.Attrs{
+		"name":          "mymodel",
+		"agent-version": "2.6.0",
+	})
+	desc := description.NewModel(description.ModelArgs{
+		Type:   "iaas",
+		Owner:  names.NewUserTag("bob"),
+		Config: cfg,
+	})
+	bytes, err := description.Serialize(desc)
+	c.Assert(err, jc.ErrorIsNil)
+
+	filename := filepath.Join(c.MkDir(), "model.tar.gz")
+	f, err := os.Create(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	binaries := &fakeModelBinariesAPI{}
+	err = migration.WriteArchive(f, migration.WriteArchiveConfig{
+		Model: params.SerializedModel{
+			Bytes:  bytes,
+			Charms: []string{"cs:xenial/mysql-1"},
+			Tools: []params.SerializedModelTools{{
+				Version: "2.6.0-xenial-amd64",
+				URI:     "/tools/2.6.0-xenial-amd64",
+			}},
+		},
+		ControllerAgentVersion: version.MustParse("2.6.1"),
+		CharmDownloader:        binaries,
+		ToolsDownloader:        binaries,
+		ResourceDownloader:     binaries,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return filename
+}
+
+func (s *ImportModelCommandSuite) runImport(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, model.NewImportModelCommandForTest(&s.api, s.store), args...)
+}
+
+func (s *ImportModelCommandSuite) modelInfo() coremigration.ModelInfo {
+	return coremigration.ModelInfo{
+		UUID:                   testing.ModelTag.Id(),
+		Name:                   "mymodel",
+		Owner:                  names.NewUserTag("bob"),
+		AgentVersion:           version.MustParse("2.6.0"),
+		ControllerAgentVersion: version.MustParse("2.6.1"),
+	}
+}
+
+func (s *ImportModelCommandSuite) TestInit(c *gc.C) {
+	_, err := s.runImport(c)
+	c.Assert(err, gc.ErrorMatches, "no filename specified")
+	_, err = s.runImport(c, "foo", "bar")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["bar"\]`)
+}
+
+func (s *ImportModelCommandSuite) TestImport(c *gc.C) {
+	ctx, err := s.runImport(c, s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Model \"mymodel\" imported\n")
+
+	uuid := testing.ModelTag.Id()
+	s.api.CheckCallNames(c,
+		"Prechecks", "Import", "UploadCharm", "UploadTools", "CheckMachines", "Activate", "Close")
+	s.api.CheckCall(c, 0, "Prechecks", s.modelInfo())
+	s.api.CheckCall(c, 2, "UploadCharm", uuid, "cs:xenial/mysql-1", "charm")
+	s.api.CheckCall(c, 3, "UploadTools", uuid, "2.6.0-xenial-amd64", "tools")
+	s.api.CheckCall(c, 5, "Activate", uuid)
+}
+
+func (s *ImportModelCommandSuite) TestImportPrechecksFail(c *gc.C) {
+	s.api.SetErrors(errors.New("model already exists"))
+	_, err := s.runImport(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, "target prechecks failed: model already exists")
+	s.api.CheckCallNames(c, "Prechecks", "Close")
+}
+
+func (s *ImportModelCommandSuite) TestImportUploadFailAborts(c *gc.C) {
+	s.api.SetErrors(nil, nil, errors.New("boom"))
+	_, err := s.runImport(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, "failed to upload model binaries: cannot upload charm: boom")
+	s.api.CheckCallNames(c, "Prechecks", "Import", "UploadCharm", "Abort", "Close")
+	s.api.CheckCall(c, 3, "Abort", testing.ModelTag.Id())
+}
+
+func (s *ImportModelCommandSuite) TestImportMachineCheckFailAborts(c *gc.C) {
+	s.api.machineErrs = []error{errors.New("machine 0 is missing")}
+	_, err := s.runImport(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, "machine sanity check failed, 1 error found")
+	s.api.CheckCallNames(c,
+		"Prechecks", "Import", "UploadCharm", "UploadTools", "CheckMachines", "Abort", "Close")
+}
+
+func (s *ImportModelCommandSuite) TestImportNotAnArchive(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "model.tar.gz")
+	err := ioutil.WriteFile(filename, []byte("not an archive"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.runImport(c, filename)
+	c.Assert(err, gc.ErrorMatches, "reading model archive: .*")
+	s.api.CheckNoCalls(c)
+}
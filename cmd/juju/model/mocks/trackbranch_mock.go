@@ -7,6 +7,7 @@ package mocks
 import (
 	gomock "github.com/golang/mock/gomock"
 	reflect "reflect"
+	time "time"
 )
 
 // MockTrackBranchCommandAPI is a mock of TrackBranchCommandAPI interface
@@ -44,6 +45,18 @@ func (mr *MockTrackBranchCommandAPIMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockTrackBranchCommandAPI)(nil).Close))
 }
 
+// StartRollout mocks base method
+func (m *MockTrackBranchCommandAPI) StartRollout(arg0, arg1 string, arg2, arg3 int, arg4 time.Duration) error {
+	ret := m.ctrl.Call(m, "StartRollout", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartRollout indicates an expected call of StartRollout
+func (mr *MockTrackBranchCommandAPIMockRecorder) StartRollout(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartRollout", reflect.TypeOf((*MockTrackBranchCommandAPI)(nil).StartRollout), arg0, arg1, arg2, arg3, arg4)
+}
+
 // TrackBranch mocks base method
 func (m *MockTrackBranchCommandAPI) TrackBranch(arg0 string, arg1 []string) error {
 	ret := m.ctrl.Call(m, "TrackBranch", arg0, arg1)
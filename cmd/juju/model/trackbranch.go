@@ -4,6 +4,10 @@
 package model
 
 import (
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
@@ -21,11 +25,19 @@ Specific units can be set to track a branch by supplying multiple unit IDs.
 All units of an application can be set to track a branch by passing an
 application name. Units can only track one branch at a time.
 
+The units of a single application can instead be moved onto the branch
+progressively, in waves of a number or percentage of its units, by supplying
+--wave-size. Each wave is started once all units in the previous one are active
+and idle. The rollout halts if any unit goes into error, or if a wave does not
+become active and idle within --wave-timeout. The progress of the rollout is
+shown by "juju diff".
+
 Examples:
     juju track test-branch redis/0
     juju track test-branch redis
-    juju track test-branch redis -n 2
     juju track test-branch redis/0 mysql
+    juju track test-branch redis --wave-size 2
+    juju track test-branch redis --wave-size 25% --wave-timeout 10m
 
 See also:
     branch
@@ -36,6 +48,10 @@ See also:
 `
 )
 
+// defaultWaveTimeout is the time allowed for units in a rollout wave to
+// become active and idle, if not supplied.
+const defaultWaveTimeout = 30 * time.Minute
+
 // NewTrackBranchCommand wraps trackBranchCommand with sane model settings.
 func NewTrackBranchCommand() cmd.Command {
 	return modelcmd.Wrap(&trackBranchCommand{})
@@ -50,6 +66,11 @@ type trackBranchCommand struct {
 
 	branchName string
 	entities   []string
+
+	waveSizeArg string
+	waveSize    int
+	wavePercent int
+	waveTimeout time.Duration
 }
 
 // TrackBranchCommandAPI describes API methods required
@@ -61,6 +82,10 @@ type TrackBranchCommandAPI interface {
 	// TrackBranch sets the input units and/or applications
 	// to track changes made under the input branch name.
 	TrackBranch(branchName string, entities []string) error
+
+	// StartRollout begins moving the units of the input application
+	// onto the input branch in waves.
+	StartRollout(branchName, appName string, waveSize, wavePercent int, waveTimeout time.Duration) error
 }
 
 // Info implements part of the cmd.Command interface.
@@ -77,6 +102,8 @@ func (c *trackBranchCommand) Info() *cmd.Info {
 // SetFlags implements part of the cmd.Command interface.
 func (c *trackBranchCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.waveSizeArg, "wave-size", "", "Move application units onto the branch in waves of this number, or percentage (e.g. 25%), of units")
+	f.DurationVar(&c.waveTimeout, "wave-timeout", defaultWaveTimeout, "Time allowed for the units in a wave to become active and idle")
 }
 
 // Init implements part of the cmd.Command interface.
@@ -94,6 +121,28 @@ func (c *trackBranchCommand) Init(args []string) error {
 	}
 	c.branchName = args[0]
 	c.entities = args[1:]
+	if c.waveSizeArg == "" {
+		return nil
+	}
+
+	if len(c.entities) != 1 || !names.IsValidApplication(c.entities[0]) {
+		return errors.Errorf("--wave-size requires a single application name")
+	}
+	if c.waveTimeout <= 0 {
+		return errors.Errorf("--wave-timeout must be positive")
+	}
+	var err error
+	if strings.HasSuffix(c.waveSizeArg, "%") {
+		c.wavePercent, err = strconv.Atoi(strings.TrimSuffix(c.waveSizeArg, "%"))
+		if err != nil || c.wavePercent < 1 || c.wavePercent > 100 {
+			return errors.Errorf("invalid wave size %q: percentage must be between 1%% and 100%%", c.waveSizeArg)
+		}
+		return nil
+	}
+	c.waveSize, err = strconv.Atoi(c.waveSizeArg)
+	if err != nil || c.waveSize < 1 {
+		return errors.Errorf("invalid wave size %q: expected a positive number or percentage", c.waveSizeArg)
+	}
 	return nil
 }
 
@@ -119,5 +168,13 @@ func (c *trackBranchCommand) Run(ctx *cmd.Context) error {
 	}
 	defer func() { _ = client.Close() }()
 
+	if c.waveSizeArg != "" {
+		err = client.StartRollout(c.branchName, c.entities[0], c.waveSize, c.wavePercent, c.waveTimeout)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ctx.Infof("Rollout of %q onto branch %q started", c.entities[0], c.branchName)
+		return nil
+	}
 	return errors.Trace(client.TrackBranch(c.branchName, c.entities))
 }
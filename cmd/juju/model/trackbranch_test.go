@@ -4,6 +4,8 @@
 package model_test
 
 import (
+	"time"
+
 	"github.com/golang/mock/gomock"
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -55,6 +57,31 @@ func (s *trackBranchSuite) TestInitInvalid(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `invalid application or unit name "test me"`)
 }
 
+func (s *trackBranchSuite) TestInitWaveSize(c *gc.C) {
+	err := s.runInit(s.branchName, "ubuntu", "--wave-size", "2")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.runInit(s.branchName, "ubuntu", "--wave-size", "25%", "--wave-timeout", "5m")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *trackBranchSuite) TestInitWaveSizeInvalid(c *gc.C) {
+	err := s.runInit(s.branchName, "ubuntu/0", "--wave-size", "2")
+	c.Check(err, gc.ErrorMatches, "--wave-size requires a single application name")
+
+	err = s.runInit(s.branchName, "ubuntu", "redis", "--wave-size", "2")
+	c.Check(err, gc.ErrorMatches, "--wave-size requires a single application name")
+
+	err = s.runInit(s.branchName, "ubuntu", "--wave-size", "0")
+	c.Check(err, gc.ErrorMatches, `invalid wave size "0": expected a positive number or percentage`)
+
+	err = s.runInit(s.branchName, "ubuntu", "--wave-size", "150%")
+	c.Check(err, gc.ErrorMatches, `invalid wave size "150%": percentage must be between 1% and 100%`)
+
+	err = s.runInit(s.branchName, "ubuntu", "--wave-size", "2", "--wave-timeout", "0s")
+	c.Check(err, gc.ErrorMatches, "--wave-timeout must be positive")
+}
+
 func (s *trackBranchSuite) TestRunCommand(c *gc.C) {
 	mockController, api := setUpAdvanceMocks(c)
 	defer mockController.Finish()
@@ -75,6 +102,27 @@ func (s *trackBranchSuite) TestRunCommandFail(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "fail")
 }
 
+func (s *trackBranchSuite) TestRunCommandRollout(c *gc.C) {
+	ctrl, api := setUpAdvanceMocks(c)
+	defer ctrl.Finish()
+
+	api.EXPECT().StartRollout(s.branchName, "redis", 0, 25, 10*time.Minute).Return(nil)
+
+	ctx, err := s.runCommand(c, api, s.branchName, "redis", "--wave-size", "25%", "--wave-timeout", "10m")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Rollout of \"redis\" onto branch \""+s.branchName+"\" started\n")
+}
+
+func (s *trackBranchSuite) TestRunCommandRolloutDefaultTimeout(c *gc.C) {
+	ctrl, api := setUpAdvanceMocks(c)
+	defer ctrl.Finish()
+
+	api.EXPECT().StartRollout(s.branchName, "redis", 2, 0, 30*time.Minute).Return(nil)
+
+	_, err := s.runCommand(c, api, s.branchName, "redis", "--wave-size", "2")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *trackBranchSuite) runInit(args ...string) error {
 	return cmdtesting.InitCommand(model.NewTrackBranchCommandForTest(nil, s.store), args)
 }
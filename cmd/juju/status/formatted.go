@@ -48,7 +48,8 @@ type modelStatus struct {
 }
 
 type controllerStatus struct {
-	Timestamp string `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+	Timestamp       string              `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+	ScheduledBackup *statusInfoContents `json:"scheduled-backup,omitempty" yaml:"scheduled-backup,omitempty"`
 }
 
 type networkInterface struct {
@@ -99,6 +99,13 @@ func (sf *statusFormatter) format() (formattedStatus, error) {
 			Timestamp: common.FormatTimeAsTimestamp(sf.status.ControllerTimestamp, sf.isoTime),
 		}
 	}
+	if sf.status.ScheduledBackup != nil {
+		if out.Controller == nil {
+			out.Controller = &controllerStatus{}
+		}
+		backupStatus := sf.getStatusInfoContents(*sf.status.ScheduledBackup)
+		out.Controller.ScheduledBackup = &backupStatus
+	}
 	for k, m := range sf.status.Machines {
 		out.Machines[k] = sf.formatMachine(m)
 	}
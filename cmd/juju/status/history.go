@@ -189,6 +189,15 @@ func (c *statusHistoryCommand) Run(ctx *cmd.Context) error {
 			return errors.Errorf("%q is not a valid name for a %s", c.entityName, kind)
 		}
 		tag = names.NewUnitTag(c.entityName)
+	case status.KindCloudEvent:
+		switch {
+		case names.IsValidUnit(c.entityName):
+			tag = names.NewUnitTag(c.entityName)
+		case names.IsValidApplication(c.entityName):
+			tag = names.NewApplicationTag(c.entityName)
+		default:
+			return errors.Errorf("%q is not a valid name for a %s", c.entityName, kind)
+		}
 	default:
 		if !names.IsValidMachine(c.entityName) {
 			return errors.Errorf("%q is not a valid name for a %s", c.entityName, kind)
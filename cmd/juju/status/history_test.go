@@ -109,9 +109,35 @@ func (s *StatusHistorySuite) TestResults(c *gc.C) {
 	c.Check(cmdtesting.Stdout(ctx), gc.Equals, expected)
 }
 
+func (s *StatusHistorySuite) TestCloudEventsForOperator(c *gc.C) {
+	s.api = &fakeHistoryAPI{
+		history: status.History{
+			{
+				Kind:   status.KindCloudEvent,
+				Status: "BackOff",
+				Info:   "Back-off pulling image",
+				Since:  s.next(),
+			},
+		},
+	}
+	expected := "" +
+		"Time                  Type         Status   Message\n" +
+		"2017-11-28 12:34:56Z  cloud-event  BackOff  Back-off pulling image\n"
+
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "mariadb", "--type", "cloud-event", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, expected)
+	api := s.api.(*fakeHistoryAPI)
+	c.Check(api.kind, gc.Equals, status.KindCloudEvent)
+	c.Check(api.tag, gc.Equals, names.NewApplicationTag("mariadb"))
+}
+
 type fakeHistoryAPI struct {
 	err     error
 	history status.History
+
+	kind status.HistoryKind
+	tag  names.Tag
 }
 
 func (*fakeHistoryAPI) Close() error {
@@ -119,5 +145,7 @@ func (*fakeHistoryAPI) Close() error {
 }
 
 func (f *fakeHistoryAPI) StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error) {
+	f.kind = kind
+	f.tag = tag
 	return f.history, f.err
 }
@@ -66,6 +66,10 @@ func FormatTabular(writer io.Writer, forceColor bool, value interface{}) error {
 		header = append(header, "Timestamp")
 		values = append(values, cs.Timestamp)
 	}
+	if cs := fs.Controller; cs != nil && cs.ScheduledBackup != nil {
+		header = append(header, "Backup")
+		values = append(values, cs.ScheduledBackup.Current)
+	}
 	if message != "" {
 		header = append(header, "Notes")
 		values = append(values, message)
@@ -74,6 +74,28 @@ transcode/1   shared-fs/0   filesystem  radiance              1.0GiB  attached
 `[1:])
 }
 
+func (s *MinimalStatusSuite) TestScheduledBackupStatus(c *gc.C) {
+	s.statusapi.result.ScheduledBackup = &params.DetailedStatus{
+		Status: "error",
+		Info:   "backup failed",
+	}
+	context, err := s.runStatus(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), jc.HasPrefix, `
+Model  Controller  Cloud/Region  Version  Backup
+test   test        foo                    error
+`[1:])
+
+	context, err = s.runStatus(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), jc.Contains, `
+controller:
+  scheduled-backup:
+    current: error
+    message: backup failed
+`[1:])
+}
+
 func (s *MinimalStatusSuite) TestRetryOnError(c *gc.C) {
 	s.statusapi.errors = []error{
 		errors.New("boom"),
@@ -34,12 +34,15 @@ var (
 	requireValidCredentialModelWorkers = []string{
 		"action-pruner",          // tertiary dependency: will be inactive because migration workers will be inactive
 		"application-scaler",     // tertiary dependency: will be inactive because migration workers will be inactive
+		"branch-pruner",          // tertiary dependency: will be inactive because migration workers will be inactive
+		"branch-rollout",         // tertiary dependency: will be inactive because migration workers will be inactive
 		"charm-revision-updater", // tertiary dependency: will be inactive because migration workers will be inactive
 		"compute-provisioner",
 		"environ-tracker",
 		"firewaller",
 		"instance-mutater",
 		"instance-poller",
+		"machine-health",          // tertiary dependency: will be inactive because migration workers will be inactive
 		"machine-undertaker",      // tertiary dependency: will be inactive because migration workers will be inactive
 		"metric-worker",           // tertiary dependency: will be inactive because migration workers will be inactive
 		"migration-fortress",      // secondary dependency: will be inactive because depends on environ-upgrader
@@ -56,6 +59,8 @@ var (
 	aliveModelWorkers = []string{
 		"action-pruner",
 		"application-scaler",
+		"branch-pruner",
+		"branch-rollout",
 		"charm-revision-updater",
 		"compute-provisioner",
 		"environ-tracker",
@@ -63,6 +68,7 @@ var (
 		"instance-mutater",
 		"instance-poller",
 		"log-forwarder",
+		"machine-health",
 		"machine-undertaker",
 		"metric-worker",
 		"migration-fortress",
@@ -1007,6 +1007,8 @@ func (a *MachineAgent) startModelWorkers(modelUUID string, modelType state.Model
 		InstPollerAggregationDelay:  3 * time.Second,
 		StatusHistoryPrunerInterval: 5 * time.Minute,
 		ActionPrunerInterval:        24 * time.Hour,
+		BranchPrunerInterval:        24 * time.Hour,
+		MachineHealthPollInterval:   5 * time.Minute,
 		NewEnvironFunc:              newEnvirons,
 		NewContainerBrokerFunc:      newCAASBroker,
 		NewMigrationMaster:          migrationmaster.NewWorker,
@@ -28,6 +28,8 @@ import (
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
 	"github.com/juju/juju/worker/applicationscaler"
+	"github.com/juju/juju/worker/branchpruner"
+	"github.com/juju/juju/worker/branchrollout"
 	"github.com/juju/juju/worker/caasbroker"
 	"github.com/juju/juju/worker/caasenvironupgrader"
 	"github.com/juju/juju/worker/caasfirewaller"
@@ -47,6 +49,7 @@ import (
 	"github.com/juju/juju/worker/lifeflag"
 	"github.com/juju/juju/worker/logforwarder"
 	"github.com/juju/juju/worker/logforwarder/sinks"
+	"github.com/juju/juju/worker/machinehealth"
 	"github.com/juju/juju/worker/machineundertaker"
 	"github.com/juju/juju/worker/metricworker"
 	"github.com/juju/juju/worker/migrationflag"
@@ -104,6 +107,14 @@ type ManifoldsConfig struct {
 	// worker is run.
 	ActionPrunerInterval time.Duration
 
+	// BranchPrunerInterval controls the rate at which the branch pruner
+	// worker is run.
+	BranchPrunerInterval time.Duration
+
+	// MachineHealthPollInterval controls how often the machine health
+	// worker checks for machines whose agents have been lost.
+	MachineHealthPollInterval time.Duration
+
 	// NewEnvironFunc is a function opens a provider "environment"
 	// (typically environs.New).
 	NewEnvironFunc environs.NewEnvironFunc
@@ -251,6 +262,10 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 			ClockName:     clockName,
 		})),
+		branchRolloutName: ifNotMigrating(branchrollout.Manifold(branchrollout.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+		})),
 		statusHistoryPrunerName: ifNotMigrating(pruner.Manifold(pruner.ManifoldConfig{
 			APICallerName: apiCallerName,
 			EnvironName:   environTrackerName,
@@ -267,6 +282,14 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     actionpruner.NewFacade,
 			PruneInterval: config.ActionPrunerInterval,
 		})),
+		branchPrunerName: ifNotMigrating(pruner.Manifold(pruner.ManifoldConfig{
+			APICallerName: apiCallerName,
+			EnvironName:   environTrackerName,
+			ClockName:     clockName,
+			NewWorker:     branchpruner.New,
+			NewFacade:     branchpruner.NewFacade,
+			PruneInterval: config.BranchPrunerInterval,
+		})),
 		logForwarderName: ifNotDead(logforwarder.Manifold(logforwarder.ManifoldConfig{
 			APICallerName: apiCallerName,
 			Sinks: []logforwarder.LogSinkSpec{{
@@ -385,6 +408,14 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker:                    machineundertaker.NewWorker,
 			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
 		}))),
+		machineHealthName: ifNotMigrating(ifCredentialValid(machinehealth.Manifold(machinehealth.ManifoldConfig{
+			APICallerName:                apiCallerName,
+			EnvironName:                  environTrackerName,
+			ClockName:                    clockName,
+			PollInterval:                 config.MachineHealthPollInterval,
+			NewWorker:                    machinehealth.NewWorker,
+			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
+		}))),
 		environUpgraderName: ifCredentialValid(modelupgrader.Manifold(modelupgrader.ManifoldConfig{
 			APICallerName:                apiCallerName,
 			EnvironName:                  environTrackerName,
@@ -594,7 +625,10 @@ const (
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
 	actionPrunerName         = "action-pruner"
+	branchPrunerName         = "branch-pruner"
+	branchRolloutName        = "branch-rollout"
 	machineUndertakerName    = "machine-undertaker"
+	machineHealthName        = "machine-health"
 	remoteRelationsName      = "remote-relations"
 	logForwarderName         = "log-forwarder"
 	instanceMutaterName      = "instance-mutater"
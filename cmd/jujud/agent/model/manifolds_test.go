@@ -37,6 +37,8 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 		"api-caller",
 		"api-config-watcher",
 		"application-scaler",
+		"branch-pruner",
+		"branch-rollout",
 		"charm-revision-updater",
 		"clock",
 		"compute-provisioner",
@@ -49,6 +51,7 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 		"instance-poller",
 		"is-responsible-flag",
 		"log-forwarder",
+		"machine-health",
 		"machine-undertaker",
 		"metric-worker",
 		"migration-fortress",
@@ -81,6 +84,8 @@ func (s *ManifoldsSuite) TestCAASNames(c *gc.C) {
 		"agent",
 		"api-caller",
 		"api-config-watcher",
+		"branch-pruner",
+		"branch-rollout",
 		"caas-broker-tracker",
 		"caas-firewaller",
 		"caas-operator-provisioner",
@@ -207,6 +212,18 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 
 	"api-config-watcher": {"agent"},
 
+	"branch-pruner": {
+		"agent",
+		"api-caller",
+		"clock",
+		"environ-tracker",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"environ-upgrade-gate",
+		"environ-upgraded-flag",
+		"not-dead-flag"},
+
 	"caas-broker-tracker": {"agent", "api-caller", "clock", "is-responsible-flag"},
 
 	"caas-firewaller": {
@@ -340,6 +357,17 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 		"environ-upgraded-flag",
 		"not-dead-flag"},
 
+	"branch-rollout": {
+		"agent",
+		"api-caller",
+		"clock",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"environ-upgrade-gate",
+		"environ-upgraded-flag",
+		"not-dead-flag"},
+
 	"status-history-pruner": {
 		"agent",
 		"api-caller",
@@ -389,6 +417,20 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 
 	"api-config-watcher": {"agent"},
 
+	"branch-pruner": {
+		"agent",
+		"api-caller",
+		"clock",
+		"environ-tracker",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"environ-upgrade-gate",
+		"environ-upgraded-flag",
+		"not-dead-flag",
+		"valid-credential-flag",
+	},
+
 	"application-scaler": {
 		"agent",
 		"api-caller",
@@ -486,6 +528,20 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"is-responsible-flag",
 		"not-dead-flag"},
 
+	"machine-health": {
+		"agent",
+		"api-caller",
+		"clock",
+		"environ-tracker",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"environ-upgrade-gate",
+		"environ-upgraded-flag",
+		"not-dead-flag",
+		"valid-credential-flag",
+	},
+
 	"machine-undertaker": {
 		"agent",
 		"api-caller",
@@ -579,6 +635,17 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"environ-upgraded-flag",
 		"not-dead-flag"},
 
+	"branch-rollout": {
+		"agent",
+		"api-caller",
+		"clock",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"environ-upgrade-gate",
+		"environ-upgraded-flag",
+		"not-dead-flag"},
+
 	"status-history-pruner": {
 		"agent",
 		"api-caller",
@@ -9,8 +9,15 @@ func (s *Server) ClusterSupported() bool {
 
 // UseTargetServer returns a new Server based on the input target node name.
 // It is intended for use when operations must target specific nodes in a
-// cluster.
+// cluster. The new Server's operations are scoped to the same project as
+// this one.
 func (s Server) UseTargetServer(name string) (*Server, error) {
 	logger.Debugf("creating LXD server for cluster node %q", name)
-	return NewServer(s.UseTarget(name))
+	svr, err := NewServer(s.UseTarget(name))
+	if err != nil {
+		return nil, err
+	}
+	svr.project = s.project
+	svr.projectAPISupport = s.projectAPISupport
+	return svr, nil
 }
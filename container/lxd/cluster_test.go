@@ -8,9 +8,11 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	lxdapi "github.com/lxc/lxd/shared/api"
+	"github.com/pkg/errors"
+
 	"github.com/juju/juju/container/lxd"
 	lxdtesting "github.com/juju/juju/container/lxd/testing"
-	"github.com/pkg/errors"
 )
 
 type clusterSuite struct {
@@ -51,3 +53,34 @@ func (s *imageSuite) TestUseTargetBadNode(c *gc.C) {
 	_, err = jujuSvr.UseTargetServer("cluster-2")
 	c.Assert(err, gc.ErrorMatches, "not a cluster member")
 }
+
+func (s *imageSuite) TestUseTargetKeepsProject(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	c1Svr := s.NewMockServer(ctrl, func(svr *lxdapi.Server) {
+		svr.APIExtensions = []string{"network", "clustering", "projects"}
+		svr.Environment.ServerClustered = true
+		svr.Environment.ServerName = "cluster-1"
+	})
+	projSvr := lxdtesting.NewMockContainerServer(ctrl)
+	c2Svr := s.NewMockServerClustered(ctrl, "cluster-2")
+
+	exp := c1Svr.EXPECT()
+	gomock.InOrder(
+		exp.GetProject("juju").Return(&lxdapi.Project{Name: "juju"}, lxdtesting.ETag, nil),
+		exp.UseProject("juju").Return(projSvr),
+	)
+	projSvr.EXPECT().UseTarget("cluster-2").Return(c2Svr)
+
+	jujuSvr, err := lxd.NewServer(c1Svr)
+	c.Assert(err, jc.ErrorIsNil)
+	projectJujuSvr, err := jujuSvr.UseProject("juju")
+	c.Assert(err, jc.ErrorIsNil)
+
+	targetSvr, err := projectJujuSvr.UseTargetServer("cluster-2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(targetSvr.Name(), gc.Equals, "cluster-2")
+	c.Check(targetSvr.Project(), gc.Equals, "juju")
+	c.Check(targetSvr.ProjectSupported(), jc.IsTrue)
+}
@@ -29,6 +29,7 @@ type ServerSpec struct {
 	Name           string
 	Host           string
 	Protocol       Protocol
+	Project        string
 	connectionArgs *lxd.ConnectionArgs
 }
 
@@ -71,6 +72,14 @@ func (s ServerSpec) WithSkipGetServer(b bool) ServerSpec {
 	return s
 }
 
+// WithProject sets the LXD project that connections made using the server
+// spec are scoped to.
+// Returns the ServerSpec to enable chaining of optional values
+func (s ServerSpec) WithProject(project string) ServerSpec {
+	s.Project = project
+	return s
+}
+
 // NewInsecureServerSpec creates a ServerSpec without certificate requirements,
 // which also bypasses the TLS verification.
 // It also ensures the HTTPS for the host implicitly
@@ -37,6 +37,19 @@ type ContainerSpec struct {
 	Config       map[string]string
 	Profiles     []string
 	InstanceType string
+
+	// VirtType is the type of LXD instance to create.
+	// An empty value indicates a container.
+	VirtType string
+
+	// RemoteImage is the image used to create a virtual machine.
+	// It is used in place of Image when VirtType is "virtual-machine".
+	RemoteImage RemoteImage
+}
+
+// IsVirtualMachine returns true if the spec is for a virtual machine.
+func (c *ContainerSpec) IsVirtualMachine() bool {
+	return c.VirtType == VirtTypeVirtualMachine
 }
 
 // minMiBVersion is the minimum LXD version that we are sure will recognise the
@@ -52,6 +65,9 @@ func (c *ContainerSpec) ApplyConstraints(serverVersion string, cons constraints.
 	if cons.HasInstanceType() {
 		c.InstanceType = *cons.InstanceType
 	}
+	if cons.HasVirtType() {
+		c.VirtType = *cons.VirtType
+	}
 	if cons.HasCpuCores() {
 		c.Config["limits.cpu"] = fmt.Sprintf("%d", *cons.CpuCores)
 	}
@@ -163,7 +179,7 @@ func (s *Server) AliveContainers(prefix string) ([]Container, error) {
 // FilterContainers retrieves the list of containers from the server and filters
 // them based on the input namespace prefix and any supplied statuses.
 func (s *Server) FilterContainers(prefix string, statuses ...string) ([]Container, error) {
-	containers, err := s.GetContainers()
+	containers, err := s.getInstances()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -184,13 +200,16 @@ func (s *Server) FilterContainers(prefix string, statuses ...string) ([]Containe
 // ContainerAddresses gets usable network addresses for the container
 // identified by the input name.
 func (s *Server) ContainerAddresses(name string) ([]network.Address, error) {
-	state, _, err := s.GetContainerState(name)
+	state, _, err := s.getInstanceState(name)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
+	// Virtual machines only report their network state once the LXD agent
+	// is running inside them, so this may be empty for some time after start.
 	networks := state.Network
 	if networks == nil {
+		logger.Debugf("no network state yet for %q", name)
 		return []network.Address{}, nil
 	}
 
@@ -216,6 +235,15 @@ func (s *Server) ContainerAddresses(name string) ([]network.Address, error) {
 // If the container fails to be started, it is removed.
 // Upon successful creation and start, the container is returned.
 func (s *Server) CreateContainerFromSpec(spec ContainerSpec) (*Container, error) {
+	if spec.IsVirtualMachine() {
+		logger.Infof("starting new virtual machine %q (image %q)", spec.Name, spec.RemoteImage.Alias)
+		logger.Debugf("new virtual machine has profiles %v", spec.Profiles)
+		if err := s.createVirtualMachine(spec); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return s.startNewContainer(spec.Name)
+	}
+
 	logger.Infof("starting new container %q (image %q)", spec.Name, spec.Image.Image.Filename)
 	logger.Debugf("new container has profiles %v", spec.Profiles)
 	req := api.ContainersPost{
@@ -244,16 +272,22 @@ func (s *Server) CreateContainerFromSpec(spec ContainerSpec) (*Container, error)
 		return nil, fmt.Errorf("container creation failed: %s", opInfo.Err)
 	}
 
-	logger.Debugf("created container %q, waiting for start...", spec.Name)
+	return s.startNewContainer(spec.Name)
+}
+
+// startNewContainer starts the newly created instance with the input name,
+// removing it if it fails to start.
+func (s *Server) startNewContainer(name string) (*Container, error) {
+	logger.Debugf("created container %q, waiting for start...", name)
 
-	if err := s.StartContainer(spec.Name); err != nil {
-		if remErr := s.RemoveContainer(spec.Name); remErr != nil {
+	if err := s.StartContainer(name); err != nil {
+		if remErr := s.RemoveContainer(name); remErr != nil {
 			logger.Errorf("failed to remove container after unsuccessful start: %s", remErr.Error())
 		}
 		return nil, errors.Trace(err)
 	}
 
-	container, _, err := s.GetContainer(spec.Name)
+	container, _, err := s.getInstance(name)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -269,7 +303,7 @@ func (s *Server) StartContainer(name string) error {
 		Force:    false,
 		Stateful: false,
 	}
-	op, err := s.UpdateContainerState(name, req, "")
+	op, err := s.updateInstanceState(name, req, "")
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -300,7 +334,7 @@ func (s *Server) RemoveContainers(names []string) error {
 // Remove container first ensures that the container is stopped,
 // then deletes it.
 func (s *Server) RemoveContainer(name string) error {
-	state, eTag, err := s.GetContainerState(name)
+	state, eTag, err := s.getInstanceState(name)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -312,7 +346,7 @@ func (s *Server) RemoveContainer(name string) error {
 			Force:    true,
 			Stateful: false,
 		}
-		op, err := s.UpdateContainerState(name, req, eTag)
+		op, err := s.updateInstanceState(name, req, eTag)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -332,7 +366,7 @@ func (s *Server) RemoveContainer(name string) error {
 			return errors.IsBadRequest(err)
 		},
 		Func: func() error {
-			op, err := s.DeleteContainer(name)
+			op, err := s.deleteInstance(name)
 			if err != nil {
 				// sigh, LXD not found container - it's been deleted so, we
 				// just need to return nil.
@@ -356,7 +390,7 @@ func (s *Server) RemoveContainer(name string) error {
 // WriteContainer writes the current representation of the input container to
 // the server.
 func (s *Server) WriteContainer(c *Container) error {
-	resp, err := s.UpdateContainer(c.Name, c.Writable(), "")
+	resp, err := s.updateInstance(c.Name, c.Writable(), "")
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -560,3 +560,15 @@ func (s *managerSuite) TestSpecApplyConstraints(c *gc.C) {
 	c.Check(spec.Config, gc.DeepEquals, exp)
 	c.Check(spec.InstanceType, gc.Equals, instType)
 }
+
+func (s *managerSuite) TestSpecApplyConstraintsVirtType(c *gc.C) {
+	virtType := lxd.VirtTypeVirtualMachine
+	cons := constraints.Value{VirtType: &virtType}
+
+	spec := lxd.ContainerSpec{Config: map[string]string{}}
+	c.Check(spec.IsVirtualMachine(), jc.IsFalse)
+
+	spec.ApplyConstraints("3.10.0", cons)
+	c.Check(spec.VirtType, gc.Equals, lxd.VirtTypeVirtualMachine)
+	c.Check(spec.IsVirtualMachine(), jc.IsTrue)
+}
@@ -7,7 +7,6 @@ import (
 	"fmt"
 
 	"github.com/juju/errors"
-	lxd "github.com/lxc/lxd/client"
 	"github.com/lxc/lxd/shared/api"
 
 	"github.com/juju/juju/core/instance"
@@ -19,7 +18,7 @@ import (
 
 type lxdInstance struct {
 	id     string
-	server lxd.ContainerServer
+	server *Server
 }
 
 var _ instances.Instance = (*lxdInstance)(nil)
@@ -40,7 +39,7 @@ func (lxd *lxdInstance) Addresses(ctx context.ProviderCallContext) ([]network.Ad
 // Status implements instances.Instance.Status.
 func (lxd *lxdInstance) Status(ctx context.ProviderCallContext) instance.Status {
 	jujuStatus := status.Pending
-	instStatus, _, err := lxd.server.GetContainerState(lxd.id)
+	instStatus, _, err := lxd.server.getInstanceState(lxd.id)
 	if err != nil {
 		return instance.Status{
 			Status:  status.Empty,
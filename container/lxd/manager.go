@@ -74,6 +74,12 @@ func NewContainerManager(cfg container.ManagerConfig, svr *Server) (container.Ma
 	imageMetaDataURL := cfg.PopValue(config.ContainerImageMetadataURLKey)
 	imageStream := cfg.PopValue(config.ContainerImageStreamKey)
 
+	if project := cfg.PopValue(config.ContainerLXDProjectKey); project != "" {
+		if svr, err = svr.UseProject(project); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
 	cfg.WarnAboutUnused()
 	return &containerManager{
 		server:           svr,
@@ -119,7 +125,7 @@ func (m *containerManager) CreateContainer(
 	}
 	callback(status.Running, "Container started", nil)
 
-	return &lxdInstance{c.Name, m.server.ContainerServer},
+	return &lxdInstance{c.Name, m.server},
 		&instance.HardwareCharacteristics{AvailabilityZone: &m.availabilityZone}, nil
 }
 
@@ -132,7 +138,7 @@ func (m *containerManager) ListContainers() ([]instances.Instance, error) {
 
 	var result []instances.Instance
 	for _, i := range containers {
-		result = append(result, &lxdInstance{i.Name, m.server.ContainerServer})
+		result = append(result, &lxdInstance{i.Name, m.server})
 	}
 	return result, nil
 }
@@ -158,6 +164,10 @@ func (m *containerManager) getContainerSpec(
 		return ContainerSpec{}, errors.Trace(err)
 	}
 
+	if cons.HasVirtType() && *cons.VirtType == VirtTypeVirtualMachine {
+		return m.getVirtualMachineSpec(instanceConfig, cons, series, imageSources)
+	}
+
 	// Lock around finding an image.
 	// The provisioner works concurrently to create containers.
 	// If an image needs to be copied from a remote, we don't want many
@@ -237,6 +247,49 @@ func (m *containerManager) getContainerSpec(
 	return spec, nil
 }
 
+// getVirtualMachineSpec returns the specification for a new LXD virtual
+// machine. Network devices are taken from the assigned profiles, and the
+// network configuration provided by the image (DHCP on the first NIC)
+// is left in place. The machine's addresses are reported by the LXD agent.
+func (m *containerManager) getVirtualMachineSpec(
+	instanceConfig *instancecfg.InstanceConfig,
+	cons constraints.Value,
+	series string,
+	imageSources []ServerSpec,
+) (ContainerSpec, error) {
+	image, err := m.server.FindVirtualMachineImage(series, jujuarch.HostArch(), imageSources)
+	if err != nil {
+		return ContainerSpec{}, errors.Annotatef(err, "acquiring LXD virtual machine image")
+	}
+
+	name, err := m.namespace.Hostname(instanceConfig.MachineId)
+	if err != nil {
+		return ContainerSpec{}, errors.Trace(err)
+	}
+
+	userData, err := containerinit.CloudInitUserData(instanceConfig, nil)
+	if err != nil {
+		return ContainerSpec{}, errors.Trace(err)
+	}
+
+	cfg := map[string]string{
+		UserDataKey:  string(userData),
+		AutoStartKey: "true",
+		// Extra info to indicate the origin of this virtual machine.
+		JujuModelKey: m.modelUUID,
+	}
+
+	spec := ContainerSpec{
+		Name:        name,
+		RemoteImage: image,
+		Config:      cfg,
+		Profiles:    instanceConfig.Profiles,
+	}
+	spec.ApplyConstraints(m.server.serverVersion, cons)
+
+	return spec, nil
+}
+
 // getImageSources returns a list of LXD remote image sources based on the
 // configuration that was passed into the container manager.
 func (m *containerManager) getImageSources() ([]ServerSpec, error) {
@@ -336,6 +336,27 @@ func (s *managerSuite) TestIsInitialized(c *gc.C) {
 	c.Check(s.manager.IsInitialized(), gc.Equals, true)
 }
 
+func (s *managerSuite) TestListContainersInProject(c *gc.C) {
+	ctrl := s.setupWithExtensions(c, "projects")
+	defer ctrl.Finish()
+
+	projSvr := lxdtesting.NewMockContainerServer(ctrl)
+	exp := s.cSvr.EXPECT()
+	gomock.InOrder(
+		exp.GetProject("juju").Return(&lxdapi.Project{Name: "juju"}, lxdtesting.ETag, nil),
+		exp.UseProject("juju").Return(projSvr),
+	)
+	projSvr.EXPECT().GetContainers().Return(nil, nil)
+
+	cfg := getBaseConfig()
+	cfg[config.ContainerLXDProjectKey] = "juju"
+	s.makeManagerForConfig(c, cfg)
+
+	result, err := s.manager.ListContainers()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, gc.HasLen, 0)
+}
+
 func (s *managerSuite) TestNetworkDevicesFromConfigWithEmptyParentDevice(c *gc.C) {
 	defer s.setup(c).Finish()
 
@@ -0,0 +1,98 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd
+
+import (
+	"github.com/juju/errors"
+	"github.com/lxc/lxd/shared/api"
+)
+
+// DefaultProject is the LXD project used when no other is specified.
+const DefaultProject = "default"
+
+// ProjectSupported returns true if the server supports projects.
+func (s *Server) ProjectSupported() bool {
+	return s.projectAPISupport
+}
+
+// Project returns the name of the LXD project that this server's operations
+// are scoped to. An empty string indicates the default project.
+func (s *Server) Project() string {
+	return s.project
+}
+
+// UseProject returns a new Server whose operations are scoped to the project
+// with the input name, creating the project if it does not already exist.
+// Projects created by Juju have their own images and profiles, so that
+// these do not clutter the default project.
+func (s *Server) UseProject(name string) (*Server, error) {
+	if name == DefaultProject {
+		name = ""
+	}
+	if name == s.project {
+		return s, nil
+	}
+	if name == "" {
+		svr := *s
+		svr.ContainerServer = s.ContainerServer.UseProject(DefaultProject)
+		svr.project = ""
+		return &svr, nil
+	}
+	if !s.projectAPISupport {
+		return nil, errors.NotSupportedf("LXD projects")
+	}
+	if err := s.ensureProject(name); err != nil {
+		return nil, errors.Annotatef(err, "ensuring LXD project %q", name)
+	}
+
+	logger.Debugf("using LXD project %q", name)
+	svr := *s
+	svr.ContainerServer = s.ContainerServer.UseProject(name)
+	svr.project = name
+	return &svr, nil
+}
+
+// ensureProject creates the project with the input name if it does not exist.
+// The devices of the default profile in the server's current project are
+// copied to the default profile of a newly created project, so that containers created
+// there have the same network and storage as they would otherwise.
+func (s *Server) ensureProject(name string) error {
+	if _, _, err := s.GetProject(name); err == nil {
+		return nil
+	} else if !IsLXDNotFound(err) {
+		return errors.Trace(err)
+	}
+
+	logger.Infof("creating LXD project %q", name)
+	err := s.CreateProject(api.ProjectsPost{
+		Name: name,
+		ProjectPut: api.ProjectPut{
+			Description: "Project created by Juju",
+			Config: map[string]string{
+				"features.images":   "true",
+				"features.profiles": "true",
+			},
+		},
+	})
+	if err != nil {
+		// Another process may have created the project concurrently
+		// with us checking for it.
+		if _, _, getErr := s.GetProject(name); getErr == nil {
+			return nil
+		}
+		return errors.Trace(err)
+	}
+
+	defaultProfile, _, err := s.GetProfile(lxdDefaultProfileName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	projectSvr := s.ContainerServer.UseProject(name)
+	projectProfile, eTag, err := projectSvr.GetProfile(lxdDefaultProfileName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	projectProfile.Devices = defaultProfile.Devices
+	return errors.Trace(projectSvr.UpdateProfile(lxdDefaultProfileName, projectProfile.Writable(), eTag))
+}
@@ -0,0 +1,136 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	lxdapi "github.com/lxc/lxd/shared/api"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/container/lxd"
+	lxdtesting "github.com/juju/juju/container/lxd/testing"
+)
+
+type projectSuite struct {
+	lxdtesting.BaseSuite
+}
+
+var _ = gc.Suite(&projectSuite{})
+
+func (s *projectSuite) TestUseProjectExisting(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	cSvr := s.NewMockServerWithExtensions(ctrl, "projects")
+	projSvr := lxdtesting.NewMockContainerServer(ctrl)
+	exp := cSvr.EXPECT()
+	gomock.InOrder(
+		exp.GetProject("juju").Return(&lxdapi.Project{Name: "juju"}, lxdtesting.ETag, nil),
+		exp.UseProject("juju").Return(projSvr),
+	)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	projectJujuSvr, err := jujuSvr.UseProject("juju")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(projectJujuSvr.Project(), gc.Equals, "juju")
+	c.Check(jujuSvr.Project(), gc.Equals, "")
+}
+
+func (s *projectSuite) TestUseProjectCreatesProject(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	cSvr := s.NewMockServerWithExtensions(ctrl, "projects")
+	projSvr := lxdtesting.NewMockContainerServer(ctrl)
+
+	devices := map[string]map[string]string{
+		"eth0": {"type": "nic", "nictype": "bridged", "parent": "lxdbr0"},
+		"root": {"type": "disk", "path": "/", "pool": "default"},
+	}
+	defaultProfile := &lxdapi.Profile{
+		Name:       "default",
+		ProfilePut: lxdapi.ProfilePut{Devices: devices},
+	}
+	projectProfile := &lxdapi.Profile{Name: "default"}
+
+	exp := cSvr.EXPECT()
+	projExp := projSvr.EXPECT()
+	gomock.InOrder(
+		exp.GetProject("juju").Return(nil, "", errors.New("not found")),
+		exp.CreateProject(lxdapi.ProjectsPost{
+			Name: "juju",
+			ProjectPut: lxdapi.ProjectPut{
+				Description: "Project created by Juju",
+				Config: map[string]string{
+					"features.images":   "true",
+					"features.profiles": "true",
+				},
+			},
+		}).Return(nil),
+		exp.GetProfile("default").Return(defaultProfile, lxdtesting.ETag, nil),
+		exp.UseProject("juju").Return(projSvr),
+		projExp.GetProfile("default").Return(projectProfile, "projectETag", nil),
+		projExp.UpdateProfile("default", lxdapi.ProfilePut{Devices: devices}, "projectETag").Return(nil),
+		exp.UseProject("juju").Return(projSvr),
+	)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	projectJujuSvr, err := jujuSvr.UseProject("juju")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(projectJujuSvr.Project(), gc.Equals, "juju")
+}
+
+func (s *projectSuite) TestUseProjectCreateRace(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	cSvr := s.NewMockServerWithExtensions(ctrl, "projects")
+	projSvr := lxdtesting.NewMockContainerServer(ctrl)
+	exp := cSvr.EXPECT()
+	gomock.InOrder(
+		exp.GetProject("juju").Return(nil, "", errors.New("not found")),
+		exp.CreateProject(gomock.Any()).Return(errors.New("already exists")),
+		exp.GetProject("juju").Return(&lxdapi.Project{Name: "juju"}, lxdtesting.ETag, nil),
+		exp.UseProject("juju").Return(projSvr),
+	)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = jujuSvr.UseProject("juju")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *projectSuite) TestUseProjectNotSupported(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	cSvr := s.NewMockServerWithExtensions(ctrl, "network")
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = jujuSvr.UseProject("juju")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *projectSuite) TestUseProjectDefault(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	cSvr := s.NewMockServerWithExtensions(ctrl, "projects")
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	projectJujuSvr, err := jujuSvr.UseProject("default")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(projectJujuSvr, gc.Equals, jujuSvr)
+}
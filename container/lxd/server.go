@@ -44,6 +44,16 @@ type Server struct {
 	networkAPISupport bool
 	clusterAPISupport bool
 	storageAPISupport bool
+	projectAPISupport bool
+
+	// instancesAPISupport and vmAPISupport indicate that the server
+	// can create, and report on, virtual machines.
+	instancesAPISupport bool
+	vmAPISupport        bool
+
+	// project is the name of the LXD project that operations are
+	// scoped to. It is empty for the default project.
+	project string
 
 	localBridgeName string
 
@@ -84,8 +94,18 @@ func NewRemoteServer(spec ServerSpec) (*Server, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+
+	// A certificate restricted to a project may not be used outside of
+	// it, so the project must be in scope before the first request.
+	if spec.Project != "" {
+		cSvr = cSvr.UseProject(spec.Project)
+	}
 	svr, err := NewServer(cSvr)
-	return svr, err
+	if err != nil {
+		return nil, err
+	}
+	svr.project = spec.Project
+	return svr, nil
 }
 
 // NewServer builds and returns a Server for high-level interaction with the
@@ -112,16 +132,19 @@ func NewServer(svr lxd.ContainerServer) (*Server, error) {
 	hostArch := arch.NormaliseArch(info.Environment.KernelArchitecture)
 
 	return &Server{
-		ContainerServer:   svr,
-		name:              name,
-		clustered:         clustered,
-		serverCertificate: serverCertificate,
-		hostArch:          hostArch,
-		networkAPISupport: shared.StringInSlice("network", apiExt),
-		clusterAPISupport: shared.StringInSlice("clustering", apiExt),
-		storageAPISupport: shared.StringInSlice("storage", apiExt),
-		serverVersion:     info.Environment.ServerVersion,
-		clock:             clock.WallClock,
+		ContainerServer:     svr,
+		name:                name,
+		clustered:           clustered,
+		serverCertificate:   serverCertificate,
+		hostArch:            hostArch,
+		networkAPISupport:   shared.StringInSlice("network", apiExt),
+		clusterAPISupport:   shared.StringInSlice("clustering", apiExt),
+		storageAPISupport:   shared.StringInSlice("storage", apiExt),
+		projectAPISupport:   shared.StringInSlice("projects", apiExt),
+		instancesAPISupport: shared.StringInSlice("instances", apiExt),
+		vmAPISupport:        shared.StringInSlice("virtual-machines", apiExt),
+		serverVersion:       info.Environment.ServerVersion,
+		clock:               clock.WallClock,
 	}, nil
 }
 
@@ -152,7 +175,7 @@ func (s *Server) UpdateServerConfig(cfg map[string]string) error {
 // UpdateContainerConfig updates the configuration for the container with the
 // input name, using the input values.
 func (s *Server) UpdateContainerConfig(name string, cfg map[string]string) error {
-	container, eTag, err := s.GetContainer(name)
+	container, eTag, err := s.getInstance(name)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -163,7 +186,7 @@ func (s *Server) UpdateContainerConfig(name string, cfg map[string]string) error
 		container.Config[k] = v
 	}
 
-	resp, err := s.UpdateContainer(name, container.Writable(), eTag)
+	resp, err := s.updateInstance(name, container.Writable(), eTag)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -173,7 +196,7 @@ func (s *Server) UpdateContainerConfig(name string, cfg map[string]string) error
 // GetContainerProfiles returns the list of profiles that are assocated with a
 // container.
 func (s *Server) GetContainerProfiles(name string) ([]string, error) {
-	container, _, err := s.GetContainer(name)
+	container, _, err := s.getInstance(name)
 	if err != nil {
 		return []string{}, errors.Trace(err)
 	}
@@ -186,14 +209,14 @@ func (s *Server) GetContainerProfiles(name string) ([]string, error) {
 // ReplaceOrAddContainerProfile updates the profiles for the container with the
 // input name, using the input values.
 func (s *Server) ReplaceOrAddContainerProfile(name, oldProfile, newProfile string) error {
-	container, eTag, err := s.GetContainer(name)
+	container, eTag, err := s.getInstance(name)
 	if err != nil {
 		return errors.Trace(errors.Annotatef(err, "failed to get container %q", name))
 	}
 	profiles := addRemoveReplaceProfileName(container.Profiles, oldProfile, newProfile)
 
 	container.Profiles = profiles
-	resp, err := s.UpdateContainer(name, container.Writable(), eTag)
+	resp, err := s.updateInstance(name, container.Writable(), eTag)
 	if err != nil {
 		return errors.Trace(errors.Annotatef(err, "failed to updated container %q", name))
 	}
@@ -232,13 +255,13 @@ func addRemoveReplaceProfileName(profiles []string, oldProfile, newProfile strin
 // named container.  It is assumed the profiles have all been added to
 // the server before hand.
 func (s *Server) UpdateContainerProfiles(name string, profiles []string) error {
-	container, eTag, err := s.GetContainer(name)
+	container, eTag, err := s.getInstance(name)
 	if err != nil {
 		return errors.Trace(errors.Annotatef(err, "failed to get %q", name))
 	}
 
 	container.Profiles = profiles
-	resp, err := s.UpdateContainer(name, container.Writable(), eTag)
+	resp, err := s.updateInstance(name, container.Writable(), eTag)
 	if err != nil {
 		return errors.Trace(errors.Annotatef(err, "failed to update %q with profiles", name))
 	}
@@ -0,0 +1,271 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/juju/errors"
+	lxd "github.com/lxc/lxd/client"
+	"github.com/lxc/lxd/shared"
+	"github.com/lxc/lxd/shared/api"
+	"github.com/lxc/lxd/shared/simplestreams"
+)
+
+const (
+	// VirtTypeContainer is the virt-type constraint value for a LXD
+	// system container. This is the default.
+	VirtTypeContainer = "container"
+
+	// VirtTypeVirtualMachine is the virt-type constraint value for a LXD
+	// virtual machine.
+	VirtTypeVirtualMachine = "virtual-machine"
+)
+
+// RemoteImage identifies an image by alias on a simplestreams server.
+// Our LXD client predates virtual machines and can not locate their images,
+// so the LXD server is left to download such images itself.
+type RemoteImage struct {
+	Server ServerSpec
+	Alias  string
+}
+
+// instancesPost represents the fields of a new LXD instance.
+// It extends the container request with the type of instance to create.
+type instancesPost struct {
+	api.ContainersPost `yaml:",inline"`
+
+	Type string `json:"type" yaml:"type"`
+}
+
+// VirtualMachineSupported returns true if the server supports the creation
+// of virtual machines.
+func (s *Server) VirtualMachineSupported() bool {
+	return s.instancesAPISupport && s.vmAPISupport
+}
+
+// FindVirtualMachineImage returns the image for a virtual machine with the
+// input series and architecture, from the first of the input simplestreams
+// sources that has one.
+func (s *Server) FindVirtualMachineImage(series, arch string, sources []ServerSpec) (RemoteImage, error) {
+	if !s.VirtualMachineSupported() {
+		return RemoteImage{}, errors.NotSupportedf("LXD virtual machines")
+	}
+	aliases, err := seriesRemoteAliases(series, arch)
+	if err != nil {
+		return RemoteImage{}, errors.Trace(err)
+	}
+	lastErr := errors.NotFoundf("simplestreams source for virtual machine image")
+	for _, source := range sources {
+		if source.Protocol != SimpleStreamsProtocol {
+			continue
+		}
+		alias, err := findVirtualMachineImageAlias(source, aliases)
+		if err != nil {
+			logger.Infof("no virtual machine image from %q: %s", source.Host, err)
+			lastErr = errors.Trace(err)
+			continue
+		}
+		return RemoteImage{Server: source, Alias: alias}, nil
+	}
+	return RemoteImage{}, lastErr
+}
+
+// findVirtualMachineImageAlias returns the first of the input aliases
+// that identifies a virtual machine image in the simplestreams metadata
+// of the input source.
+func findVirtualMachineImageAlias(source ServerSpec, aliases []string) (string, error) {
+	var index simplestreams.SimpleStreamsIndex
+	if err := getSimpleStreamsJSON(source.Host, "streams/v1/index.json", &index); err != nil {
+		return "", errors.Trace(err)
+	}
+	for _, stream := range index.Index {
+		if stream.DataType != "image-downloads" {
+			continue
+		}
+		var manifest simplestreams.SimpleStreamsManifest
+		if err := getSimpleStreamsJSON(source.Host, stream.Path, &manifest); err != nil {
+			return "", errors.Trace(err)
+		}
+		for _, alias := range aliases {
+			if hasVirtualMachineImage(&manifest, alias) {
+				return alias, nil
+			}
+		}
+	}
+	return "", errors.NotFoundf("virtual machine image %q", strings.Join(aliases, ", "))
+}
+
+// virtualMachineImageFileTypes are the simplestreams file types of the
+// disk images that LXD boots virtual machines from.
+var virtualMachineImageFileTypes = []string{"disk-kvm.img", "uefi1.img"}
+
+// hasVirtualMachineImage returns true if the manifest has a virtual
+// machine image for the product identified by the input alias, which is
+// made up of a product alias and architecture, as in "bionic/amd64".
+func hasVirtualMachineImage(manifest *simplestreams.SimpleStreamsManifest, alias string) bool {
+	name, arch := path.Dir(alias), path.Base(alias)
+	for _, product := range manifest.Products {
+		if product.Architecture != arch || !shared.StringInSlice(name, strings.Split(product.Aliases, ",")) {
+			continue
+		}
+		for _, version := range product.Versions {
+			for _, item := range version.Items {
+				if shared.StringInSlice(item.FileType, virtualMachineImageFileTypes) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// getSimpleStreamsJSON decodes the simplestreams JSON document at the
+// input path of the host into v.
+func getSimpleStreamsJSON(host, docPath string, v interface{}) error {
+	docURL := strings.TrimSuffix(host, "/") + "/" + docPath
+	resp, err := http.Get(docURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return errors.NotFoundf("%q", docURL)
+	default:
+		return errors.Errorf("cannot get %q: %s", docURL, resp.Status)
+	}
+	return errors.Annotatef(json.NewDecoder(resp.Body).Decode(v), "decoding %q", docURL)
+}
+
+// createVirtualMachine creates a new virtual machine based on the input spec.
+// The "cloud-init:config" disk device supplies the machine with its cloud-init
+// data, and with the LXD agent that reports its network state.
+func (s *Server) createVirtualMachine(spec ContainerSpec) error {
+	if !s.VirtualMachineSupported() {
+		return errors.NotSupportedf("LXD virtual machines")
+	}
+
+	devices := make(map[string]device, len(spec.Devices)+1)
+	for name, dev := range spec.Devices {
+		devices[name] = dev
+	}
+	devices["config"] = device{
+		"type":   "disk",
+		"source": "cloud-init:config",
+	}
+
+	req := instancesPost{
+		ContainersPost: api.ContainersPost{
+			Name:         spec.Name,
+			InstanceType: spec.InstanceType,
+			ContainerPut: api.ContainerPut{
+				Profiles:  spec.Profiles,
+				Devices:   devices,
+				Config:    spec.Config,
+				Ephemeral: false,
+			},
+			Source: api.ContainerSource{
+				Type:     "image",
+				Mode:     "pull",
+				Server:   spec.RemoteImage.Server.Host,
+				Protocol: string(spec.RemoteImage.Server.Protocol),
+				Alias:    spec.RemoteImage.Alias,
+			},
+		},
+		Type: VirtTypeVirtualMachine,
+	}
+	op, _, err := s.RawOperation("POST", "/instances", req, "")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(op.Wait())
+}
+
+// The methods below use the instances API when the server supports it,
+// so that they apply equally to containers and virtual machines.
+// Otherwise they fall back to the containers API used by our LXD client.
+
+func (s *Server) getInstances() ([]api.Container, error) {
+	if !s.instancesAPISupport {
+		return s.GetContainers()
+	}
+	var instances []api.Container
+	_, err := s.getInstanceStruct("", url.Values{"recursion": {"1"}}, &instances)
+	return instances, errors.Trace(err)
+}
+
+func (s *Server) getInstance(name string) (*api.Container, string, error) {
+	if !s.instancesAPISupport {
+		return s.GetContainer(name)
+	}
+	var instance api.Container
+	eTag, err := s.getInstanceStruct("/"+url.PathEscape(name), nil, &instance)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	return &instance, eTag, nil
+}
+
+func (s *Server) getInstanceState(name string) (*api.ContainerState, string, error) {
+	if !s.instancesAPISupport {
+		return s.GetContainerState(name)
+	}
+	var state api.ContainerState
+	eTag, err := s.getInstanceStruct("/"+url.PathEscape(name)+"/state", nil, &state)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	return &state, eTag, nil
+}
+
+func (s *Server) updateInstance(name string, put api.ContainerPut, eTag string) (lxd.Operation, error) {
+	if !s.instancesAPISupport {
+		return s.UpdateContainer(name, put, eTag)
+	}
+	op, _, err := s.RawOperation("PUT", "/instances/"+url.PathEscape(name), put, eTag)
+	return op, errors.Trace(err)
+}
+
+func (s *Server) updateInstanceState(name string, req api.ContainerStatePut, eTag string) (lxd.Operation, error) {
+	if !s.instancesAPISupport {
+		return s.UpdateContainerState(name, req, eTag)
+	}
+	op, _, err := s.RawOperation("PUT", "/instances/"+url.PathEscape(name)+"/state", req, eTag)
+	return op, errors.Trace(err)
+}
+
+func (s *Server) deleteInstance(name string) (lxd.Operation, error) {
+	if !s.instancesAPISupport {
+		return s.DeleteContainer(name)
+	}
+	op, _, err := s.RawOperation("DELETE", "/instances/"+url.PathEscape(name), nil, "")
+	return op, errors.Trace(err)
+}
+
+// getInstanceStruct queries the instances API at the input path and decodes
+// the response metadata into the target.
+// RawQuery does not add the project to the request, so we do it here.
+func (s *Server) getInstanceStruct(path string, query url.Values, target interface{}) (string, error) {
+	if query == nil {
+		query = url.Values{}
+	}
+	if s.project != "" {
+		query.Set("project", s.project)
+	}
+	uri := "/1.0/instances" + path
+	if len(query) > 0 {
+		uri += "?" + query.Encode()
+	}
+	resp, eTag, err := s.RawQuery("GET", uri, nil, "")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return eTag, errors.Trace(resp.MetadataAsStruct(target))
+}
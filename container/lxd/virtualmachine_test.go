@@ -0,0 +1,263 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	lxdclient "github.com/lxc/lxd/client"
+	"github.com/lxc/lxd/shared/api"
+	"github.com/lxc/lxd/shared/simplestreams"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/container/lxd"
+	lxdtesting "github.com/juju/juju/container/lxd/testing"
+)
+
+type virtualMachineSuite struct {
+	lxdtesting.BaseSuite
+}
+
+var _ = gc.Suite(&virtualMachineSuite{})
+
+func (s *virtualMachineSuite) TestVirtualMachineSupported(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	jujuSvr, err := lxd.NewServer(s.NewMockServerWithExtensions(ctrl, "instances", "virtual-machines"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(jujuSvr.VirtualMachineSupported(), jc.IsTrue)
+
+	jujuSvr, err = lxd.NewServer(s.NewMockServerWithExtensions(ctrl, "instances"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(jujuSvr.VirtualMachineSupported(), jc.IsFalse)
+}
+
+func (s *virtualMachineSuite) TestFindVirtualMachineImage(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	jujuSvr, err := lxd.NewServer(s.NewMockServerWithExtensions(ctrl, "instances", "virtual-machines"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Neither the source without simplestreams metadata nor the one with
+	// only container images is used.
+	noStreams := httptest.NewServer(http.NotFoundHandler())
+	defer noStreams.Close()
+	containerStreams := newSimpleStreamsServer("bionic", "amd64", "squashfs")
+	defer containerStreams.Close()
+	vmStreams := newSimpleStreamsServer("bionic", "amd64", "disk-kvm.img")
+	defer vmStreams.Close()
+
+	local := lxd.ServerSpec{Name: "local", Protocol: lxd.LXDProtocol}
+	vmSource := lxd.ServerSpec{Name: "vm", Host: vmStreams.URL, Protocol: lxd.SimpleStreamsProtocol}
+	sources := []lxd.ServerSpec{
+		local,
+		{Name: "none", Host: noStreams.URL, Protocol: lxd.SimpleStreamsProtocol},
+		{Name: "container", Host: containerStreams.URL, Protocol: lxd.SimpleStreamsProtocol},
+		vmSource,
+	}
+
+	image, err := jujuSvr.FindVirtualMachineImage("bionic", "amd64", sources)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(image, gc.DeepEquals, lxd.RemoteImage{
+		Server: vmSource,
+		Alias:  "bionic/amd64",
+	})
+}
+
+func (s *virtualMachineSuite) TestFindVirtualMachineImageNotFound(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	jujuSvr, err := lxd.NewServer(s.NewMockServerWithExtensions(ctrl, "instances", "virtual-machines"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// There is a virtual machine image, but not for the architecture.
+	vmStreams := newSimpleStreamsServer("bionic", "arm64", "disk-kvm.img")
+	defer vmStreams.Close()
+	sources := []lxd.ServerSpec{{Name: "vm", Host: vmStreams.URL, Protocol: lxd.SimpleStreamsProtocol}}
+
+	_, err = jujuSvr.FindVirtualMachineImage("bionic", "amd64", sources)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+// newSimpleStreamsServer returns a server of simplestreams metadata
+// describing an image of the input file type, for the product with the
+// input alias and architecture.
+func newSimpleStreamsServer(alias, arch, fileType string) *httptest.Server {
+	index := simplestreams.SimpleStreamsIndex{
+		Format: "index:1.0",
+		Index: map[string]simplestreams.SimpleStreamsIndexStream{
+			"images": {
+				DataType: "image-downloads",
+				Path:     "streams/v1/images.json",
+			},
+		},
+	}
+	manifest := simplestreams.SimpleStreamsManifest{
+		DataType: "image-downloads",
+		Format:   "products:1.0",
+		Products: map[string]simplestreams.SimpleStreamsManifestProduct{
+			"product": {
+				Aliases:      "18.04," + alias,
+				Architecture: arch,
+				Versions: map[string]simplestreams.SimpleStreamsManifestProductVersion{
+					"20191001": {
+						Items: map[string]simplestreams.SimpleStreamsManifestProductVersionItem{
+							"lxd.tar.xz": {FileType: "lxd.tar.xz"},
+							"image":      {FileType: fileType},
+						},
+					},
+				},
+			},
+		},
+	}
+	mux := http.NewServeMux()
+	serveJSON := func(v interface{}) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) {
+			_ = json.NewEncoder(w).Encode(v)
+		}
+	}
+	mux.Handle("/streams/v1/index.json", serveJSON(index))
+	mux.Handle("/streams/v1/images.json", serveJSON(manifest))
+	return httptest.NewServer(mux)
+}
+
+func (s *virtualMachineSuite) TestFindVirtualMachineImageNotSupported(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	jujuSvr, err := lxd.NewServer(s.NewMockServer(ctrl))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = jujuSvr.FindVirtualMachineImage("bionic", "amd64", []lxd.ServerSpec{lxd.CloudImagesRemote})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *virtualMachineSuite) TestCreateContainerFromSpecVirtualMachine(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServerWithExtensions(ctrl, "instances", "virtual-machines")
+
+	createOp := lxdtesting.NewMockOperation(ctrl)
+	createOp.EXPECT().Wait().Return(nil)
+	startOp := lxdtesting.NewMockOperation(ctrl)
+	startOp.EXPECT().Wait().Return(nil)
+
+	spec := lxd.ContainerSpec{
+		Name:     "vm1",
+		VirtType: lxd.VirtTypeVirtualMachine,
+		RemoteImage: lxd.RemoteImage{
+			Server: lxd.CloudImagesRemote,
+			Alias:  "bionic/amd64",
+		},
+		Profiles: []string{"default"},
+		Config: map[string]string{
+			"limits.cpu": "2",
+		},
+	}
+
+	// The request is checked in its JSON representation,
+	// which is how it is sent to the server.
+	createReq := map[string]interface{}{}
+
+	startReq := api.ContainerStatePut{
+		Action:  "start",
+		Timeout: -1,
+	}
+
+	vm, err := json.Marshal(api.Container{Name: "vm1"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	exp := cSvr.EXPECT()
+	gomock.InOrder(
+		exp.RawOperation("POST", "/instances", gomock.Any(), "").DoAndReturn(
+			func(_, _ string, req interface{}, _ string) (lxdclient.Operation, string, error) {
+				data, err := json.Marshal(req)
+				c.Assert(err, jc.ErrorIsNil)
+				c.Assert(json.Unmarshal(data, &createReq), jc.ErrorIsNil)
+				return createOp, "", nil
+			}),
+		exp.RawOperation("PUT", "/instances/vm1/state", startReq, "").Return(startOp, "", nil),
+		exp.RawQuery("GET", "/1.0/instances/vm1", nil, "").Return(&api.Response{Metadata: vm}, lxdtesting.ETag, nil),
+	)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	container, err := jujuSvr.CreateContainerFromSpec(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(container.Name, gc.Equals, "vm1")
+
+	c.Check(createReq["type"], gc.Equals, "virtual-machine")
+	c.Check(createReq["source"], gc.DeepEquals, map[string]interface{}{
+		"type":        "image",
+		"certificate": "",
+		"mode":        "pull",
+		"server":      lxd.CloudImagesRemote.Host,
+		"protocol":    "simplestreams",
+		"alias":       "bionic/amd64",
+	})
+	c.Check(createReq["devices"], gc.DeepEquals, map[string]interface{}{
+		"config": map[string]interface{}{
+			"type":   "disk",
+			"source": "cloud-init:config",
+		},
+	})
+}
+
+func (s *virtualMachineSuite) TestContainerAddressesNoAgent(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServerWithExtensions(ctrl, "instances", "virtual-machines")
+
+	// A virtual machine reports no network state until its agent is up.
+	state, err := json.Marshal(api.ContainerState{StatusCode: api.Running})
+	c.Assert(err, jc.ErrorIsNil)
+	cSvr.EXPECT().RawQuery("GET", "/1.0/instances/vm1/state", nil, "").Return(
+		&api.Response{Metadata: state}, lxdtesting.ETag, nil)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	addrs, err := jujuSvr.ContainerAddresses("vm1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addrs, gc.HasLen, 0)
+}
+
+func (s *virtualMachineSuite) TestFilterContainersWithProject(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServerWithExtensions(ctrl, "instances", "projects")
+	projSvr := lxdtesting.NewMockContainerServer(ctrl)
+
+	instances, err := json.Marshal([]api.Container{
+		{Name: "juju-vm1", StatusCode: api.Running},
+		{Name: "other", StatusCode: api.Running},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	gomock.InOrder(
+		cSvr.EXPECT().GetProject("juju").Return(&api.Project{Name: "juju"}, lxdtesting.ETag, nil),
+		cSvr.EXPECT().UseProject("juju").Return(projSvr),
+		projSvr.EXPECT().RawQuery("GET", "/1.0/instances?project=juju&recursion=1", nil, "").Return(
+			&api.Response{Metadata: instances}, lxdtesting.ETag, nil),
+	)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+	jujuSvr, err = jujuSvr.UseProject("juju")
+	c.Assert(err, jc.ErrorIsNil)
+
+	filtered, err := jujuSvr.FilterContainers("juju-")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filtered, gc.HasLen, 1)
+	c.Check(filtered[0].Name, gc.Equals, "juju-vm1")
+}
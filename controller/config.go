@@ -6,6 +6,7 @@ package controller
 import (
 	"fmt"
 	"net/url"
+	"path/filepath"
 	"regexp"
 	"time"
 
@@ -156,6 +157,50 @@ const (
 	// to not sleep at all.
 	PruneTxnSleepTime = "prune-txn-sleep-time"
 
+	// BackupScheduleInterval is how often the controller creates a
+	// scheduled backup, eg "24h". An empty or zero value disables
+	// scheduled backups.
+	BackupScheduleInterval = "backup-schedule-interval"
+
+	// BackupRetentionCount is the number of the most recent scheduled
+	// backups which are always kept.
+	BackupRetentionCount = "backup-retention-count"
+
+	// BackupRetentionDaily is the number of days for which the most
+	// recent scheduled backup of each day is kept.
+	BackupRetentionDaily = "backup-retention-daily"
+
+	// BackupRetentionWeekly is the number of weeks for which the most
+	// recent scheduled backup of each week is kept.
+	BackupRetentionWeekly = "backup-retention-weekly"
+
+	// BackupStorage is where backup archives are stored: "controller"
+	// (the controller's database), "directory" or "s3".
+	BackupStorage = "backup-storage"
+
+	// BackupStoragePath is the directory in which backups are stored
+	// when backup-storage is "directory".
+	BackupStoragePath = "backup-storage-path"
+
+	// BackupS3Endpoint is the URL of the S3-compatible object store in
+	// which backups are stored when backup-storage is "s3". If empty,
+	// the AWS endpoint for backup-s3-region is used.
+	BackupS3Endpoint = "backup-s3-endpoint"
+
+	// BackupS3Region is the region of the bucket in which backups
+	// are stored when backup-storage is "s3".
+	BackupS3Region = "backup-s3-region"
+
+	// BackupS3Bucket is the name of the bucket in which backups are
+	// stored when backup-storage is "s3".
+	BackupS3Bucket = "backup-s3-bucket"
+
+	// BackupS3AccessKey is the access key used for the backup bucket.
+	BackupS3AccessKey = "backup-s3-access-key"
+
+	// BackupS3SecretKey is the secret key used for the backup bucket.
+	BackupS3SecretKey = "backup-s3-secret-key"
+
 	// Attribute Defaults
 
 	// DefaultAuditingEnabled contains the default value for the
@@ -217,6 +262,21 @@ const (
 	// other systems to operate concurrently.
 	DefaultPruneTxnSleepTime = "10ms"
 
+	// DefaultBackupRetentionCount is the default number of the most
+	// recent scheduled backups to keep.
+	DefaultBackupRetentionCount = 7
+
+	// BackupStorageController stores backups in the controller's
+	// database. This is the default.
+	BackupStorageController = "controller"
+
+	// BackupStorageDirectory stores backups in a local or
+	// network-mounted directory on the controller machines.
+	BackupStorageDirectory = "directory"
+
+	// BackupStorageS3 stores backups in an S3-compatible object store.
+	BackupStorageS3 = "s3"
+
 	// JujuHASpace is the network space within which the MongoDB replica-set
 	// should communicate.
 	JujuHASpace = "juju-ha-space"
@@ -266,6 +326,17 @@ var (
 		MaxPruneTxnPasses,
 		PruneTxnQueryCount,
 		PruneTxnSleepTime,
+		BackupScheduleInterval,
+		BackupRetentionCount,
+		BackupRetentionDaily,
+		BackupRetentionWeekly,
+		BackupStorage,
+		BackupStoragePath,
+		BackupS3Endpoint,
+		BackupS3Region,
+		BackupS3Bucket,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
 		JujuHASpace,
 		JujuManagementSpace,
 		AuditingEnabled,
@@ -297,6 +368,17 @@ var (
 		MongoMemoryProfile,
 		PruneTxnQueryCount,
 		PruneTxnSleepTime,
+		BackupScheduleInterval,
+		BackupRetentionCount,
+		BackupRetentionDaily,
+		BackupRetentionWeekly,
+		BackupStorage,
+		BackupStoragePath,
+		BackupS3Endpoint,
+		BackupS3Region,
+		BackupS3Bucket,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
 		JujuHASpace,
 		JujuManagementSpace,
 		CAASOperatorImagePath,
@@ -304,6 +386,12 @@ var (
 		Features,
 	)
 
+	// SecretAttributes are attributes whose values are secret, and
+	// which are therefore not returned to clients.
+	SecretAttributes = set.NewStrings(
+		BackupS3SecretKey,
+	)
+
 	// DefaultAuditLogExcludeMethods is the default list of methods to
 	// exclude from the audit log.
 	DefaultAuditLogExcludeMethods = []string{
@@ -620,6 +708,72 @@ func (c Config) PruneTxnSleepTime() time.Duration {
 	return val
 }
 
+// BackupScheduleInterval returns how often scheduled backups are
+// created. A zero value means scheduled backups are disabled.
+func (c Config) BackupScheduleInterval() time.Duration {
+	// Value has already been validated.
+	val, _ := time.ParseDuration(c.asString(BackupScheduleInterval))
+	return val
+}
+
+// BackupRetentionCount returns the number of the most recent
+// scheduled backups which are always kept.
+func (c Config) BackupRetentionCount() int {
+	return c.intOrDefault(BackupRetentionCount, DefaultBackupRetentionCount)
+}
+
+// BackupRetentionDaily returns the number of days for which the
+// most recent scheduled backup of each day is kept.
+func (c Config) BackupRetentionDaily() int {
+	return c.intOrDefault(BackupRetentionDaily, 0)
+}
+
+// BackupRetentionWeekly returns the number of weeks for which the
+// most recent scheduled backup of each week is kept.
+func (c Config) BackupRetentionWeekly() int {
+	return c.intOrDefault(BackupRetentionWeekly, 0)
+}
+
+// BackupStorage returns where backup archives are stored.
+func (c Config) BackupStorage() string {
+	if v := c.asString(BackupStorage); v != "" {
+		return v
+	}
+	return BackupStorageController
+}
+
+// BackupStoragePath returns the directory in which backups are stored
+// when using directory backup storage.
+func (c Config) BackupStoragePath() string {
+	return c.asString(BackupStoragePath)
+}
+
+// BackupS3Endpoint returns the URL of the object store in which
+// backups are stored when using S3 backup storage.
+func (c Config) BackupS3Endpoint() string {
+	return c.asString(BackupS3Endpoint)
+}
+
+// BackupS3Region returns the region of the backup bucket.
+func (c Config) BackupS3Region() string {
+	return c.asString(BackupS3Region)
+}
+
+// BackupS3Bucket returns the name of the backup bucket.
+func (c Config) BackupS3Bucket() string {
+	return c.asString(BackupS3Bucket)
+}
+
+// BackupS3AccessKey returns the access key for the backup bucket.
+func (c Config) BackupS3AccessKey() string {
+	return c.asString(BackupS3AccessKey)
+}
+
+// BackupS3SecretKey returns the secret key for the backup bucket.
+func (c Config) BackupS3SecretKey() string {
+	return c.asString(BackupS3SecretKey)
+}
+
 // JujuHASpace is the network space within which the MongoDB replica-set
 // should communicate.
 func (c Config) JujuHASpace() string {
@@ -718,6 +872,29 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[BackupScheduleInterval].(string); ok && v != "" {
+		interval, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotatef(err, `%s must be a valid duration (eg "24h")`, BackupScheduleInterval)
+		}
+		if interval < 0 {
+			return errors.Errorf("%s must not be negative, got %v", BackupScheduleInterval, interval)
+		}
+	}
+
+	if v, ok := c[BackupRetentionCount].(int); ok && v < 1 {
+		return errors.Errorf("%s must be at least 1, got %d", BackupRetentionCount, v)
+	}
+	for _, key := range []string{BackupRetentionDaily, BackupRetentionWeekly} {
+		if v, ok := c[key].(int); ok && v < 0 {
+			return errors.Errorf("%s must not be negative, got %d", key, v)
+		}
+	}
+
+	if err := c.validateBackupStorage(); err != nil {
+		return errors.Trace(err)
+	}
+
 	if err := c.validateSpaceConfig(JujuHASpace, "juju HA"); err != nil {
 		return errors.Trace(err)
 	}
@@ -795,6 +972,39 @@ func Validate(c Config) error {
 	return nil
 }
 
+func (c Config) validateBackupStorage() error {
+	switch storage := c.BackupStorage(); storage {
+	case BackupStorageController:
+	case BackupStorageDirectory:
+		path := c.BackupStoragePath()
+		if path == "" {
+			return errors.Errorf("%s must be set when %s is %q", BackupStoragePath, BackupStorage, storage)
+		}
+		if !filepath.IsAbs(path) {
+			return errors.Errorf("%s must be an absolute path, got %q", BackupStoragePath, path)
+		}
+	case BackupStorageS3:
+		for _, key := range []string{BackupS3Bucket, BackupS3AccessKey, BackupS3SecretKey} {
+			if c.asString(key) == "" {
+				return errors.Errorf("%s must be set when %s is %q", key, BackupStorage, storage)
+			}
+		}
+		if c.BackupS3Endpoint() == "" && c.BackupS3Region() == "" {
+			return errors.Errorf("one of %s or %s must be set when %s is %q",
+				BackupS3Endpoint, BackupS3Region, BackupStorage, storage)
+		}
+		if v := c.BackupS3Endpoint(); v != "" {
+			if u, err := url.Parse(v); err != nil || u.Scheme == "" || u.Host == "" {
+				return errors.Errorf("%s must be a URL, got %q", BackupS3Endpoint, v)
+			}
+		}
+	default:
+		return errors.Errorf("%s must be one of %q, %q or %q, got %q", BackupStorage,
+			BackupStorageController, BackupStorageDirectory, BackupStorageS3, storage)
+	}
+	return nil
+}
+
 func (c Config) validateSpaceConfig(key, topic string) error {
 	val := c[key]
 	if val == nil {
@@ -870,6 +1080,17 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MaxPruneTxnPasses:       schema.ForceInt(),
 	PruneTxnQueryCount:      schema.ForceInt(),
 	PruneTxnSleepTime:       schema.String(),
+	BackupScheduleInterval:  schema.String(),
+	BackupRetentionCount:    schema.ForceInt(),
+	BackupRetentionDaily:    schema.ForceInt(),
+	BackupRetentionWeekly:   schema.ForceInt(),
+	BackupStorage:           schema.String(),
+	BackupStoragePath:       schema.String(),
+	BackupS3Endpoint:        schema.String(),
+	BackupS3Region:          schema.String(),
+	BackupS3Bucket:          schema.String(),
+	BackupS3AccessKey:       schema.String(),
+	BackupS3SecretKey:       schema.String(),
 	JujuHASpace:             schema.String(),
 	JujuManagementSpace:     schema.String(),
 	CAASOperatorImagePath:   schema.String(),
@@ -901,6 +1122,17 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MaxPruneTxnPasses:       DefaultMaxPruneTxnPasses,
 	PruneTxnQueryCount:      DefaultPruneTxnQueryCount,
 	PruneTxnSleepTime:       DefaultPruneTxnSleepTime,
+	BackupScheduleInterval:  schema.Omit,
+	BackupRetentionCount:    schema.Omit,
+	BackupRetentionDaily:    schema.Omit,
+	BackupRetentionWeekly:   schema.Omit,
+	BackupStorage:           schema.Omit,
+	BackupStoragePath:       schema.Omit,
+	BackupS3Endpoint:        schema.Omit,
+	BackupS3Region:          schema.Omit,
+	BackupS3Bucket:          schema.Omit,
+	BackupS3AccessKey:       schema.Omit,
+	BackupS3SecretKey:       schema.Omit,
 	JujuHASpace:             schema.Omit,
 	JujuManagementSpace:     schema.Omit,
 	CAASOperatorImagePath:   schema.Omit,
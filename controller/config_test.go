@@ -275,6 +275,87 @@ var validateTests = []struct {
 		controller.MongoMemoryProfile: "not-valid",
 	},
 	expectError: `mongo-memory-profile: expected one of "low" or "default" got string\("not-valid"\)`,
+}, {
+	about: "backup-schedule-interval not a duration",
+	config: controller.Config{
+		controller.CACertKey:              testing.CACert,
+		controller.BackupScheduleInterval: "daily",
+	},
+	expectError: `backup-schedule-interval must be a valid duration \(eg "24h"\): time: invalid duration .*`,
+}, {
+	about: "backup-schedule-interval negative",
+	config: controller.Config{
+		controller.CACertKey:              testing.CACert,
+		controller.BackupScheduleInterval: "-1h",
+	},
+	expectError: `backup-schedule-interval must not be negative, got -1h0m0s`,
+}, {
+	about: "backup-retention-count zero",
+	config: controller.Config{
+		controller.CACertKey:            testing.CACert,
+		controller.BackupRetentionCount: 0,
+	},
+	expectError: `backup-retention-count must be at least 1, got 0`,
+}, {
+	about: "backup-retention-weekly negative",
+	config: controller.Config{
+		controller.CACertKey:             testing.CACert,
+		controller.BackupRetentionWeekly: -1,
+	},
+	expectError: `backup-retention-weekly must not be negative, got -1`,
+}, {
+	about: "unknown backup-storage",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.BackupStorage: "tape",
+	},
+	expectError: `backup-storage must be one of "controller", "directory" or "s3", got "tape"`,
+}, {
+	about: "backup-storage directory without path",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.BackupStorage: "directory",
+	},
+	expectError: `backup-storage-path must be set when backup-storage is "directory"`,
+}, {
+	about: "backup-storage-path relative",
+	config: controller.Config{
+		controller.CACertKey:         testing.CACert,
+		controller.BackupStorage:     "directory",
+		controller.BackupStoragePath: "backups",
+	},
+	expectError: `backup-storage-path must be an absolute path, got "backups"`,
+}, {
+	about: "backup-storage s3 without bucket",
+	config: controller.Config{
+		controller.CACertKey:         testing.CACert,
+		controller.BackupStorage:     "s3",
+		controller.BackupS3Region:    "us-east-1",
+		controller.BackupS3AccessKey: "access",
+		controller.BackupS3SecretKey: "secret",
+	},
+	expectError: `backup-s3-bucket must be set when backup-storage is "s3"`,
+}, {
+	about: "backup-storage s3 without endpoint or region",
+	config: controller.Config{
+		controller.CACertKey:         testing.CACert,
+		controller.BackupStorage:     "s3",
+		controller.BackupS3Bucket:    "backups",
+		controller.BackupS3AccessKey: "access",
+		controller.BackupS3SecretKey: "secret",
+	},
+	expectError: `one of backup-s3-endpoint or backup-s3-region must be set when backup-storage is "s3"`,
+}, {
+	about: "backup-s3-endpoint not a URL",
+	config: controller.Config{
+		controller.CACertKey:         testing.CACert,
+		controller.BackupStorage:     "s3",
+		controller.BackupS3Endpoint:  "minio.local",
+		controller.BackupS3Bucket:    "backups",
+		controller.BackupS3AccessKey: "access",
+		controller.BackupS3SecretKey: "secret",
+	},
+	expectError: `backup-s3-endpoint must be a URL, got "minio.local"`,
 }}
 
 func (s *ConfigSuite) TestValidate(c *gc.C) {
@@ -372,6 +453,61 @@ func (s *ConfigSuite) TestPruneTxnQueryCount(c *gc.C) {
 	c.Check(cfg.PruneTxnSleepTime(), gc.Equals, 5*time.Millisecond)
 }
 
+func (s *ConfigSuite) TestBackupScheduleDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupScheduleInterval(), gc.Equals, time.Duration(0))
+	c.Check(cfg.BackupRetentionCount(), gc.Equals, controller.DefaultBackupRetentionCount)
+	c.Check(cfg.BackupRetentionDaily(), gc.Equals, 0)
+	c.Check(cfg.BackupRetentionWeekly(), gc.Equals, 0)
+}
+
+func (s *ConfigSuite) TestBackupSchedule(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-schedule-interval": "12h",
+			"backup-retention-count":   "3",
+			"backup-retention-daily":   7,
+			"backup-retention-weekly":  4,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupScheduleInterval(), gc.Equals, 12*time.Hour)
+	c.Check(cfg.BackupRetentionCount(), gc.Equals, 3)
+	c.Check(cfg.BackupRetentionDaily(), gc.Equals, 7)
+	c.Check(cfg.BackupRetentionWeekly(), gc.Equals, 4)
+	c.Check(controller.AllowedUpdateConfigAttributes.Contains(controller.BackupScheduleInterval), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestBackupStorageDefault(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupStorage(), gc.Equals, controller.BackupStorageController)
+}
+
+func (s *ConfigSuite) TestBackupStorageS3(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-storage":       "s3",
+			"backup-s3-endpoint":   "https://minio.local:9000",
+			"backup-s3-bucket":     "backups",
+			"backup-s3-access-key": "access",
+			"backup-s3-secret-key": "secret",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupStorage(), gc.Equals, controller.BackupStorageS3)
+	c.Check(cfg.BackupS3Endpoint(), gc.Equals, "https://minio.local:9000")
+	c.Check(cfg.BackupS3Region(), gc.Equals, "")
+	c.Check(cfg.BackupS3Bucket(), gc.Equals, "backups")
+	c.Check(cfg.BackupS3AccessKey(), gc.Equals, "access")
+	c.Check(cfg.BackupS3SecretKey(), gc.Equals, "secret")
+}
+
 func (s *ConfigSuite) TestNetworkSpaceConfigValues(c *gc.C) {
 	haSpace := "space1"
 	managementSpace := "space2"
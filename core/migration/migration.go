@@ -32,6 +32,15 @@ type MigrationStatus struct {
 	// TargetInfo contains the details of how to connect to the target
 	// controller.
 	TargetInfo TargetInfo
+
+	// Resume indicates that the migration resumes an earlier, aborted
+	// attempt to migrate the model to the same target controller.
+	Resume bool
+
+	// KeepImport indicates that the imported model is kept on the
+	// target controller if the migration is aborted, so that a later
+	// migration can resume it.
+	KeepImport bool
 }
 
 // SerializedModel wraps a buffer contain a serialised Juju model as
@@ -53,6 +62,26 @@ type SerializedModel struct {
 	Resources []SerializedModelResource
 }
 
+// ImportedBinaries describes the binaries that a target controller
+// already holds for a model being imported, so that they need not be
+// uploaded again when a migration is resumed.
+type ImportedBinaries struct {
+	// Charms maps the URLs of the charms that have been uploaded to
+	// the hex encoded SHA256 hashes of their archives.
+	Charms map[string]string
+
+	// Tools maps the versions of the agent binaries that have been
+	// uploaded to the hex encoded SHA256 hashes of their archives.
+	Tools map[version.Binary]string
+
+	// Resources maps the application resources that have been
+	// uploaded, as "application/name", to the hex encoded
+	// fingerprints of their content. The content of a resource can
+	// change without its revision changing, so resources are only
+	// skipped when the fingerprints match.
+	Resources map[string]string
+}
+
 // SerializedModelResource defines the resource revisions for a
 // specific application and its units.
 type SerializedModelResource struct {
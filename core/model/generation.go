@@ -52,9 +52,47 @@ type GenerationApplication struct {
 
 	// Config changes are the differing configuration values between this
 	// generation and the current.
-	// TODO (manadart 2018-02-22) This data-type will evolve as more aspects
-	// of the application are made generational.
 	ConfigChanges map[string]interface{} `yaml:"config"`
+
+	// CharmURL is the charm that units tracking the generation
+	// are upgraded to, if it was changed under the generation.
+	CharmURL string `yaml:"charm,omitempty"`
+
+	// Resources is the revisions of resources, keyed by name,
+	// that were changed under the generation.
+	Resources map[string]int `yaml:"resources,omitempty"`
+
+	// Constraints is the application constraints,
+	// if they were changed under the generation.
+	Constraints string `yaml:"constraints,omitempty"`
+
+	// Rollout is the progress of moving the application's units onto the
+	// generation in waves, if such a rollout was started.
+	Rollout *GenerationRollout `yaml:"rollout,omitempty"`
+}
+
+// GenerationRollout describes the progress of moving
+// an application's units onto a generation in waves.
+type GenerationRollout struct {
+	// Status is one of "running", "completed" or "halted".
+	Status string `yaml:"status"`
+
+	// Message describes why the rollout was halted.
+	Message string `yaml:"message,omitempty"`
+
+	// WaveSize is the number of units in each wave,
+	// or the percentage of units if suffixed with "%".
+	WaveSize string `yaml:"wave-size"`
+
+	// WaveTimeout is the time allowed for units in a wave
+	// to become active and idle.
+	WaveTimeout string `yaml:"wave-timeout"`
+
+	// Wave is the number of the current wave.
+	Wave int `yaml:"wave"`
+
+	// WaveUnits is the names of the units in the current wave.
+	WaveUnits []string `yaml:"wave-units,omitempty"`
 }
 
 // Generation represents detail of a model generation including config changes.
@@ -225,6 +225,11 @@ const (
 	Provisioning      Status = "allocating"
 	Running           Status = "running"
 	ProvisioningError Status = "provisioning error"
+
+	// Preempted is set when:
+	// The cloud has reclaimed an interruptible (spot or preemptible)
+	// instance, leaving the machine without its instance.
+	Preempted Status = "preempted"
 )
 
 // ModificationStatus
@@ -261,6 +266,7 @@ func (status Status) KnownInstanceStatus() bool {
 		ProvisioningError,
 		Allocating,
 		Running,
+		Preempted,
 		Error,
 		Unknown:
 		return true
@@ -93,6 +93,9 @@ const (
 	KindContainerInstance HistoryKind = "container"
 	// KindContainer represents an entry for a container agent.
 	KindContainer HistoryKind = "juju-container"
+	// KindCloudEvent represents an event reported by the cloud
+	// for a unit's container or an application's operator.
+	KindCloudEvent HistoryKind = "cloud-event"
 )
 
 // String returns a string representation of the HistoryKind.
@@ -105,7 +108,8 @@ func (k HistoryKind) Valid() bool {
 	switch k {
 	case KindUnit, KindUnitAgent, KindWorkload,
 		KindMachineInstance, KindMachine,
-		KindContainerInstance, KindContainer:
+		KindContainerInstance, KindContainer,
+		KindCloudEvent:
 		return true
 	}
 	return false
@@ -114,12 +118,13 @@ func (k HistoryKind) Valid() bool {
 // AllHistoryKind will return all valid HistoryKinds.
 func AllHistoryKind() map[HistoryKind]string {
 	return map[HistoryKind]string{
-		KindUnit:              "statuses for specified unit and its workload",
+		KindUnit:              "statuses for specified unit, its workload and cloud events",
 		KindUnitAgent:         "statuses from the agent that is managing a unit",
 		KindWorkload:          "statuses for unit's workload",
 		KindMachineInstance:   "statuses that occur due to provisioning of a machine",
 		KindMachine:           "status of the agent that is managing a machine",
 		KindContainerInstance: "statuses from the agent that is managing containers",
 		KindContainer:         "statuses from the containers only and not their host machines",
+		KindCloudEvent:        "events reported by the cloud for a unit or an application operator",
 	}
 }
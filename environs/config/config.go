@@ -88,6 +88,10 @@ const (
 	// of OS image metadata for containers.
 	ContainerImageMetadataURLKey = "container-image-metadata-url"
 
+	// ContainerLXDProjectKey is the key used to specify the LXD project
+	// in which LXD containers are created on machines.
+	ContainerLXDProjectKey = "container-lxd-project"
+
 	// Proxy behaviour has become something of an annoying thing to define
 	// well. These following four proxy variables are being kept to continue
 	// with the existing behaviour for those deployments that specify them.
@@ -216,6 +220,21 @@ const (
 	// grow to before it is pruned, eg "5M"
 	MaxActionResultsSize = "max-action-results-size"
 
+	// MaxBranchAge is the maximum age of committed or aborted model
+	// branches to keep when pruning, eg "72h"
+	MaxBranchAge = "max-branch-age"
+
+	// LostMachineThresholdKey is how long a machine's agent must be lost,
+	// with its instance stopped or missing, before the machine is marked
+	// as failed, eg "2h". Machines are never marked as failed when it is
+	// unset or zero.
+	LostMachineThresholdKey = "lost-machine-threshold"
+
+	// ReprovisionLostMachinesKey determines whether a new instance is
+	// started for a machine that has been marked as failed because it
+	// was lost.
+	ReprovisionLostMachinesKey = "reprovision-lost-machines"
+
 	// UpdateStatusHookInterval is how often to run the update-status hook.
 	UpdateStatusHookInterval = "update-status-hook-interval"
 
@@ -402,6 +421,9 @@ const (
 	DefaultActionResultsAge = "336h" // 2 weeks
 
 	DefaultActionResultsSize = "5G"
+
+	// DefaultBranchAge is the default value for MaxBranchAge.
+	DefaultBranchAge = "720h" // 30 days
 )
 
 var defaultConfigValues = map[string]interface{}{
@@ -486,6 +508,13 @@ var defaultConfigValues = map[string]interface{}{
 	MaxStatusHistorySize: DefaultStatusHistorySize,
 	MaxActionResultsAge:  DefaultActionResultsAge,
 	MaxActionResultsSize: DefaultActionResultsSize,
+
+	// Branch settings
+	MaxBranchAge: DefaultBranchAge,
+
+	// Machine health settings
+	LostMachineThresholdKey:    "",
+	ReprovisionLostMachinesKey: false,
 }
 
 // ConfigDefaults returns the config default values
@@ -628,6 +657,20 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[MaxBranchAge].(string); ok {
+		if _, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid max branch age in model configuration")
+		}
+	}
+
+	if v, ok := cfg.defined[LostMachineThresholdKey].(string); ok && v != "" {
+		if f, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid lost machine threshold in model configuration")
+		} else if f < 0 {
+			return errors.Errorf("lost machine threshold in model configuration must not be negative, got %v", f)
+		}
+	}
+
 	if v, ok := cfg.defined[UpdateStatusHookInterval].(string); ok {
 		if f, err := time.ParseDuration(v); err != nil {
 			return errors.Annotate(err, "invalid update status hook interval in model configuration")
@@ -675,35 +718,9 @@ func Validate(cfg, old *Config) error {
 	}
 
 	if raw, ok := cfg.defined[CloudInitUserDataKey].(string); ok && raw != "" {
-		userDataMap, err := ensureStringMaps(raw)
-		if err != nil {
+		if _, err := ParseCloudInitUserData(raw); err != nil {
 			return errors.Annotate(err, "cloudinit-userdata")
 		}
-
-		// if there packages, ensure they are strings
-		if packages, ok := userDataMap["packages"].([]interface{}); ok {
-			for _, v := range packages {
-				checker := schema.String()
-				if _, err := checker.Coerce(v, nil); err != nil {
-					return errors.Annotate(err, "cloudinit-userdata: packages must be a list of strings")
-				}
-			}
-		}
-
-		// error if users is specified
-		if _, ok := userDataMap["users"]; ok {
-			return errors.New("cloudinit-userdata: users not allowed")
-		}
-
-		// error if runcmd is specified
-		if _, ok := userDataMap["runcmd"]; ok {
-			return errors.New("cloudinit-userdata: runcmd not allowed, use preruncmd or postruncmd instead")
-		}
-
-		// error if bootcmd is specified
-		if _, ok := userDataMap["bootcmd"]; ok {
-			return errors.New("cloudinit-userdata: bootcmd not allowed")
-		}
 	}
 
 	if raw, ok := cfg.defined[ContainerInheritProperiesKey].(string); ok && raw != "" {
@@ -747,6 +764,42 @@ func Validate(cfg, old *Config) error {
 	return nil
 }
 
+// ParseCloudInitUserData parses the input YAML cloud-init user data,
+// returning an error if it contains keys that Juju does not allow to be
+// specified by the user, as it needs to control them itself.
+func ParseCloudInitUserData(raw string) (map[string]interface{}, error) {
+	userDataMap, err := ensureStringMaps(raw)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// if there packages, ensure they are strings
+	if packages, ok := userDataMap["packages"].([]interface{}); ok {
+		for _, v := range packages {
+			checker := schema.String()
+			if _, err := checker.Coerce(v, nil); err != nil {
+				return nil, errors.Annotate(err, "packages must be a list of strings")
+			}
+		}
+	}
+
+	// error if users is specified
+	if _, ok := userDataMap["users"]; ok {
+		return nil, errors.New("users not allowed")
+	}
+
+	// error if runcmd is specified
+	if _, ok := userDataMap["runcmd"]; ok {
+		return nil, errors.New("runcmd not allowed, use preruncmd or postruncmd instead")
+	}
+
+	// error if bootcmd is specified
+	if _, ok := userDataMap["bootcmd"]; ok {
+		return nil, errors.New("bootcmd not allowed")
+	}
+	return userDataMap, nil
+}
+
 // ensureStringMaps takes in a string and returns YAML in a map
 // where all keys of any nested maps are strings.
 func ensureStringMaps(in string) (map[string]interface{}, error) {
@@ -1206,6 +1259,13 @@ func (c *Config) ImageStream() string {
 	return "released"
 }
 
+// ContainerLXDProject returns the LXD project in which LXD containers are
+// created on machines. The empty string indicates the default project.
+func (c *Config) ContainerLXDProject() string {
+	v, _ := c.defined[ContainerLXDProjectKey].(string)
+	return v
+}
+
 // AgentStream returns the simplestreams stream
 // used to identify which tools to use when
 // when bootstrapping or upgrading an environment.
@@ -1316,6 +1376,37 @@ func (c *Config) MaxActionResultsSizeMB() uint {
 	return uint(val)
 }
 
+// MaxBranchAge is the length of time that committed or aborted
+// branches are kept before being pruned.
+func (c *Config) MaxBranchAge() time.Duration {
+	// Models created before this setting was introduced
+	// will not have a value, so fall back to the default.
+	raw := c.asString(MaxBranchAge)
+	if raw == "" {
+		raw = DefaultBranchAge
+	}
+	// Value has already been validated.
+	val, _ := time.ParseDuration(raw)
+	return val
+}
+
+// LostMachineThreshold is how long a machine's agent must be lost,
+// with its instance stopped or missing, before the machine is marked as
+// failed. Zero means that machines are never marked as failed.
+func (c *Config) LostMachineThreshold() time.Duration {
+	// Value has already been validated.
+	val, _ := time.ParseDuration(c.asString(LostMachineThresholdKey))
+	return val
+}
+
+// ReprovisionLostMachines reports whether a new instance should be
+// started for a machine that has been marked as failed because it
+// was lost.
+func (c *Config) ReprovisionLostMachines() bool {
+	value, _ := c.defined[ReprovisionLostMachinesKey].(bool)
+	return value
+}
+
 // UpdateStatusHookInterval is how often to run the charm
 // update-status hook.
 func (c *Config) UpdateStatusHookInterval() time.Duration {
@@ -1478,6 +1569,7 @@ var alwaysOptional = schema.Defaults{
 	AgentMetadataURLKey:          schema.Omit,
 	ContainerImageStreamKey:      schema.Omit,
 	ContainerImageMetadataURLKey: schema.Omit,
+	ContainerLXDProjectKey:       schema.Omit,
 	"default-series":             schema.Omit,
 	"development":                schema.Omit,
 	"ssl-hostname-verification":  schema.Omit,
@@ -1493,6 +1585,9 @@ var alwaysOptional = schema.Defaults{
 	MaxStatusHistorySize:         schema.Omit,
 	MaxActionResultsAge:          schema.Omit,
 	MaxActionResultsSize:         schema.Omit,
+	MaxBranchAge:                 schema.Omit,
+	LostMachineThresholdKey:      schema.Omit,
+	ReprovisionLostMachinesKey:   schema.Omit,
 	UpdateStatusHookInterval:     schema.Omit,
 	EgressSubnets:                schema.Omit,
 	FanConfig:                    schema.Omit,
@@ -1843,6 +1938,11 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	ContainerLXDProjectKey: {
+		Description: `The LXD project in which LXD containers, profiles and images are created on machines. The default project is used if empty.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	"logging-config": {
 		Description: `The configuration string to use when configuring Juju agent logging (see http://godoc.org/github.com/juju/loggo#ParseConfigurationString for details)`,
 		Type:        environschema.Tstring,
@@ -1973,6 +2073,21 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	MaxBranchAge: {
+		Description: "The maximum age for committed or aborted branches before they are pruned, in human-readable time format",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LostMachineThresholdKey: {
+		Description: "How long a machine agent must be lost, with its instance stopped or missing, before the machine is marked as failed, in human-readable time format (unset to disable)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	ReprovisionLostMachinesKey: {
+		Description: "Whether to start a new instance for a machine marked as failed because it was lost",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
 	UpdateStatusHookInterval: {
 		Description: "How often to run the charm update-status hook, in human-readable time format (default 5m, range 1-60m)",
 		Type:        environschema.Tstring,
@@ -97,6 +97,12 @@ var configTests = []configTest{
 			"agent-stream":           "released",
 			"container-image-stream": "daily",
 		}),
+	}, {
+		about:       "Container LXD project",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"container-lxd-project": "juju",
+		}),
 	}, {
 		about:       "Metadata URLs",
 		useDefaults: config.UseDefaults,
@@ -737,6 +743,12 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 		c.Assert(cfg.ContainerImageStream(), gc.Equals, "released")
 	}
 
+	if v, ok := test.attrs["container-lxd-project"]; ok {
+		c.Assert(cfg.ContainerLXDProject(), gc.Equals, v)
+	} else {
+		c.Assert(cfg.ContainerLXDProject(), gc.Equals, "")
+	}
+
 	resourceTags, cfgHasResourceTags := cfg.ResourceTags()
 	c.Assert(cfgHasResourceTags, jc.IsTrue)
 	if tags, ok := test.attrs["resource-tags"]; ok {
@@ -1306,6 +1318,50 @@ func (s *ConfigSuite) TestStatusHistoryConfigValues(c *gc.C) {
 	c.Assert(cfg.MaxStatusHistorySizeMB(), gc.Equals, uint(8192))
 }
 
+func (s *ConfigSuite) TestMaxBranchAgeConfigDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.MaxBranchAge(), gc.Equals, 720*time.Hour)
+}
+
+func (s *ConfigSuite) TestMaxBranchAgeConfigValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{"max-branch-age": "48h"})
+	c.Assert(cfg.MaxBranchAge(), gc.Equals, 48*time.Hour)
+}
+
+func (s *ConfigSuite) TestMaxBranchAgeConfigInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		"max-branch-age": "lots",
+	}))
+	c.Assert(err, gc.ErrorMatches, `invalid max branch age in model configuration: .*`)
+}
+
+func (s *ConfigSuite) TestLostMachineConfigDefaults(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.LostMachineThreshold(), gc.Equals, time.Duration(0))
+	c.Assert(cfg.ReprovisionLostMachines(), jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestLostMachineConfigValues(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"lost-machine-threshold":    "2h",
+		"reprovision-lost-machines": true,
+	})
+	c.Assert(cfg.LostMachineThreshold(), gc.Equals, 2*time.Hour)
+	c.Assert(cfg.ReprovisionLostMachines(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestLostMachineThresholdInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		"lost-machine-threshold": "lots",
+	}))
+	c.Assert(err, gc.ErrorMatches, `invalid lost machine threshold in model configuration: .*`)
+
+	_, err = config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		"lost-machine-threshold": "-1h",
+	}))
+	c.Assert(err, gc.ErrorMatches, `lost machine threshold in model configuration must not be negative, got -1h0m0s`)
+}
+
 func (s *ConfigSuite) TestUpdateStatusHookIntervalConfigDefault(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{})
 	c.Assert(cfg.UpdateStatusHookInterval(), gc.Equals, 5*time.Minute)
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
@@ -504,6 +505,21 @@ type InstanceTypesFetcher interface {
 	InstanceTypes(context.ProviderCallContext, constraints.Value) (instances.InstanceTypesWithCostMetadata, error)
 }
 
+// InstanceSpecPreviewer is an interface that allows an Environ to report the
+// instance type and image it would choose for a new instance, without
+// starting it.
+type InstanceSpecPreviewer interface {
+	// PreviewInstanceSpec returns the instance spec that StartInstance
+	// would choose for a non-controller instance with the given series
+	// and constraints, from the given image metadata.
+	PreviewInstanceSpec(
+		ctx context.ProviderCallContext,
+		series string,
+		cons constraints.Value,
+		imageMetadata []*imagemetadata.ImageMetadata,
+	) (*instances.InstanceSpec, error)
+}
+
 // Upgrader is an interface that can be used for upgrading Environs. If an
 // Environ implements this interface, its UpgradeOperations method will be
 // invoked to identify operations that should be run on upgrade.
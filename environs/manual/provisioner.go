@@ -12,6 +12,7 @@ import (
 	"github.com/juju/utils/winrm"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
 )
 
 var (
@@ -30,6 +31,17 @@ type ProvisionMachineArgs struct {
 	Host string
 	User string
 
+	// PrivateKey is the path to the SSH private key used to connect to
+	// the host. If left blank, the user's default SSH identities are used.
+	PrivateKey string
+
+	// Series, if set, is the series the host is expected to be running.
+	// Provisioning fails if the series detected on the host differs.
+	Series string
+
+	// Constraints are recorded against the machine added to the model.
+	Constraints constraints.Value
+
 	// DataDir is the root directory for juju data.
 	// If left blank, the default location "/var/lib/juju" will be used.
 	DataDir string
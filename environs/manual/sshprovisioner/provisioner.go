@@ -5,6 +5,7 @@
 package sshprovisioner
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
 	"github.com/juju/juju/apiserver/params"
@@ -32,15 +33,19 @@ func ProvisionMachine(args manual.ProvisionMachineArgs) (machineId string, err e
 	// the ubuntu user's authorized_keys file with the public keys in the current
 	// user's ~/.ssh directory. The authenticationworker will later update the
 	// ubuntu user's authorized_keys.
-	if err = InitUbuntuUser(args.Host, args.User,
-		args.AuthorizedKeys, args.Stdin, args.Stdout); err != nil {
+	if err = initUbuntuUser(args.Host, args.User,
+		args.AuthorizedKeys, args.PrivateKey, args.Stdin, args.Stdout); err != nil {
 		return "", err
 	}
 
-	machineParams, err := gatherMachineParams(args.Host)
+	machineParams, err := gatherMachineParams(args.Host, args.PrivateKey)
 	if err != nil {
 		return "", err
 	}
+	if args.Series != "" && args.Series != machineParams.Series {
+		return "", errors.Errorf("host %q is running series %q, not %q", args.Host, machineParams.Series, args.Series)
+	}
+	machineParams.Constraints = args.Constraints
 
 	// Inform Juju that the machine exists.
 	machineId, err = manual.RecordMachineInState(args.Client, *machineParams)
@@ -60,7 +65,7 @@ func ProvisionMachine(args manual.ProvisionMachineArgs) (machineId string, err e
 	}
 
 	// Finally, provision the machine agent.
-	err = runProvisionScript(provisioningScript, args.Host, args.Stderr)
+	err = runProvisionScript(provisioningScript, args.Host, args.PrivateKey, args.Stderr)
 	if err != nil {
 		return machineId, err
 	}
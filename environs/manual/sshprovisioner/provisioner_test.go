@@ -127,6 +127,61 @@ func (s *provisionerSuite) TestProvisionMachine(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "error checking if provisioned: subprocess encountered error code 255")
 }
 
+func (s *provisionerSuite) TestProvisionMachineSeriesMismatch(c *gc.C) {
+	var series = jujuversion.SupportedLTS()
+
+	args := s.getArgs(c)
+	args.User = "ubuntu"
+	args.Series = "precise"
+
+	defer fakeSSH{
+		Series:             series,
+		Arch:               "amd64",
+		InitUbuntuUser:     true,
+		SkipProvisionAgent: true,
+	}.install(c).Restore()
+
+	machineId, err := sshprovisioner.ProvisionMachine(args)
+	c.Assert(err, gc.ErrorMatches, fmt.Sprintf(`host %q is running series %q, not "precise"`, args.Host, series))
+	c.Assert(machineId, gc.Equals, "")
+
+	// No machine was recorded for the host.
+	machines, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, m := range machines {
+		instanceId, err := m.InstanceId()
+		if err == nil {
+			c.Check(instanceId, gc.Not(gc.Equals), instance.Id("manual:"+args.Host))
+		}
+	}
+}
+
+func (s *provisionerSuite) TestProvisionMachineDetectsWithPrivateKey(c *gc.C) {
+	args := s.getArgs(c)
+	args.User = "ubuntu"
+	args.Series = "precise"
+	args.PrivateKey = "/path/to/key"
+
+	var detectedHost, detectedKey string
+	s.PatchValue(&sshprovisioner.DetectSeriesAndHardwareCharacteristics,
+		func(host, privateKey string) (instance.HardwareCharacteristics, string, error) {
+			detectedHost, detectedKey = host, privateKey
+			amd64 := "amd64"
+			return instance.HardwareCharacteristics{Arch: &amd64}, "trusty", nil
+		},
+	)
+	defer fakeSSH{
+		InitUbuntuUser:     true,
+		SkipDetection:      true,
+		SkipProvisionAgent: true,
+	}.install(c).Restore()
+
+	_, err := sshprovisioner.ProvisionMachine(args)
+	c.Assert(err, gc.ErrorMatches, fmt.Sprintf(`host %q is running series "trusty", not "precise"`, args.Host))
+	c.Assert(detectedHost, gc.Equals, args.Host)
+	c.Assert(detectedKey, gc.Equals, "/path/to/key")
+}
+
 func (s *provisionerSuite) TestFinishInstancConfig(c *gc.C) {
 	var series = jujuversion.SupportedLTS()
 	const arch = "amd64"
@@ -0,0 +1,160 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sshprovisioner
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/utils/ssh"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/juju/paths"
+)
+
+// releaseScript is the script run on a manually provisioned host to
+// remove everything Juju left behind, after its machine has been removed.
+// It is safe to run more than once, and on a host where the machine agent
+// has already uninstalled itself.
+const releaseScript = `
+set -x
+touch %[1]s
+
+stopped=0
+function wait_for_jujud {
+    for i in {1..30}; do
+        if pgrep jujud > /dev/null ; then
+            sleep 1
+        else
+            stopped=1
+            break
+        fi
+    done
+}
+
+# SIGABRT not SIGTERM, as abort lets the agent know it should uninstall
+# itself rather than terminate normally.
+pkill -SIGABRT jujud
+wait_for_jujud
+[[ $stopped -ne 1 ]] && pkill -SIGKILL jujud && wait_for_jujud
+
+if which systemctl > /dev/null ; then
+    for unit in $(systemctl list-unit-files --no-legend 'jujud-*' 'juju-*' | awk '{print $1}'); do
+        systemctl stop $unit
+        systemctl disable $unit
+    done
+fi
+rm -f /etc/init/juju*
+rm -f /etc/systemd/system{,/multi-user.target.wants}/juju*
+rm -f /lib/systemd/system/juju*
+which systemctl > /dev/null && systemctl daemon-reload
+
+if which lxc > /dev/null ; then
+    for c in $(lxc list --format csv -c n %[5]s 2> /dev/null); do
+        lxc delete --force $c
+    done
+fi
+
+rm -f %[2]s
+rm -f /etc/profile.d/juju-introspection.sh
+rm -fr %[3]s %[4]s
+exit 0
+`
+
+// verifyReleaseScript is the script run on a released host to report,
+// one per line, anything that Juju has left behind. It runs as root, like
+// the release script, so that it sees what that script could not remove.
+// Containers that can't be listed are reported, as they may remain.
+const verifyReleaseScript = `
+pgrep jujud > /dev/null && echo "jujud is running"
+ls /etc/init/juju* /etc/systemd/system/juju* /lib/systemd/system/juju* 2> /dev/null
+[ -e %[1]s ] && echo %[1]s
+if which lxc > /dev/null ; then
+    lxc list --format csv -c n %[2]s 2> /dev/null || echo "cannot list LXD containers"
+fi
+exit 0
+`
+
+// ReleaseMachine removes the Juju agent, its services, containers and data
+// from a manually provisioned host running the input series, so that it can
+// be enlisted again. Only the containers of the input model are removed, as
+// the host may also run containers for other models. It returns an error if
+// anything is left behind.
+var ReleaseMachine = releaseMachine
+
+func releaseMachine(host, series, modelUUID string) error {
+	logger.Infof("releasing %s", host)
+
+	namespace, err := instance.NewNamespace(modelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	containerPrefix := utils.ShQuote(namespace.Prefix())
+
+	dataDir, err := paths.DataDir(series)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logDir, err := paths.LogDir(series)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var symlinks []string
+	for _, f := range []func(string) (string, error){
+		paths.JujuRun, paths.JujuDumpLogs, paths.JujuIntrospect, paths.JujuUpdateSeries,
+	} {
+		link, err := f(series)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		symlinks = append(symlinks, utils.ShQuote(link))
+	}
+
+	script := fmt.Sprintf(
+		releaseScript,
+		// WARNING: this is linked with the use of UninstallFile in the
+		// agent package. Don't change it without extreme care.
+		utils.ShQuote(path.Join(dataDir, agent.UninstallFile)),
+		strings.Join(symlinks, " "),
+		utils.ShQuote(dataDir),
+		utils.ShQuote(logDir),
+		containerPrefix,
+	)
+	logger.Tracef("release script: %s", script)
+	if _, err := runSSHScript(host, []string{"sudo", "/bin/bash"}, script); err != nil {
+		return errors.Annotatef(err, "releasing host %q", host)
+	}
+
+	output, err := runSSHScript(host, []string{"sudo", "/bin/bash"}, fmt.Sprintf(verifyReleaseScript, utils.ShQuote(dataDir), containerPrefix))
+	if err != nil {
+		return errors.Annotatef(err, "verifying host %q", host)
+	}
+	if output != "" {
+		leftovers := strings.Split(output, "\n")
+		return errors.Errorf("host %q is not clean after release: %s", host, strings.Join(leftovers, ", "))
+	}
+	return nil
+}
+
+// runSSHScript runs the input script as the ubuntu user on the input host,
+// returning its standard output.
+func runSSHScript(host string, command []string, script string) (string, error) {
+	cmd := ssh.Command("ubuntu@"+host, command, nil)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Stdin = strings.NewReader(script)
+	if err := cmd.Run(); err != nil {
+		if stderr.Len() != 0 {
+			err = fmt.Errorf("%v (%v)", err, strings.TrimSpace(stderr.String()))
+		}
+		return "", err
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sshprovisioner_test
+
+import (
+	"fmt"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/manual/sshprovisioner"
+	"github.com/juju/juju/testing"
+)
+
+type releaseSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&releaseSuite{})
+
+func (s *releaseSuite) TestReleaseMachine(c *gc.C) {
+	defer installFakeSSH(c, nil, "", 0)()  // verification finds nothing
+	defer installFakeSSH(c, nil, nil, 0)() // release script
+	err := sshprovisioner.ReleaseMachine("example.com", "bionic", testing.ModelTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *releaseSuite) TestReleaseMachineVerifiesModelContainers(c *gc.C) {
+	// Only the model's containers are expected to be gone.
+	verify := fmt.Sprintf(sshprovisioner.VerifyReleaseScript, "'/var/lib/juju'", "'juju-06f00d-'")
+	defer installFakeSSH(c, verify, "", 0)()
+	defer installFakeSSH(c, nil, nil, 0)()
+	err := sshprovisioner.ReleaseMachine("example.com", "bionic", testing.ModelTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *releaseSuite) TestReleaseMachineNotClean(c *gc.C) {
+	defer installFakeSSH(c, nil, "jujud is running\n/etc/systemd/system/jujud-machine-1.service\njuju-06f00d-1-lxd-0", 0)()
+	defer installFakeSSH(c, nil, nil, 0)()
+	err := sshprovisioner.ReleaseMachine("example.com", "bionic", testing.ModelTag.Id())
+	c.Assert(err, gc.ErrorMatches, `host "example.com" is not clean after release: `+
+		`jujud is running, /etc/systemd/system/jujud-machine-1.service, juju-06f00d-1-lxd-0`)
+}
+
+func (s *releaseSuite) TestReleaseMachineContainersNotListed(c *gc.C) {
+	defer installFakeSSH(c, nil, "cannot list LXD containers", 0)()
+	defer installFakeSSH(c, nil, nil, 0)()
+	err := sshprovisioner.ReleaseMachine("example.com", "bionic", testing.ModelTag.Id())
+	c.Assert(err, gc.ErrorMatches, `host "example.com" is not clean after release: cannot list LXD containers`)
+}
+
+func (s *releaseSuite) TestReleaseMachineError(c *gc.C) {
+	defer installFakeSSH(c, nil, []string{"", "permission denied"}, 1)()
+	err := sshprovisioner.ReleaseMachine("example.com", "bionic", testing.ModelTag.Id())
+	c.Assert(err, gc.ErrorMatches, `releasing host "example.com": subprocess encountered error code 1 \(permission denied\)`)
+}
@@ -112,6 +112,10 @@ func (s *cmdJujuSuite) TestApplicationUnset(c *gc.C) {
 func (s *cmdJujuSuite) TestApplicationGetIAASModel(c *gc.C) {
 	expected := `application: dummy-application
 application-config:
+  cloudinit-userdata:
+    description: Cloud-init user data added to the model's cloudinit-userdata
+    source: unset
+    type: string
   trust:
     default: false
     description: Does this application have access to trusted credentials
@@ -172,12 +176,24 @@ application-config:
     source: default
     type: bool
     value: false
+  kubernetes-ingress-annotations:
+    description: a space separated set of annotations to add to the ingress resource
+    source: unset
+    type: attrs
   kubernetes-ingress-class:
     default: nginx
     description: the class of the ingress controller to be used by the ingress resource
     source: default
     type: string
     value: nginx
+  kubernetes-ingress-hostnames:
+    description: a space separated list of additional hostnames to route to the application
+    source: unset
+    type: string
+  kubernetes-ingress-paths:
+    description: a space separated list of http path prefixes to route to the application
+    source: unset
+    type: string
   kubernetes-ingress-ssl-passthrough:
     default: false
     description: whether to passthrough SSL traffic to the ingress controller
@@ -190,6 +206,24 @@ application-config:
     source: default
     type: bool
     value: false
+  kubernetes-ingress-tls-certificate:
+    description: the PEM encoded TLS certificate for the ingress
+    source: unset
+    type: string
+  kubernetes-ingress-tls-key:
+    description: the PEM encoded TLS private key for the ingress
+    source: unset
+    type: string
+  kubernetes-ingress-tls-secret:
+    description: the name of an existing secret holding the TLS certificate and key
+      for the ingress
+    source: unset
+    type: string
+  kubernetes-pdb-min-available:
+    description: number or percentage of pods that must remain available during a
+      voluntary disruption
+    source: unset
+    type: string
   kubernetes-service-annotations:
     description: a space separated set of annotations to add to the service
     source: unset
@@ -224,6 +258,21 @@ application-config:
     source: default
     type: string
     value: ClusterIP
+  kubernetes-update-max-surge:
+    description: number or percentage of pods that can be created above the desired
+      number during a rolling update
+    source: unset
+    type: string
+  kubernetes-update-max-unavailable:
+    description: number or percentage of pods that can be unavailable during a rolling
+      update
+    source: unset
+    type: string
+  kubernetes-update-partition:
+    description: pods with an ordinal lower than the partition are not updated during
+      a rolling update
+    source: unset
+    type: int
   trust:
     default: false
     description: Does this application have access to trusted credentials
@@ -280,6 +329,10 @@ settings:
 func (s *cmdJujuSuite) TestApplicationGetWeirdYAML(c *gc.C) {
 	expected := `application: yaml-config
 application-config:
+  cloudinit-userdata:
+    description: Cloud-init user data added to the model's cloudinit-userdata
+    source: unset
+    type: string
   trust:
     default: false
     description: Does this application have access to trusted credentials
@@ -0,0 +1,374 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6"
+
+	apicommon "github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/environs/config"
+)
+
+// A model archive is a gzipped tarball holding everything needed to
+// import a model into a controller without access to the controller
+// it was exported from: the serialized model description, and the
+// charms, agent binaries and resources used by the model.
+const (
+	archiveModelFile    = "model.yaml"
+	archiveMetadataFile = "metadata.json"
+	archiveCharmsDir    = "charms"
+	archiveToolsDir     = "tools"
+	archiveResourcesDir = "resources"
+)
+
+// ArchiveMetadata records the binaries held in a model archive, and
+// where the model came from.
+type ArchiveMetadata struct {
+	// ControllerAgentVersion is the agent version of the controller
+	// the model was exported from.
+	ControllerAgentVersion version.Number `json:"controller-agent-version"`
+
+	// Charms holds the URLs of the charms in the archive.
+	Charms []string `json:"charms"`
+
+	// Tools holds the versions of the agent binaries in the archive,
+	// with URIs giving their paths within the archive.
+	Tools []params.SerializedModelTools `json:"tools"`
+
+	// Resources holds the resources used by the model. The
+	// content of each application resource which is not a
+	// placeholder is held in the archive.
+	Resources []params.SerializedModelResource `json:"resources"`
+}
+
+// WriteArchiveConfig holds what is needed to write a model archive.
+type WriteArchiveConfig struct {
+	// Model is the exported model, as returned by the source controller.
+	Model params.SerializedModel
+
+	// ControllerAgentVersion is the agent version of the source controller.
+	ControllerAgentVersion version.Number
+
+	CharmDownloader    CharmDownloader
+	ToolsDownloader    ToolsDownloader
+	ResourceDownloader ResourceDownloader
+}
+
+// Validate makes sure that all the config values are non-nil.
+func (c *WriteArchiveConfig) Validate() error {
+	if len(c.Model.Bytes) == 0 {
+		return errors.NotValidf("empty Model")
+	}
+	if c.CharmDownloader == nil {
+		return errors.NotValidf("missing CharmDownloader")
+	}
+	if c.ToolsDownloader == nil {
+		return errors.NotValidf("missing ToolsDownloader")
+	}
+	if c.ResourceDownloader == nil {
+		return errors.NotValidf("missing ResourceDownloader")
+	}
+	return nil
+}
+
+// WriteArchive writes a model archive to w, downloading the binaries
+// used by the model from the source controller.
+func WriteArchive(w io.Writer, config WriteArchiveConfig) error {
+	if err := config.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	gzw := gzip.NewWriter(w)
+	aw := &archiveWriter{tw: tar.NewWriter(gzw)}
+
+	if err := aw.writeBytes(archiveModelFile, config.Model.Bytes); err != nil {
+		return errors.Trace(err)
+	}
+
+	metadata := ArchiveMetadata{
+		ControllerAgentVersion: config.ControllerAgentVersion,
+		Charms:                 config.Model.Charms,
+		Resources:              config.Model.Resources,
+	}
+	for _, curlStr := range config.Model.Charms {
+		logger.Debugf("adding charm %s to archive", curlStr)
+		curl, err := charm.ParseURL(curlStr)
+		if err != nil {
+			return errors.Annotate(err, "bad charm URL")
+		}
+		reader, err := config.CharmDownloader.OpenCharm(curl)
+		if err != nil {
+			return errors.Annotate(err, "cannot open charm")
+		}
+		err = aw.writeStream(charmPath(curlStr), reader)
+		reader.Close()
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, tools := range config.Model.Tools {
+		logger.Debugf("adding agent binaries %s to archive", tools.Version)
+		reader, err := config.ToolsDownloader.OpenURI(tools.URI, nil)
+		if err != nil {
+			return errors.Annotate(err, "cannot open agent binaries")
+		}
+		toolsPath := path.Join(archiveToolsDir, tools.Version+".tgz")
+		err = aw.writeStream(toolsPath, reader)
+		reader.Close()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		metadata.Tools = append(metadata.Tools, params.SerializedModelTools{
+			Version: tools.Version,
+			URI:     toolsPath,
+		})
+	}
+	for _, res := range config.Model.Resources {
+		if isPlaceholder(res.ApplicationRevision) {
+			// Placeholders have no content; they are
+			// recreated by the model import.
+			continue
+		}
+		logger.Debugf("adding resource %s/%s to archive", res.Application, res.Name)
+		reader, err := config.ResourceDownloader.OpenResource(res.Application, res.Name)
+		if err != nil {
+			return errors.Annotate(err, "cannot open resource")
+		}
+		err = aw.writeStream(resourcePath(res.Application, res.Name), reader)
+		reader.Close()
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	metadataBytes, err := json.Marshal(metadata)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := aw.writeBytes(archiveMetadataFile, metadataBytes); err != nil {
+		return errors.Trace(err)
+	}
+	if err := aw.tw.Close(); err != nil {
+		return errors.Annotate(err, "writing model archive")
+	}
+	return errors.Annotate(gzw.Close(), "writing model archive")
+}
+
+// isPlaceholder reports whether the resource revision is a
+// placeholder, which has no content, in the same way as
+// resource.Resource.IsPlaceholder.
+func isPlaceholder(rev params.SerializedModelResourceRevision) bool {
+	return rev.Timestamp.IsZero()
+}
+
+func charmPath(curl string) string {
+	return path.Join(archiveCharmsDir, url.PathEscape(curl))
+}
+
+func resourcePath(application, name string) string {
+	return path.Join(archiveResourcesDir, url.PathEscape(application), url.PathEscape(name))
+}
+
+type archiveWriter struct {
+	tw *tar.Writer
+}
+
+func (w *archiveWriter) writeBytes(name string, data []byte) error {
+	hdr := &tar.Header{
+		Name: name,
+		Mode: 0644,
+		Size: int64(len(data)),
+	}
+	if err := w.tw.WriteHeader(hdr); err != nil {
+		return errors.Annotate(err, "writing model archive")
+	}
+	_, err := w.tw.Write(data)
+	return errors.Annotate(err, "writing model archive")
+}
+
+// writeStream writes the content read from r to the archive. As the
+// size of a tar entry must be known before it is written, the content
+// is streamed through a temporary file.
+func (w *archiveWriter) writeStream(name string, r io.Reader) error {
+	content, cleanup, err := streamThroughTempFile(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer cleanup()
+	size, err := content.Seek(0, io.SeekEnd)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return errors.Trace(err)
+	}
+	hdr := &tar.Header{
+		Name: name,
+		Mode: 0644,
+		Size: size,
+	}
+	if err := w.tw.WriteHeader(hdr); err != nil {
+		return errors.Annotate(err, "writing model archive")
+	}
+	_, err = io.Copy(w.tw, content)
+	return errors.Annotate(err, "writing model archive")
+}
+
+// Archive is a model archive which has been unpacked into a
+// directory. It implements the CharmDownloader, ToolsDownloader and
+// ResourceDownloader interfaces, so the binaries it holds may be
+// sent to a controller with UploadBinaries.
+type Archive struct {
+	dir      string
+	bytes    []byte
+	metadata ArchiveMetadata
+}
+
+// ReadArchive unpacks the model archive read from r into dir, which
+// should be empty, and returns the unpacked archive.
+func ReadArchive(r io.Reader, dir string) (*Archive, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading model archive")
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Annotate(err, "reading model archive")
+		}
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+			return nil, errors.Errorf("unexpected entry %q in model archive", hdr.Name)
+		}
+		// Only ever write within dir, whatever the archive says.
+		name := path.Clean(hdr.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, errors.Errorf("unexpected entry %q in model archive", hdr.Name)
+		}
+		if err := extractFile(filepath.Join(dir, filepath.FromSlash(name)), tr); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	archive := &Archive{dir: dir}
+	archive.bytes, err = ioutil.ReadFile(filepath.Join(dir, archiveModelFile))
+	if os.IsNotExist(err) {
+		return nil, errors.NotValidf("model archive without %s", archiveModelFile)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	metadataBytes, err := ioutil.ReadFile(filepath.Join(dir, archiveMetadataFile))
+	if os.IsNotExist(err) {
+		return nil, errors.NotValidf("model archive without %s", archiveMetadataFile)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := json.Unmarshal(metadataBytes, &archive.metadata); err != nil {
+		return nil, errors.Annotatef(err, "reading %s", archiveMetadataFile)
+	}
+	return archive, nil
+}
+
+func extractFile(filename string, r io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return errors.Trace(err)
+	}
+	f, err := os.OpenFile(filename, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, r); err != nil {
+		return errors.Annotate(err, "reading model archive")
+	}
+	return errors.Trace(f.Close())
+}
+
+// Metadata returns the metadata recorded in the archive.
+func (a *Archive) Metadata() ArchiveMetadata {
+	return a.metadata
+}
+
+// SerializedModel returns the serialized model held in the archive,
+// with agent binary URIs referring to paths within the archive.
+func (a *Archive) SerializedModel() (coremigration.SerializedModel, error) {
+	return apicommon.SerializedModelFromParams(params.SerializedModel{
+		Bytes:     a.bytes,
+		Charms:    a.metadata.Charms,
+		Tools:     a.metadata.Tools,
+		Resources: a.metadata.Resources,
+	})
+}
+
+// ModelInfo returns the details of the archived model needed for
+// migration prechecks in the target controller.
+func (a *Archive) ModelInfo() (coremigration.ModelInfo, error) {
+	model, err := description.Deserialize(a.bytes)
+	if err != nil {
+		return coremigration.ModelInfo{}, errors.Annotate(err, "reading model description")
+	}
+	cfg, err := config.New(config.NoDefaults, model.Config())
+	if err != nil {
+		return coremigration.ModelInfo{}, errors.Annotate(err, "reading model config")
+	}
+	agentVersion, ok := cfg.AgentVersion()
+	if !ok {
+		return coremigration.ModelInfo{}, errors.NotValidf("model without agent version")
+	}
+	return coremigration.ModelInfo{
+		UUID:                   model.Tag().Id(),
+		Name:                   cfg.Name(),
+		Owner:                  model.Owner(),
+		AgentVersion:           agentVersion,
+		ControllerAgentVersion: a.metadata.ControllerAgentVersion,
+	}, nil
+}
+
+// OpenCharm is part of the CharmDownloader interface.
+func (a *Archive) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	return a.open(charmPath(curl.String()))
+}
+
+// OpenURI is part of the ToolsDownloader interface. The URI is the
+// path of the agent binaries within the archive, as given by
+// SerializedModel.
+func (a *Archive) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
+	return a.open(uri)
+}
+
+// OpenResource is part of the ResourceDownloader interface.
+func (a *Archive) OpenResource(application, name string) (io.ReadCloser, error) {
+	return a.open(resourcePath(application, name))
+}
+
+func (a *Archive) open(name string) (io.ReadCloser, error) {
+	name = path.Clean(name)
+	if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+		return nil, errors.NotValidf("archive path %q", name)
+	}
+	f, err := os.Open(filepath.Join(a.dir, filepath.FromSlash(name)))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("%q in model archive", name)
+	}
+	return f, errors.Trace(err)
+}
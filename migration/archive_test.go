@@ -0,0 +1,204 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"time"
+
+	"github.com/juju/description"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/migration"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ArchiveSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&ArchiveSuite{})
+
+func (s *ArchiveSuite) serializedModel(c *gc.C) params.SerializedModel {
+	cfg := coretesting.FakeConfig().Merge(coretesting.Attrs{
+		"name":          "foo",
+		"agent-version": "2.6.0",
+	})
+	model := description.NewModel(description.ModelArgs{
+		Type:   "iaas",
+		Owner:  names.NewUserTag("bob"),
+		Config: cfg,
+	})
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+
+	uploaded := params.SerializedModelResourceRevision{
+		Revision:  1,
+		Type:      "file",
+		Origin:    "upload",
+		Timestamp: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	placeholder := params.SerializedModelResourceRevision{
+		Type:   "file",
+		Origin: "upload",
+	}
+	return params.SerializedModel{
+		Bytes:  bytes,
+		Charms: []string{"cs:xenial/mysql-1", "local:xenial/magic-2"},
+		Tools: []params.SerializedModelTools{{
+			Version: "2.6.0-xenial-amd64",
+			URI:     "/tools/2.6.0-xenial-amd64",
+		}},
+		Resources: []params.SerializedModelResource{{
+			Application:         "magic",
+			Name:                "blob",
+			ApplicationRevision: uploaded,
+			UnitRevisions: map[string]params.SerializedModelResourceRevision{
+				"magic/0": uploaded,
+			},
+		}, {
+			Application:         "magic",
+			Name:                "empty",
+			ApplicationRevision: placeholder,
+		}},
+	}
+}
+
+func (s *ArchiveSuite) writeArchive(c *gc.C, downloader *fakeDownloader) []byte {
+	var buf bytes.Buffer
+	err := migration.WriteArchive(&buf, migration.WriteArchiveConfig{
+		Model:                  s.serializedModel(c),
+		ControllerAgentVersion: version.MustParse("2.6.1"),
+		CharmDownloader:        downloader,
+		ToolsDownloader:        downloader,
+		ResourceDownloader:     downloader,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return buf.Bytes()
+}
+
+func (s *ArchiveSuite) TestWriteArchiveDownloads(c *gc.C) {
+	downloader := &fakeDownloader{}
+	s.writeArchive(c, downloader)
+	c.Check(downloader.charms, jc.DeepEquals, []string{"cs:xenial/mysql-1", "local:xenial/magic-2"})
+	c.Check(downloader.uris, jc.DeepEquals, []string{"/tools/2.6.0-xenial-amd64"})
+	// Placeholder resources have no content to download.
+	c.Check(downloader.resources, jc.DeepEquals, []string{"magic/blob"})
+}
+
+func (s *ArchiveSuite) TestWriteArchiveConfigValidate(c *gc.C) {
+	err := migration.WriteArchive(ioutil.Discard, migration.WriteArchiveConfig{
+		Model: params.SerializedModel{Bytes: []byte("model")},
+	})
+	c.Assert(err, gc.ErrorMatches, "missing CharmDownloader not valid")
+}
+
+func (s *ArchiveSuite) TestRoundTrip(c *gc.C) {
+	data := s.writeArchive(c, &fakeDownloader{})
+	archive, err := migration.ReadArchive(bytes.NewReader(data), c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(archive.Metadata().ControllerAgentVersion, gc.Equals, version.MustParse("2.6.1"))
+
+	serialized, err := archive.SerializedModel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(serialized.Bytes, jc.DeepEquals, s.serializedModel(c).Bytes)
+	c.Check(serialized.Charms, jc.DeepEquals, []string{"cs:xenial/mysql-1", "local:xenial/magic-2"})
+	c.Check(serialized.Tools, jc.DeepEquals, map[version.Binary]string{
+		version.MustParseBinary("2.6.0-xenial-amd64"): "tools/2.6.0-xenial-amd64.tgz",
+	})
+	c.Assert(serialized.Resources, gc.HasLen, 2)
+	c.Check(serialized.Resources[0].ApplicationRevision.Name, gc.Equals, "blob")
+	c.Check(serialized.Resources[1].ApplicationRevision.IsPlaceholder(), jc.IsTrue)
+
+	info, err := archive.ModelInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info, jc.DeepEquals, coremigration.ModelInfo{
+		UUID:                   coretesting.ModelTag.Id(),
+		Name:                   "foo",
+		Owner:                  names.NewUserTag("bob"),
+		AgentVersion:           version.MustParse("2.6.0"),
+		ControllerAgentVersion: version.MustParse("2.6.1"),
+	})
+
+	reader, err := archive.OpenCharm(charm.MustParseURL("cs:xenial/mysql-1"))
+	c.Assert(err, jc.ErrorIsNil)
+	content, err := ioutil.ReadAll(reader)
+	reader.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, "cs:xenial/mysql-1 content")
+
+	reader, err = archive.OpenResource("magic", "blob")
+	c.Assert(err, jc.ErrorIsNil)
+	content, err = ioutil.ReadAll(reader)
+	reader.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, "blob")
+}
+
+func (s *ArchiveSuite) TestUploadBinariesFromArchive(c *gc.C) {
+	data := s.writeArchive(c, &fakeDownloader{})
+	archive, err := migration.ReadArchive(bytes.NewReader(data), c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	serialized, err := archive.SerializedModel()
+	c.Assert(err, jc.ErrorIsNil)
+
+	uploader := &fakeUploader{
+		tools:     make(map[version.Binary]string),
+		resources: make(map[string]string),
+	}
+	err = migration.UploadBinaries(migration.UploadBinariesConfig{
+		Charms:             serialized.Charms,
+		CharmDownloader:    archive,
+		CharmUploader:      uploader,
+		Tools:              serialized.Tools,
+		ToolsDownloader:    archive,
+		ToolsUploader:      uploader,
+		Resources:          serialized.Resources,
+		ResourceDownloader: archive,
+		ResourceUploader:   uploader,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(uploader.charms, jc.DeepEquals, []string{"cs:xenial/mysql-1", "local:xenial/magic-2"})
+	c.Check(uploader.tools, jc.DeepEquals, map[version.Binary]string{
+		version.MustParseBinary("2.6.0-xenial-amd64"): "/tools/2.6.0-xenial-amd64",
+	})
+	c.Check(uploader.resources, jc.DeepEquals, map[string]string{"magic/blob": "blob"})
+	c.Check(uploader.unitResources, jc.DeepEquals, []string{"magic/0-blob"})
+}
+
+func (s *ArchiveSuite) TestReadArchiveRejectsEscapingPaths(c *gc.C) {
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	err := tw.WriteHeader(&tar.Header{Name: "../evil", Mode: 0644, Size: 4})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = tw.Write([]byte("evil"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+	c.Assert(gzw.Close(), jc.ErrorIsNil)
+
+	_, err = migration.ReadArchive(&buf, c.MkDir())
+	c.Assert(err, gc.ErrorMatches, `unexpected entry "../evil" in model archive`)
+}
+
+func (s *ArchiveSuite) TestReadArchiveMissingModel(c *gc.C) {
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	c.Assert(tar.NewWriter(gzw).Close(), jc.ErrorIsNil)
+	c.Assert(gzw.Close(), jc.ErrorIsNil)
+
+	_, err := migration.ReadArchive(&buf, c.MkDir())
+	c.Assert(err, gc.ErrorMatches, "model archive without model.yaml not valid")
+}
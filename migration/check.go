@@ -0,0 +1,109 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/migrationtarget"
+	"github.com/juju/juju/apiserver/params"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/state"
+)
+
+// CheckMigration runs the source and target prechecks for migrating
+// the model of st to the target controller, updating information in
+// targetInfo as needed. If validate is not nil, it is called with a
+// client for the target controller once the prechecks have passed.
+func CheckMigration(
+	st, ctlrSt *state.State,
+	targetInfo *coremigration.TargetInfo,
+	modelPresence, controllerPresence ModelPresence,
+	validate func(*migrationtarget.Client) error,
+) error {
+	// Check model and source controller.
+	backend, err := PrecheckShim(st, ctlrSt)
+	if err != nil {
+		return errors.Annotate(err, "creating backend")
+	}
+	if err := SourcePrecheck(backend, modelPresence, controllerPresence); err != nil {
+		return errors.Annotate(err, "source prechecks failed")
+	}
+
+	// Check target controller.
+	conn, err := api.Open(targetToAPIInfo(targetInfo), ControllerDialOpts())
+	if err != nil {
+		return errors.Annotate(err, "connect to target controller")
+	}
+	defer conn.Close()
+	modelInfo, err := makeModelInfo(st, ctlrSt)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	client := migrationtarget.NewClient(conn)
+	if targetInfo.CACert == "" {
+		targetInfo.CACert, err = client.CACert()
+		if err != nil {
+			if !params.IsCodeNotImplemented(err) {
+				return errors.Annotatef(err, "cannot retrieve CA certificate")
+			}
+			// If the call's not implemented, it indicates an earlier version
+			// of the controller, which we can't migrate to.
+			return errors.New("controller API version is too old")
+		}
+	}
+	if err := client.Prechecks(modelInfo); err != nil {
+		return errors.Annotate(err, "target prechecks failed")
+	}
+	if validate == nil {
+		return nil
+	}
+	return errors.Trace(validate(client))
+}
+
+func makeModelInfo(st, ctlrSt *state.State) (coremigration.ModelInfo, error) {
+	var empty coremigration.ModelInfo
+
+	model, err := st.Model()
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+
+	// Retrieve agent version for the model.
+	conf, err := model.ModelConfig()
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	agentVersion, _ := conf.AgentVersion()
+
+	// Retrieve agent version for the controller.
+	controllerModel, err := ctlrSt.Model()
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	controllerConfig, err := controllerModel.Config()
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	controllerVersion, _ := controllerConfig.AgentVersion()
+
+	return coremigration.ModelInfo{
+		UUID:                   model.UUID(),
+		Name:                   model.Name(),
+		Owner:                  model.Owner(),
+		AgentVersion:           agentVersion,
+		ControllerAgentVersion: controllerVersion,
+	}, nil
+}
+
+func targetToAPIInfo(ti *coremigration.TargetInfo) *api.Info {
+	return &api.Info{
+		Addrs:     ti.Addrs,
+		CACert:    ti.CACert,
+		Tag:       ti.AuthTag,
+		Password:  ti.Password,
+		Macaroons: ti.Macaroons,
+	}
+}
@@ -4,6 +4,8 @@
 package migration
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"io"
 	"io/ioutil"
 	"net/url"
@@ -55,6 +57,23 @@ type StateImporter interface {
 	Import(model description.Model) (*state.Model, *state.State, error)
 }
 
+// StateImportValidator describes the method needed to check that a
+// model could be imported into the database.
+type StateImportValidator interface {
+	ValidateImport(model description.Model) error
+}
+
+// ValidateImportModel deserializes a model description from the bytes and
+// checks that it could be imported as a new database model, without
+// importing it.
+func ValidateImportModel(validator StateImportValidator, bytes []byte) error {
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(validator.ValidateImport(model))
+}
+
 // ClaimerFunc is a function that returns a leadership claimer for the
 // model UUID passed.
 type ClaimerFunc func(string) (leadership.Claimer, error)
@@ -63,12 +82,33 @@ type ClaimerFunc func(string) (leadership.Claimer, error)
 // the model config based on information from the controller model, and then
 // imports that as a new database model.
 func ImportModel(importer StateImporter, getClaimer ClaimerFunc, bytes []byte) (*state.Model, *state.State, error) {
+	return importModel(importer.Import, getClaimer, bytes)
+}
+
+// StateResumeImporter describes the method needed to import a model,
+// replacing what was left behind by an earlier failed import of it.
+type StateResumeImporter interface {
+	ResumeImport(model description.Model) (*state.Model, *state.State, error)
+}
+
+// ResumeImportModel behaves like ImportModel, except that a model left
+// behind by an earlier, failed import of the same model is replaced.
+// The binaries already uploaded into that model are kept.
+func ResumeImportModel(importer StateResumeImporter, getClaimer ClaimerFunc, bytes []byte) (*state.Model, *state.State, error) {
+	return importModel(importer.ResumeImport, getClaimer, bytes)
+}
+
+func importModel(
+	importFunc func(description.Model) (*state.Model, *state.State, error),
+	getClaimer ClaimerFunc,
+	bytes []byte,
+) (*state.Model, *state.State, error) {
 	model, err := description.Deserialize(bytes)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
 
-	dbModel, dbState, err := importer.Import(model)
+	dbModel, dbState, err := importFunc(model)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
@@ -176,6 +216,11 @@ type UploadBinariesConfig struct {
 	Resources          []migration.SerializedModelResource
 	ResourceDownloader ResourceDownloader
 	ResourceUploader   ResourceUploader
+
+	// Imported describes the binaries which the target controller
+	// already has from an earlier attempt at the migration. These
+	// aren't uploaded again if their content matches the source.
+	Imported migration.ImportedBinaries
 }
 
 // Validate makes sure that all the config values are non-nil.
@@ -243,6 +288,19 @@ func streamThroughTempFile(r io.Reader) (_ io.ReadSeeker, cleanup func(), err er
 	return tempFile, rmTempFile, nil
 }
 
+// contentSHA256 returns the hex encoded SHA256 hash of the content,
+// leaving it ready to be read again from the start.
+func contentSHA256(content io.ReadSeeker) (string, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return "", errors.Trace(err)
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", errors.Trace(err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 func uploadCharms(config UploadBinariesConfig) error {
 	// It is critical that charms are uploaded in ascending charm URL
 	// order so that charm revisions end up the same in the target as
@@ -250,8 +308,6 @@ func uploadCharms(config UploadBinariesConfig) error {
 	naturalsort.Sort(config.Charms)
 
 	for _, charmURL := range config.Charms {
-		logger.Debugf("sending charm %s to target", charmURL)
-
 		curl, err := charm.ParseURL(charmURL)
 		if err != nil {
 			return errors.Annotate(err, "bad charm URL")
@@ -269,6 +325,21 @@ func uploadCharms(config UploadBinariesConfig) error {
 		}
 		defer cleanup()
 
+		if importedSHA256, ok := config.Imported.Charms[charmURL]; ok {
+			sourceSHA256, err := contentSHA256(content)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if sourceSHA256 != importedSHA256 {
+				// The target would keep its own archive for the
+				// charm URL, so the upload can't fix this.
+				return errors.Errorf("charm %s on target does not match the source", charmURL)
+			}
+			logger.Debugf("target already has charm %s", charmURL)
+			continue
+		}
+		logger.Debugf("sending charm %s to target", charmURL)
+
 		if usedCurl, err := config.CharmUploader.UploadCharm(curl, content); err != nil {
 			return errors.Annotate(err, "cannot upload charm")
 		} else if usedCurl.String() != curl.String() {
@@ -281,8 +352,6 @@ func uploadCharms(config UploadBinariesConfig) error {
 
 func uploadTools(config UploadBinariesConfig) error {
 	for v, uri := range config.Tools {
-		logger.Debugf("sending agent binaries to target: %s", v)
-
 		reader, err := config.ToolsDownloader.OpenURI(uri, nil)
 		if err != nil {
 			return errors.Annotate(err, "cannot open charm")
@@ -295,6 +364,19 @@ func uploadTools(config UploadBinariesConfig) error {
 		}
 		defer cleanup()
 
+		if importedSHA256, ok := config.Imported.Tools[v]; ok {
+			sourceSHA256, err := contentSHA256(content)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if sourceSHA256 == importedSHA256 {
+				logger.Debugf("target already has agent binaries %s", v)
+				continue
+			}
+			logger.Debugf("agent binaries %s on target do not match the source", v)
+		}
+		logger.Debugf("sending agent binaries to target: %s", v)
+
 		if _, err := config.ToolsUploader.UploadTools(content, v); err != nil {
 			return errors.Annotate(err, "cannot upload agent binaries")
 		}
@@ -307,6 +389,9 @@ func uploadResources(config UploadBinariesConfig) error {
 		if res.ApplicationRevision.IsPlaceholder() {
 			// Resource placeholders created in the migration import rather
 			// than attempting to post empty resources.
+		} else if isImportedResource(config.Imported, res.ApplicationRevision) {
+			logger.Debugf("target already has application resource for %s: %s",
+				res.ApplicationRevision.ApplicationID, res.ApplicationRevision.Name)
 		} else {
 			err := uploadAppResource(config, res.ApplicationRevision)
 			if err != nil {
@@ -325,6 +410,13 @@ func uploadResources(config UploadBinariesConfig) error {
 	return nil
 }
 
+// isImportedResource returns true if the target controller already
+// has the content of the resource revision.
+func isImportedResource(imported migration.ImportedBinaries, rev resource.Resource) bool {
+	fingerprint, ok := imported.Resources[rev.ApplicationID+"/"+rev.Name]
+	return ok && fingerprint == rev.Fingerprint.Hex()
+}
+
 func uploadAppResource(config UploadBinariesConfig, rev resource.Resource) error {
 	logger.Debugf("opening application resource for %s: %s", rev.ApplicationID, rev.Name)
 	reader, err := config.ResourceDownloader.OpenResource(rev.ApplicationID, rev.Name)
@@ -5,6 +5,8 @@ package migration_test
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -104,6 +106,28 @@ func (s *ImportSuite) TestImportModel(c *gc.C) {
 	s.exportImport(c, fakeGetClaimer)
 }
 
+func (s *ImportSuite) TestResumeImportModel(c *gc.C) {
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	uuid := utils.MustNewUUID().String()
+	model.UpdateConfig(map[string]interface{}{
+		"name": "new-model",
+		"uuid": uuid,
+	})
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Resuming replaces the model left behind by the first import.
+	controller := state.NewController(s.StatePool)
+	for i := 0; i < 2; i++ {
+		dbModel, dbState, err := migration.ResumeImportModel(controller, fakeGetClaimer, bytes)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(dbModel.UUID(), gc.Equals, uuid)
+		c.Check(dbModel.MigrationMode(), gc.Equals, state.MigrationModeImporting)
+		dbState.Close()
+	}
+}
+
 func (s *ImportSuite) TestImportsLeadership(c *gc.C) {
 	s.makeApplicationWithUnits(c, "wordpress", 3)
 	s.makeUnitApplicationLeader(c, "wordpress/1", "wordpress")
@@ -268,6 +292,125 @@ func (s *ImportSuite) TestBinariesMigration(c *gc.C) {
 	c.Assert(uploader.unitResources, jc.SameContents, []string{"app1/99-blob1"})
 }
 
+func (s *ImportSuite) TestBinariesMigrationSkipsImported(c *gc.C) {
+	downloader := &fakeDownloader{}
+	uploader := &fakeUploader{
+		tools:     make(map[version.Binary]string),
+		resources: make(map[string]string),
+	}
+
+	importedTools := version.MustParseBinary("2.1.0-trusty-amd64")
+	toolsMap := map[version.Binary]string{
+		importedTools: "/tools/0",
+		version.MustParseBinary("2.0.0-xenial-amd64"): "/tools/1",
+	}
+
+	app0Res := resourcetesting.NewResource(c, nil, "blob0", "app0", "blob0").Resource
+	app1Res := resourcetesting.NewResource(c, nil, "blob1", "app1", "blob1").Resource
+	app2Res := resourcetesting.NewResource(c, nil, "blob2", "app2", "blob2").Resource
+	resources := []coremigration.SerializedModelResource{
+		{
+			ApplicationRevision: app0Res,
+			UnitRevisions:       map[string]resource.Resource{"app0/0": app0Res},
+		},
+		{ApplicationRevision: app1Res},
+		{ApplicationRevision: app2Res},
+	}
+
+	config := migration.UploadBinariesConfig{
+		Charms:             []string{"local:trusty/magic-2", "cs:trusty/postgresql-42"},
+		CharmDownloader:    downloader,
+		CharmUploader:      uploader,
+		Tools:              toolsMap,
+		ToolsDownloader:    downloader,
+		ToolsUploader:      uploader,
+		Resources:          resources,
+		ResourceDownloader: downloader,
+		ResourceUploader:   uploader,
+		Imported: coremigration.ImportedBinaries{
+			Charms: map[string]string{
+				"cs:trusty/postgresql-42": contentSHA256("cs:trusty/postgresql-42 content"),
+			},
+			Tools: map[version.Binary]string{
+				importedTools: contentSHA256("/tools/0"),
+			},
+			Resources: map[string]string{
+				"app0/blob0": app0Res.Fingerprint.Hex(),
+				// The content of app1's resource has changed since
+				// it was uploaded.
+				"app1/blob1": app2Res.Fingerprint.Hex(),
+			},
+		},
+	}
+	err := migration.UploadBinaries(config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Imported binaries are downloaded to compare their content
+	// with the target's, but are not uploaded again.
+	c.Assert(downloader.charms, jc.DeepEquals, []string{"cs:trusty/postgresql-42", "local:trusty/magic-2"})
+	c.Assert(uploader.charms, jc.DeepEquals, []string{"local:trusty/magic-2"})
+	c.Assert(downloader.uris, jc.SameContents, []string{"/tools/0", "/tools/1"})
+	c.Assert(uploader.tools, jc.DeepEquals, map[version.Binary]string{
+		version.MustParseBinary("2.0.0-xenial-amd64"): "/tools/1",
+	})
+	c.Assert(downloader.resources, jc.SameContents, []string{
+		"app1/blob1",
+		"app2/blob2",
+	})
+	// Unit resources are always set.
+	c.Assert(uploader.unitResources, jc.SameContents, []string{"app0/0-blob0"})
+}
+
+func (s *ImportSuite) TestBinariesMigrationImportedToolsMismatch(c *gc.C) {
+	downloader := &fakeDownloader{}
+	uploader := &fakeUploader{tools: make(map[version.Binary]string)}
+
+	v := version.MustParseBinary("2.1.0-trusty-amd64")
+	config := migration.UploadBinariesConfig{
+		CharmDownloader:    downloader,
+		CharmUploader:      uploader,
+		Tools:              map[version.Binary]string{v: "/tools/0"},
+		ToolsDownloader:    downloader,
+		ToolsUploader:      uploader,
+		ResourceDownloader: downloader,
+		ResourceUploader:   uploader,
+		Imported: coremigration.ImportedBinaries{
+			Tools: map[version.Binary]string{v: contentSHA256("partial")},
+		},
+	}
+	err := migration.UploadBinaries(config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The agent binaries on the target are replaced.
+	c.Assert(uploader.tools, jc.DeepEquals, map[version.Binary]string{v: "/tools/0"})
+}
+
+func (s *ImportSuite) TestBinariesMigrationImportedCharmMismatch(c *gc.C) {
+	downloader := &fakeDownloader{}
+	uploader := &fakeUploader{}
+
+	config := migration.UploadBinariesConfig{
+		Charms:             []string{"local:trusty/magic-2"},
+		CharmDownloader:    downloader,
+		CharmUploader:      uploader,
+		ToolsDownloader:    downloader,
+		ToolsUploader:      uploader,
+		ResourceDownloader: downloader,
+		ResourceUploader:   uploader,
+		Imported: coremigration.ImportedBinaries{
+			Charms: map[string]string{"local:trusty/magic-2": contentSHA256("other content")},
+		},
+	}
+	err := migration.UploadBinaries(config)
+	c.Assert(err, gc.ErrorMatches, "charm local:trusty/magic-2 on target does not match the source")
+	c.Assert(uploader.charms, gc.HasLen, 0)
+}
+
+func contentSHA256(content string) string {
+	hash := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(hash[:])
+}
+
 func (s *ImportSuite) TestWrongCharmURLAssigned(c *gc.C) {
 	downloader := &fakeDownloader{}
 	uploader := &fakeUploader{
@@ -238,9 +238,13 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 		defer release()
 
 		// If the model is importing then it's probably left behind
-		// from a previous migration attempt. It will be removed
-		// before the next import.
-		if model.UUID() == modelInfo.UUID && model.MigrationMode() != state.MigrationModeImporting {
+		// from a previous migration attempt. It will be removed, or
+		// replaced when the migration is resumed, before the next
+		// import, so its name doesn't conflict either.
+		if model.UUID() == modelInfo.UUID {
+			if model.MigrationMode() == state.MigrationModeImporting {
+				continue
+			}
 			return errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID)
 		}
 		if model.Name() == modelInfo.Name && model.Owner() == modelInfo.Owner {
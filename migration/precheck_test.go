@@ -649,6 +649,24 @@ func (s *TargetPrecheckSuite) TestUUIDAlreadyExistsButImporting(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *TargetPrecheckSuite) TestModelNameInUseByImportingModelWithSameUUID(c *gc.C) {
+	pool := &fakePool{
+		models: []migration.PrecheckModel{
+			&fakeModel{
+				uuid:          modelUUID,
+				name:          modelName,
+				modelType:     state.ModelTypeIAAS,
+				owner:         modelOwner,
+				migrationMode: state.MigrationModeImporting,
+			},
+		},
+	}
+	backend := newFakeBackend()
+	backend.models = pool.uuids()
+	err := migration.TargetPrecheck(backend, pool, s.modelInfo, allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 type precheckRunner func(migration.PrecheckBackend) error
 
 type precheckBaseSuite struct {
@@ -16,6 +16,15 @@ import (
 
 const (
 	configAttrStorageAccountType = "storage-account-type"
+	configAttrInstanceMarketType = "instance-market-type"
+
+	// marketTypeOnDemand is the instance-market-type value for
+	// regular VMs.
+	marketTypeOnDemand = "on-demand"
+
+	// marketTypeSpot is the instance-market-type value for spot
+	// VMs, which Azure may evict at any time.
+	marketTypeSpot = "spot"
 
 	// The below bits are internal book-keeping things, rather than
 	// configuration. Config is just what we have to work with.
@@ -27,10 +36,12 @@ const (
 
 var configFields = schema.Fields{
 	configAttrStorageAccountType: schema.String(),
+	configAttrInstanceMarketType: schema.String(),
 }
 
 var configDefaults = schema.Defaults{
 	configAttrStorageAccountType: string(storage.StandardLRS),
+	configAttrInstanceMarketType: marketTypeOnDemand,
 }
 
 var immutableConfigAttributes = []string{
@@ -40,12 +51,17 @@ var immutableConfigAttributes = []string{
 type azureModelConfig struct {
 	*config.Config
 	storageAccountType string
+	instanceMarketType string
 }
 
 var knownStorageAccountTypes = []string{
 	"Standard_LRS", "Standard_GRS", "Standard_RAGRS", "Standard_ZRS", "Premium_LRS",
 }
 
+var knownInstanceMarketTypes = []string{
+	marketTypeOnDemand, marketTypeSpot,
+}
+
 // Validate ensures that the provided configuration is valid for this
 // provider, and that changes between the old (if provided) and new
 // configurations are valid.
@@ -118,9 +134,18 @@ Please choose a model name of no more than %d characters.`,
 		)
 	}
 
+	instanceMarketType := validated[configAttrInstanceMarketType].(string)
+	if instanceMarketType != marketTypeOnDemand && instanceMarketType != marketTypeSpot {
+		return nil, errors.Errorf(
+			"invalid instance market type %q, expected one of: %q",
+			instanceMarketType, knownInstanceMarketTypes,
+		)
+	}
+
 	azureConfig := &azureModelConfig{
 		newCfg,
 		storageAccountType,
+		instanceMarketType,
 	}
 	return azureConfig, nil
 }
@@ -46,6 +46,14 @@ func (s *configSuite) TestValidateInvalidStorageAccountType(c *gc.C) {
 	)
 }
 
+func (s *configSuite) TestValidateInstanceMarketType(c *gc.C) {
+	s.assertConfigValid(c, testing.Attrs{"instance-market-type": "spot"})
+	s.assertConfigInvalid(
+		c, testing.Attrs{"instance-market-type": "reserved"},
+		`invalid instance market type "reserved", expected one of: \["on-demand" "spot"\]`,
+	)
+}
+
 func (s *configSuite) TestValidateInvalidFirewallMode(c *gc.C) {
 	s.assertConfigInvalid(
 		c, testing.Attrs{"firewall-mode": "global"},
@@ -443,6 +443,7 @@ func (env *azureEnviron) StartInstance(ctx context.ProviderCallContext, args env
 		env.config,
 	)
 	storageAccountType := env.config.storageAccountType
+	instanceMarketType := env.config.instanceMarketType
 	imageStream := env.config.ImageStream()
 	instanceTypes, err := env.getInstanceTypesLocked(ctx)
 	if err != nil {
@@ -533,7 +534,7 @@ func (env *azureEnviron) StartInstance(ctx context.ProviderCallContext, args env
 	if err := env.createVirtualMachine(
 		ctx, vmName, vmTags, envTags,
 		instanceSpec, args.InstanceConfig,
-		storageAccountType,
+		storageAccountType, instanceMarketType,
 	); err != nil {
 		logger.Errorf("creating instance failed, destroying: %v", err)
 		if err := env.StopInstances(ctx, instance.Id(vmName)); err != nil {
@@ -545,7 +546,12 @@ func (env *azureEnviron) StartInstance(ctx context.ProviderCallContext, args env
 	// Note: the instance is initialised without addresses to keep the
 	// API chatter down. We will refresh the instance if we need to know
 	// the addresses.
-	inst := &azureInstance{vmName, "Creating", env, nil, nil}
+	inst := &azureInstance{
+		vmName:            vmName,
+		provisioningState: "Creating",
+		env:               env,
+		spot:              instanceMarketType == marketTypeSpot,
+	}
 	amd64 := arch.AMD64
 	hc := &instance.HardwareCharacteristics{
 		Arch:     &amd64,
@@ -570,6 +576,7 @@ func (env *azureEnviron) createVirtualMachine(
 	instanceSpec *instances.InstanceSpec,
 	instanceConfig *instancecfg.InstanceConfig,
 	storageAccountType string,
+	instanceMarketType string,
 ) error {
 	deploymentsClient := resources.DeploymentsClient{
 		BaseClient: env.resources,
@@ -755,26 +762,41 @@ func (env *azureEnviron) createVirtualMachine(
 		},
 	}}
 	vmDependsOn = append(vmDependsOn, nicId)
+	properties := &compute.VirtualMachineProperties{
+		HardwareProfile: &compute.HardwareProfile{
+			VMSize: compute.VirtualMachineSizeTypes(
+				instanceSpec.InstanceType.Name,
+			),
+		},
+		StorageProfile: storageProfile,
+		OsProfile:      osProfile,
+		NetworkProfile: &compute.NetworkProfile{
+			&nics,
+		},
+		AvailabilitySet: availabilitySetSubResource,
+	}
+	vmAPIVersion := computeAPIVersion
+	var vmProperties interface{} = properties
+	var outputs map[string]armtemplates.Output
+	if instanceMarketType == marketTypeSpot {
+		vmAPIVersion = spotComputeAPIVersion
+		vmProperties = &spotVirtualMachineProperties{
+			VirtualMachineProperties: properties,
+			Priority:                 "Spot",
+			EvictionPolicy:           "Deallocate",
+		}
+		outputs = map[string]armtemplates.Output{
+			instanceMarketTypeOutput: {Type: "string", Value: marketTypeSpot},
+		}
+	}
 	resources = append(resources, armtemplates.Resource{
-		APIVersion: computeAPIVersion,
+		APIVersion: vmAPIVersion,
 		Type:       "Microsoft.Compute/virtualMachines",
 		Name:       vmName,
 		Location:   env.location,
 		Tags:       vmTags,
-		Properties: &compute.VirtualMachineProperties{
-			HardwareProfile: &compute.HardwareProfile{
-				VMSize: compute.VirtualMachineSizeTypes(
-					instanceSpec.InstanceType.Name,
-				),
-			},
-			StorageProfile: storageProfile,
-			OsProfile:      osProfile,
-			NetworkProfile: &compute.NetworkProfile{
-				&nics,
-			},
-			AvailabilitySet: availabilitySetSubResource,
-		},
-		DependsOn: vmDependsOn,
+		Properties: vmProperties,
+		DependsOn:  vmDependsOn,
 	})
 
 	// On Windows and CentOS, we must add the CustomScript VM
@@ -799,7 +821,7 @@ func (env *azureEnviron) createVirtualMachine(
 	}
 
 	logger.Debugf("- creating virtual machine deployment")
-	template := armtemplates.Template{Resources: resources}
+	template := armtemplates.Template{Resources: resources, Outputs: outputs}
 	// NOTE(axw) VMs take a long time to go to "Succeeded", so we do not
 	// block waiting for them to be fully provisioned. This means we won't
 	// return an error from StartInstance if the VM fails provisioning;
@@ -1575,11 +1597,22 @@ func (env *azureEnviron) allInstances(
 		if controllerOnly && !isControllerDeployment(deployment) {
 			continue
 		}
-		provisioningState := to.String(deployment.Properties.ProvisioningState)
-		inst := &azureInstance{name, provisioningState, env, nil, nil}
+		inst := &azureInstance{
+			vmName:            name,
+			provisioningState: to.String(deployment.Properties.ProvisioningState),
+			env:               env,
+			spot:              isSpotDeployment(deployment),
+		}
 		azureInstances = append(azureInstances, inst)
 	}
 
+	setSpotInstancesEvicted(
+		ctx,
+		resourceGroup,
+		compute.VirtualMachinesClient{env.compute},
+		azureInstances,
+	)
+
 	if len(azureInstances) > 0 && refreshAddresses {
 		if err := setInstanceAddresses(
 			ctx,
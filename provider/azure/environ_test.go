@@ -594,6 +594,34 @@ func (s *environSuite) assertStartInstance(c *gc.C, wantedRootDisk *int) {
 	})
 }
 
+func (s *environSuite) TestStartInstanceSpot(c *gc.C) {
+	env := s.openEnviron(c, testing.Attrs{"instance-market-type": "spot"})
+	s.sender = s.startInstanceSenders(false)
+	s.requests = nil
+	_, err := env.StartInstance(s.callCtx, makeStartInstanceParams(c, s.controllerUUID, "quantal"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	deploymentRequest := s.requests[len(s.requests)-1]
+	c.Assert(deploymentRequest.Method, gc.Equals, "PUT")
+	var deployment resources.Deployment
+	unmarshalRequestBody(c, deploymentRequest, &deployment)
+	template := deployment.Properties.Template.(map[string]interface{})
+	c.Check(template["outputs"], jc.DeepEquals, map[string]interface{}{
+		"instanceMarketType": map[string]interface{}{
+			"type":  "string",
+			"value": "spot",
+		},
+	})
+	templateResources := template["resources"].([]interface{})
+	vmResource := templateResources[len(templateResources)-1].(map[string]interface{})
+	c.Check(vmResource["type"], gc.Equals, "Microsoft.Compute/virtualMachines")
+	c.Check(vmResource["apiVersion"], gc.Equals, "2019-07-01")
+	vmProperties := vmResource["properties"].(map[string]interface{})
+	c.Check(vmProperties["priority"], gc.Equals, "Spot")
+	c.Check(vmProperties["evictionPolicy"], gc.Equals, "Deallocate")
+	c.Check(vmProperties["hardwareProfile"], gc.NotNil)
+}
+
 func (s *environSuite) TestStartInstanceNoAuthorizedKeys(c *gc.C) {
 	env := s.openEnviron(c)
 	cfg, err := env.Config().Remove([]string{"authorized-keys"})
@@ -29,6 +29,11 @@ type azureInstance struct {
 	env               *azureEnviron
 	networkInterfaces []network.Interface
 	publicIPAddresses []network.PublicIPAddress
+
+	// spot records whether the instance is a spot VM, and evicted
+	// whether Azure has since evicted it.
+	spot    bool
+	evicted bool
 }
 
 // Id is specified in the Instance interface.
@@ -41,6 +46,12 @@ func (inst *azureInstance) Id() instance.Id {
 
 // Status is specified in the Instance interface.
 func (inst *azureInstance) Status(ctx context.ProviderCallContext) instance.Status {
+	if inst.evicted {
+		return instance.Status{
+			Status:  status.Preempted,
+			Message: "spot VM evicted",
+		}
+	}
 	instanceStatus := status.Empty
 	message := inst.provisioningState
 	switch inst.provisioningState {
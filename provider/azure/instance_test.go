@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"path"
 
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-10-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-08-01/network"
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
 	"github.com/Azure/go-autorest/autorest/mocks"
@@ -207,6 +208,105 @@ func (s *instanceSuite) TestInstanceStatusNilProvisioningState(c *gc.C) {
 	assertInstanceStatus(c, inst.Status(s.callCtx), status.Allocating, "")
 }
 
+func (s *instanceSuite) TestInstanceStatusSpotEvicted(c *gc.C) {
+	s.assertInstanceStatusSpot(c, "PowerState/deallocated", status.Preempted, "spot VM evicted")
+}
+
+func (s *instanceSuite) TestInstanceStatusSpotRunning(c *gc.C) {
+	s.assertInstanceStatusSpot(c, "PowerState/running", status.Running, "")
+}
+
+func (s *instanceSuite) assertInstanceStatusSpot(c *gc.C, powerState string, expectStatus status.Status, expectMessage string) {
+	s.setSpotDeployment(0)
+	vmsSender := spotVirtualMachinesSender(map[string]string{
+		"machine-0": powerState,
+	})
+	senders := s.getInstancesSender()
+	s.sender = append(azuretesting.Senders{senders[0], vmsSender}, senders[1:]...)
+
+	instances, err := s.env.Instances(s.callCtx, []instance.Id{"machine-0", "machine-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.requests, gc.HasLen, 4)
+	assertVirtualMachinesListRequest(c, s.requests[1])
+	assertInstanceStatus(c, instances[0].Status(s.callCtx), expectStatus, expectMessage)
+	assertInstanceStatus(c, instances[1].Status(s.callCtx), status.Running, "")
+}
+
+func (s *instanceSuite) TestInstanceStatusSpotInstanceViewsListedOnce(c *gc.C) {
+	s.setSpotDeployment(0)
+	s.setSpotDeployment(1)
+	vmsSender := spotVirtualMachinesSender(map[string]string{
+		"machine-0": "PowerState/running",
+		"machine-1": "PowerState/deallocated",
+	})
+	senders := s.getInstancesSender()
+	s.sender = append(azuretesting.Senders{senders[0], vmsSender}, senders[1:]...)
+
+	instances, err := s.env.Instances(s.callCtx, []instance.Id{"machine-0", "machine-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	// The instance views of both spot VMs come from one list request.
+	c.Assert(s.requests, gc.HasLen, 4)
+	assertVirtualMachinesListRequest(c, s.requests[1])
+	assertInstanceStatus(c, instances[0].Status(s.callCtx), status.Running, "")
+	assertInstanceStatus(c, instances[1].Status(s.callCtx), status.Preempted, "spot VM evicted")
+}
+
+func (s *instanceSuite) TestInstanceStatusSpotInstanceViewNotFound(c *gc.C) {
+	s.setSpotDeployment(0)
+	vmsSender := &azuretesting.MockSender{
+		Sender:      mocks.NewSender(),
+		PathPattern: ".*/virtualMachines",
+	}
+	vmsSender.AppendResponse(mocks.NewResponseWithStatus("resource group not found", http.StatusNotFound))
+	senders := s.getInstancesSender()
+	s.sender = append(azuretesting.Senders{senders[0], vmsSender}, senders[1:]...)
+
+	// The VMs not being found doesn't prevent the instances being
+	// reported.
+	instances, err := s.env.Instances(s.callCtx, []instance.Id{"machine-0", "machine-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	assertInstanceStatus(c, instances[0].Status(s.callCtx), status.Running, "")
+	assertInstanceStatus(c, instances[1].Status(s.callCtx), status.Running, "")
+}
+
+func (s *instanceSuite) setSpotDeployment(i int) {
+	s.deployments[i].Properties.Outputs = map[string]interface{}{
+		"instanceMarketType": map[string]interface{}{
+			"type":  "String",
+			"value": "spot",
+		},
+	}
+}
+
+func spotVirtualMachinesSender(powerStates map[string]string) *azuretesting.MockSender {
+	var vms []compute.VirtualMachine
+	for name, powerState := range powerStates {
+		vms = append(vms, compute.VirtualMachine{
+			Name: to.StringPtr(name),
+			VirtualMachineProperties: &compute.VirtualMachineProperties{
+				InstanceView: &compute.VirtualMachineInstanceView{
+					Statuses: &[]compute.InstanceViewStatus{{
+						Code: to.StringPtr("ProvisioningState/succeeded"),
+					}, {
+						Code: to.StringPtr(powerState),
+					}},
+				},
+			},
+		})
+	}
+	vmsSender := azuretesting.NewSenderWithValue(&compute.VirtualMachineListResult{
+		Value: &vms,
+	})
+	vmsSender.PathPattern = ".*/virtualMachines"
+	return vmsSender
+}
+
+func assertVirtualMachinesListRequest(c *gc.C, req *http.Request) {
+	c.Assert(req.Method, gc.Equals, "GET")
+	c.Assert(req.URL.Path, gc.Matches, ".*/virtualMachines")
+	c.Assert(req.URL.Query().Get("$expand"), gc.Equals, "instanceView")
+}
+
 func assertInstanceStatus(c *gc.C, actual instance.Status, status status.Status, message string) {
 	c.Assert(actual, jc.DeepEquals, instance.Status{
 		Status:  status,
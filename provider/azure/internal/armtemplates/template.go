@@ -16,6 +16,10 @@ type Template struct {
 	// Resources contains the definitions of resources that will
 	// be created by the template.
 	Resources []Resource `json:"resources"`
+
+	// Outputs contains the values to return from the deployment
+	// of the template, keyed by name.
+	Outputs map[string]Output `json:"outputs,omitempty"`
 }
 
 // Map returns the template as a map, suitable for use in
@@ -26,9 +30,19 @@ func (t *Template) Map() (map[string]interface{}, error) {
 		"contentVersion": contentVersion,
 		"resources":      t.Resources,
 	}
+	if len(t.Outputs) > 0 {
+		m["outputs"] = t.Outputs
+	}
 	return m, nil
 }
 
+// Output describes a template output. For information on the
+// individual fields, see https://docs.microsoft.com/en-us/azure/azure-resource-manager/resource-group-authoring-templates#outputs.
+type Output struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
 // Resource describes a template resource. For information on the
 // individual fields, see https://azure.microsoft.com/en-us/documentation/articles/resource-group-authoring-templates/.
 type Resource struct {
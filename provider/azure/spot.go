@@ -0,0 +1,146 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package azure
+
+import (
+	stdcontext "context"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-10-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/azure/internal/errorutils"
+)
+
+const (
+	// spotComputeAPIVersion is the compute API version used to
+	// create spot VMs, which earlier versions don't support.
+	spotComputeAPIVersion = "2019-07-01"
+
+	// instanceMarketTypeOutput is the name of the deployment output
+	// recording the market type of a machine's VM. Only spot VM
+	// deployments have it.
+	instanceMarketTypeOutput = "instanceMarketType"
+
+	// vmListExpandAPIVersion is the compute API version used to list
+	// VMs along with their instance views, which earlier versions
+	// don't support.
+	vmListExpandAPIVersion = "2022-08-01"
+
+	// powerStateDeallocated is the instance view status code of a
+	// deallocated VM, which is what an evicted spot VM becomes.
+	powerStateDeallocated = "PowerState/deallocated"
+)
+
+// spotVirtualMachineProperties extends the VM properties with those
+// needed to request a spot VM, which the compute SDK we use lacks.
+type spotVirtualMachineProperties struct {
+	*compute.VirtualMachineProperties
+	Priority       string `json:"priority"`
+	EvictionPolicy string `json:"evictionPolicy"`
+}
+
+// isSpotDeployment reports whether or not the given machine
+// deployment created a spot VM.
+func isSpotDeployment(deployment resources.DeploymentExtended) bool {
+	outputs, _ := deployment.Properties.Outputs.(map[string]interface{})
+	output, _ := outputs[instanceMarketTypeOutput].(map[string]interface{})
+	return output["value"] == marketTypeSpot
+}
+
+// setSpotInstancesEvicted records whether each provisioned spot VM
+// among the given instances has been evicted. The VMs are listed along
+// with their instance views in one request, rather than getting the
+// instance view of each VM in turn. A VM missing from the list is left
+// as not evicted, as is every VM if the list can't be had, so that the
+// instances are still reported.
+func setSpotInstancesEvicted(
+	ctx context.ProviderCallContext,
+	resourceGroup string,
+	vmClient compute.VirtualMachinesClient,
+	instances []*azureInstance,
+) {
+	spotInstances := make(map[string]*azureInstance)
+	for _, inst := range instances {
+		if inst.spot && inst.provisioningState == "Succeeded" {
+			spotInstances[inst.vmName] = inst
+		}
+	}
+	if len(spotInstances) == 0 {
+		return
+	}
+	err := listVirtualMachineInstanceViews(vmClient, resourceGroup, func(vm compute.VirtualMachine) {
+		inst := spotInstances[to.String(vm.Name)]
+		if inst == nil || vm.VirtualMachineProperties == nil || vm.InstanceView == nil {
+			return
+		}
+		if vm.InstanceView.Statuses == nil {
+			return
+		}
+		for _, status := range *vm.InstanceView.Statuses {
+			if to.String(status.Code) == powerStateDeallocated {
+				inst.evicted = true
+			}
+		}
+	})
+	if err != nil {
+		err = errorutils.HandleCredentialError(err, ctx)
+		logger.Warningf("cannot get instance views of spot VMs: %v", err)
+	}
+}
+
+// listVirtualMachineInstanceViews calls f with each VM in the resource
+// group, including its instance view. The compute SDK we use can't
+// expand the instance views of listed VMs, so the list request is
+// amended here with an API version which can.
+func listVirtualMachineInstanceViews(
+	vmClient compute.VirtualMachinesClient,
+	resourceGroup string,
+	f func(compute.VirtualMachine),
+) error {
+	sdkCtx := stdcontext.Background()
+	req, err := vmClient.ListPreparer(sdkCtx, resourceGroup)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	query := req.URL.Query()
+	query.Set("api-version", vmListExpandAPIVersion)
+	query.Set("$expand", "instanceView")
+	req.URL.RawQuery = query.Encode()
+	for req != nil {
+		resp, err := vmClient.ListSender(req)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		result, err := vmClient.ListResponder(resp)
+		if err != nil {
+			if result.StatusCode == http.StatusNotFound {
+				// The resource group is being created or deleted.
+				return nil
+			}
+			return errors.Trace(err)
+		}
+		if result.Value != nil {
+			for _, vm := range *result.Value {
+				f(vm)
+			}
+		}
+		req = nil
+		if nextLink := to.String(result.NextLink); nextLink != "" {
+			req, err = autorest.Prepare(
+				(&http.Request{}).WithContext(sdkCtx),
+				autorest.AsGet(),
+				autorest.WithBaseURL(nextLink),
+			)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
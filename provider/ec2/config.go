@@ -5,6 +5,7 @@ package ec2
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/juju/schema"
 	"gopkg.in/juju/environschema.v1"
@@ -26,8 +27,28 @@ var configSchema = environschema.Fields{
 		Group:       environschema.AccountGroup,
 		Immutable:   true,
 	},
+	"instance-market-type": {
+		Description: "The purchasing option for new instances: on-demand, or spot to request spot instances, falling back to on-demand when no spot capacity is available.",
+		Type:        environschema.Tstring,
+		Values:      []interface{}{marketTypeOnDemand, marketTypeSpot},
+	},
+	"spot-max-price": {
+		Description: "The maximum hourly price, in US dollars, to pay for a spot instance (optional). When not specified, the on-demand price is the maximum. Not accepted unless instance-market-type is spot.",
+		Example:     "0.05",
+		Type:        environschema.Tstring,
+	},
 }
 
+const (
+	// marketTypeOnDemand is the instance-market-type value for
+	// on-demand instances.
+	marketTypeOnDemand = "on-demand"
+
+	// marketTypeSpot is the instance-market-type value for spot
+	// instances.
+	marketTypeSpot = "spot"
+)
+
 var configFields = func() schema.Fields {
 	fs, _, err := configSchema.ValidationSchema()
 	if err != nil {
@@ -37,8 +58,10 @@ var configFields = func() schema.Fields {
 }()
 
 var configDefaults = schema.Defaults{
-	"vpc-id":       "",
-	"vpc-id-force": false,
+	"vpc-id":               "",
+	"vpc-id-force":         false,
+	"instance-market-type": marketTypeOnDemand,
+	"spot-max-price":       "",
 }
 
 type environConfig struct {
@@ -54,6 +77,14 @@ func (c *environConfig) forceVPCID() bool {
 	return c.attrs["vpc-id-force"].(bool)
 }
 
+func (c *environConfig) instanceMarketType() string {
+	return c.attrs["instance-market-type"].(string)
+}
+
+func (c *environConfig) spotMaxPrice() string {
+	return c.attrs["spot-max-price"].(string)
+}
+
 func (p environProvider) newConfig(cfg *config.Config) (*environConfig, error) {
 	valid, err := p.Validate(cfg, nil)
 	if err != nil {
@@ -100,6 +131,15 @@ func validateConfig(cfg, old *config.Config) (*environConfig, error) {
 		return nil, fmt.Errorf("cannot use vpc-id-force without specifying vpc-id as well")
 	}
 
+	if maxPrice := ecfg.spotMaxPrice(); maxPrice != "" {
+		if ecfg.instanceMarketType() != marketTypeSpot {
+			return nil, fmt.Errorf("cannot use spot-max-price without instance-market-type %q", marketTypeSpot)
+		}
+		if price, err := strconv.ParseFloat(maxPrice, 64); err != nil || price <= 0 {
+			return nil, fmt.Errorf("spot-max-price: %q is not a valid price", maxPrice)
+		}
+	}
+
 	if old != nil {
 		attrs := old.UnknownAttrs()
 
@@ -268,6 +268,50 @@ var configTests = []configTest{
 			"ssl-hostname-verification": false,
 		},
 		err: ".*disabling ssh-hostname-verification is not supported",
+	}, {
+		config: attrs{},
+		expect: attrs{
+			"instance-market-type": "on-demand",
+			"spot-max-price":       "",
+		},
+	}, {
+		config: attrs{
+			"instance-market-type": "spot",
+			"spot-max-price":       "0.05",
+		},
+		expect: attrs{
+			"instance-market-type": "spot",
+			"spot-max-price":       "0.05",
+		},
+	}, {
+		config: attrs{
+			"instance-market-type": "reserved",
+		},
+		err: `.*instance-market-type: expected one of \[on-demand spot\], got "reserved"`,
+	}, {
+		config: attrs{
+			"spot-max-price": "0.05",
+		},
+		err: `.*cannot use spot-max-price without instance-market-type "spot"`,
+	}, {
+		config: attrs{
+			"instance-market-type": "spot",
+			"spot-max-price":       "cheap",
+		},
+		err: `.*spot-max-price: "cheap" is not a valid price`,
+	}, {
+		config: attrs{
+			"instance-market-type": "spot",
+			"spot-max-price":       "-1",
+		},
+		err: `.*spot-max-price: "-1" is not a valid price`,
+	}, {
+		change: attrs{
+			"instance-market-type": "spot",
+		},
+		expect: attrs{
+			"instance-market-type": "spot",
+		},
 	}, {
 		config: attrs{
 			"future": "hammerstein",
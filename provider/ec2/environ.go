@@ -601,7 +601,18 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 	}
 
 	callback(status.Allocating, fmt.Sprintf("Trying to start instance in availability zone %q", availabilityZone), nil)
-	instResp, err = runInstances(e.ec2, ctx, runArgs, callback)
+	spot := e.ecfg().instanceMarketType() == marketTypeSpot
+	if spot {
+		instResp, err = runInstances(spotClient(e.ec2, e.ecfg().spotMaxPrice()), ctx, runArgs, callback)
+		if isSpotCapacityError(err) {
+			logger.Infof("no spot instance available in AZ %q, starting an on-demand instance: %v", availabilityZone, err)
+			callback(status.Allocating, "No spot capacity available, trying an on-demand instance", nil)
+			spot = false
+			instResp, err = runInstances(e.ec2, ctx, runArgs, callback)
+		}
+	} else {
+		instResp, err = runInstances(e.ec2, ctx, runArgs, callback)
+	}
 	if err != nil {
 		if !isZoneOrSubnetConstrainedError(err) {
 			err = annotateWrapError(err, "cannot run instances")
@@ -630,6 +641,13 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 		names.NewMachineTag(args.InstanceConfig.MachineId), e.Config().Name(),
 	)
 	args.InstanceConfig.Tags[tagName] = instanceName
+	if spot {
+		args.InstanceConfig.Tags[tagInstanceMarket] = marketTypeSpot
+	} else if e.ecfg().instanceMarketType() == marketTypeSpot {
+		// Record that the instance was started on demand in place
+		// of a spot instance, so that its status can say so.
+		args.InstanceConfig.Tags[tagInstanceMarket] = marketTypeOnDemand
+	}
 	if err := tagResources(e.ec2, ctx, args.InstanceConfig.Tags, string(inst.Id())); err != nil {
 		return nil, annotateWrapError(err, "tagging instance")
 	}
@@ -946,6 +964,7 @@ func (e *environ) gatherInstances(
 		return maybeConvertCredentialError(err, ctx)
 	}
 	n := 0
+	var found []*ec2Instance
 	// For each requested id, add it to the returned instances
 	// if we find it in the response.
 	for i, id := range ids {
@@ -961,11 +980,14 @@ func (e *environ) gatherInstances(
 				}
 				inst := r.Instances[k]
 				// TODO(wallyworld): lookup the details to fill in the instance type data
-				insts[i] = &ec2Instance{e: e, Instance: &inst}
+				ec2Inst := &ec2Instance{e: e, Instance: &inst}
+				insts[i] = ec2Inst
+				found = append(found, ec2Inst)
 				n++
 			}
 		}
 	}
+	e.setSpotInterruptions(found)
 	if n < len(ids) {
 		return environs.ErrPartialInstances
 	}
@@ -1267,14 +1289,19 @@ func (e *environ) allInstances(ctx context.ProviderCallContext, filter *ec2.Filt
 	if err != nil {
 		return nil, errors.Annotate(maybeConvertCredentialError(err, ctx), "listing instances")
 	}
-	var insts []instances.Instance
+	var ec2Insts []*ec2Instance
 	for _, r := range resp.Reservations {
 		for i := range r.Instances {
 			inst := r.Instances[i]
 			// TODO(wallyworld): lookup the details to fill in the instance type data
-			insts = append(insts, &ec2Instance{e: e, Instance: &inst})
+			ec2Insts = append(ec2Insts, &ec2Instance{e: e, Instance: &inst})
 		}
 	}
+	e.setSpotInterruptions(ec2Insts)
+	var insts []instances.Instance
+	for _, inst := range ec2Insts {
+		insts = append(insts, inst)
+	}
 	return insts, nil
 }
 
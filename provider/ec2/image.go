@@ -4,11 +4,18 @@
 package ec2
 
 import (
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/instances"
 )
 
+var _ environs.InstanceSpecPreviewer = (*environ)(nil)
+
 // filterImages returns only that subset of the input (in the same order) that
 // this provider finds suitable.
 func filterImages(images []*imagemetadata.ImageMetadata, ic *instances.InstanceConstraint) []*imagemetadata.ImageMetadata {
@@ -68,3 +75,35 @@ func withDefaultNonControllerConstraints(cons constraints.Value) constraints.Val
 	}
 	return cons
 }
+
+// PreviewInstanceSpec is specified in the environs.InstanceSpecPreviewer
+// interface. It chooses the instance type and image as StartInstance does
+// for a non-controller instance, with any of the images' architectures.
+func (e *environ) PreviewInstanceSpec(
+	ctx context.ProviderCallContext,
+	series string,
+	cons constraints.Value,
+	imageMetadata []*imagemetadata.ImageMetadata,
+) (*instances.InstanceSpec, error) {
+	instanceTypes, err := e.supportedInstanceTypes(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	arches := set.NewStrings()
+	for _, m := range imageMetadata {
+		arches.Add(m.Arch)
+	}
+	spec, err := findInstanceSpec(
+		false,
+		imageMetadata,
+		instanceTypes,
+		&instances.InstanceConstraint{
+			Region:      e.cloud.Region,
+			Series:      series,
+			Arches:      arches.SortedValues(),
+			Constraints: cons,
+			Storage:     []string{ssdStorage, ebsStorage},
+		},
+	)
+	return spec, errors.Trace(err)
+}
@@ -20,6 +20,11 @@ type ec2Instance struct {
 	e *environ
 
 	*ec2.Instance
+
+	// spotInterruption holds the message of the instance's spot
+	// request, if it's a spot instance EC2 has reclaimed or is about
+	// to reclaim.
+	spotInterruption string
 }
 
 func (inst *ec2Instance) String() string {
@@ -45,9 +50,26 @@ func (inst *ec2Instance) Status(ctx context.ProviderCallContext) instance.Status
 	default:
 		jujuStatus = status.Empty
 	}
+	message := inst.State.Name
+	switch inst.State.Name {
+	case "shutting-down", "terminated", "stopping", "stopped":
+		// Only report the instance as preempted if its spot
+		// request shows that EC2 reclaimed it, rather than it
+		// being terminated by the user.
+		if inst.spotInterruption != "" {
+			jujuStatus = status.Preempted
+			message = "spot instance interrupted: " + inst.spotInterruption
+		}
+	case "running":
+		if inst.spotInterruption != "" {
+			message = "spot instance interruption notice: " + inst.spotInterruption
+		} else if isSpotFallback(inst.Instance) {
+			message = "running on-demand instance, no spot capacity was available"
+		}
+	}
 	return instance.Status{
 		Status:  jujuStatus,
-		Message: inst.State.Name,
+		Message: message,
 	}
 
 }
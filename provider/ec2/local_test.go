@@ -5,6 +5,7 @@ package ec2_test
 
 import (
 	"fmt"
+	"net/http"
 	"net/http/httptest"
 	"net/http/httputil"
 	"net/url"
@@ -734,6 +735,156 @@ func (t *localServerSuite) TestInstanceStatus(c *gc.C) {
 	c.Assert(inst.Status(t.callCtx).Message, gc.Equals, "terminated")
 }
 
+func (t *localServerSuite) TestStartInstanceSpot(c *gc.C) {
+	env := t.prepareAndBootstrapWithConfig(c, coretesting.Attrs{
+		"instance-market-type": "spot",
+		"spot-max-price":       "0.05",
+	})
+
+	// The market options are added to the request when it is
+	// signed, so sign a request with the client used for it.
+	var query url.Values
+	realRunInstances := *ec2.RunInstances
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ctx context.ProviderCallContext, ri *amzec2.RunInstances, callback environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+		req, err := http.NewRequest("GET", "http://ec2.invalid/?Action=RunInstances", nil)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(e.Sign(req, e.Auth), jc.ErrorIsNil)
+		query = req.URL.Query()
+		return realRunInstances(e, ctx, ri, callback)
+	})
+
+	t.srv.ec2srv.SetInitialInstanceState(ec2test.Terminated)
+	inst, _ := testing.AssertStartInstance(c, env, t.callCtx, t.ControllerUUID, "1")
+	c.Check(query.Get("Version"), gc.Equals, "2016-11-15")
+	c.Check(query.Get("InstanceMarketOptions.MarketType"), gc.Equals, "spot")
+	c.Check(query.Get("InstanceMarketOptions.SpotOptions.SpotInstanceType"), gc.Equals, "one-time")
+	c.Check(query.Get("InstanceMarketOptions.SpotOptions.InstanceInterruptionBehavior"), gc.Equals, "terminate")
+	c.Check(query.Get("InstanceMarketOptions.SpotOptions.MaxPrice"), gc.Equals, "0.05")
+
+	// The instance is only preempted if EC2 reclaimed it.
+	t.PatchValue(ec2.SpotInterruptions, func(_ *amzec2.EC2, ids []string) (map[string]string, error) {
+		return map[string]string{
+			string(inst.Id()): "Spot Instance terminated due to price",
+		}, nil
+	})
+	c.Check(t.terminatedInstance(c, env, inst.Id()).Status(t.callCtx), jc.DeepEquals, instance.Status{
+		Status:  status.Preempted,
+		Message: "spot instance interrupted: Spot Instance terminated due to price",
+	})
+}
+
+func (t *localServerSuite) TestSpotInstanceTerminatedByUser(c *gc.C) {
+	env := t.prepareAndBootstrapWithConfig(c, coretesting.Attrs{
+		"instance-market-type": "spot",
+	})
+	t.srv.ec2srv.SetInitialInstanceState(ec2test.Terminated)
+	inst, _ := testing.AssertStartInstance(c, env, t.callCtx, t.ControllerUUID, "1")
+
+	t.PatchValue(ec2.SpotInterruptions, func(_ *amzec2.EC2, ids []string) (map[string]string, error) {
+		c.Check(ids, jc.DeepEquals, []string{string(inst.Id())})
+		return map[string]string{}, nil
+	})
+	c.Check(t.terminatedInstance(c, env, inst.Id()).Status(t.callCtx), jc.DeepEquals, instance.Status{
+		Status:  status.Empty,
+		Message: "terminated",
+	})
+}
+
+func (t *localServerSuite) terminatedInstance(c *gc.C, env environs.Environ, id instance.Id) instances.Instance {
+	insts, err := ec2.TerminatedInstances(env)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, terminated := range insts {
+		if terminated.Id() == id {
+			return terminated
+		}
+	}
+	c.Fatalf("instance %q not found", id)
+	return nil
+}
+
+func (t *localServerSuite) TestStartInstanceSpotFallsBackToOnDemand(c *gc.C) {
+	t.testStartInstanceSpotFallsBackToOnDemand(c, &amzec2.Error{
+		Code:    "InsufficientSpotInstanceCapacity",
+		Message: "There is no Spot capacity available that matches your request.",
+	})
+}
+
+func (t *localServerSuite) TestStartInstanceSpotUnknownParameter(c *gc.C) {
+	env := t.prepareAndBootstrapWithConfig(c, coretesting.Attrs{
+		"instance-market-type": "spot",
+	})
+
+	// A rejected request isn't retried on demand.
+	onDemand := ec2.EnvironEC2(env)
+	var calls []bool
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ctx context.ProviderCallContext, ri *amzec2.RunInstances, callback environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+		calls = append(calls, e == onDemand)
+		return nil, &amzec2.Error{
+			Code:    "UnknownParameter",
+			Message: "The parameter InstanceMarketOptions is not recognized",
+		}
+	})
+
+	_, _, _, err := testing.StartInstance(env, t.callCtx, t.ControllerUUID, "1")
+	c.Assert(err, gc.ErrorMatches, ".*The parameter InstanceMarketOptions is not recognized.*")
+	c.Check(calls, jc.DeepEquals, []bool{false})
+}
+
+func (t *localServerSuite) testStartInstanceSpotFallsBackToOnDemand(c *gc.C, spotErr error) {
+	env := t.prepareAndBootstrapWithConfig(c, coretesting.Attrs{
+		"instance-market-type": "spot",
+	})
+
+	onDemand := ec2.EnvironEC2(env)
+	var calls []bool
+	realRunInstances := *ec2.RunInstances
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ctx context.ProviderCallContext, ri *amzec2.RunInstances, callback environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+		calls = append(calls, e == onDemand)
+		if e != onDemand {
+			return nil, spotErr
+		}
+		return realRunInstances(e, ctx, ri, callback)
+	})
+
+	t.srv.ec2srv.SetInitialInstanceState(ec2test.Running)
+	inst, _ := testing.AssertStartInstance(c, env, t.callCtx, t.ControllerUUID, "1")
+	c.Check(calls, jc.DeepEquals, []bool{false, true})
+
+	// The instance's status says it isn't a spot instance, and no
+	// spot instance requests are described for it.
+	t.PatchValue(ec2.SpotInterruptions, func(_ *amzec2.EC2, ids []string) (map[string]string, error) {
+		c.Errorf("unexpected spot instance request lookup for %v", ids)
+		return nil, nil
+	})
+	insts, err := env.Instances(t.callCtx, []instance.Id{inst.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(insts[0].Status(t.callCtx), jc.DeepEquals, instance.Status{
+		Status:  status.Running,
+		Message: "running on-demand instance, no spot capacity was available",
+	})
+}
+
+func (t *localServerSuite) TestInstanceStatusSpotInterruption(c *gc.C) {
+	env := t.prepareAndBootstrapWithConfig(c, coretesting.Attrs{
+		"instance-market-type": "spot",
+	})
+	t.srv.ec2srv.SetInitialInstanceState(ec2test.Running)
+	inst, _ := testing.AssertStartInstance(c, env, t.callCtx, t.ControllerUUID, "1")
+
+	t.PatchValue(ec2.SpotInterruptions, func(_ *amzec2.EC2, ids []string) (map[string]string, error) {
+		c.Check(ids, jc.DeepEquals, []string{string(inst.Id())})
+		return map[string]string{
+			string(inst.Id()): "Spot Instance is marked for termination",
+		}, nil
+	})
+	insts, err := env.Instances(t.callCtx, []instance.Id{inst.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(insts[0].Status(t.callCtx), jc.DeepEquals, instance.Status{
+		Status:  status.Running,
+		Message: "spot instance interruption notice: Spot Instance is marked for termination",
+	})
+}
+
 func (t *localServerSuite) TestStartInstanceHardwareCharacteristics(c *gc.C) {
 	env := t.prepareAndBootstrap(c)
 	_, hc := testing.AssertStartInstance(c, env, t.callCtx, t.ControllerUUID, "1")
@@ -1378,6 +1529,36 @@ func (t *localServerSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 	c.Assert(unsupported, jc.SameContents, []string{"tags", "virt-type"})
 }
 
+func (t *localServerSuite) TestPreviewInstanceSpec(c *gc.C) {
+	env := t.Prepare(c)
+	previewer, ok := env.(environs.InstanceSpecPreviewer)
+	c.Assert(ok, jc.IsTrue)
+	imageMetadata := []*imagemetadata.ImageMetadata{{
+		Id:       "ami-ebs",
+		Arch:     "amd64",
+		Version:  "16.04",
+		Storage:  "ebs",
+		VirtType: "hvm",
+	}, {
+		Id:       "ami-ssd",
+		Arch:     "amd64",
+		Version:  "16.04",
+		Storage:  "ssd",
+		VirtType: "hvm",
+	}}
+
+	// As for any non-controller instance, the ssd image and the
+	// cheapest non-burstable instance type are chosen.
+	spec, err := previewer.PreviewInstanceSpec(t.callCtx, "xenial", constraints.Value{}, imageMetadata)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(spec.InstanceType.Name, gc.Equals, "t3.micro")
+	c.Check(spec.Image.Id, gc.Equals, "ami-ssd")
+
+	spec, err = previewer.PreviewInstanceSpec(t.callCtx, "xenial", constraints.MustParse("cores=4"), imageMetadata)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(spec.InstanceType.Name, gc.Equals, "t3.xlarge")
+}
+
 func (t *localServerSuite) TestConstraintsValidatorVocab(c *gc.C) {
 	env := t.Prepare(c)
 	validator, err := env.ConstraintsValidator(t.callCtx)
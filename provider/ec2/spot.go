@@ -0,0 +1,237 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/ec2"
+)
+
+const (
+	// tagInstanceMarket is the AWS-specific tag key recording the
+	// market an instance was purchased from, as the EC2 API we use
+	// doesn't report the lifecycle of an instance. It is only set
+	// when instance-market-type is "spot": to "spot" on spot
+	// instances, and to "on-demand" on instances started on demand
+	// because no spot instance could be had.
+	tagInstanceMarket = "juju-instance-market"
+
+	// spotAPIVersion is the EC2 API version used for requests
+	// involving spot instances. The version used by the EC2 client
+	// predates instance market options.
+	spotAPIVersion = "2016-11-15"
+)
+
+// spotClient returns a copy of the given EC2 client whose RunInstances
+// requests ask for one-time spot instances, paying no more than
+// maxPrice per hour. The on-demand price is the maximum when maxPrice
+// is empty.
+//
+// The EC2 API we use has no support for instance market options, so
+// they are added to the request's query parameters before it is
+// signed, along with an API version which accepts them.
+func spotClient(client *ec2.EC2, maxPrice string) *ec2.EC2 {
+	spot := *client
+	sign := client.Sign
+	spot.Sign = func(req *http.Request, auth aws.Auth) error {
+		query := req.URL.Query()
+		if query.Get("Action") == "RunInstances" {
+			query.Set("Version", spotAPIVersion)
+			query.Set("InstanceMarketOptions.MarketType", marketTypeSpot)
+			query.Set("InstanceMarketOptions.SpotOptions.SpotInstanceType", "one-time")
+			query.Set("InstanceMarketOptions.SpotOptions.InstanceInterruptionBehavior", "terminate")
+			if maxPrice != "" {
+				query.Set("InstanceMarketOptions.SpotOptions.MaxPrice", maxPrice)
+			}
+			req.URL.RawQuery = query.Encode()
+		}
+		return sign(req, auth)
+	}
+	return &spot
+}
+
+// isSpotCapacityError reports whether or not the error indicates
+// RunInstances failed because no spot instance could be had for the
+// request, in which case an on-demand instance should be started
+// instead.
+func isSpotCapacityError(err error) bool {
+	switch ec2ErrCode(err) {
+	case "InsufficientInstanceCapacity",
+		"InsufficientSpotInstanceCapacity",
+		"MaxSpotInstanceCountExceeded",
+		"SpotMaxPriceTooLow":
+		return true
+	}
+	return false
+}
+
+// instanceMarket returns the market the instance was purchased from,
+// if it was recorded when the instance was started.
+func instanceMarket(inst *ec2.Instance) string {
+	for _, tag := range inst.Tags {
+		if tag.Key == tagInstanceMarket {
+			return tag.Value
+		}
+	}
+	return ""
+}
+
+// isSpotInstance reports whether or not the instance was started as a
+// spot instance.
+func isSpotInstance(inst *ec2.Instance) bool {
+	return instanceMarket(inst) == marketTypeSpot
+}
+
+// isSpotFallback reports whether or not the instance was started on
+// demand because no spot instance could be had for it.
+func isSpotFallback(inst *ec2.Instance) bool {
+	return instanceMarket(inst) == marketTypeOnDemand
+}
+
+// spotInstanceRequest holds the details of a spot instance request
+// which are needed to tell whether its instance is to be interrupted.
+type spotInstanceRequest struct {
+	InstanceId    string `xml:"instanceId"`
+	StatusCode    string `xml:"status>code"`
+	StatusMessage string `xml:"status>message"`
+}
+
+type spotInstanceRequestsResp struct {
+	Requests []spotInstanceRequest `xml:"spotInstanceRequestSet>item"`
+}
+
+var spotInterruptions = _spotInterruptions
+
+// spotInterruptions returns, keyed by instance id, the messages of the
+// given spot instances' requests where they show that EC2 has reclaimed
+// the instance, or is about to. EC2 gives two minutes notice before it
+// reclaims a spot instance.
+//
+// The EC2 API we use has no support for spot instance requests, so the
+// request is made here.
+func _spotInterruptions(client *ec2.EC2, ids []string) (map[string]string, error) {
+	params := map[string]string{
+		"Action":        "DescribeSpotInstanceRequests",
+		"Version":       spotAPIVersion,
+		"Filter.1.Name": "instance-id",
+	}
+	for i, id := range ids {
+		params[fmt.Sprintf("Filter.1.Value.%d", i+1)] = id
+	}
+	var result spotInstanceRequestsResp
+	if err := ec2Query(client, params, &result); err != nil {
+		return nil, errors.Annotate(err, "describing spot instance requests")
+	}
+	interruptions := make(map[string]string)
+	for _, request := range result.Requests {
+		if isSpotReclaimCode(request.StatusCode) {
+			interruptions[request.InstanceId] = request.StatusMessage
+		}
+	}
+	return interruptions, nil
+}
+
+// isSpotReclaimCode reports whether or not the status code of a spot
+// instance request shows that EC2 has reclaimed its instance, or has
+// issued a notice that it's about to. Instances terminated by the user
+// or by the service for other reasons have other codes.
+func isSpotReclaimCode(code string) bool {
+	switch code {
+	case "marked-for-termination",
+		"marked-for-stop",
+		"marked-for-hibernation",
+		"instance-terminated-by-price",
+		"instance-terminated-no-capacity",
+		"instance-terminated-capacity-oversubscribed",
+		"instance-terminated-launch-group-constraint",
+		"instance-stopped-by-price",
+		"instance-stopped-no-capacity",
+		"instance-stopped-capacity-oversubscribed",
+		"instance-hibernated-by-price",
+		"instance-hibernated-no-capacity",
+		"instance-hibernated-capacity-oversubscribed":
+		return true
+	}
+	return false
+}
+
+// ec2Query makes an EC2 API request which the EC2 client doesn't
+// support, decoding the response into resp. The request is signed and
+// sent as the client sends its own requests, so that it uses the same
+// transport, and failures are reported as *ec2.Error.
+func ec2Query(client *ec2.EC2, params map[string]string, resp interface{}) error {
+	req, err := http.NewRequest("GET", client.Region.EC2Endpoint, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	query := req.URL.Query()
+	for name, value := range params {
+		query.Set(name, value)
+	}
+	now := time.Now().In(time.UTC)
+	query.Set("Timestamp", now.Format(time.RFC3339))
+	req.URL.RawQuery = query.Encode()
+	req.Header.Set("x-amz-date", now.Format(aws.ISO8601BasicFormat))
+	if err := client.Sign(req, client.Auth); err != nil {
+		return errors.Trace(err)
+	}
+
+	r, err := ec2HTTPClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer r.Body.Close()
+	if r.StatusCode != http.StatusOK {
+		var errResp struct {
+			RequestId string      `xml:"RequestID"`
+			Errors    []ec2.Error `xml:"Errors>Error"`
+		}
+		_ = xml.NewDecoder(r.Body).Decode(&errResp)
+		var ec2Err ec2.Error
+		if len(errResp.Errors) > 0 {
+			ec2Err = errResp.Errors[0]
+		}
+		ec2Err.RequestId = errResp.RequestId
+		ec2Err.StatusCode = r.StatusCode
+		if ec2Err.Message == "" {
+			ec2Err.Message = r.Status
+		}
+		return &ec2Err
+	}
+	return errors.Trace(xml.NewDecoder(r.Body).Decode(resp))
+}
+
+// ec2HTTPClient is the HTTP client the EC2 client sends its requests
+// with; it has no transport of its own.
+var ec2HTTPClient = http.DefaultClient
+
+// setSpotInterruptions records any interruptions of the spot instances
+// among insts. Nothing is requested unless there are spot instances.
+func (e *environ) setSpotInterruptions(insts []*ec2Instance) {
+	var ids []string
+	for _, inst := range insts {
+		if isSpotInstance(inst.Instance) {
+			ids = append(ids, inst.InstanceId)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	interruptions, err := spotInterruptions(e.ec2, ids)
+	if err != nil {
+		// The instances' status is still worth reporting without
+		// any interruptions.
+		logger.Warningf("cannot get spot instance interruptions: %v", err)
+		return
+	}
+	for _, inst := range insts {
+		inst.spotInterruption = interruptions[inst.InstanceId]
+	}
+}
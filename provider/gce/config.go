@@ -12,7 +12,18 @@ import (
 )
 
 const (
-	cfgBaseImagePath = "base-image-path"
+	cfgBaseImagePath      = "base-image-path"
+	cfgInstanceMarketType = "instance-market-type"
+)
+
+const (
+	// marketTypeOnDemand is the instance-market-type value for
+	// regular instances.
+	marketTypeOnDemand = "on-demand"
+
+	// marketTypeSpot is the instance-market-type value for
+	// preemptible instances.
+	marketTypeSpot = "spot"
 )
 
 var configSchema = environschema.Fields{
@@ -20,6 +31,11 @@ var configSchema = environschema.Fields{
 		Description: "Base path to look for machine disk images.",
 		Type:        environschema.Tstring,
 	},
+	cfgInstanceMarketType: {
+		Description: "The purchasing option for new instances: on-demand, or spot to start preemptible instances, which GCE may stop at any time.",
+		Type:        environschema.Tstring,
+		Values:      []interface{}{marketTypeOnDemand, marketTypeSpot},
+	},
 }
 
 // configFields is the spec for each GCE config value's type.
@@ -34,7 +50,8 @@ var configFields = func() schema.Fields {
 var configImmutableFields = []string{}
 
 var configDefaults = schema.Defaults{
-	cfgBaseImagePath: schema.Omit,
+	cfgBaseImagePath:      schema.Omit,
+	cfgInstanceMarketType: marketTypeOnDemand,
 }
 
 type environConfig struct {
@@ -85,3 +102,7 @@ func (c *environConfig) baseImagePath() (string, bool) {
 	path, ok := c.attrs[cfgBaseImagePath].(string)
 	return path, ok
 }
+
+func (c *environConfig) preemptible() bool {
+	return c.attrs[cfgInstanceMarketType] == marketTypeSpot
+}
@@ -92,6 +92,14 @@ var newConfigTests = []configTestSpec{{
 	info:   "unknown field is not touched",
 	insert: testing.Attrs{"unknown-field": 12345},
 	expect: testing.Attrs{"unknown-field": 12345},
+}, {
+	info:   "instance-market-type can be spot",
+	insert: testing.Attrs{"instance-market-type": "spot"},
+	expect: testing.Attrs{"instance-market-type": "spot"},
+}, {
+	info:   "instance-market-type must be known",
+	insert: testing.Attrs{"instance-market-type": "reserved"},
+	err:    `instance-market-type: expected one of \[on-demand spot\], got "reserved"`,
 }}
 
 func (s *ConfigSuite) TestNewModelConfig(c *gc.C) {
@@ -154,6 +162,10 @@ var changeConfigTests = []configTestSpec{{
 	info:   "can insert unknown field",
 	insert: testing.Attrs{"unknown": "ignoti"},
 	expect: testing.Attrs{"unknown": "ignoti"},
+}, {
+	info:   "can change instance-market-type",
+	insert: testing.Attrs{"instance-market-type": "spot"},
+	expect: testing.Attrs{"instance-market-type": "spot"},
 }}
 
 // TODO(wwitzel3) refactor this to the provider_test file.
@@ -71,9 +71,28 @@ var getInstances = func(env *environ, ctx context.ProviderCallContext) ([]instan
 	return env.instances(ctx)
 }
 
+// preemptedStatuses is the list of statuses, in addition to
+// instStatuses, in which preemptible instances are accepted, so
+// that their preemption is seen.
+var preemptedStatuses = []string{
+	google.StatusStopping,
+	google.StatusTerminated,
+}
+
 func (env *environ) gceInstances(ctx context.ProviderCallContext) ([]google.Instance, error) {
 	prefix := env.namespace.Prefix()
-	instances, err := env.gce.Instances(prefix, instStatuses...)
+	statuses := append(append([]string(nil), instStatuses...), preemptedStatuses...)
+	all, err := env.gce.Instances(prefix, statuses...)
+	var instances []google.Instance
+	for _, inst := range all {
+		switch inst.Status() {
+		case google.StatusStopping, google.StatusTerminated:
+			if !inst.Preemptible {
+				continue
+			}
+		}
+		instances = append(instances, inst)
+	}
 	return instances, google.HandleCredentialError(errors.Trace(err), ctx)
 }
 
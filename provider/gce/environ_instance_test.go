@@ -99,7 +99,27 @@ func (s *environInstSuite) TestBasicInstancesAPI(c *gc.C) {
 	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "Instances")
 	c.Check(s.FakeConn.Calls[0].Prefix, gc.Equals, s.Prefix())
-	c.Check(s.FakeConn.Calls[0].Statuses, jc.DeepEquals, []string{google.StatusPending, google.StatusStaging, google.StatusRunning})
+	c.Check(s.FakeConn.Calls[0].Statuses, jc.DeepEquals, []string{
+		google.StatusPending, google.StatusStaging, google.StatusRunning,
+		google.StatusStopping, google.StatusTerminated,
+	})
+}
+
+func (s *environInstSuite) TestBasicInstancesPreempted(c *gc.C) {
+	spam := s.NewBaseInstance(c, "spam")
+	ham := s.NewBaseInstance(c, "ham")
+	ham.InstanceSummary.Status = google.StatusTerminated
+	eggs := s.NewBaseInstance(c, "eggs")
+	eggs.InstanceSummary.Status = google.StatusTerminated
+	eggs.InstanceSummary.Preemptible = true
+	s.FakeConn.Insts = []google.Instance{*spam, *ham, *eggs}
+
+	// Only preemptible instances are seen once stopped.
+	insts, err := gce.GetInstances(s.Env, s.CallCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(insts, gc.HasLen, 2)
+	c.Check(insts[0].Id(), gc.Equals, instance.Id("spam"))
+	c.Check(insts[1].Id(), gc.Equals, instance.Id("eggs"))
 }
 
 func (s *environInstSuite) TestControllerInstances(c *gc.C) {
@@ -37,6 +37,7 @@ func (inst *environInstance) Id() instance.Id {
 func (inst *environInstance) Status(ctx context.ProviderCallContext) instance.Status {
 	instStatus := inst.base.Status()
 	jujuStatus := status.Provisioning
+	message := instStatus
 	switch instStatus {
 	case "PROVISIONING", "STAGING":
 		jujuStatus = status.Provisioning
@@ -44,12 +45,16 @@ func (inst *environInstance) Status(ctx context.ProviderCallContext) instance.St
 		jujuStatus = status.Running
 	case "STOPPING", "TERMINATED":
 		jujuStatus = status.Empty
+		if inst.base.Preemptible {
+			jujuStatus = status.Preempted
+			message = "instance preempted: " + instStatus
+		}
 	default:
 		jujuStatus = status.Empty
 	}
 	return instance.Status{
 		Status:  jujuStatus,
-		Message: instStatus,
+		Message: message,
 	}
 }
 
@@ -8,6 +8,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/provider/gce"
 	"github.com/juju/juju/provider/gce/google"
 )
@@ -40,6 +41,31 @@ func (s *instanceSuite) TestStatus(c *gc.C) {
 	s.CheckNoAPI(c)
 }
 
+func (s *instanceSuite) TestStatusPreempted(c *gc.C) {
+	base := *s.BaseInstance
+	base.InstanceSummary.Status = google.StatusTerminated
+	base.InstanceSummary.Preemptible = true
+	inst := gce.NewInstance(&base, s.Env)
+
+	c.Check(inst.Status(s.CallCtx), jc.DeepEquals, instance.Status{
+		Status:  status.Preempted,
+		Message: "instance preempted: TERMINATED",
+	})
+	s.CheckNoAPI(c)
+}
+
+func (s *instanceSuite) TestStatusStopped(c *gc.C) {
+	base := *s.BaseInstance
+	base.InstanceSummary.Status = google.StatusTerminated
+	inst := gce.NewInstance(&base, s.Env)
+
+	c.Check(inst.Status(s.CallCtx), jc.DeepEquals, instance.Status{
+		Status:  status.Empty,
+		Message: "TERMINATED",
+	})
+	s.CheckNoAPI(c)
+}
+
 func (s *instanceSuite) TestAddresses(c *gc.C) {
 	addresses, err := s.Instance.Addresses(s.CallCtx)
 	c.Assert(err, jc.ErrorIsNil)
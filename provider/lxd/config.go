@@ -11,8 +11,18 @@ import (
 	"github.com/juju/juju/environs/config"
 )
 
+const (
+	cfgProject = "project"
+)
+
 var (
-	configSchema                 = environschema.Fields{}
+	configSchema = environschema.Fields{
+		cfgProject: {
+			Description: "The LXD project in which instances, profiles and images are created. The default project is used if empty.",
+			Type:        environschema.Tstring,
+			Immutable:   true,
+		},
+	}
 	configFields, configDefaults = func() (schema.Fields, schema.Defaults) {
 		fields, defaults, err := configSchema.ValidationSchema()
 		if err != nil {
@@ -56,12 +66,14 @@ func newValidConfig(cfg *config.Config) (*environConfig, error) {
 	return ecfg, nil
 }
 
+// project returns the LXD project configured for the model.
+func (c *environConfig) project() string {
+	project, _ := c.attrs[cfgProject].(string)
+	return project
+}
+
 // validate validates LXD-specific configuration.
 func (c *environConfig) validate() error {
 	_, err := c.ValidateUnknownAttrs(configFields, configDefaults)
-	if err != nil {
-		return errors.Trace(err)
-	}
-	// There are currently no known extra fields for LXD
-	return nil
+	return errors.Trace(err)
 }
@@ -130,6 +130,10 @@ var newConfigTests = []configTestSpec{{
 	info:   "unknown field is not touched",
 	insert: testing.Attrs{"unknown-field": 12345},
 	expect: testing.Attrs{"unknown-field": 12345},
+}, {
+	info:   "project is set",
+	insert: testing.Attrs{"project": "juju"},
+	expect: testing.Attrs{"project": "juju"},
 }}
 
 func (s *configSuite) TestNewModelConfig(c *gc.C) {
@@ -231,6 +235,19 @@ func (s *configSuite) TestValidateChange(c *gc.C) {
 	}
 }
 
+func (s *configSuite) TestValidateChangeProject(c *gc.C) {
+	oldCfg, err := s.config.Apply(testing.Attrs{"project": "juju"})
+	c.Assert(err, jc.ErrorIsNil)
+	newCfg, err := s.config.Apply(testing.Attrs{"project": "other"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.provider.Validate(newCfg, oldCfg)
+	c.Assert(err, gc.ErrorMatches, `cannot change project from "juju" to "other"`)
+
+	_, err = s.provider.Validate(oldCfg, oldCfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *configSuite) TestSetConfig(c *gc.C) {
 	// TODO(ericsnow) Move to a functional suite.
 	if !s.IsRunningLocally(c) {
@@ -31,6 +31,7 @@ const (
 	credAttrClientCert    = "client-cert"
 	credAttrClientKey     = "client-key"
 	credAttrTrustPassword = "trust-password"
+	credAttrProject       = "project"
 )
 
 // CertificateReadWriter groups methods that is required to read and write
@@ -96,6 +97,12 @@ func (environProviderCredentials) CredentialSchemas() map[cloud.AuthType]cloud.C
 					Description:    "the path to the PEM-encoded LXD client key file",
 					ExpandFilePath: true,
 				},
+			}, {
+				Name: credAttrProject,
+				CredentialAttr: cloud.CredentialAttr{
+					Description: "the LXD project that the client certificate is restricted to",
+					Optional:    true,
+				},
 			},
 		},
 		cloud.InteractiveAuthType: {
@@ -105,6 +112,12 @@ func (environProviderCredentials) CredentialSchemas() map[cloud.AuthType]cloud.C
 					Description: "the LXD server trust password",
 					Hidden:      true,
 				},
+			}, {
+				Name: credAttrProject,
+				CredentialAttr: cloud.CredentialAttr{
+					Description: "the LXD project that the client certificate is restricted to",
+					Optional:    true,
+				},
 			},
 		},
 	}
@@ -349,7 +362,10 @@ func (p environProviderCredentials) finalizeCredential(
 			stderr, svr, certPEM, keyPEM,
 			args.Credential.Label,
 		)
-		return cred, errors.Trace(err)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return withProject(cred, credAttrs[credAttrProject]), nil
 	}
 
 	// We're not local, so setup the remote server and automate the remote
@@ -450,7 +466,7 @@ func (p environProviderCredentials) finalizeRemoteCredential(
 		credAttrServerCert: server.ServerCertificate(),
 	})
 	out.Label = credentials.Label
-	return &out, nil
+	return withProject(&out, credAttrs[credAttrProject]), nil
 }
 
 func (p environProviderCredentials) finalizeLocalCredential(
@@ -625,6 +641,25 @@ func addrsContains(haystack []net.Addr, needle string) bool {
 	return false
 }
 
+// withProject returns the input credential with the project attribute set,
+// if the project is not empty.
+func withProject(cred *cloud.Credential, project string) *cloud.Credential {
+	if project == "" {
+		return cred
+	}
+	attrs := cred.Attributes()
+	attrs[credAttrProject] = project
+	out := cloud.NewCredential(cred.AuthType(), attrs)
+	out.Label = cred.Label
+	return &out
+}
+
+// getProject returns the LXD project that the credentials are restricted to.
+// An empty string is returned if there is no such restriction.
+func getProject(credentials cloud.Credential) string {
+	return credentials.Attributes()[credAttrProject]
+}
+
 func getCertificates(credentials cloud.Credential) (client *lxd.Certificate, server string, ok bool) {
 	clientCert, ok := getClientCertificates(credentials)
 	if !ok {
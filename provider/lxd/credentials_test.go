@@ -475,6 +475,36 @@ func (s *credentialsSuite) TestFinalizeCredentialLocalAddCert(c *gc.C) {
 	})
 }
 
+func (s *credentialsSuite) TestFinalizeCredentialLocalWithProject(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	deps := s.createProvider(ctrl)
+
+	deps.server.EXPECT().GetCertificate(s.clientCertFingerprint(c)).Return(nil, "", nil)
+	deps.server.EXPECT().ServerCertificate().Return("server-cert")
+
+	cred := cloud.NewCredential(cloud.CertificateAuthType, map[string]string{
+		"client-cert": coretesting.CACert,
+		"client-key":  coretesting.CAKey,
+		"project":     "juju",
+	})
+	cred.Label = "label"
+	out, err := deps.provider.FinalizeCredential(cmdtesting.Context(c), environs.FinalizeCredentialParams{
+		CloudEndpoint: "",
+		Credential:    cred,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(out.Label, gc.Equals, "label")
+	c.Assert(out.Attributes(), jc.DeepEquals, map[string]string{
+		"client-cert": coretesting.CACert,
+		"client-key":  coretesting.CAKey,
+		"server-cert": "server-cert",
+		"project":     "juju",
+	})
+}
+
 func (s *credentialsSuite) TestFinalizeCredentialLocalAddCertAlreadyExists(c *gc.C) {
 	// If we get back an error from CreateClientCertificate, we'll make another
 	// call to GetCertificate. If that call succeeds, then we assume
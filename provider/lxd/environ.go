@@ -137,10 +137,43 @@ func (env *environ) SetCloudSpec(spec environs.CloudSpec) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+
+	project, err := env.projectUnlocked(spec)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if project != "" && server.Project() != project {
+		projectServer, err := server.UseProject(project)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		server = projectServer
+	}
+
 	env.serverUnlocked = server
 	return env.initProfile()
 }
 
+// projectUnlocked returns the LXD project for the environ's instances,
+// profiles and images. A project in the credential takes precedence over
+// one in the model config, as the credential's certificate may be restricted
+// to that project.
+func (env *environ) projectUnlocked(spec environs.CloudSpec) (string, error) {
+	project := env.ecfgUnlocked.project()
+	if spec.Credential == nil {
+		return project, nil
+	}
+	credProject := getProject(*spec.Credential)
+	if credProject == "" {
+		return project, nil
+	}
+	if project != "" && project != credProject {
+		return "", errors.Errorf(
+			"model config %s %q does not match credential %s %q", cfgProject, project, credAttrProject, credProject)
+	}
+	return credProject, nil
+}
+
 func (env *environ) server() Server {
 	env.lock.Lock()
 	defer env.lock.Unlock()
@@ -120,6 +120,11 @@ func (env *environ) newContainer(
 		return nil, errors.Trace(err)
 	}
 
+	cons := args.Constraints
+	if cons.HasVirtType() && *cons.VirtType == lxd.VirtTypeVirtualMachine {
+		return env.newVirtualMachine(target, args, arch, imageSources, statusCallback)
+	}
+
 	image, err := target.FindImage(args.InstanceConfig.Series, arch, imageSources, true, statusCallback)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -140,6 +145,36 @@ func (env *environ) newContainer(
 	return container, nil
 }
 
+// newVirtualMachine creates a new LXD virtual machine on the target server.
+// The image is downloaded by the LXD server as part of the creation,
+// so there is no download progress to report.
+func (env *environ) newVirtualMachine(
+	target Server,
+	args environs.StartInstanceParams,
+	arch string,
+	imageSources []lxd.ServerSpec,
+	statusCallback environs.StatusCallbackFunc,
+) (*lxd.Container, error) {
+	image, err := target.FindVirtualMachineImage(args.InstanceConfig.Series, arch, imageSources)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	cSpec, err := env.getContainerSpec(lxd.SourcedImage{}, target.ServerVersion(), args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cSpec.RemoteImage = image
+
+	statusCallback(status.Allocating, "Creating virtual machine", nil)
+	container, err := target.CreateContainerFromSpec(cSpec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	statusCallback(status.Running, "Virtual machine started", nil)
+	return container, nil
+}
+
 func (env *environ) getImageSources() ([]lxd.ServerSpec, error) {
 	metadataSources, err := environs.ImageMetadataSources(env)
 	if err != nil {
@@ -192,27 +227,12 @@ func (env *environ) getContainerSpec(
 		return cSpec, errors.Trace(err)
 	}
 
-	// Check to see if there are any non-eth0 devices in the default profile.
-	// If there are, we need cloud-init to configure them, and we need to
-	// explicitly add them to the container spec.
-	nics, err := env.server().GetNICsFromProfile("default")
-	if err != nil {
-		return cSpec, errors.Trace(err)
-	}
-	if !(len(nics) == 1 && nics["eth0"] != nil) {
-		logger.Debugf("generating custom cloud-init networking")
-
-		cSpec.Config[lxd.NetworkConfigKey] = cloudinit.CloudInitNetworkConfigDisabled
-
-		info, err := lxd.InterfaceInfoFromDevices(nics)
-		if err != nil {
+	// Virtual machines do not name their NICs after the profile devices,
+	// so they rely on the network configuration supplied with the image.
+	if !cSpec.IsVirtualMachine() {
+		if err := env.addProfileNICs(&cSpec, cloudCfg); err != nil {
 			return cSpec, errors.Trace(err)
 		}
-		if err := cloudCfg.AddNetworkConfig(info); err != nil {
-			return cSpec, errors.Trace(err)
-		}
-
-		cSpec.Devices = nics
 	}
 
 	userData, err := providerinit.ComposeUserData(args.InstanceConfig, cloudCfg, lxdRenderer{})
@@ -242,6 +262,33 @@ func (env *environ) getContainerSpec(
 	return cSpec, nil
 }
 
+// addProfileNICs checks to see if there are any non-eth0 devices in the
+// default profile. If there are, we need cloud-init to configure them,
+// and we need to explicitly add them to the container spec.
+func (env *environ) addProfileNICs(cSpec *lxd.ContainerSpec, cloudCfg cloudinit.CloudConfig) error {
+	nics, err := env.server().GetNICsFromProfile("default")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(nics) == 1 && nics["eth0"] != nil {
+		return nil
+	}
+	logger.Debugf("generating custom cloud-init networking")
+
+	cSpec.Config[lxd.NetworkConfigKey] = cloudinit.CloudInitNetworkConfigDisabled
+
+	info, err := lxd.InterfaceInfoFromDevices(nics)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := cloudCfg.AddNetworkConfig(info); err != nil {
+		return errors.Trace(err)
+	}
+
+	cSpec.Devices = nics
+	return nil
+}
+
 // getTargetServer checks to see if a valid zone was passed as a placement
 // directive in the start-up start-up arguments. If so, a server for the
 // specific node is returned.
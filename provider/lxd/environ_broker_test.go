@@ -313,6 +313,53 @@ func (s *environBrokerSuite) TestStartInstanceWithConstraints(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *environBrokerSuite) TestStartInstanceVirtualMachine(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	image := containerlxd.RemoteImage{
+		Server: containerlxd.CloudImagesRemote,
+		Alias:  "bionic/amd64",
+	}
+
+	// Check that the virtual machine is created from the remote image,
+	// with constraints applied and no custom network devices.
+	check := func(spec containerlxd.ContainerSpec) bool {
+		if !spec.IsVirtualMachine() || spec.RemoteImage != image {
+			return false
+		}
+		if spec.Config["limits.cpu"] != "2" {
+			return false
+		}
+		if spec.Config[containerlxd.NetworkConfigKey] != "" {
+			return false
+		}
+		return len(spec.Devices) == 0
+	}
+
+	exp := svr.EXPECT()
+	gomock.InOrder(
+		exp.HostArch().Return(arch.AMD64),
+		exp.FindVirtualMachineImage("bionic", arch.AMD64, gomock.Any()).Return(image, nil),
+		exp.ServerVersion().Return("3.10.0"),
+		exp.CreateContainerFromSpec(matchesContainerSpec(check)).Return(&containerlxd.Container{}, nil),
+		exp.HostArch().Return(arch.AMD64),
+	)
+
+	args := s.GetStartInstanceArgs(c, "bionic")
+	cores := uint64(2)
+	virtType := containerlxd.VirtTypeVirtualMachine
+	args.Constraints = constraints.Value{
+		CpuCores: &cores,
+		VirtType: &virtType,
+	}
+
+	env := s.NewEnviron(c, svr, nil)
+	_, err := env.StartInstance(s.callCtx, args)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *environBrokerSuite) TestStartInstanceWithCharmLXDProfile(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
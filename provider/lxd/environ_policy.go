@@ -6,6 +6,7 @@ package lxd
 import (
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/container/lxd"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
@@ -21,7 +22,6 @@ func (env *environ) PrecheckInstance(ctx context.ProviderCallContext, args envir
 var unsupportedConstraints = []string{
 	constraints.CpuPower,
 	constraints.Tags,
-	constraints.VirtType,
 	constraints.Container,
 }
 
@@ -33,6 +33,12 @@ func (env *environ) ConstraintsValidator(ctx context.ProviderCallContext) (const
 	validator.RegisterUnsupported(unsupportedConstraints)
 	validator.RegisterVocabulary(constraints.Arch, []string{env.server().HostArch()})
 
+	virtTypes := []string{lxd.VirtTypeContainer}
+	if env.server().VirtualMachineSupported() {
+		virtTypes = append(virtTypes, lxd.VirtTypeVirtualMachine)
+	}
+	validator.RegisterVocabulary(constraints.VirtType, virtTypes)
+
 	return validator, nil
 }
 
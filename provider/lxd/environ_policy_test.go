@@ -120,6 +120,7 @@ func (s *environPolicySuite) TestConstraintsValidatorOkay(c *gc.C) {
 
 	exp := svr.EXPECT()
 	exp.HostArch().Return(arch.AMD64)
+	exp.VirtualMachineSupported().Return(false)
 
 	validator, err := env.ConstraintsValidator(context.NewCloudCallContext())
 	c.Assert(err, jc.ErrorIsNil)
@@ -140,6 +141,7 @@ func (s *environPolicySuite) TestConstraintsValidatorEmpty(c *gc.C) {
 
 	exp := svr.EXPECT()
 	exp.HostArch().Return(arch.AMD64)
+	exp.VirtualMachineSupported().Return(false)
 
 	validator, err := env.ConstraintsValidator(context.NewCloudCallContext())
 	c.Assert(err, jc.ErrorIsNil)
@@ -159,6 +161,7 @@ func (s *environPolicySuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 
 	exp := svr.EXPECT()
 	exp.HostArch().Return(arch.AMD64)
+	exp.VirtualMachineSupported().Return(false)
 
 	validator, err := env.ConstraintsValidator(context.NewCloudCallContext())
 	c.Assert(err, jc.ErrorIsNil)
@@ -170,7 +173,7 @@ func (s *environPolicySuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 		"instance-type=some-type",
 		"cores=2",
 		"cpu-power=250",
-		"virt-type=kvm",
+		"virt-type=container",
 	}, " "))
 	unsupported, err := validator.Validate(cons)
 	c.Assert(err, jc.ErrorIsNil)
@@ -178,7 +181,6 @@ func (s *environPolicySuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 	expected := []string{
 		"tags",
 		"cpu-power",
-		"virt-type",
 	}
 	c.Check(unsupported, jc.SameContents, expected)
 }
@@ -192,6 +194,7 @@ func (s *environPolicySuite) TestConstraintsValidatorVocabArchKnown(c *gc.C) {
 
 	exp := svr.EXPECT()
 	exp.HostArch().Return(arch.AMD64)
+	exp.VirtualMachineSupported().Return(false)
 
 	validator, err := env.ConstraintsValidator(context.NewCloudCallContext())
 	c.Assert(err, jc.ErrorIsNil)
@@ -211,6 +214,7 @@ func (s *environPolicySuite) TestConstraintsValidatorVocabArchUnknown(c *gc.C) {
 
 	exp := svr.EXPECT()
 	exp.HostArch().Return(arch.AMD64)
+	exp.VirtualMachineSupported().Return(false)
 
 	validator, err := env.ConstraintsValidator(context.NewCloudCallContext())
 	c.Assert(err, jc.ErrorIsNil)
@@ -221,6 +225,46 @@ func (s *environPolicySuite) TestConstraintsValidatorVocabArchUnknown(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "invalid constraint value: arch=ppc64el\nvalid values are: \\[amd64\\]")
 }
 
+func (s *environPolicySuite) TestConstraintsValidatorVocabVirtTypeVirtualMachine(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	env := s.NewEnviron(c, svr, nil)
+
+	exp := svr.EXPECT()
+	exp.HostArch().Return(arch.AMD64)
+	exp.VirtualMachineSupported().Return(true)
+
+	validator, err := env.ConstraintsValidator(context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons := constraints.MustParse("virt-type=virtual-machine")
+	_, err = validator.Validate(cons)
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *environPolicySuite) TestConstraintsValidatorVocabVirtTypeNoVirtualMachines(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	env := s.NewEnviron(c, svr, nil)
+
+	exp := svr.EXPECT()
+	exp.HostArch().Return(arch.AMD64)
+	exp.VirtualMachineSupported().Return(false)
+
+	validator, err := env.ConstraintsValidator(context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons := constraints.MustParse("virt-type=virtual-machine")
+	_, err = validator.Validate(cons)
+
+	c.Check(err, gc.ErrorMatches, "invalid constraint value: virt-type=virtual-machine\nvalid values are: \\[container\\]")
+}
+
 func (s *environPolicySuite) TestConstraintsValidatorVocabContainerUnknown(c *gc.C) {
 	c.Skip("this will fail until we add a container vocabulary")
 	ctrl := gomock.NewController(c)
@@ -247,6 +291,7 @@ func (s *environPolicySuite) TestConstraintsValidatorConflicts(c *gc.C) {
 
 	exp := svr.EXPECT()
 	exp.HostArch().Return(arch.AMD64)
+	exp.VirtualMachineSupported().Return(false)
 
 	validator, err := env.ConstraintsValidator(context.NewCloudCallContext())
 	c.Assert(err, jc.ErrorIsNil)
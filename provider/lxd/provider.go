@@ -203,9 +203,16 @@ func (p *environProvider) PrepareConfig(args environs.PrepareConfigParams) (*con
 
 // Validate implements environs.EnvironProvider.
 func (*environProvider) Validate(cfg, old *config.Config) (valid *config.Config, err error) {
-	if _, err := newValidConfig(cfg); err != nil {
+	ecfg, err := newValidConfig(cfg)
+	if err != nil {
 		return nil, errors.Annotate(err, "invalid base config")
 	}
+	if old != nil {
+		oldEcfg := newConfig(old)
+		if ecfg.project() != oldEcfg.project() {
+			return nil, errors.Errorf("cannot change %s from %q to %q", cfgProject, oldEcfg.project(), ecfg.project())
+		}
+	}
 	return cfg, nil
 }
 
@@ -457,6 +457,85 @@ func (s *providerSuite) TestValidateWithInvalidConfig(c *gc.C) {
 	c.Assert(err, gc.NotNil)
 }
 
+func (s *providerSuite) projectCloudSpec(project string) environs.CloudSpec {
+	attrs := map[string]string{
+		"client-cert": "client-cert",
+		"client-key":  "client-key",
+		"server-cert": "server-cert",
+	}
+	if project != "" {
+		attrs["project"] = project
+	}
+	cred := cloud.NewCredential(cloud.CertificateAuthType, attrs)
+	return environs.CloudSpec{
+		Type:       "lxd",
+		Name:       "remote",
+		Endpoint:   "https://10.0.0.9:8443",
+		Credential: &cred,
+	}
+}
+
+func (s *providerSuite) TestOpenWithCredentialProject(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	deps := s.createProvider(ctrl)
+	server := lxd.NewMockServer(ctrl)
+
+	spec := s.projectCloudSpec("juju")
+	deps.factory.EXPECT().RemoteServer(spec).Return(server, nil)
+	server.EXPECT().Project().Return("juju")
+	server.EXPECT().HasProfile("juju-"+s.Config.Name()).Return(true, nil)
+
+	_, err := environs.Open(deps.provider, environs.OpenParams{
+		Cloud:  spec,
+		Config: s.Config,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *providerSuite) TestOpenWithConfigProject(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	deps := s.createProvider(ctrl)
+	server := lxd.NewMockServer(ctrl)
+
+	cfg, err := s.Config.Apply(map[string]interface{}{"project": "juju"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	spec := s.projectCloudSpec("")
+	deps.factory.EXPECT().RemoteServer(spec).Return(server, nil)
+	server.EXPECT().Project().Return("")
+	server.EXPECT().UseProject("juju").Return(nil, errors.New("boom"))
+
+	_, err = environs.Open(deps.provider, environs.OpenParams{
+		Cloud:  spec,
+		Config: cfg,
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *providerSuite) TestOpenWithMismatchedProject(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	deps := s.createProvider(ctrl)
+	server := lxd.NewMockServer(ctrl)
+
+	cfg, err := s.Config.Apply(map[string]interface{}{"project": "other"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	spec := s.projectCloudSpec("juju")
+	deps.factory.EXPECT().RemoteServer(spec).Return(server, nil)
+
+	_, err = environs.Open(deps.provider, environs.OpenParams{
+		Cloud:  spec,
+		Config: cfg,
+	})
+	c.Assert(err, gc.ErrorMatches, `model config project "other" does not match credential project "juju"`)
+}
+
 func (s *providerSuite) TestCloudSchema(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
@@ -33,6 +33,8 @@ import (
 //go:generate mockgen -package lxd -destination server_mock_test.go github.com/juju/juju/provider/lxd Server,ServerFactory,InterfaceAddress
 type Server interface {
 	FindImage(string, string, []lxd.ServerSpec, bool, environs.StatusCallbackFunc) (lxd.SourcedImage, error)
+	FindVirtualMachineImage(string, string, []lxd.ServerSpec) (lxd.RemoteImage, error)
+	VirtualMachineSupported() bool
 	GetServer() (server *lxdapi.Server, ETag string, err error)
 	ServerVersion() string
 	GetConnectionInfo() (info *lxdclient.ConnectionInfo, err error)
@@ -75,6 +77,8 @@ type Server interface {
 	GetNICsFromProfile(profName string) (map[string]map[string]string, error)
 	IsClustered() bool
 	UseTargetServer(name string) (*lxd.Server, error)
+	Project() string
+	UseProject(name string) (*lxd.Server, error)
 	GetClusterMembers() (members []lxdapi.ClusterMember, err error)
 	Name() string
 }
@@ -195,7 +199,7 @@ func (s *serverFactory) RemoteServer(spec environs.CloudSpec) (Server, error) {
 	serverSpec := lxd.NewServerSpec(spec.Endpoint,
 		serverCert,
 		clientCert,
-	)
+	).WithProject(getProject(*cred))
 	serverSpec.WithProxy(proxy.DefaultConfig.GetProxy)
 	svr, err := s.newRemoteServerFunc(serverSpec)
 	if err == nil {
@@ -239,6 +239,19 @@ func (mr *MockServerMockRecorder) FindImage(arg0, arg1, arg2, arg3, arg4 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindImage", reflect.TypeOf((*MockServer)(nil).FindImage), arg0, arg1, arg2, arg3, arg4)
 }
 
+// FindVirtualMachineImage mocks base method
+func (m *MockServer) FindVirtualMachineImage(arg0, arg1 string, arg2 []lxd.ServerSpec) (lxd.RemoteImage, error) {
+	ret := m.ctrl.Call(m, "FindVirtualMachineImage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(lxd.RemoteImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindVirtualMachineImage indicates an expected call of FindVirtualMachineImage
+func (mr *MockServerMockRecorder) FindVirtualMachineImage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVirtualMachineImage", reflect.TypeOf((*MockServer)(nil).FindVirtualMachineImage), arg0, arg1, arg2)
+}
+
 // GetCertificate mocks base method
 func (m *MockServer) GetCertificate(arg0 string) (*api.Certificate, string, error) {
 	ret := m.ctrl.Call(m, "GetCertificate", arg0)
@@ -448,6 +461,18 @@ func (mr *MockServerMockRecorder) Name() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockServer)(nil).Name))
 }
 
+// Project mocks base method
+func (m *MockServer) Project() string {
+	ret := m.ctrl.Call(m, "Project")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Project indicates an expected call of Project
+func (mr *MockServerMockRecorder) Project() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Project", reflect.TypeOf((*MockServer)(nil).Project))
+}
+
 // RemoveContainer mocks base method
 func (m *MockServer) RemoveContainer(arg0 string) error {
 	ret := m.ctrl.Call(m, "RemoveContainer", arg0)
@@ -568,6 +593,19 @@ func (mr *MockServerMockRecorder) UpdateStoragePoolVolume(arg0, arg1, arg2, arg3
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStoragePoolVolume", reflect.TypeOf((*MockServer)(nil).UpdateStoragePoolVolume), arg0, arg1, arg2, arg3, arg4)
 }
 
+// UseProject mocks base method
+func (m *MockServer) UseProject(arg0 string) (*lxd.Server, error) {
+	ret := m.ctrl.Call(m, "UseProject", arg0)
+	ret0, _ := ret[0].(*lxd.Server)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseProject indicates an expected call of UseProject
+func (mr *MockServerMockRecorder) UseProject(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseProject", reflect.TypeOf((*MockServer)(nil).UseProject), arg0)
+}
+
 // UseTargetServer mocks base method
 func (m *MockServer) UseTargetServer(arg0 string) (*lxd.Server, error) {
 	ret := m.ctrl.Call(m, "UseTargetServer", arg0)
@@ -593,6 +631,18 @@ func (mr *MockServerMockRecorder) VerifyNetworkDevice(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyNetworkDevice", reflect.TypeOf((*MockServer)(nil).VerifyNetworkDevice), arg0, arg1)
 }
 
+// VirtualMachineSupported mocks base method
+func (m *MockServer) VirtualMachineSupported() bool {
+	ret := m.ctrl.Call(m, "VirtualMachineSupported")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// VirtualMachineSupported indicates an expected call of VirtualMachineSupported
+func (mr *MockServerMockRecorder) VirtualMachineSupported() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VirtualMachineSupported", reflect.TypeOf((*MockServer)(nil).VirtualMachineSupported))
+}
+
 // WriteContainer mocks base method
 func (m *MockServer) WriteContainer(arg0 *lxd.Container) error {
 	ret := m.ctrl.Call(m, "WriteContainer", arg0)
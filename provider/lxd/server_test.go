@@ -411,6 +411,48 @@ func (s *serverSuite) TestRemoteServer(c *gc.C) {
 	c.Assert(err, gc.IsNil)
 }
 
+func (s *serverSuite) TestRemoteServerWithProject(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	serverInfo := &api.Server{
+		ServerUntrusted: api.ServerUntrusted{
+			APIVersion: "1.1",
+		},
+	}
+
+	server := lxd.NewMockServer(ctrl)
+	var project string
+	factory := lxd.NewServerFactoryWithMocks(
+		defaultLocalServerFunc(ctrl),
+		func(spec containerLXD.ServerSpec) (lxd.Server, error) {
+			project = spec.Project
+			return server, nil
+		},
+		lxd.NewMockInterfaceAddress(ctrl),
+		&lxd.MockClock{},
+	)
+
+	gomock.InOrder(
+		server.EXPECT().StorageSupported().Return(false),
+		server.EXPECT().GetServer().Return(serverInfo, "etag", nil),
+	)
+
+	creds := cloud.NewCredential("any", map[string]string{
+		"client-cert": "client-cert",
+		"client-key":  "client-key",
+		"server-cert": "server-cert",
+		"project":     "juju",
+	})
+	svr, err := factory.RemoteServer(environs.CloudSpec{
+		Endpoint:   "https://10.0.0.9:8443",
+		Credential: &creds,
+	})
+	c.Assert(err, gc.IsNil)
+	c.Assert(svr, gc.Equals, server)
+	c.Check(project, gc.Equals, "juju")
+}
+
 func (s *serverSuite) TestRemoteServerWithNoStorage(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
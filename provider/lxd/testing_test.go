@@ -437,6 +437,7 @@ type StubClient struct {
 	ServerCert         string
 	ServerHostArch     string
 	ServerVer          string
+	VMSupported        bool
 }
 
 func (conn *StubClient) FilterContainers(prefix string, statuses ...string) ([]lxd.Container, error) {
@@ -468,6 +469,22 @@ func (conn *StubClient) FindImage(
 	return lxd.SourcedImage{}, nil
 }
 
+func (conn *StubClient) FindVirtualMachineImage(
+	series, arch string, sources []lxd.ServerSpec,
+) (lxd.RemoteImage, error) {
+	conn.AddCall("FindVirtualMachineImage", series, arch)
+	if err := conn.NextErr(); err != nil {
+		return lxd.RemoteImage{}, errors.Trace(err)
+	}
+
+	return lxd.RemoteImage{Alias: series + "/" + arch}, nil
+}
+
+func (conn *StubClient) VirtualMachineSupported() bool {
+	conn.AddCall("VirtualMachineSupported")
+	return conn.VMSupported
+}
+
 func (conn *StubClient) CreateCertificate(cert api.CertificatesPost) error {
 	conn.AddCall("CreateCertificate", cert)
 	return conn.NextErr()
@@ -723,6 +740,19 @@ func (conn *StubClient) UseTargetServer(name string) (*lxd.Server, error) {
 	return nil, conn.NextErr()
 }
 
+func (conn *StubClient) Project() string {
+	conn.AddCall("Project")
+	return ""
+}
+
+// TODO (manadart 2018-07-20): This exists to satisfy the testing stub
+// interface. It is temporary, pending replacement with mocks and
+// should not be called in tests.
+func (conn *StubClient) UseProject(name string) (*lxd.Server, error) {
+	conn.AddCall("UseProject", name)
+	return nil, conn.NextErr()
+}
+
 func (conn *StubClient) GetClusterMembers() (members []api.ClusterMember, err error) {
 	conn.AddCall("GetClusterMembers")
 	return nil, conn.NextErr()
@@ -137,6 +137,10 @@ func allCollections() CollectionSchema {
 		// migration minions.
 		migrationsMinionSyncC: {global: true},
 
+		// This collection holds batches of model migrations which
+		// are started a few at a time.
+		migrationBatchesC: {global: true},
+
 		// This collection holds user information that's not specific to any
 		// one model.
 		usersC: {
@@ -567,6 +571,7 @@ const (
 	metricsC                   = "metrics"
 	metricsManagerC            = "metricsmanager"
 	minUnitsC                  = "minunits"
+	migrationBatchesC          = "migrations.batches"
 	migrationsActiveC          = "migrations.active"
 	migrationsC                = "migrations"
 	migrationsMinionSyncC      = "migrations.minionsync"
@@ -117,6 +117,12 @@ func applicationGlobalOperatorKey(appName string) string {
 	return applicationGlobalKey(appName) + "#operator"
 }
 
+// applicationGlobalOperatorEventsKey returns the global database key for
+// the history of events reported by the cloud for the application's operator.
+func applicationGlobalOperatorEventsKey(appName string) string {
+	return applicationGlobalOperatorKey(appName) + "#events"
+}
+
 func applicationCharmConfigKey(appName string, curl *charm.URL) string {
 	return fmt.Sprintf("a#%s#%s", appName, curl)
 }
@@ -780,7 +786,10 @@ func (a *Application) checkStorageUpgrade(newMeta, oldMeta *charm.Meta, units []
 }
 
 // changeCharmOps returns the operations necessary to set a application's
-// charm URL to a new value.
+// charm URL to a new value. If charmRefHeld is true, the caller already
+// holds references to the new charm and its settings and storage
+// constraints, which are passed to the application instead of new
+// references being added.
 func (a *Application) changeCharmOps(
 	ch *Charm,
 	channel string,
@@ -788,6 +797,7 @@ func (a *Application) changeCharmOps(
 	forceUnits bool,
 	resourceIDs map[string]string,
 	updatedStorageConstraints map[string]StorageConstraints,
+	charmRefHeld bool,
 ) ([]txn.Op, error) {
 	// Build the new application config from what can be used of the old one.
 	var newSettings charm.Settings
@@ -851,9 +861,12 @@ func (a *Application) changeCharmOps(
 
 	// Add or create a reference to the new charm, settings,
 	// and storage constraints docs.
-	incOps, err := appCharmIncRefOps(a.st, a.doc.Name, ch.URL(), true)
-	if err != nil {
-		return nil, errors.Trace(err)
+	var incOps []txn.Op
+	if !charmRefHeld {
+		incOps, err = appCharmIncRefOps(a.st, a.doc.Name, ch.URL(), true)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 	var decOps []txn.Op
 	// Drop the references to the old settings, storage constraints,
@@ -1283,6 +1296,7 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 				cfg.ForceUnits,
 				cfg.ResourceIDs,
 				cfg.StorageConstraints,
+				false,
 			)
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -1381,6 +1395,83 @@ func (a *Application) UpdateApplicationSeries(series string, force bool) (err er
 	return errors.Annotatef(err, "updating application series")
 }
 
+// SetBranchCharm records that units tracking the input branch are to be
+// upgraded to the configured charm, with the configured pending resources.
+// Changes to charm config and storage constraints are not supported as part
+// of such an upgrade; config can be changed under the branch separately.
+func (a *Application) SetBranchCharm(branchName string, cfg SetCharmConfig) (err error) {
+	if branchName == model.GenerationMaster {
+		return errors.Trace(a.SetCharm(cfg))
+	}
+	defer errors.DeferredAnnotatef(
+		&err, "cannot upgrade application %q to charm %q under branch %q", a, cfg.Charm, branchName,
+	)
+	if len(cfg.ConfigSettings) > 0 || len(cfg.StorageConstraints) > 0 {
+		return errors.NotSupportedf("changing config or storage constraints with a charm upgrade under a branch")
+	}
+	if cfg.ForceSeries {
+		return errors.NotSupportedf("forcing the series of a charm upgraded under a branch")
+	}
+	if cfg.Charm.Meta().Subordinate != a.doc.Subordinate {
+		return errors.Errorf("cannot change an application's subordinacy")
+	}
+	supportedSeries := cfg.Charm.Meta().Series
+	if len(supportedSeries) == 0 {
+		supportedSeries = append(supportedSeries, cfg.Charm.URL().Series)
+	}
+	if _, err := charm.SeriesForCharm(a.doc.Series, supportedSeries); err != nil {
+		return &ErrIncompatibleSeries{
+			SeriesList: supportedSeries,
+			Series:     a.doc.Series,
+			CharmName:  cfg.Charm.String(),
+		}
+	}
+	if a.doc.Life == Dead {
+		return ErrDead
+	}
+	branch, err := a.st.Branch(branchName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(branch.UpdateCharm(a.doc.Name, cfg.Charm, cfg.ResourceIDs))
+}
+
+// branchCharmOps returns the operations necessary for a branch to hold a
+// reference to the input charm for the application, creating the settings
+// and storage constraints documents for the charm if they do not exist.
+func (a *Application) branchCharmOps(ch *Charm) ([]txn.Op, error) {
+	var ops []txn.Op
+
+	settingsKey := applicationCharmConfigKey(a.doc.Name, ch.URL())
+	if _, err := readSettings(a.st.db(), settingsC, settingsKey); errors.IsNotFound(err) {
+		current, err := readSettings(a.st.db(), settingsC, a.charmConfigKey())
+		if err != nil {
+			return nil, errors.Annotatef(err, "application %q", a.doc.Name)
+		}
+		settings := ch.Config().FilterSettings(current.Map())
+		ops = append(ops, createSettingsOp(settingsC, settingsKey, settings))
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "application %q", a.doc.Name)
+	}
+
+	storageConstraintsKey := applicationStorageConstraintsKey(a.doc.Name, ch.URL())
+	if _, err := readStorageConstraints(a.st, storageConstraintsKey); errors.IsNotFound(err) {
+		current, err := readStorageConstraints(a.st, a.storageConstraintsKey())
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Annotatef(err, "application %q", a.doc.Name)
+		}
+		ops = append(ops, createStorageConstraintsOp(storageConstraintsKey, current))
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "application %q", a.doc.Name)
+	}
+
+	incOps, err := appCharmIncRefOps(a.st, a.doc.Name, ch.URL(), true)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, incOps...), nil
+}
+
 // VerifySupportedSeries verifies if the given series is supported by the
 // application.
 func (a *Application) VerifySupportedSeries(series string, force bool) error {
@@ -2359,6 +2450,33 @@ func (a *Application) SetConstraints(cons constraints.Value) (err error) {
 	return onAbort(a.st.db().RunTransaction(ops), applicationNotAliveErr)
 }
 
+// SetBranchConstraints sets the application constraints used for units
+// tracking the input branch.
+func (a *Application) SetBranchConstraints(branchName string, cons constraints.Value) (err error) {
+	if branchName == model.GenerationMaster {
+		return errors.Trace(a.SetConstraints(cons))
+	}
+	unsupported, err := a.st.validateConstraints(cons)
+	if len(unsupported) > 0 {
+		logger.Warningf(
+			"setting constraints on application %q: unsupported constraints: %v", a.Name(), strings.Join(unsupported, ","))
+	} else if err != nil {
+		return err
+	}
+	if a.doc.Subordinate {
+		return ErrSubordinateConstraints
+	}
+	defer errors.DeferredAnnotatef(&err, "cannot set constraints under branch %q", branchName)
+	if a.doc.Life != Alive {
+		return applicationNotAliveErr
+	}
+	branch, err := a.st.Branch(branchName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(branch.UpdateConstraints(a.doc.Name, cons))
+}
+
 // EndpointBindings returns the mapping for each endpoint name and the space
 // name it is bound to (or empty if unspecified). When no bindings are stored
 // for the application, defaults are returned.
@@ -2591,6 +2709,27 @@ func (a *Application) SetOperatorStatus(sInfo status.StatusInfo) error {
 	return nil
 }
 
+// RecordOperatorEvents records events reported by the cloud for the
+// application's operator in status history.
+// This is used on CAAS models.
+func (a *Application) RecordOperatorEvents(events []status.StatusInfo) error {
+	m, err := a.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if m.Type() != ModelTypeCAAS {
+		return errors.NotSupportedf("caas operation on non-caas model")
+	}
+	recordCloudEvents(a.st.db(), applicationGlobalOperatorEventsKey(a.Name()), events, a.st.clock())
+	return nil
+}
+
+// OperatorEventHistory returns a StatusHistoryGetter which can be used to
+// query the history of events reported by the cloud for the application's operator.
+func (a *Application) OperatorEventHistory() status.StatusHistoryGetter {
+	return &HistoryGetter{st: a.st, globalKey: applicationGlobalOperatorEventsKey(a.Name())}
+}
+
 // StatusHistory returns a slice of at most filter.Size StatusInfo items
 // or items as old as filter.Date or items newer than now - filter.Delta time
 // representing past statuses for this application.
@@ -2760,6 +2899,10 @@ type UnitUpdateProperties struct {
 	AgentStatus          *status.StatusInfo
 	UnitStatus           *status.StatusInfo
 	CloudContainerStatus *status.StatusInfo
+
+	// CloudContainerEvents holds events reported by the cloud for the
+	// unit's container which are to be recorded in status history.
+	CloudContainerEvents []status.StatusInfo
 }
 
 // UpdateUnits applies the given application unit update operations.
@@ -2881,6 +3024,10 @@ func (op *AddUnitOperation) Done(err error) error {
 	if err != nil {
 		return errors.Annotatef(err, "adding unit to %q", op.application.Name())
 	}
+	recordCloudEvents(
+		op.application.st.db(), globalCloudContainerEventsKey(op.unitName),
+		op.props.CloudContainerEvents, op.application.st.clock(),
+	)
 	if op.props.AgentStatus == nil && op.props.CloudContainerStatus == nil {
 		return nil
 	}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -3948,6 +3949,67 @@ func (s *CAASApplicationSuite) TestRewriteStatusHistory(c *gc.C) {
 	c.Assert(history[2].Message, gc.Equals, "waiting for container")
 }
 
+func (s *CAASApplicationSuite) TestRecordOperatorEvents(c *gc.C) {
+	t0 := time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Minute)
+	err := s.app.RecordOperatorEvents([]status.StatusInfo{{
+		Status:  "Pulled",
+		Message: "pulled image",
+		Data:    map[string]interface{}{"type": "Normal", "object": "Pod/gitlab-operator-0"},
+		Since:   &t0,
+	}, {
+		Status:  "BackOff",
+		Message: "back-off restarting",
+		Data:    map[string]interface{}{"type": "Warning", "object": "Pod/gitlab-operator-0"},
+		Since:   &t1,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.app.OperatorEventHistory().StatusHistory(status.StatusHistoryFilter{Size: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Assert(history[0].Status, gc.Equals, status.Status("BackOff"))
+	c.Assert(history[0].Message, gc.Equals, "back-off restarting")
+	c.Assert(history[0].Data, jc.DeepEquals, map[string]interface{}{"type": "Warning", "object": "Pod/gitlab-operator-0"})
+	c.Assert(history[0].Since.Equal(t1), jc.IsTrue)
+	c.Assert(history[1].Status, gc.Equals, status.Status("Pulled"))
+	c.Assert(history[1].Since.Equal(t0), jc.IsTrue)
+
+	// Operator events are kept separate from the application status history.
+	appHistory, err := s.app.StatusHistory(status.StatusHistoryFilter{Size: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(appHistory, gc.HasLen, 1)
+	c.Assert(appHistory[0].Status, gc.Equals, status.Waiting)
+}
+
+func (s *CAASApplicationSuite) TestRecordOperatorEventsSkipsRecorded(c *gc.C) {
+	t0 := time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Minute)
+	pulled := status.StatusInfo{Status: "Pulled", Message: "pulled image", Since: &t0}
+	backOff := status.StatusInfo{Status: "BackOff", Message: "back-off restarting", Since: &t1}
+	err := s.app.RecordOperatorEvents([]status.StatusInfo{pulled})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// After a provisioner restart, all the events are reported again.
+	err = s.app.RecordOperatorEvents([]status.StatusInfo{pulled, backOff})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.RecordOperatorEvents([]status.StatusInfo{pulled, backOff})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.app.OperatorEventHistory().StatusHistory(status.StatusHistoryFilter{Size: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Assert(history[0].Status, gc.Equals, status.Status("BackOff"))
+	c.Assert(history[0].Since.Equal(t1), jc.IsTrue)
+	c.Assert(history[1].Status, gc.Equals, status.Status("Pulled"))
+	c.Assert(history[1].Since.Equal(t0), jc.IsTrue)
+}
+
+func (s *ApplicationSuite) TestRecordOperatorEventsNotCAAS(c *gc.C) {
+	err := s.mysql.RecordOperatorEvents(nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *ApplicationSuite) TestApplicationSetAgentPresence(c *gc.C) {
 	alive, err := s.mysql.AgentPresence()
 	c.Assert(err, jc.ErrorIsNil)
@@ -87,8 +87,9 @@ func StoreArchive(stor filestorage.FileStorage, meta *Metadata, file io.Reader)
 // Backups is an abstraction around all juju backup-related functionality.
 type Backups interface {
 	// Create creates a new juju backup archive. It updates
-	// the provided metadata.
-	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool) (string, error)
+	// the provided metadata. If encryption is not nil, the
+	// archive is encrypted with the given key.
+	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool, encryption *EncryptionKey) (string, error)
 
 	// Add stores the backup archive and returns its new ID.
 	Add(archive io.Reader, meta *Metadata) (string, error)
@@ -122,10 +123,17 @@ func NewBackups(stor filestorage.FileStorage) Backups {
 
 // Create creates and stores a new juju backup archive (based on arguments)
 // and updates the provided metadata.  A filename to download the backup is provided.
-func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool) (string, error) {
+func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool, encryption *EncryptionKey) (string, error) {
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	meta.Started = time.Now().UTC()
 
+	if encryption != nil {
+		if err := encryption.Validate(); err != nil {
+			return "", errors.Annotate(err, "while preparing encryption")
+		}
+		meta.Encryption = encryption.Method()
+	}
+
 	// The metadata file will not contain the ID or the "finished" data.
 	// However, that information is not as critical. The alternatives
 	// are either adding the metadata file to the archive after the fact
@@ -135,6 +143,11 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy,
 	if err != nil {
 		return "", errors.Annotate(err, "while preparing the metadata")
 	}
+	if encryption != nil {
+		// The secrets are only kept in the copy of the
+		// metadata inside the encrypted archive.
+		meta.removeSecrets()
+	}
 
 	// Create the archive.
 	filesToBackUp, err := getFilesToBackUp("", paths, meta.Origin.Machine)
@@ -147,7 +160,7 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy,
 		return "", errors.Annotate(err, "while preparing for DB dump")
 	}
 
-	args := createArgs{paths.BackupDir, filesToBackUp, dumper, metadataFile, noDownload}
+	args := createArgs{paths.BackupDir, filesToBackUp, dumper, metadataFile, noDownload, encryption}
 	result, err := runCreate(&args)
 	if err != nil {
 		return "", errors.Annotate(err, "while creating backup archive")
@@ -76,6 +76,12 @@ func (b *backups) Restore(backupId string, args RestoreArgs) (names.Tag, error)
 
 	defer backupReader.Close()
 
+	// Encrypted archives can only be decrypted by the client, which
+	// holds the key, and uploaded again.
+	if meta.Encryption != "" {
+		return nil, errors.Errorf("backup %q is encrypted; restore it from the client with the key used to create it", backupId)
+	}
+
 	workspace, err := NewArchiveWorkspaceReader(backupReader)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot unpack backup file")
@@ -33,6 +33,7 @@ type createArgs struct {
 	db             DBDumper
 	metadataReader io.Reader
 	noDownload     bool
+	encryption     *EncryptionKey
 }
 
 type createResult struct {
@@ -50,6 +51,7 @@ func create(args *createArgs) (_ *createResult, err error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	builder.encryption = args.encryption
 	defer func() {
 		if cerr := builder.cleanUp(args.noDownload); cerr != nil {
 			cerr.Log(logger)
@@ -105,6 +107,9 @@ type builder struct {
 	// bundleFile is the inner archive file containing all the juju
 	// state-related files gathered during backup.
 	bundleFile io.WriteCloser
+	// encryption is the key with which to encrypt the archive file,
+	// if any.
+	encryption *EncryptionKey
 }
 
 // newBuilder returns a new backup archive builder.  It creates the temp
@@ -316,9 +321,25 @@ func (b *builder) buildArchiveAndChecksum() error {
 	// than to the uncompressed contents of the tarball.  This is so
 	// that users can compare the published checksum against the
 	// checksum of the file without having to decompress it first.
+	// If the archive is to be encrypted, the hash is of the encrypted
+	// file for the same reason.
 	hasher := hash.NewHashingWriter(b.archiveFile, sha1.New())
-	if err := b.buildArchive(hasher); err != nil {
-		return errors.Trace(err)
+	if b.encryption == nil {
+		if err := b.buildArchive(hasher); err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		encrypter, err := EncryptArchive(hasher, *b.encryption)
+		if err != nil {
+			return errors.Annotate(err, "while encrypting archive")
+		}
+		if err := b.buildArchive(encrypter); err != nil {
+			encrypter.Close()
+			return errors.Trace(err)
+		}
+		if err := encrypter.Close(); err != nil {
+			return errors.Annotate(err, "while encrypting archive")
+		}
 	}
 
 	// Save the SHA1 checksum.
@@ -0,0 +1,169 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bufio"
+	// OpenPGP needs a signature hash function to be linked in
+	// when encrypting to a public key.
+	_ "crypto/sha256"
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+	"golang.org/x/crypto/openpgp"
+	pgperrors "golang.org/x/crypto/openpgp/errors"
+)
+
+// Backup archives may be encrypted using OpenPGP, either symmetrically
+// with a passphrase or to a public key. This means that an encrypted
+// archive can also be decrypted with standard tools such as gpg.
+const (
+	// EncryptionPassphrase identifies archives encrypted with a passphrase.
+	EncryptionPassphrase = "passphrase"
+
+	// EncryptionPublicKey identifies archives encrypted to an
+	// OpenPGP public key.
+	EncryptionPublicKey = "public-key"
+)
+
+// ErrWrongKey is returned when an encrypted archive cannot be
+// decrypted with the key or passphrase provided.
+var ErrWrongKey = errors.New("cannot decrypt backup archive: wrong key or passphrase")
+
+// EncryptionKey holds the key with which to encrypt a backup archive.
+// Exactly one of Passphrase and PublicKey must be set.
+type EncryptionKey struct {
+	// Passphrase is used to encrypt the archive symmetrically.
+	Passphrase string
+
+	// PublicKey is an armored OpenPGP public key to which the
+	// archive is encrypted.
+	PublicKey string
+}
+
+// Validate returns an error if the key is not valid.
+func (k EncryptionKey) Validate() error {
+	if k.Passphrase == "" && k.PublicKey == "" {
+		return errors.NotValidf("empty encryption key")
+	}
+	if k.Passphrase != "" && k.PublicKey != "" {
+		return errors.NotValidf("both passphrase and public key")
+	}
+	if k.PublicKey != "" {
+		if _, err := readPublicKey(k.PublicKey); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// Method returns the name of the encryption method used with the key.
+func (k EncryptionKey) Method() string {
+	if k.PublicKey != "" {
+		return EncryptionPublicKey
+	}
+	return EncryptionPassphrase
+}
+
+func readPublicKey(armored string) (openpgp.EntityList, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(armored))
+	if err != nil {
+		return nil, errors.Annotate(err, "reading public key")
+	}
+	if len(entities) == 0 {
+		return nil, errors.NotValidf("empty public key")
+	}
+	return entities, nil
+}
+
+// EncryptArchive returns a writer which encrypts everything written to
+// it with the given key, and writes the result to w. The returned
+// writer must be closed to complete the encrypted archive.
+func EncryptArchive(w io.Writer, key EncryptionKey) (io.WriteCloser, error) {
+	if err := key.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	hints := &openpgp.FileHints{IsBinary: true}
+	if key.Passphrase != "" {
+		plaintext, err := openpgp.SymmetricallyEncrypt(w, []byte(key.Passphrase), hints, nil)
+		return plaintext, errors.Trace(err)
+	}
+	entities, err := readPublicKey(key.PublicKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	plaintext, err := openpgp.Encrypt(w, entities, nil, hints, nil)
+	return plaintext, errors.Annotate(err, "encrypting to public key")
+}
+
+// DecryptionKey holds the key with which to decrypt a backup archive.
+type DecryptionKey struct {
+	// Passphrase is the passphrase with which the archive was
+	// encrypted or, if PrivateKey is set, the passphrase which
+	// protects the private key.
+	Passphrase string
+
+	// PrivateKey is an armored OpenPGP private key corresponding to
+	// the public key to which the archive was encrypted.
+	PrivateKey string
+}
+
+// DecryptArchive returns a reader of the decrypted content of the
+// encrypted archive read from r. If the key is wrong, ErrWrongKey
+// is returned. Tampering with the archive is detected when the
+// returned reader reaches the end of the archive.
+func DecryptArchive(r io.Reader, key DecryptionKey) (io.Reader, error) {
+	var keyring openpgp.EntityList
+	if key.PrivateKey != "" {
+		var err error
+		keyring, err = openpgp.ReadArmoredKeyRing(strings.NewReader(key.PrivateKey))
+		if err != nil {
+			return nil, errors.Annotate(err, "reading private key")
+		}
+	}
+
+	prompted := false
+	prompt := func(keys []openpgp.Key, symmetric bool) ([]byte, error) {
+		// We are only prompted again if the passphrase
+		// we gave last time was wrong.
+		if prompted || key.Passphrase == "" {
+			return nil, ErrWrongKey
+		}
+		prompted = true
+		for _, k := range keys {
+			// If the passphrase does not unlock the private key, the
+			// key stays encrypted and we'll be prompted again.
+			k.PrivateKey.Decrypt([]byte(key.Passphrase))
+		}
+		return []byte(key.Passphrase), nil
+	}
+
+	md, err := openpgp.ReadMessage(r, keyring, prompt, nil)
+	if err == pgperrors.ErrKeyIncorrect || errors.Cause(err) == ErrWrongKey {
+		return nil, ErrWrongKey
+	} else if err != nil {
+		return nil, errors.Annotate(err, "reading encrypted backup archive")
+	}
+	if !md.IsEncrypted {
+		return nil, errors.New("backup archive is not encrypted")
+	}
+	return md.UnverifiedBody, nil
+}
+
+// IsEncryptedArchive reports whether the archive read from r is
+// encrypted, and returns a reader from which the whole archive may
+// still be read.
+func IsEncryptedArchive(r io.Reader) (bool, io.Reader, error) {
+	br := bufio.NewReader(r)
+	head, err := br.Peek(2)
+	if err != nil && err != io.EOF {
+		return false, nil, errors.Trace(err)
+	}
+	// Unencrypted archives are gzip files, which begin with the
+	// magic bytes 0x1f 0x8b. All OpenPGP packets have the most
+	// significant bit of their first byte set.
+	encrypted := len(head) > 0 && head[0]&0x80 != 0
+	return encrypted, br, nil
+}
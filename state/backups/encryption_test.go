@@ -0,0 +1,162 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+
+	jc "github.com/juju/testing/checkers"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+type encryptionSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&encryptionSuite{})
+
+// newKeyPair returns a new armored OpenPGP public and private key pair.
+func newKeyPair(c *gc.C) (string, string) {
+	entity, err := openpgp.NewEntity("backup", "", "backup@example.com", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	// Generated keys state no hash preferences, in which case only
+	// RIPEMD160 would be acceptable, so prefer SHA256 as gpg does.
+	for _, id := range entity.Identities {
+		id.SelfSignature.PreferredHash = []uint8{8}
+		err := id.SelfSignature.SignUserId(id.UserId.Id, entity.PrimaryKey, entity.PrivateKey, nil)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	var public bytes.Buffer
+	w, err := armor.Encode(&public, openpgp.PublicKeyType, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = entity.Serialize(w)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+
+	var private bytes.Buffer
+	w, err = armor.Encode(&private, openpgp.PrivateKeyType, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = entity.SerializePrivate(w, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+
+	return public.String(), private.String()
+}
+
+func encrypt(c *gc.C, data string, key backups.EncryptionKey) []byte {
+	var buf bytes.Buffer
+	w, err := backups.EncryptArchive(&buf, key)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = w.Write([]byte(data))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+	return buf.Bytes()
+}
+
+func decrypt(c *gc.C, data []byte, key backups.DecryptionKey) (string, error) {
+	r, err := backups.DecryptArchive(bytes.NewReader(data), key)
+	if err != nil {
+		return "", err
+	}
+	decrypted, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	return string(decrypted), nil
+}
+
+func (s *encryptionSuite) TestPassphrase(c *gc.C) {
+	encrypted := encrypt(c, "archive", backups.EncryptionKey{Passphrase: "sekrit"})
+	c.Check(bytes.Contains(encrypted, []byte("archive")), jc.IsFalse)
+
+	data, err := decrypt(c, encrypted, backups.DecryptionKey{Passphrase: "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(data, gc.Equals, "archive")
+}
+
+func (s *encryptionSuite) TestWrongPassphrase(c *gc.C) {
+	encrypted := encrypt(c, "archive", backups.EncryptionKey{Passphrase: "sekrit"})
+	_, err := decrypt(c, encrypted, backups.DecryptionKey{Passphrase: "wrong"})
+	c.Check(err, gc.Equals, backups.ErrWrongKey)
+	_, err = decrypt(c, encrypted, backups.DecryptionKey{})
+	c.Check(err, gc.Equals, backups.ErrWrongKey)
+}
+
+func (s *encryptionSuite) TestPublicKey(c *gc.C) {
+	public, private := newKeyPair(c)
+	encrypted := encrypt(c, "archive", backups.EncryptionKey{PublicKey: public})
+
+	data, err := decrypt(c, encrypted, backups.DecryptionKey{PrivateKey: private})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(data, gc.Equals, "archive")
+}
+
+func (s *encryptionSuite) TestWrongPrivateKey(c *gc.C) {
+	public, _ := newKeyPair(c)
+	_, other := newKeyPair(c)
+	encrypted := encrypt(c, "archive", backups.EncryptionKey{PublicKey: public})
+
+	_, err := decrypt(c, encrypted, backups.DecryptionKey{PrivateKey: other})
+	c.Check(err, gc.Equals, backups.ErrWrongKey)
+	_, err = decrypt(c, encrypted, backups.DecryptionKey{Passphrase: "sekrit"})
+	c.Check(err, gc.Equals, backups.ErrWrongKey)
+}
+
+func (s *encryptionSuite) TestEncryptionKeyValidate(c *gc.C) {
+	public, _ := newKeyPair(c)
+	for i, test := range []struct {
+		key         backups.EncryptionKey
+		expectError string
+	}{{
+		key:         backups.EncryptionKey{},
+		expectError: "empty encryption key not valid",
+	}, {
+		key:         backups.EncryptionKey{Passphrase: "sekrit", PublicKey: public},
+		expectError: "both passphrase and public key not valid",
+	}, {
+		key:         backups.EncryptionKey{PublicKey: "not a key"},
+		expectError: "reading public key: .*",
+	}, {
+		key: backups.EncryptionKey{Passphrase: "sekrit"},
+	}, {
+		key: backups.EncryptionKey{PublicKey: public},
+	}} {
+		c.Logf("test %d", i)
+		err := test.key.Validate()
+		if test.expectError == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.expectError)
+		}
+	}
+}
+
+func (s *encryptionSuite) TestIsEncryptedArchive(c *gc.C) {
+	var archive bytes.Buffer
+	w := gzip.NewWriter(&archive)
+	_, err := w.Write([]byte("archive"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+
+	encrypted, r, err := backups.IsEncryptedArchive(bytes.NewReader(archive.Bytes()))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(encrypted, jc.IsFalse)
+	data, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(data, jc.DeepEquals, archive.Bytes())
+
+	ciphertext := encrypt(c, "archive", backups.EncryptionKey{Passphrase: "sekrit"})
+	encrypted, r, err = backups.IsEncryptedArchive(bytes.NewReader(ciphertext))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(encrypted, jc.IsTrue)
+	data, err = ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(data, jc.DeepEquals, ciphertext)
+}
@@ -105,11 +105,22 @@ func NewTestCreateArgs(backupDir string, filesToBackUp []string, db DBDumper, me
 	return &args
 }
 
+// SetTestCreateArgsEncryption sets the key with which create() will
+// encrypt the archive.
+func SetTestCreateArgsEncryption(args *createArgs, key *EncryptionKey) {
+	args.encryption = key
+}
+
 // ExposeCreateResult extracts the values in a create() args value.
 func ExposeCreateArgs(args *createArgs) (string, []string, DBDumper) {
 	return args.backupDir, args.filesToBackUp, args.db
 }
 
+// ExposeCreateArgsMetadata returns the metadata file in a create() args value.
+func ExposeCreateArgsMetadata(args *createArgs) io.Reader {
+	return args.metadataReader
+}
+
 // NewTestCreateResult builds a new create() result.
 func NewTestCreateResult(file io.ReadCloser, size int64, checksum, filename string) *createResult {
 	result := createResult{
@@ -68,6 +68,15 @@ type Metadata struct {
 	// Notes is an optional user-supplied annotation.
 	Notes string
 
+	// Scheduled records whether the backup was created by the
+	// controller's backup schedule rather than by a user.
+	Scheduled bool
+
+	// Encryption records how the backup archive was encrypted, if at
+	// all. It is either empty, EncryptionPassphrase or
+	// EncryptionPublicKey.
+	Encryption string
+
 	// TODO(wallyworld) - remove these ASAP
 	// These are only used by the restore CLI when re-bootstrapping.
 	// We will use a better solution but the way restore currently
@@ -126,6 +135,14 @@ func NewMetadataState(db DB, machine, series string) (*Metadata, error) {
 	return meta, nil
 }
 
+// removeSecrets clears the values taken from the agent's state serving
+// info and the controller config, which are only needed to re-bootstrap
+// a controller during restore.
+func (m *Metadata) removeSecrets() {
+	m.CACert = ""
+	m.CAPrivateKey = ""
+}
+
 // MarkComplete populates the remaining metadata values.  The default
 // checksum format is used.
 func (m *Metadata) MarkComplete(size int64, checksum string) error {
@@ -167,6 +184,8 @@ type flatMetadata struct {
 	Hostname    string
 	Version     version.Number
 	Series      string
+	Encryption  string `json:",omitempty"`
+	Scheduled   bool   `json:",omitempty"`
 
 	CACert       string
 	CAPrivateKey string
@@ -176,6 +195,16 @@ type flatMetadata struct {
 
 // AsJSONBuffer returns a bytes.Buffer containing the JSON-ified metadata.
 func (m *Metadata) AsJSONBuffer() (io.Reader, error) {
+	flat := m.flatten()
+	var outfile bytes.Buffer
+	if err := json.NewEncoder(&outfile).Encode(flat); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &outfile, nil
+}
+
+// flatten returns the metadata in the form in which it is serialised.
+func (m *Metadata) flatten() flatMetadata {
 	flat := flatMetadata{
 		ID: m.ID(),
 
@@ -190,6 +219,8 @@ func (m *Metadata) AsJSONBuffer() (io.Reader, error) {
 		Hostname:     m.Origin.Hostname,
 		Version:      m.Origin.Version,
 		Series:       m.Origin.Series,
+		Encryption:   m.Encryption,
+		Scheduled:    m.Scheduled,
 		CACert:       m.CACert,
 		CAPrivateKey: m.CAPrivateKey,
 	}
@@ -202,12 +233,7 @@ func (m *Metadata) AsJSONBuffer() (io.Reader, error) {
 	if m.Finished != nil {
 		flat.Finished = *m.Finished
 	}
-
-	var outfile bytes.Buffer
-	if err := json.NewEncoder(&outfile).Encode(flat); err != nil {
-		return nil, errors.Trace(err)
-	}
-	return &outfile, nil
+	return flat
 }
 
 // NewMetadataJSONReader extracts a new metadata from the JSON file.
@@ -234,6 +260,8 @@ func NewMetadataJSONReader(in io.Reader) (*Metadata, error) {
 		meta.Finished = &flat.Finished
 	}
 	meta.Notes = flat.Notes
+	meta.Encryption = flat.Encryption
+	meta.Scheduled = flat.Scheduled
 	meta.Origin = Origin{
 		Model:    flat.Environment,
 		Machine:  flat.Machine,
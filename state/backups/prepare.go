@@ -0,0 +1,50 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/mongo"
+)
+
+// ControllerDB describes the controller state needed to back up
+// the controller.
+type ControllerDB interface {
+	DB
+
+	// MongoVersion returns the version of the controller's mongo.
+	MongoVersion() (string, error)
+
+	// MachineSeries returns the series of the specified machine.
+	MachineSeries(id string) (string, error)
+}
+
+// PrepareBackup returns the metadata and the database info for a new
+// backup of the controller, taken on the specified controller machine.
+// The session is used to find the databases to back up, and should be
+// checked to be ready beforehand.
+func PrepareBackup(db ControllerDB, session DBSession, mgoInfo *mongo.MongoInfo, machineID string) (*Metadata, *DBInfo, error) {
+	v, err := db.MongoVersion()
+	if err != nil {
+		return nil, nil, errors.Annotatef(err, "discovering mongo version")
+	}
+	mongoVersion, err := mongo.NewVersion(v)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	dbInfo, err := NewDBInfo(mgoInfo, session, mongoVersion)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	series, err := db.MachineSeries(machineID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	meta, err := NewMetadataState(db, machineID, series)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return meta, dbInfo, nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"os"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&prepareSuite{})
+
+type prepareSuite struct {
+	testing.BaseSuite
+}
+
+type fakeControllerDB struct {
+	mongoVersion string
+	series       map[string]string
+}
+
+func (db *fakeControllerDB) MongoSession() *mgo.Session {
+	return nil
+}
+
+func (db *fakeControllerDB) ModelTag() names.ModelTag {
+	return testing.ModelTag
+}
+
+func (db *fakeControllerDB) ModelConfig() (*config.Config, error) {
+	return nil, errors.NotImplementedf("ModelConfig")
+}
+
+func (db *fakeControllerDB) ControllerConfig() (controller.Config, error) {
+	return testing.FakeControllerConfig(), nil
+}
+
+func (db *fakeControllerDB) StateServingInfo() (state.StateServingInfo, error) {
+	return state.StateServingInfo{CAPrivateKey: "ca-private-key"}, nil
+}
+
+func (db *fakeControllerDB) MongoVersion() (string, error) {
+	return db.mongoVersion, nil
+}
+
+func (db *fakeControllerDB) MachineSeries(id string) (string, error) {
+	series, ok := db.series[id]
+	if !ok {
+		return "", errors.NotFoundf("machine %s", id)
+	}
+	return series, nil
+}
+
+func (s *prepareSuite) mongoInfo() *mongo.MongoInfo {
+	return &mongo.MongoInfo{
+		Info: mongo.Info{
+			Addrs: []string{"localhost:37017"},
+		},
+		Tag:      names.NewMachineTag("0"),
+		Password: "eggs",
+	}
+}
+
+func (s *prepareSuite) TestPrepareBackup(c *gc.C) {
+	db := &fakeControllerDB{
+		mongoVersion: "3.2.15/wiredTiger",
+		series:       map[string]string{"0": "bionic"},
+	}
+	meta, dbInfo, err := backups.PrepareBackup(db, &fakeSession{}, s.mongoInfo(), "0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	hostname, err := os.Hostname()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(meta.Origin.Model, gc.Equals, testing.ModelTag.Id())
+	c.Check(meta.Origin.Machine, gc.Equals, "0")
+	c.Check(meta.Origin.Hostname, gc.Equals, hostname)
+	c.Check(meta.Origin.Series, gc.Equals, "bionic")
+	c.Check(meta.CACert, gc.Equals, testing.CACert)
+	c.Check(meta.CAPrivateKey, gc.Equals, "ca-private-key")
+
+	c.Check(dbInfo.Address, gc.Equals, "localhost:37017")
+	c.Check(dbInfo.Username, gc.Equals, "machine-0")
+	c.Check(dbInfo.Password, gc.Equals, "eggs")
+	c.Check(dbInfo.MongoVersion, jc.DeepEquals, mongo.Version{
+		Major:         3,
+		Minor:         2,
+		Point:         15,
+		StorageEngine: mongo.WiredTiger,
+	})
+}
+
+func (s *prepareSuite) TestPrepareBackupBadMongoVersion(c *gc.C) {
+	db := &fakeControllerDB{mongoVersion: "three"}
+	_, _, err := backups.PrepareBackup(db, &fakeSession{}, s.mongoInfo(), "0")
+	c.Assert(err, gc.ErrorMatches, "Invalid version string, major is not an int: .*")
+}
+
+func (s *prepareSuite) TestPrepareBackupMachineNotFound(c *gc.C) {
+	db := &fakeControllerDB{mongoVersion: "3.2.15/wiredTiger"}
+	_, _, err := backups.PrepareBackup(db, &fakeSession{}, s.mongoInfo(), "0")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
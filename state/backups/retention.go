@@ -0,0 +1,92 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/collections/set"
+)
+
+// ScheduledNotes is the notes value recorded for backups
+// created by the controller's backup schedule.
+const ScheduledNotes = "scheduled backup"
+
+// ScheduledBackups returns the backups which were created by the
+// controller's backup schedule. Only these are subject to the
+// retention policy; backups created by users are never expired.
+func ScheduledBackups(metaList []*Metadata) []*Metadata {
+	var result []*Metadata
+	for _, meta := range metaList {
+		if meta.Scheduled {
+			result = append(result, meta)
+		}
+	}
+	return result
+}
+
+// RetentionPolicy describes which scheduled backups are kept
+// when old backups are removed.
+type RetentionPolicy struct {
+	// Count is the number of the most recent backups to keep.
+	Count int
+
+	// Daily is the number of days for which the most
+	// recent backup of each day is kept.
+	Daily int
+
+	// Weekly is the number of weeks for which the most
+	// recent backup of each week is kept.
+	Weekly int
+}
+
+// ExpiredBackups returns the backups which are not kept
+// by the retention policy, newest first.
+func ExpiredBackups(metaList []*Metadata, policy RetentionPolicy) []*Metadata {
+	sorted := make([]*Metadata, len(metaList))
+	copy(sorted, metaList)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Started.After(sorted[j].Started)
+	})
+
+	keep := make(map[*Metadata]bool)
+	for i := 0; i < policy.Count && i < len(sorted); i++ {
+		keep[sorted[i]] = true
+	}
+	keepNewestPerPeriod(sorted, policy.Daily, keep, func(t time.Time) string {
+		return t.UTC().Format("2006-01-02")
+	})
+	keepNewestPerPeriod(sorted, policy.Weekly, keep, func(t time.Time) string {
+		year, week := t.UTC().ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	})
+
+	var expired []*Metadata
+	for _, meta := range sorted {
+		if !keep[meta] {
+			expired = append(expired, meta)
+		}
+	}
+	return expired
+}
+
+// keepNewestPerPeriod marks the newest backup in each of the most
+// recent periods to be kept, up to the specified number of periods.
+// The backups must be sorted newest first.
+func keepNewestPerPeriod(sorted []*Metadata, periods int, keep map[*Metadata]bool, period func(time.Time) string) {
+	seen := set.NewStrings()
+	for _, meta := range sorted {
+		key := period(meta.Started)
+		if seen.Contains(key) {
+			continue
+		}
+		if seen.Size() >= periods {
+			return
+		}
+		seen.Add(key)
+		keep[meta] = true
+	}
+}
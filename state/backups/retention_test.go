@@ -0,0 +1,105 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time" // Only used for time types and funcs, not Now().
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+type retentionSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&retentionSuite{})
+
+func backupAt(id string, started time.Time) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Started = started
+	return meta
+}
+
+func ids(metaList []*backups.Metadata) []string {
+	var result []string
+	for _, meta := range metaList {
+		result = append(result, meta.ID())
+	}
+	return result
+}
+
+func (s *retentionSuite) backups() []*backups.Metadata {
+	// Two backups a day, every 12 hours, for three weeks,
+	// starting on Monday 2019-04-01.
+	start := time.Date(2019, 4, 1, 0, 0, 0, 0, time.UTC)
+	var result []*backups.Metadata
+	for i := 0; i < 42; i++ {
+		started := start.Add(time.Duration(i) * 12 * time.Hour)
+		result = append(result, backupAt(started.Format("0102-15"), started))
+	}
+	return result
+}
+
+func (s *retentionSuite) TestExpiredBackupsCount(c *gc.C) {
+	all := s.backups()
+	expired := backups.ExpiredBackups(all, backups.RetentionPolicy{Count: 3})
+	c.Assert(expired, gc.HasLen, 39)
+	c.Assert(ids(expired)[:2], jc.DeepEquals, []string{"0420-00", "0419-12"})
+	c.Assert(ids(expired)[38], gc.Equals, "0401-00")
+}
+
+func (s *retentionSuite) TestExpiredBackupsDaily(c *gc.C) {
+	all := s.backups()
+	expired := backups.ExpiredBackups(all, backups.RetentionPolicy{Count: 1, Daily: 3})
+	var kept []string
+	for _, meta := range all {
+		if !contains(expired, meta) {
+			kept = append(kept, meta.ID())
+		}
+	}
+	c.Assert(kept, jc.DeepEquals, []string{"0419-12", "0420-12", "0421-12"})
+}
+
+func (s *retentionSuite) TestExpiredBackupsWeekly(c *gc.C) {
+	all := s.backups()
+	expired := backups.ExpiredBackups(all, backups.RetentionPolicy{Count: 2, Daily: 2, Weekly: 3})
+	var kept []string
+	for _, meta := range all {
+		if !contains(expired, meta) {
+			kept = append(kept, meta.ID())
+		}
+	}
+	c.Assert(kept, jc.DeepEquals, []string{"0407-12", "0414-12", "0420-12", "0421-00", "0421-12"})
+}
+
+func (s *retentionSuite) TestExpiredBackupsNoneExpired(c *gc.C) {
+	all := s.backups()[:5]
+	expired := backups.ExpiredBackups(all, backups.RetentionPolicy{Count: 7})
+	c.Assert(expired, gc.HasLen, 0)
+}
+
+func contains(metaList []*backups.Metadata, meta *backups.Metadata) bool {
+	for _, m := range metaList {
+		if m == meta {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *retentionSuite) TestScheduledBackups(c *gc.C) {
+	start := time.Date(2019, 4, 1, 0, 0, 0, 0, time.UTC)
+	scheduled := backupAt("scheduled", start)
+	scheduled.Scheduled = true
+	manual := backupAt("manual", start.Add(time.Hour))
+	manual.Notes = backups.ScheduledNotes
+
+	result := backups.ScheduledBackups([]*backups.Metadata{scheduled, manual})
+	c.Assert(ids(result), jc.DeepEquals, []string{"scheduled"})
+}
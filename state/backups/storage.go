@@ -44,9 +44,11 @@ type storageMetaDoc struct {
 
 	// backup
 
-	Started  int64  `bson:"started,minsize"`
-	Finished int64  `bson:"finished,minsize"`
-	Notes    string `bson:"notes,omitempty"`
+	Started    int64  `bson:"started,minsize"`
+	Finished   int64  `bson:"finished,minsize"`
+	Notes      string `bson:"notes,omitempty"`
+	Encryption string `bson:"encryption,omitempty"`
+	Scheduled  bool   `bson:"scheduled,omitempty"`
 
 	// origin
 
@@ -124,6 +126,8 @@ func docAsMetadata(doc *storageMetaDoc) *Metadata {
 	meta := NewMetadata()
 	meta.Started = metadocUnixToTime(doc.Started)
 	meta.Notes = doc.Notes
+	meta.Encryption = doc.Encryption
+	meta.Scheduled = doc.Scheduled
 
 	meta.Origin.Model = doc.Model
 	meta.Origin.Machine = doc.Machine
@@ -175,6 +179,8 @@ func newStorageMetaDoc(meta *Metadata) storageMetaDoc {
 		doc.Finished = metadocTimeToUnix(*meta.Finished)
 	}
 	doc.Notes = meta.Notes
+	doc.Encryption = meta.Encryption
+	doc.Scheduled = meta.Scheduled
 
 	doc.Model = meta.Origin.Model
 	doc.Machine = meta.Origin.Machine
@@ -0,0 +1,273 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/filestorage"
+
+	"github.com/juju/juju/controller"
+)
+
+// Target is a location outside of the controller's database in which
+// backup archives and their metadata may be kept, so that the backups
+// survive the loss of the controller they were taken from.
+type Target interface {
+	io.Closer
+
+	// Put stores the named object, replacing any existing object
+	// with the same name.
+	Put(name string, data io.Reader, size int64) error
+
+	// Get returns the content of the named object. If there is no
+	// such object, an error satisfying errors.IsNotFound is returned.
+	Get(name string) (io.ReadCloser, error)
+
+	// Remove removes the named object. If there is no such object,
+	// an error satisfying errors.IsNotFound is returned.
+	Remove(name string) error
+
+	// List returns the names of all stored objects.
+	List() ([]string, error)
+}
+
+const (
+	targetMetadataSuffix = ".json"
+	targetArchiveSuffix  = ".tar.gz"
+)
+
+// NewTargetStorage returns a new FileStorage that keeps backup
+// archives, and their metadata, in the given target. The metadata
+// for a backup is stored as JSON alongside its archive, so that the
+// backups can be listed from any controller that uses the target.
+func NewTargetStorage(target Target) filestorage.FileStorage {
+	docs := &targetMetadataStorage{
+		MetadataDocStorage: filestorage.MetadataDocStorage{&targetDocStorage{target}},
+		target:             target,
+	}
+	files := &targetFileStorage{target}
+	return filestorage.NewFileStorage(docs, files)
+}
+
+// OpenStorage returns the FileStorage to use for storing backup
+// archives (and metadata), according to the controller's
+// backup-storage configuration.
+func OpenStorage(st DB) (filestorage.FileStorage, error) {
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch cfg.BackupStorage() {
+	case controller.BackupStorageController:
+		return NewStorage(st), nil
+	case controller.BackupStorageDirectory:
+		return NewTargetStorage(NewDirectoryTarget(cfg.BackupStoragePath())), nil
+	case controller.BackupStorageS3:
+		target, err := NewS3Target(S3Config{
+			Endpoint:  cfg.BackupS3Endpoint(),
+			Region:    cfg.BackupS3Region(),
+			Bucket:    cfg.BackupS3Bucket(),
+			AccessKey: cfg.BackupS3AccessKey(),
+			SecretKey: cfg.BackupS3SecretKey(),
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return NewTargetStorage(target), nil
+	}
+	return nil, errors.NotValidf("backup storage %q", cfg.BackupStorage())
+}
+
+//---------------------------
+// metadata storage
+
+type targetDocStorage struct {
+	target Target
+}
+
+type targetMetadataStorage struct {
+	filestorage.MetadataDocStorage
+	target Target
+}
+
+func metadataName(id string) string {
+	return id + targetMetadataSuffix
+}
+
+func getTargetMetadata(target Target, id string) (*flatMetadata, error) {
+	r, err := target.Get(metadataName(id))
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("backup metadata %q", id)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer r.Close()
+
+	var flat flatMetadata
+	if err := json.NewDecoder(r).Decode(&flat); err != nil {
+		return nil, errors.Annotatef(err, "reading backup metadata %q", id)
+	}
+	return &flat, nil
+}
+
+func putTargetMetadata(target Target, flat *flatMetadata) error {
+	data, err := json.Marshal(flat)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = target.Put(metadataName(flat.ID), bytes.NewReader(data), int64(len(data)))
+	return errors.Trace(err)
+}
+
+func flatAsMetadata(flat *flatMetadata) (*Metadata, error) {
+	data, err := json.Marshal(flat)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewMetadataJSONReader(bytes.NewReader(data))
+}
+
+// AddDoc adds the document to storage and returns the new ID.
+func (s *targetDocStorage) AddDoc(doc filestorage.Document) (string, error) {
+	metadata, ok := doc.(*Metadata)
+	if !ok {
+		return "", errors.Errorf("doc must be of type *backups.Metadata")
+	}
+	metaDoc := newStorageMetaDoc(metadata)
+	id := newStorageID(&metaDoc)
+	metaDoc.ID = id
+	if err := metaDoc.validate(); err != nil {
+		return "", errors.Trace(err)
+	}
+
+	if _, err := getTargetMetadata(s.target, id); err == nil {
+		return "", errors.AlreadyExistsf("backup metadata %q", id)
+	} else if !errors.IsNotFound(err) {
+		return "", errors.Trace(err)
+	}
+
+	flat := metadata.flatten()
+	flat.ID = id
+	flat.Stored = time.Time{}
+	// The target may be readable by others, so the CA private key
+	// is only kept in the copy of the metadata inside the archive.
+	flat.CAPrivateKey = ""
+	if err := putTargetMetadata(s.target, &flat); err != nil {
+		return "", errors.Trace(err)
+	}
+	return id, nil
+}
+
+// Doc returns the stored document associated with the given ID.
+func (s *targetDocStorage) Doc(id string) (filestorage.Document, error) {
+	flat, err := getTargetMetadata(s.target, id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	metadata, err := flatAsMetadata(flat)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return metadata, nil
+}
+
+// ListDocs returns the list of all stored documents.
+func (s *targetDocStorage) ListDocs() ([]filestorage.Document, error) {
+	names, err := s.target.List()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var list []filestorage.Document
+	for _, name := range names {
+		if !strings.HasSuffix(name, targetMetadataSuffix) {
+			continue
+		}
+		doc, err := s.Doc(strings.TrimSuffix(name, targetMetadataSuffix))
+		if errors.IsNotFound(err) {
+			// Removed since we listed the target.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		list = append(list, doc)
+	}
+	return list, nil
+}
+
+// RemoveDoc removes the identified document from storage.
+func (s *targetDocStorage) RemoveDoc(id string) error {
+	err := s.target.Remove(metadataName(id))
+	if errors.IsNotFound(err) {
+		return errors.NotFoundf("backup metadata %q", id)
+	}
+	return errors.Trace(err)
+}
+
+// Close implements io.Closer.
+func (s *targetDocStorage) Close() error {
+	return nil
+}
+
+// SetStored records in the metadata the fact that the file was stored.
+func (s *targetMetadataStorage) SetStored(id string) error {
+	flat, err := getTargetMetadata(s.target, id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	flat.Stored = time.Now().UTC()
+	return errors.Trace(putTargetMetadata(s.target, flat))
+}
+
+//---------------------------
+// raw file storage
+
+type targetFileStorage struct {
+	target Target
+}
+
+func archiveName(id string) string {
+	return id + targetArchiveSuffix
+}
+
+// File returns the identified file from storage.
+func (s *targetFileStorage) File(id string) (io.ReadCloser, error) {
+	file, err := s.target.Get(archiveName(id))
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("backup archive %q", id)
+	}
+	return file, errors.Trace(err)
+}
+
+// AddFile adds the file to storage.
+func (s *targetFileStorage) AddFile(id string, file io.Reader, size int64) error {
+	existing, err := s.target.Get(archiveName(id))
+	if err == nil {
+		existing.Close()
+		return errors.AlreadyExistsf("backup archive %q", id)
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	return errors.Trace(s.target.Put(archiveName(id), file, size))
+}
+
+// RemoveFile removes the identified file from storage.
+func (s *targetFileStorage) RemoveFile(id string) error {
+	err := s.target.Remove(archiveName(id))
+	if errors.IsNotFound(err) {
+		return errors.NotFoundf("backup archive %q", id)
+	}
+	return errors.Trace(err)
+}
+
+// Close closes the storage.
+func (s *targetFileStorage) Close() error {
+	return s.target.Close()
+}
@@ -0,0 +1,116 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+type directoryTarget struct {
+	dir string
+}
+
+// NewDirectoryTarget returns a Target that stores backups as files in
+// the given directory, which will be created if it does not exist. For
+// the backups to outlive the controller, the directory should be on
+// separate storage, such as an NFS mount; in an HA controller, every
+// controller machine must mount the same directory.
+func NewDirectoryTarget(dir string) Target {
+	return &directoryTarget{dir: dir}
+}
+
+func (t *directoryTarget) path(name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return "", errors.NotValidf("backup object name %q", name)
+	}
+	return filepath.Join(t.dir, name), nil
+}
+
+// Put implements Target.
+func (t *directoryTarget) Put(name string, data io.Reader, size int64) error {
+	path, err := t.path(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Backups include the controller's secrets, so ensure that only
+	// the controller agent can read them.
+	if err := os.MkdirAll(t.dir, 0700); err != nil {
+		return errors.Trace(err)
+	}
+	// Write to a temporary file and rename it into place, so that a
+	// partially written file is never visible under its final name.
+	f, err := ioutil.TempFile(t.dir, "."+name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.Remove(f.Name())
+
+	written, err := io.Copy(f, data)
+	if err != nil {
+		f.Close()
+		return errors.Annotatef(err, "writing %q", path)
+	}
+	if err := f.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	if size >= 0 && written != size {
+		return errors.Errorf("writing %q: expected %d bytes, wrote %d", path, size, written)
+	}
+	return errors.Trace(os.Rename(f.Name(), path))
+}
+
+// Get implements Target.
+func (t *directoryTarget) Get(name string) (io.ReadCloser, error) {
+	path, err := t.path(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("%q", path)
+	}
+	return f, errors.Trace(err)
+}
+
+// Remove implements Target.
+func (t *directoryTarget) Remove(name string) error {
+	path, err := t.path(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return errors.NotFoundf("%q", path)
+	}
+	return errors.Trace(err)
+}
+
+// List implements Target.
+func (t *directoryTarget) List() ([]string, error) {
+	infos, err := ioutil.ReadDir(t.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var names []string
+	for _, info := range infos {
+		if !info.Mode().IsRegular() || strings.HasPrefix(info.Name(), ".") {
+			continue
+		}
+		names = append(names, info.Name())
+	}
+	return names, nil
+}
+
+// Close implements Target.
+func (t *directoryTarget) Close() error {
+	return nil
+}
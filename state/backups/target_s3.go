@@ -0,0 +1,143 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/s3"
+)
+
+// S3Config holds the details of an S3-compatible object store in
+// which backups may be kept.
+type S3Config struct {
+	// Endpoint is the URL of the object store. If empty, the AWS
+	// endpoint for Region is used.
+	Endpoint string
+
+	// Region is the region in which the bucket resides.
+	Region string
+
+	// Bucket is the name of the bucket in which backups are stored.
+	// The bucket must already exist.
+	Bucket string
+
+	// AccessKey and SecretKey are the credentials used to access
+	// the bucket.
+	AccessKey string
+	SecretKey string
+}
+
+// Validate returns an error if the config is not valid.
+func (cfg S3Config) Validate() error {
+	if cfg.Bucket == "" {
+		return errors.NotValidf("empty Bucket")
+	}
+	if cfg.Endpoint == "" {
+		if cfg.Region == "" {
+			return errors.NotValidf("empty Endpoint and Region")
+		}
+		if _, ok := aws.Regions[cfg.Region]; !ok {
+			return errors.NotValidf("unknown AWS region %q with no Endpoint", cfg.Region)
+		}
+	}
+	if cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return errors.NotValidf("missing credentials")
+	}
+	return nil
+}
+
+type s3Target struct {
+	bucket *s3.Bucket
+}
+
+// NewS3Target returns a Target that stores backups as objects in an
+// S3-compatible object store.
+func NewS3Target(cfg S3Config) (Target, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	region := aws.Regions[cfg.Region]
+	if cfg.Endpoint != "" {
+		if region.Name == "" {
+			region.Name = cfg.Region
+		}
+		region.S3Endpoint = cfg.Endpoint
+		region.S3BucketEndpoint = ""
+	}
+	auth := aws.Auth{
+		AccessKey: cfg.AccessKey,
+		SecretKey: cfg.SecretKey,
+	}
+	bucket, err := s3.New(auth, region).Bucket(cfg.Bucket)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &s3Target{bucket: bucket}, nil
+}
+
+func isS3NotFound(err error) bool {
+	s3err, ok := errors.Cause(err).(*s3.Error)
+	return ok && (s3err.StatusCode == http.StatusNotFound || s3err.Code == "NoSuchKey")
+}
+
+// Put implements Target.
+func (t *s3Target) Put(name string, data io.Reader, size int64) error {
+	err := t.bucket.PutReader(name, data, size, "application/octet-stream", s3.Private)
+	return errors.Annotatef(err, "storing %q in bucket %q", name, t.bucket.Name)
+}
+
+// Get implements Target.
+func (t *s3Target) Get(name string) (io.ReadCloser, error) {
+	r, err := t.bucket.GetReader(name)
+	if isS3NotFound(err) {
+		return nil, errors.NotFoundf("%q in bucket %q", name, t.bucket.Name)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "getting %q from bucket %q", name, t.bucket.Name)
+	}
+	return r, nil
+}
+
+// Remove implements Target.
+func (t *s3Target) Remove(name string) error {
+	// Deleting a missing object is not an error in S3, so check
+	// that the object exists first.
+	resp, err := t.bucket.List(name, "", "", 1)
+	if err != nil {
+		return errors.Annotatef(err, "listing bucket %q", t.bucket.Name)
+	}
+	if len(resp.Contents) == 0 || resp.Contents[0].Key != name {
+		return errors.NotFoundf("%q in bucket %q", name, t.bucket.Name)
+	}
+	err = t.bucket.Del(name)
+	return errors.Annotatef(err, "removing %q from bucket %q", name, t.bucket.Name)
+}
+
+// List implements Target.
+func (t *s3Target) List() ([]string, error) {
+	var names []string
+	marker := ""
+	for {
+		resp, err := t.bucket.List("", "/", marker, 0)
+		if err != nil {
+			return nil, errors.Annotatef(err, "listing bucket %q", t.bucket.Name)
+		}
+		for _, key := range resp.Contents {
+			names = append(names, key.Key)
+		}
+		if !resp.IsTruncated || len(resp.Contents) == 0 {
+			break
+		}
+		marker = resp.Contents[len(resp.Contents)-1].Key
+	}
+	return names, nil
+}
+
+// Close implements Target.
+func (t *s3Target) Close() error {
+	return nil
+}
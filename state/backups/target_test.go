@@ -0,0 +1,223 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/filestorage"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/s3"
+	"gopkg.in/amz.v3/s3/s3test"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+	"github.com/juju/juju/testing"
+)
+
+type targetSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&targetSuite{})
+
+func (s *targetSuite) checkTargetStorage(c *gc.C, stor filestorage.FileStorage) {
+	meta := backupstesting.NewMetadataStarted()
+	meta.Notes = "some notes"
+	meta.CACert = "ca-cert"
+	meta.CAPrivateKey = "ca-private-key"
+	archive := "<archive data>"
+	err := meta.MarkComplete(int64(len(archive)), "some hash")
+	c.Assert(err, jc.ErrorIsNil)
+
+	id, err := stor.Add(meta, bytes.NewBufferString(archive))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(id, gc.Equals, backups.NewBackupID(meta))
+	c.Check(meta.ID(), gc.Equals, "")
+
+	_, err = stor.Add(meta, bytes.NewBufferString(archive))
+	c.Check(err, jc.Satisfies, errors.IsAlreadyExists)
+
+	list, err := stor.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list, gc.HasLen, 1)
+	c.Check(list[0].ID(), gc.Equals, id)
+	c.Check(list[0].Stored(), gc.NotNil)
+
+	stored, file, err := stor.Get(id)
+	c.Assert(err, jc.ErrorIsNil)
+	defer file.Close()
+	data, err := ioutil.ReadAll(file)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, archive)
+
+	storedMeta := stored.(*backups.Metadata)
+	c.Check(storedMeta.Notes, gc.Equals, "some notes")
+	c.Check(storedMeta.Checksum(), gc.Equals, "some hash")
+	c.Check(storedMeta.Size(), gc.Equals, int64(len(archive)))
+	c.Check(storedMeta.Started.Unix(), gc.Equals, meta.Started.Unix())
+	c.Check(storedMeta.Origin, jc.DeepEquals, meta.Origin)
+	c.Check(storedMeta.CACert, gc.Equals, "ca-cert")
+	c.Check(storedMeta.CAPrivateKey, gc.Equals, "")
+
+	err = stor.Remove(id)
+	c.Assert(err, jc.ErrorIsNil)
+	list, err = stor.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(list, gc.HasLen, 0)
+
+	_, _, err = stor.Get(id)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	err = stor.Remove(id)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *targetSuite) TestDirectoryTarget(c *gc.C) {
+	dir := filepath.Join(c.MkDir(), "backups")
+	target := backups.NewDirectoryTarget(dir)
+
+	names, err := target.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(names, gc.HasLen, 0)
+
+	err = target.Put("foo", bytes.NewBufferString("bar"), 3)
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := os.Stat(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.Mode().Perm(), gc.Equals, os.FileMode(0700))
+
+	r, err := target.Get("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(r)
+	r.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "bar")
+
+	names, err = target.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(names, jc.DeepEquals, []string{"foo"})
+
+	err = target.Remove("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = target.Get("foo")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	err = target.Remove("foo")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *targetSuite) TestDirectoryTargetShortWrite(c *gc.C) {
+	dir := c.MkDir()
+	target := backups.NewDirectoryTarget(dir)
+
+	err := target.Put("foo", bytes.NewBufferString("bar"), 10)
+	c.Assert(err, gc.ErrorMatches, `writing ".*/foo": expected 10 bytes, wrote 3`)
+	names, err := target.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(names, gc.HasLen, 0)
+}
+
+func (s *targetSuite) TestDirectoryTargetInvalidName(c *gc.C) {
+	target := backups.NewDirectoryTarget(c.MkDir())
+	_, err := target.Get("../foo")
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *targetSuite) TestDirectoryTargetStorage(c *gc.C) {
+	target := backups.NewDirectoryTarget(c.MkDir())
+	s.checkTargetStorage(c, backups.NewTargetStorage(target))
+}
+
+func (s *targetSuite) TestS3TargetStorage(c *gc.C) {
+	srv, err := s3test.NewServer(&s3test.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+	defer srv.Quit()
+
+	// The target expects the bucket to exist already.
+	region := aws.Region{
+		Name:                 "test",
+		S3Endpoint:           srv.URL(),
+		S3LocationConstraint: true,
+	}
+	bucket, err := s3.New(aws.Auth{AccessKey: "access", SecretKey: "secret"}, region).Bucket("backups")
+	c.Assert(err, jc.ErrorIsNil)
+	err = bucket.PutBucket(s3.Private)
+	c.Assert(err, jc.ErrorIsNil)
+
+	target, err := backups.NewS3Target(backups.S3Config{
+		Endpoint:  srv.URL(),
+		Region:    "test",
+		Bucket:    "backups",
+		AccessKey: "access",
+		SecretKey: "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkTargetStorage(c, backups.NewTargetStorage(target))
+}
+
+func (s *targetSuite) TestS3ConfigValidate(c *gc.C) {
+	for i, test := range []struct {
+		cfg         backups.S3Config
+		expectError string
+	}{{
+		cfg:         backups.S3Config{Region: "us-east-1", AccessKey: "a", SecretKey: "s"},
+		expectError: "empty Bucket not valid",
+	}, {
+		cfg:         backups.S3Config{Bucket: "b", AccessKey: "a", SecretKey: "s"},
+		expectError: "empty Endpoint and Region not valid",
+	}, {
+		cfg:         backups.S3Config{Bucket: "b", Region: "nowhere", AccessKey: "a", SecretKey: "s"},
+		expectError: `unknown AWS region "nowhere" with no Endpoint not valid`,
+	}, {
+		cfg:         backups.S3Config{Bucket: "b", Region: "us-east-1"},
+		expectError: "missing credentials not valid",
+	}, {
+		cfg: backups.S3Config{Bucket: "b", Region: "us-east-1", AccessKey: "a", SecretKey: "s"},
+	}, {
+		cfg: backups.S3Config{Bucket: "b", Endpoint: "http://minio:9000", AccessKey: "a", SecretKey: "s"},
+	}} {
+		c.Logf("test %d", i)
+		err := test.cfg.Validate()
+		if test.expectError == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.expectError)
+		}
+	}
+}
+
+type controllerConfigDB struct {
+	backups.DB
+	cfg controller.Config
+}
+
+func (db controllerConfigDB) ControllerConfig() (controller.Config, error) {
+	return db.cfg, nil
+}
+
+func (s *targetSuite) TestOpenStorageDirectory(c *gc.C) {
+	dir := c.MkDir()
+	db := controllerConfigDB{cfg: controller.Config{
+		controller.BackupStorage:     controller.BackupStorageDirectory,
+		controller.BackupStoragePath: dir,
+	}}
+	stor, err := backups.OpenStorage(db)
+	c.Assert(err, jc.ErrorIsNil)
+	defer stor.Close()
+	s.checkTargetStorage(c, stor)
+}
+
+func (s *targetSuite) TestOpenStorageInvalid(c *gc.C) {
+	db := controllerConfigDB{cfg: controller.Config{
+		controller.BackupStorage: "tape",
+	}}
+	_, err := backups.OpenStorage(db)
+	c.Check(err, gc.ErrorMatches, `backup storage "tape" not valid`)
+}
@@ -53,6 +53,8 @@ type FakeBackups struct {
 	KeepCopy bool
 	// NoDownload holds the noDownload bool that was passed in.
 	NoDownload bool
+	// EncryptionArg holds the encryption key that was passed in.
+	EncryptionArg *backups.EncryptionKey
 }
 
 var _ backups.Backups = (*FakeBackups)(nil)
@@ -64,6 +66,7 @@ func (b *FakeBackups) Create(
 	paths *backups.Paths,
 	dbInfo *backups.DBInfo,
 	keepCopy, noDownload bool,
+	encryption *backups.EncryptionKey,
 ) (string, error) {
 	b.Calls = append(b.Calls, "Create")
 
@@ -72,6 +75,7 @@ func (b *FakeBackups) Create(
 	b.MetaArg = meta
 	b.KeepCopy = keepCopy
 	b.NoDownload = noDownload
+	b.EncryptionArg = encryption
 
 	if b.Meta != nil {
 		*meta = *b.Meta
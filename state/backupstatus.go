@@ -0,0 +1,62 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/mongo/utils"
+)
+
+// scheduledBackupsGlobalKey is the global key for the status
+// of the controller's scheduled backups.
+const scheduledBackupsGlobalKey = "backups#scheduled"
+
+// SetScheduledBackupStatus records the outcome of the most recent
+// scheduled backup of the controller.
+func (st *State) SetScheduledBackupStatus(sInfo status.StatusInfo) error {
+	if !st.IsController() {
+		return errors.NotSupportedf("scheduled backup status on non-controller model")
+	}
+	doc := statusDoc{
+		Status:     sInfo.Status,
+		StatusInfo: sInfo.Message,
+		StatusData: utils.EscapeKeys(sInfo.Data),
+		Updated:    timeOrNow(sInfo.Since, st.clock()).UnixNano(),
+	}
+	var buildTxn jujutxn.TransactionSource = func(int) ([]txn.Op, error) {
+		_, err := getStatus(st.db(), scheduledBackupsGlobalKey, "scheduled backups")
+		if errors.IsNotFound(err) {
+			return []txn.Op{createStatusOp(st, scheduledBackupsGlobalKey, doc)}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return statusSetOps(st.db(), doc, scheduledBackupsGlobalKey)
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot set scheduled backup status")
+	}
+	if _, err := probablyUpdateStatusHistory(st.db(), scheduledBackupsGlobalKey, doc); err != nil {
+		logger.Warningf("failed to record scheduled backup status history: %v", err)
+	}
+	return nil
+}
+
+// ScheduledBackupStatus returns the outcome of the most recent
+// scheduled backup of the controller.
+func (st *State) ScheduledBackupStatus() (status.StatusInfo, error) {
+	if !st.IsController() {
+		return status.StatusInfo{}, errors.NotSupportedf("scheduled backup status on non-controller model")
+	}
+	return getStatus(st.db(), scheduledBackupsGlobalKey, "scheduled backups")
+}
+
+// ScheduledBackupStatusHistory returns a StatusHistoryGetter which can be
+// used to query the outcomes of previous scheduled backups of the controller.
+func (st *State) ScheduledBackupStatusHistory() status.StatusHistoryGetter {
+	return &HistoryGetter{st: st, globalKey: scheduledBackupsGlobalKey}
+}
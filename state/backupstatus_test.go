@@ -0,0 +1,67 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+)
+
+type BackupStatusSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&BackupStatusSuite{})
+
+func (s *BackupStatusSuite) TestScheduledBackupStatusNotSet(c *gc.C) {
+	_, err := s.State.ScheduledBackupStatus()
+	c.Assert(err, gc.ErrorMatches, "cannot get status: scheduled backups not found")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *BackupStatusSuite) TestSetScheduledBackupStatus(c *gc.C) {
+	t0 := time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC)
+	err := s.State.SetScheduledBackupStatus(status.StatusInfo{
+		Status:  status.Available,
+		Message: "created backup 20190501-100000.abc",
+		Data:    map[string]interface{}{"id": "20190501-100000.abc"},
+		Since:   &t0,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	t1 := t0.Add(24 * time.Hour)
+	err = s.State.SetScheduledBackupStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: "HA not ready",
+		Since:   &t1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	sInfo, err := s.State.ScheduledBackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sInfo.Status, gc.Equals, status.Error)
+	c.Assert(sInfo.Message, gc.Equals, "HA not ready")
+	c.Assert(sInfo.Since.Equal(t1), jc.IsTrue)
+
+	history, err := s.State.ScheduledBackupStatusHistory().StatusHistory(status.StatusHistoryFilter{Size: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Assert(history[0].Status, gc.Equals, status.Error)
+	c.Assert(history[1].Status, gc.Equals, status.Available)
+	c.Assert(history[1].Data, jc.DeepEquals, map[string]interface{}{"id": "20190501-100000.abc"})
+}
+
+func (s *BackupStatusSuite) TestScheduledBackupStatusNotController(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	err := st.SetScheduledBackupStatus(status.StatusInfo{Status: status.Available})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = st.ScheduledBackupStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -80,6 +80,12 @@ func globalCloudContainerKey(name string) string {
 	return unitGlobalKey(name) + "#container"
 }
 
+// globalCloudContainerEventsKey returns the global database key for the
+// history of events reported by the cloud for this unit's container.
+func globalCloudContainerEventsKey(name string) string {
+	return globalCloudContainerKey(name) + "#events"
+}
+
 func (u *Unit) cloudContainer() (*cloudContainerDoc, error) {
 	coll, closer := u.st.db().GetCollection(cloudContainersC)
 	defer closer()
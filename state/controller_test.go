@@ -40,6 +40,17 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 		controller.MaxPruneTxnPasses,
 		controller.PruneTxnQueryCount,
 		controller.PruneTxnSleepTime,
+		controller.BackupScheduleInterval,
+		controller.BackupRetentionCount,
+		controller.BackupRetentionDaily,
+		controller.BackupRetentionWeekly,
+		controller.BackupStorage,
+		controller.BackupStoragePath,
+		controller.BackupS3Endpoint,
+		controller.BackupS3Region,
+		controller.BackupS3Bucket,
+		controller.BackupS3AccessKey,
+		controller.BackupS3SecretKey,
 		controller.MaxLogsSize,
 		controller.MaxLogsAge,
 		controller.CAASOperatorImagePath,
@@ -923,3 +923,23 @@ func (s ModelBackendShim) isController() bool {
 func (s ModelBackendShim) txnLogWatcher() watcher.BaseWatcher {
 	return s.Watcher
 }
+
+// CompleteBranchHoldingCharmRefs completes the branch with the input name
+// without releasing its charm references, as done by controllers that
+// predate the release of branch charm references on completion.
+func CompleteBranchHoldingCharmRefs(st *State, branchName string) error {
+	doc, err := st.getBranchDoc(branchName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	now, err := st.ControllerTimestamp()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return st.db().RunTransaction([]txn.Op{{
+		C:      generationsC,
+		Id:     doc.DocId,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"completed", now.Unix()}}}},
+	}})
+}
@@ -0,0 +1,428 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ReplaceMachine adds a machine to replace the machine with the given id,
+// with the same series, constraints, jobs and placement, and reassigns
+// the old machine's principal units to it before destroying the old
+// machine. Subordinate units follow their principals, as do the ports
+// opened by the units. If the old machine was placed on a particular
+// host, the new machine is left for the provider to place instead.
+//
+// The units keep their names and storage, but are deployed afresh on
+// the new machine, so their install and relation hooks are run again.
+// Storage attached to the units is detached from the old machine and
+// attached to the new one, and so must be detachable; the storage
+// provisioner completes the move.
+//
+// The units are moved and the old machine destroyed in a single
+// transaction. If that fails, the new machine is removed again.
+func (st *State) ReplaceMachine(id string) (_ *Machine, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot replace machine %s", id)
+
+	old, err := st.Machine(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := old.checkReplaceable(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Check up front that the units' storage can be moved, so that
+	// we do not add a machine only to leave it unused.
+	if _, err := old.replaceablePrincipals(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	cons, err := old.Constraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	placement, err := old.replacementPlacement()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	template := MachineTemplate{
+		Series:      old.Series(),
+		Constraints: cons,
+		Jobs:        old.Jobs(),
+		Placement:   placement,
+	}
+	var m *Machine
+	if parentId, ok := old.ParentId(); ok {
+		m, err = st.AddMachineInsideMachine(template, parentId, old.ContainerType())
+	} else {
+		m, err = st.AddOneMachine(template)
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "adding replacement machine")
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if err := m.removeUnused(); err != nil {
+			logger.Errorf("cannot remove replacement machine %s: %v", m.Id(), err)
+		}
+	}()
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := old.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+			if err := old.checkReplaceable(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		principals, err := old.replaceablePrincipals()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return old.replaceOps(m, principals)
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return nil, errors.Annotate(err, "moving units to replacement machine")
+	}
+	return m, nil
+}
+
+// checkReplaceable returns an error if the machine cannot be replaced
+// by a newly provisioned machine.
+func (m *Machine) checkReplaceable() error {
+	if m.Life() != Alive {
+		return errors.New("machine is not alive")
+	}
+	if m.IsManager() {
+		return errors.New("machine is a controller")
+	}
+	manual, err := m.IsManual()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if manual {
+		return errors.New("machine was provisioned manually")
+	}
+	containers, err := m.Containers()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(containers) > 0 {
+		return errors.Errorf("machine hosts containers %v", containers)
+	}
+	return nil
+}
+
+// replaceablePrincipals returns the principal units of the machine,
+// or an error if any of them can't be moved to another machine.
+func (m *Machine) replaceablePrincipals() ([]*Unit, error) {
+	units, err := m.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var principals []*Unit
+	for _, u := range units {
+		if !u.IsPrincipal() {
+			continue
+		}
+		if u.Life() != Alive {
+			return nil, errors.Errorf("unit %s is not alive", u.Name())
+		}
+		if _, _, _, err := u.movableStorage(m); err != nil {
+			return nil, errors.Annotatef(err, "unit %s", u.Name())
+		}
+		principals = append(principals, u)
+	}
+	return principals, nil
+}
+
+// replacementPlacement returns the placement directive for a machine
+// replacing this one. The machine's placement isn't used if it names
+// the machine's host, such as a MAAS node, or an LXD cluster member
+// given as a zone, as that is the host being replaced.
+func (m *Machine) replacementPlacement() (string, error) {
+	placement := m.Placement()
+	if placement == "" {
+		return "", nil
+	}
+	instId, displayName, err := m.InstanceNames()
+	if errors.IsNotProvisioned(err) {
+		return placement, nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	value := placement
+	if i := strings.Index(placement, "="); i >= 0 {
+		value = placement[i+1:]
+	}
+	if value == string(instId) || (displayName != "" && value == displayName) {
+		logger.Infof("not placing replacement for machine %s on its host (placement %q)", m.Id(), placement)
+		return "", nil
+	}
+	return placement, nil
+}
+
+// removeUnused removes a machine which has had no units assigned.
+func (m *Machine) removeUnused() error {
+	if err := m.EnsureDead(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(m.Remove())
+}
+
+// replaceOps returns the operations moving the given principal units of
+// the machine, along with their subordinates, storage and opened ports,
+// to the replacement machine, and destroying the machine.
+func (m *Machine) replaceOps(to *Machine, principals []*Unit) ([]txn.Op, error) {
+	var ops []txn.Op
+	unitNames := set.NewStrings()
+	for _, u := range principals {
+		unitOps, err := u.reassignToMachineOps(m, to)
+		if err != nil {
+			return nil, errors.Annotatef(err, "unit %s", u.Name())
+		}
+		ops = append(ops, unitOps...)
+		unitNames.Add(u.Name())
+		unitNames = unitNames.Union(set.NewStrings(u.doc.Subordinates...))
+	}
+	portsOps, err := moveOpenedPortsOps(m.st, m, to, unitNames)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, portsOps...)
+
+	// The machine's principals are all moved, so it can be destroyed
+	// in the same transaction; see advanceLifecycle.
+	ops = append(ops, txn.Op{
+		C:  machinesC,
+		Id: m.doc.DocID,
+		Assert: append(isAliveDoc, bson.D{
+			{"jobs", bson.D{{"$nin", []MachineJob{JobManageModel}}}},
+			{"hasvote", bson.D{{"$ne", true}}},
+			{"principals", m.doc.Principals},
+		}...),
+		Update: bson.D{{"$set", bson.D{{"life", Dying}}}},
+	}, txn.Op{
+		C:  containerRefsC,
+		Id: m.doc.DocID,
+		Assert: bson.D{{"$or", []bson.D{
+			{{"children", bson.D{{"$size", 0}}}},
+			{{"children", bson.D{{"$exists", false}}}},
+		}}},
+	}, newCleanupOp(cleanupDyingMachine, m.doc.Id, false))
+	return ops, nil
+}
+
+// reassignToMachineOps returns the operations moving the unit, along
+// with its subordinates and their storage, from one machine to another.
+func (u *Unit) reassignToMachineOps(from, to *Machine) ([]txn.Op, error) {
+	volumes, filesystems, detachOps, err := u.movableStorage(from)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	attachmentOps, err := addMachineStorageAttachmentsOps(to, volumes, filesystems)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	assert := append(isAliveDoc, bson.D{
+		// The unit's subordinates must not change while it is being
+		// moved, to ensure all of their storage is moved with it.
+		{"subordinates", u.doc.Subordinates},
+		{"machineid", from.doc.Id},
+	}...)
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: assert,
+		Update: bson.D{{"$set", bson.D{{"machineid", to.doc.Id}}}},
+	}, {
+		C:      machinesC,
+		Id:     from.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$pull", bson.D{{"principals", u.doc.Name}}}},
+	}, {
+		C:      machinesC,
+		Id:     to.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$addToSet", bson.D{{"principals", u.doc.Name}}}, {"$set", bson.D{{"clean", false}}}},
+	}}
+	ops = append(ops, detachOps...)
+	ops = append(ops, createMachineVolumeAttachmentsOps(to.doc.Id, volumes)...)
+	ops = append(ops, createMachineFilesystemAttachmentsOps(to.doc.Id, filesystems)...)
+	ops = append(ops, attachmentOps...)
+	return ops, nil
+}
+
+// moveOpenedPortsOps returns the operations moving the port ranges
+// opened by the given units from one machine to another.
+func moveOpenedPortsOps(st *State, from, to *Machine, unitNames set.Strings) ([]txn.Op, error) {
+	allPorts, err := from.AllPorts()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, ports := range allPorts {
+		var keep, move []PortRange
+		for _, portRange := range ports.doc.Ports {
+			if unitNames.Contains(portRange.UnitName) {
+				move = append(move, portRange)
+			} else {
+				keep = append(keep, portRange)
+			}
+		}
+		if len(move) == 0 {
+			continue
+		}
+		assert := bson.D{{"txn-revno", ports.doc.TxnRevno}}
+		if len(keep) > 0 {
+			ops = append(ops, setPortsDocOps(st, ports.doc, assert, keep...)...)
+		} else {
+			ops = append(ops, ports.removeOps()...)
+		}
+
+		toPorts, err := getOrCreatePorts(st, to.Id(), ports.doc.SubnetID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if toPorts.areNew {
+			ops = append(ops, addPortsDocOps(st, &toPorts.doc, txn.DocMissing, move...)...)
+		} else {
+			assert := bson.D{{"txn-revno", toPorts.doc.TxnRevno}}
+			ops = append(ops, setPortsDocOps(st, toPorts.doc, assert, append(toPorts.doc.Ports, move...)...)...)
+		}
+	}
+	return ops, nil
+}
+
+// movableStorage returns templates for attaching the volumes and
+// filesystems of the unit and its subordinates to another machine, and
+// the operations to detach them from the given machine. An error is
+// returned if any of them cannot be detached from the machine.
+func (u *Unit) movableStorage(from *Machine) (
+	[]volumeAttachmentTemplate, []filesystemAttachmentTemplate, []txn.Op, error,
+) {
+	sb, err := NewStorageBackend(u.st)
+	if err != nil {
+		return nil, nil, nil, errors.Trace(err)
+	}
+	units := []*Unit{u}
+	for _, name := range u.doc.Subordinates {
+		sub, err := u.st.Unit(name)
+		if err != nil {
+			return nil, nil, nil, errors.Trace(err)
+		}
+		units = append(units, sub)
+	}
+
+	const existing = true
+	var volumes []volumeAttachmentTemplate
+	var filesystems []filesystemAttachmentTemplate
+	var ops []txn.Op
+	fromTag := from.MachineTag()
+	for _, unit := range units {
+		ch, err := unit.charm()
+		if err != nil {
+			return nil, nil, nil, errors.Annotate(err, "getting charm")
+		}
+		storageAttachments, err := sb.UnitStorageAttachments(unit.UnitTag())
+		if err != nil {
+			return nil, nil, nil, errors.Annotate(err, "getting storage attachments")
+		}
+		for _, sa := range storageAttachments {
+			storage, err := sb.storageInstance(sa.StorageInstance())
+			if err != nil {
+				return nil, nil, nil, errors.Annotate(err, "getting storage instance")
+			}
+			charmStorage := ch.Meta().Storage[storage.StorageName()]
+
+			var volumeTag names.VolumeTag
+			switch storage.Kind() {
+			case StorageKindFilesystem:
+				f, err := sb.StorageInstanceFilesystem(storage.StorageTag())
+				if errors.IsNotFound(err) {
+					continue
+				} else if err != nil {
+					return nil, nil, nil, errors.Trace(err)
+				}
+				if !f.Detachable() {
+					return nil, nil, nil, errors.Errorf(
+						"%s cannot be detached from %s",
+						names.ReadableString(f.FilesystemTag()),
+						names.ReadableString(fromTag),
+					)
+				}
+				location, err := filesystemMountPoint(charmStorage, storage.StorageTag(), unit.Series())
+				if err != nil {
+					return nil, nil, nil, errors.Trace(err)
+				}
+				filesystems = append(filesystems, filesystemAttachmentTemplate{
+					f.FilesystemTag(), storage.StorageTag(), FilesystemAttachmentParams{
+						charmStorage.Location == "", location, charmStorage.ReadOnly,
+					}, existing,
+				})
+				if fsa, err := sb.FilesystemAttachment(fromTag, f.FilesystemTag()); err == nil && fsa.Life() == Alive {
+					// Removing the filesystem attachment will
+					// detach any volume backing the filesystem.
+					ops = append(ops, detachFilesystemOps(fromTag, f.FilesystemTag())...)
+				} else if err != nil && !errors.IsNotFound(err) {
+					return nil, nil, nil, errors.Trace(err)
+				}
+				if volumeTag, err = f.Volume(); err == ErrNoBackingVolume {
+					continue
+				} else if err != nil {
+					return nil, nil, nil, errors.Trace(err)
+				}
+
+			case StorageKindBlock:
+				v, err := sb.StorageInstanceVolume(storage.StorageTag())
+				if errors.IsNotFound(err) {
+					continue
+				} else if err != nil {
+					return nil, nil, nil, errors.Trace(err)
+				}
+				if !v.Detachable() {
+					return nil, nil, nil, errors.Errorf(
+						"%s cannot be detached from %s",
+						names.ReadableString(v.VolumeTag()),
+						names.ReadableString(fromTag),
+					)
+				}
+				volumeTag = v.VolumeTag()
+				if va, err := sb.VolumeAttachment(fromTag, volumeTag); err == nil && va.Life() == Alive {
+					plans, err := sb.machineVolumeAttachmentPlans(fromTag, volumeTag)
+					if err != nil {
+						return nil, nil, nil, errors.Trace(err)
+					}
+					if len(plans) > 0 {
+						ops = append(ops, detachStorageAttachmentOps(fromTag, volumeTag)...)
+					} else {
+						ops = append(ops, detachVolumeOps(fromTag, volumeTag)...)
+					}
+				} else if err != nil && !errors.IsNotFound(err) {
+					return nil, nil, nil, errors.Trace(err)
+				}
+
+			default:
+				return nil, nil, nil, errors.Errorf("invalid storage kind %v", storage.Kind())
+			}
+			volumes = append(volumes, volumeAttachmentTemplate{
+				volumeTag, VolumeAttachmentParams{charmStorage.ReadOnly}, existing,
+			})
+		}
+	}
+	return volumes, filesystems, ops, nil
+}
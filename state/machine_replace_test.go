@@ -0,0 +1,194 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/state"
+)
+
+type MachineReplaceSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&MachineReplaceSuite{})
+
+func (s *MachineReplaceSuite) TestReplaceMachine(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	err := s.st.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	old := unitMachine(c, s.st, u)
+	err = old.SetConstraints(constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	m, err := s.st.ReplaceMachine(old.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Id(), gc.Not(gc.Equals), old.Id())
+	c.Assert(m.Series(), gc.Equals, old.Series())
+	c.Assert(m.Jobs(), jc.DeepEquals, old.Jobs())
+	cons, err := m.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, jc.DeepEquals, constraints.MustParse("mem=4G"))
+	c.Assert(m.Principals(), jc.DeepEquals, []string{u.Name()})
+
+	err = u.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Equals, m.Id())
+
+	err = old.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(old.Life(), gc.Equals, state.Dying)
+	c.Assert(old.Principals(), gc.HasLen, 0)
+
+	// The volume is detached from the old machine and attached to the new one.
+	volume := s.storageInstanceVolume(c, storageTag)
+	c.Assert(s.volumeAttachment(c, old.MachineTag(), volume.VolumeTag()).Life(), gc.Equals, state.Dying)
+	c.Assert(s.volumeAttachment(c, m.MachineTag(), volume.VolumeTag()).Life(), gc.Equals, state.Alive)
+}
+
+func (s *MachineReplaceSuite) TestReplaceMachineFilesystem(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorageDetachable(c, "filesystem", "modelscoped")
+	err := s.st.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	old := unitMachine(c, s.st, u)
+
+	m, err := s.st.ReplaceMachine(old.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	c.Assert(s.filesystemAttachment(c, old.MachineTag(), filesystem.FilesystemTag()).Life(), gc.Equals, state.Dying)
+	c.Assert(s.filesystemAttachment(c, m.MachineTag(), filesystem.FilesystemTag()).Life(), gc.Equals, state.Alive)
+}
+
+func (s *MachineReplaceSuite) TestReplaceMachineOpenedPorts(c *gc.C) {
+	_, u, _ := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	err := s.st.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	old := unitMachine(c, s.st, u)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	m, err := s.st.ReplaceMachine(old.Id())
+	c.Assert(err, jc.ErrorIsNil)
+
+	ports, err := old.OpenedPorts("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, gc.IsNil)
+	ports, err = m.OpenedPorts("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, gc.NotNil)
+	c.Assert(ports.PortsForUnit(u.Name()), jc.DeepEquals, []state.PortRange{{
+		UnitName: u.Name(),
+		FromPort: 80,
+		ToPort:   80,
+		Protocol: "tcp",
+	}})
+}
+
+func (s *MachineReplaceSuite) TestReplaceMachinePlacement(c *gc.C) {
+	old, err := s.st.AddOneMachine(state.MachineTemplate{
+		Series:    "quantal",
+		Jobs:      []state.MachineJob{state.JobHostUnits},
+		Placement: "zone=az-1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = old.SetProvisioned("inst-0", "node-0", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	m, err := s.st.ReplaceMachine(old.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Placement(), gc.Equals, "zone=az-1")
+}
+
+func (s *MachineReplaceSuite) TestReplaceMachinePlacedOnHost(c *gc.C) {
+	// LXD cluster members are given as zones.
+	old, err := s.st.AddOneMachine(state.MachineTemplate{
+		Series:    "quantal",
+		Jobs:      []state.MachineJob{state.JobHostUnits},
+		Placement: "zone=node-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = old.SetProvisioned("inst-0", "node-0", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The replacement is not placed on the host being replaced.
+	m, err := s.st.ReplaceMachine(old.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Placement(), gc.Equals, "")
+}
+
+func (s *MachineReplaceSuite) TestReplaceMachineRemovesReplacementOnError(c *gc.C) {
+	_, u, _ := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	err := s.st.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	old := unitMachine(c, s.st, u)
+
+	defer state.SetBeforeHooks(c, s.st, func() {
+		_, err := s.st.AddMachineInsideMachine(state.MachineTemplate{
+			Series: "quantal",
+			Jobs:   []state.MachineJob{state.JobHostUnits},
+		}, old.Id(), instance.LXD)
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err = s.st.ReplaceMachine(old.Id())
+	c.Assert(err, gc.ErrorMatches, `cannot replace machine 0: moving units to replacement machine: machine hosts containers \[0/lxd/0\]`)
+
+	// The unit stays where it was, and the replacement is removed.
+	err = u.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Equals, old.Id())
+	_, err = s.st.Machine("1")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MachineReplaceSuite) TestReplaceMachineStorageNotDetachable(c *gc.C) {
+	_, u, _ := s.setupSingleStorageDetachable(c, "block", "machinescoped")
+	err := s.st.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	old := unitMachine(c, s.st, u)
+
+	_, err = s.st.ReplaceMachine(old.Id())
+	c.Assert(err, gc.ErrorMatches, `cannot replace machine 0: unit storage-block/0: volume 0/0 cannot be detached from machine 0`)
+
+	// No machine is added to replace the old one.
+	machines, err := s.st.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 1)
+}
+
+func (s *MachineReplaceSuite) TestReplaceMachineController(c *gc.C) {
+	m, err := s.st.AddMachine("quantal", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.st.ReplaceMachine(m.Id())
+	c.Assert(err, gc.ErrorMatches, `cannot replace machine 0: machine is a controller`)
+}
+
+func (s *MachineReplaceSuite) TestReplaceMachineWithContainers(c *gc.C) {
+	m, err := s.st.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.st.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, m.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.st.ReplaceMachine(m.Id())
+	c.Assert(err, gc.ErrorMatches, `cannot replace machine 0: machine hosts containers \[0/lxd/0\]`)
+}
+
+func (s *MachineReplaceSuite) TestReplaceMachineNotFound(c *gc.C) {
+	_, err := s.st.ReplaceMachine("42")
+	c.Assert(err, gc.ErrorMatches, `cannot replace machine 42: machine 42 not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
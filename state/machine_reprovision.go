@@ -0,0 +1,79 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/status"
+)
+
+// Reprovision removes the record of the machine's instance so that the
+// provisioner starts a new instance for it, as it does for a machine
+// whose provisioning failed with a transient error. The message, which
+// should say why the machine is being reprovisioned, is recorded in the
+// machine's instance status.
+//
+// The units assigned to the machine remain assigned to it, and are
+// deployed afresh on the new instance. Machines with attached storage
+// cannot be reprovisioned, as the storage cannot be recreated.
+func (m *Machine) Reprovision(message string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot reprovision machine %s", m.Id())
+
+	if m.IsContainer() {
+		return errors.New("machine is a container")
+	}
+	sb, err := NewStorageBackend(m.st)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	volumes, err := sb.MachineVolumeAttachments(m.MachineTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	filesystems, err := sb.MachineFilesystemAttachments(m.MachineTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(volumes) > 0 || len(filesystems) > 0 {
+		return errors.New("machine has attached storage")
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := m.checkReplaceable(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if m.doc.Nonce == "" {
+			return nil, errors.NotProvisionedf("machine %v", m.Id())
+		}
+		return []txn.Op{{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: append(isAliveDoc, bson.DocElem{"nonce", m.doc.Nonce}),
+			Update: bson.D{{"$set", bson.D{{"nonce", ""}}}},
+		}, {
+			C:      instanceDataC,
+			Id:     m.doc.DocID,
+			Assert: txn.DocExists,
+			Remove: true,
+		}}, nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	m.doc.Nonce = ""
+
+	return errors.Trace(m.SetInstanceStatus(status.StatusInfo{
+		Status:  status.ProvisioningError,
+		Message: message,
+		Data:    map[string]interface{}{"transient": true},
+	}))
+}
@@ -0,0 +1,96 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+type MachineReprovisionSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&MachineReprovisionSuite{})
+
+func (s *MachineReprovisionSuite) TestReprovision(c *gc.C) {
+	m, err := s.st.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.SetProvisioned("i-old", "", "nonce-old", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = m.Reprovision("instance i-old is stopped")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = m.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = m.InstanceId()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+	c.Assert(m.CheckProvisioned("nonce-old"), jc.IsFalse)
+
+	// The instance status marks the machine for the provisioner to retry.
+	instanceStatus, err := m.InstanceStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instanceStatus.Status, gc.Equals, status.ProvisioningError)
+	c.Assert(instanceStatus.Message, gc.Equals, "instance i-old is stopped")
+	c.Assert(instanceStatus.Data, jc.DeepEquals, map[string]interface{}{"transient": true})
+
+	// The machine can be provisioned again.
+	err = m.SetProvisioned("i-new", "", "nonce-new", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	instId, err := m.InstanceId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instId, gc.Equals, instance.Id("i-new"))
+}
+
+func (s *MachineReprovisionSuite) TestReprovisionNotProvisioned(c *gc.C) {
+	m, err := s.st.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.Reprovision("")
+	c.Assert(err, gc.ErrorMatches, `cannot reprovision machine 0: machine 0 not provisioned`)
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *MachineReprovisionSuite) TestReprovisionManual(c *gc.C) {
+	m, err := s.st.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.SetProvisioned("i-manual", "", "manual:host", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.Reprovision("")
+	c.Assert(err, gc.ErrorMatches, `cannot reprovision machine 0: machine was provisioned manually`)
+}
+
+func (s *MachineReprovisionSuite) TestReprovisionContainer(c *gc.C) {
+	m, err := s.st.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	container, err := s.st.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, m.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = container.Reprovision("")
+	c.Assert(err, gc.ErrorMatches, `cannot reprovision machine 0/lxd/0: machine is a container`)
+	err = m.Reprovision("")
+	c.Assert(err, gc.ErrorMatches, `cannot reprovision machine 0: machine hosts containers \[0/lxd/0\]`)
+}
+
+func (s *MachineReprovisionSuite) TestReprovisionWithStorage(c *gc.C) {
+	_, u, _ := s.setupSingleStorageDetachable(c, "block", "modelscoped")
+	err := s.st.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	m := unitMachine(c, s.st, u)
+	err = m.SetProvisioned("i-old", "", "nonce-old", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = m.Reprovision("")
+	c.Assert(err, gc.ErrorMatches, `cannot reprovision machine 0: machine has attached storage`)
+	_, err = m.InstanceId()
+	c.Assert(err, jc.ErrorIsNil)
+}
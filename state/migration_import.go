@@ -40,83 +40,14 @@ func (ctrl *Controller) Import(model description.Model) (_ *Model, _ *State, err
 	logger := loggo.GetLogger("juju.state.import-model")
 	logger.Debugf("import starting for model %s", modelUUID)
 
-	// At this stage, attempting to import a model with the same
-	// UUID as an existing model will error.
-	if modelExists, err := st.ModelExists(modelUUID); err != nil {
-		return nil, nil, errors.Trace(err)
-	} else if modelExists {
-		// We have an existing matching model.
-		return nil, nil, errors.AlreadyExistsf("model %s", modelUUID)
-	}
-
-	if len(model.RemoteApplications()) != 0 {
-		// Cross-model relations are currently limited to models on
-		// the same controller, while migration is for getting the
-		// model to a new controller.
-		return nil, nil, errors.New("can't import models with remote applications")
-	}
-
-	// Unfortunately a version was released that exports v4 models
-	// with the Type field blank. Treat this as IAAS.
-	modelType := ModelTypeIAAS
-	if model.Type() != "" {
-		modelType, err = ParseModelType(model.Type())
-		if err != nil {
-			return nil, nil, errors.Trace(err)
-		}
-	}
-
-	// Create the model.
-	cfg, err := config.New(config.NoDefaults, model.Config())
+	args, newCredential, err := importModelArgs(st, model)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
-	args := ModelArgs{
-		Type:                    modelType,
-		CloudName:               model.Cloud(),
-		CloudRegion:             model.CloudRegion(),
-		Config:                  cfg,
-		Owner:                   model.Owner(),
-		MigrationMode:           MigrationModeImporting,
-		EnvironVersion:          model.EnvironVersion(),
-		StorageProviderRegistry: storage.StaticProviderRegistry{},
-	}
-	if creds := model.CloudCredential(); creds != nil {
-		// Need to add credential or make sure an existing credential
-		// matches.
-		// TODO: there really should be a way to create a cloud credential
-		// tag in the names package from the cloud, owner and name.
-		credID := fmt.Sprintf("%s/%s/%s", creds.Cloud(), creds.Owner(), creds.Name())
-		if !names.IsValidCloudCredential(credID) {
-			return nil, nil, errors.NotValidf("cloud credential ID %q", credID)
-		}
-		credTag := names.NewCloudCredentialTag(credID)
-
-		existingCreds, err := st.CloudCredential(credTag)
-
-		if errors.IsNotFound(err) {
-			credential := cloud.NewCredential(
-				cloud.AuthType(creds.AuthType()),
-				creds.Attributes())
-			if err := st.UpdateCloudCredential(credTag, credential); err != nil {
-				return nil, nil, errors.Trace(err)
-			}
-		} else if err != nil {
+	if newCredential != nil {
+		if err := st.UpdateCloudCredential(args.CloudCredential, *newCredential); err != nil {
 			return nil, nil, errors.Trace(err)
-		} else {
-			// ensure existing creds match
-			if existingCreds.AuthType != creds.AuthType() {
-				return nil, nil, errors.Errorf("credential auth type mismatch: %q != %q", existingCreds.AuthType, creds.AuthType())
-			}
-			if !reflect.DeepEqual(existingCreds.Attributes, creds.Attributes()) {
-				return nil, nil, errors.Errorf("credential attribute mismatch: %v != %v", existingCreds.Attributes, creds.Attributes())
-			}
-			if existingCreds.Revoked {
-				return nil, nil, errors.Errorf("credential %q is revoked", credID)
-			}
 		}
-
-		args.CloudCredential = credTag
 	}
 	dbModel, newSt, err := ctrl.NewModel(args)
 	if err != nil {
@@ -219,6 +150,119 @@ func (ctrl *Controller) Import(model description.Model) (_ *Model, _ *State, err
 	return dbModel, newSt, nil
 }
 
+// ValidateImport checks that the model description could be imported into
+// the controller, without importing it. This allows a migration to be
+// checked ahead of time.
+func (ctrl *Controller) ValidateImport(model description.Model) error {
+	st := ctrl.pool.SystemState()
+	if _, _, err := importModelArgs(st, model); err != nil {
+		return errors.Trace(err)
+	}
+	modelCloud, err := st.Cloud(model.Cloud())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := validateCloudRegion(modelCloud, model.CloudRegion()); err != nil {
+		return errors.Annotatef(err, "cloud %q", model.Cloud())
+	}
+	if owner := model.Owner(); owner.IsLocal() {
+		if _, err := st.User(owner); err != nil {
+			return errors.Annotate(err, "model owner")
+		}
+	}
+	return nil
+}
+
+// importModelArgs validates the input model description and returns the
+// arguments used to create it in the database. If the model's cloud
+// credential does not yet exist in the controller, it is also returned
+// so that it can be added before the model is created.
+func importModelArgs(st *State, model description.Model) (ModelArgs, *cloud.Credential, error) {
+	var (
+		args          ModelArgs
+		newCredential *cloud.Credential
+	)
+	modelUUID := model.Tag().Id()
+
+	// At this stage, attempting to import a model with the same
+	// UUID as an existing model will error.
+	if modelExists, err := st.ModelExists(modelUUID); err != nil {
+		return args, nil, errors.Trace(err)
+	} else if modelExists {
+		// We have an existing matching model.
+		return args, nil, errors.AlreadyExistsf("model %s", modelUUID)
+	}
+
+	if len(model.RemoteApplications()) != 0 {
+		// Cross-model relations are currently limited to models on
+		// the same controller, while migration is for getting the
+		// model to a new controller.
+		return args, nil, errors.New("can't import models with remote applications")
+	}
+
+	// Unfortunately a version was released that exports v4 models
+	// with the Type field blank. Treat this as IAAS.
+	modelType := ModelTypeIAAS
+	if model.Type() != "" {
+		var err error
+		modelType, err = ParseModelType(model.Type())
+		if err != nil {
+			return args, nil, errors.Trace(err)
+		}
+	}
+
+	cfg, err := config.New(config.NoDefaults, model.Config())
+	if err != nil {
+		return args, nil, errors.Trace(err)
+	}
+	args = ModelArgs{
+		Type:                    modelType,
+		CloudName:               model.Cloud(),
+		CloudRegion:             model.CloudRegion(),
+		Config:                  cfg,
+		Owner:                   model.Owner(),
+		MigrationMode:           MigrationModeImporting,
+		EnvironVersion:          model.EnvironVersion(),
+		StorageProviderRegistry: storage.StaticProviderRegistry{},
+	}
+	if creds := model.CloudCredential(); creds != nil {
+		// Need to add credential or make sure an existing credential
+		// matches.
+		// TODO: there really should be a way to create a cloud credential
+		// tag in the names package from the cloud, owner and name.
+		credID := fmt.Sprintf("%s/%s/%s", creds.Cloud(), creds.Owner(), creds.Name())
+		if !names.IsValidCloudCredential(credID) {
+			return args, nil, errors.NotValidf("cloud credential ID %q", credID)
+		}
+		credTag := names.NewCloudCredentialTag(credID)
+
+		existingCreds, err := st.CloudCredential(credTag)
+
+		if errors.IsNotFound(err) {
+			credential := cloud.NewCredential(
+				cloud.AuthType(creds.AuthType()),
+				creds.Attributes())
+			newCredential = &credential
+		} else if err != nil {
+			return args, nil, errors.Trace(err)
+		} else {
+			// ensure existing creds match
+			if existingCreds.AuthType != creds.AuthType() {
+				return args, nil, errors.Errorf("credential auth type mismatch: %q != %q", existingCreds.AuthType, creds.AuthType())
+			}
+			if !reflect.DeepEqual(existingCreds.Attributes, creds.Attributes()) {
+				return args, nil, errors.Errorf("credential attribute mismatch: %v != %v", existingCreds.Attributes, creds.Attributes())
+			}
+			if existingCreds.Revoked {
+				return args, nil, errors.Errorf("credential %q is revoked", credID)
+			}
+		}
+
+		args.CloudCredential = credTag
+	}
+	return args, newCredential, nil
+}
+
 type importer struct {
 	st      *State
 	dbModel *Model
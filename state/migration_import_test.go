@@ -65,6 +65,28 @@ func (s *MigrationImportSuite) TestExisting(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
 }
 
+func (s *MigrationImportSuite) TestValidateImportExisting(c *gc.C) {
+	out, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.Controller.ValidateImport(out)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *MigrationImportSuite) TestValidateImport(c *gc.C) {
+	out, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	uuid := utils.MustNewUUID().String()
+	in := newModel(out, uuid, "new")
+	c.Assert(s.Controller.ValidateImport(in), jc.ErrorIsNil)
+
+	// Nothing is imported.
+	exists, err := s.State.ModelExists(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(exists, jc.IsFalse)
+}
+
 func (s *MigrationImportSuite) importModel(c *gc.C, st *state.State, transform ...func(map[string]interface{})) (*state.Model, *state.State) {
 	out, err := st.Export()
 	c.Assert(err, jc.ErrorIsNil)
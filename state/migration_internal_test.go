@@ -137,6 +137,7 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		migrationsStatusC,
 		migrationsActiveC,
 		migrationsMinionSyncC,
+		migrationBatchesC,
 
 		// The container ref document is primarily there to keep track
 		// of a particular machine's containers. The migration format
@@ -0,0 +1,203 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// importedBinaryDocs holds the documents describing the binaries that
+// were uploaded into a model while it was being imported. The binaries
+// themselves live in the blobstore, which isn't cleared when the
+// model's documents are removed.
+type importedBinaryDocs struct {
+	charms    []bson.M
+	sequences []bson.M
+	tools     []bson.M
+	resources []bson.M
+}
+
+// ResumeImport recreates the model described by the given description
+// in the same way as Import. If an earlier, failed attempt to import
+// the model left it behind, it is replaced first. The charms, agent
+// binaries and application resources already uploaded for the
+// leftover model are kept, so that they need not be uploaded again.
+func (ctrl *Controller) ResumeImport(model description.Model) (_ *Model, _ *State, err error) {
+	modelUUID := model.Tag().Id()
+	if exists, err := ctrl.pool.SystemState().ModelExists(modelUUID); err != nil {
+		return nil, nil, errors.Trace(err)
+	} else if !exists {
+		return ctrl.Import(model)
+	}
+
+	st, err := ctrl.pool.Get(modelUUID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	binaries, err := st.replaceImportingModel(model)
+	st.Release()
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "replacing previous import")
+	}
+
+	dbModel, newSt, err := ctrl.Import(model)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			newSt.Close()
+		}
+	}()
+	if err := newSt.restoreImportedBinaryDocs(binaries); err != nil {
+		return nil, nil, errors.Annotate(err, "restoring imported binaries")
+	}
+	return dbModel, newSt, nil
+}
+
+// replaceImportingModel removes all the documents of a model which is
+// being imported, returning the documents for the binaries that are
+// still wanted by the new description of the model.
+func (st *State) replaceImportingModel(model description.Model) (*importedBinaryDocs, error) {
+	dbModel, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if dbModel.MigrationMode() != MigrationModeImporting {
+		return nil, errors.AlreadyExistsf("model %s", dbModel.UUID())
+	}
+	binaries, err := st.importedBinaryDocs(model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := st.RemoveImportingModelDocs(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return binaries, nil
+}
+
+// importedBinaryDocs returns the documents for the binaries which have
+// been completely uploaded into the model and are still in use by the
+// given description of it.
+func (st *State) importedBinaryDocs(model description.Model) (*importedBinaryDocs, error) {
+	charmURLs := make(map[string]bool)
+	applications := make(map[string]bool)
+	for _, app := range model.Applications() {
+		applications[app.Name()] = true
+		charmURLs[app.CharmURL()] = true
+		for _, unit := range app.Units() {
+			if unit.CharmURL() != "" {
+				charmURLs[unit.CharmURL()] = true
+			}
+		}
+	}
+
+	var binaries importedBinaryDocs
+	charms, err := st.findAllDocs(charmsC, bson.D{
+		{"pendingupload", false},
+		{"placeholder", false},
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "reading charms")
+	}
+	for _, doc := range charms {
+		if url, ok := doc["url"].(string); ok && charmURLs[url] {
+			binaries.charms = append(binaries.charms, doc)
+		}
+	}
+
+	sequences, err := st.findAllDocs(sequenceC, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading sequences")
+	}
+	for _, doc := range sequences {
+		if name, ok := doc["name"].(string); ok && isCharmRevSeqName(name) {
+			binaries.sequences = append(binaries.sequences, doc)
+		}
+	}
+
+	binaries.tools, err = st.findAllDocs(toolsmetadataC, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading agent binaries")
+	}
+
+	// Only application resources hold uploaded blobs. Unit resources
+	// are set again once the blobs are in place.
+	resources, err := st.findAllDocs(resourcesC, bson.D{
+		{"unit-id", ""},
+		{"pending-id", ""},
+		{"storage-path", bson.D{{"$ne", ""}}},
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "reading resources")
+	}
+	for _, doc := range resources {
+		if app, ok := doc["application-id"].(string); ok && applications[app] {
+			binaries.resources = append(binaries.resources, doc)
+		}
+	}
+	return &binaries, nil
+}
+
+// restoreImportedBinaryDocs inserts the binary documents kept from an
+// earlier import of the model. Documents which the new import has
+// already created are left alone.
+func (st *State) restoreImportedBinaryDocs(binaries *importedBinaryDocs) error {
+	for _, coll := range []struct {
+		name string
+		docs []bson.M
+	}{
+		{charmsC, binaries.charms},
+		{sequenceC, binaries.sequences},
+		{toolsmetadataC, binaries.tools},
+		{resourcesC, binaries.resources},
+	} {
+		existing, err := st.findAllDocs(coll.name, nil)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ids := make(map[interface{}]bool)
+		for _, doc := range existing {
+			ids[doc["_id"]] = true
+		}
+
+		var ops []txn.Op
+		for _, doc := range coll.docs {
+			if ids[doc["_id"]] {
+				continue
+			}
+			delete(doc, "txn-revno")
+			delete(doc, "txn-queue")
+			ops = append(ops, txn.Op{
+				C:      coll.name,
+				Id:     doc["_id"],
+				Assert: txn.DocMissing,
+				Insert: doc,
+			})
+		}
+		if len(ops) == 0 {
+			continue
+		}
+		if err := st.db().RunTransaction(ops); err != nil {
+			return errors.Annotatef(err, "restoring %s", coll.name)
+		}
+	}
+	return nil
+}
+
+// findAllDocs returns the raw documents of the model matching the
+// selector in the named collection.
+func (st *State) findAllDocs(name string, sel interface{}) ([]bson.M, error) {
+	coll, closer := st.db().GetCollection(name)
+	defer closer()
+
+	var docs []bson.M
+	if err := coll.Find(sel).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return docs, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/binarystorage"
+)
+
+func (s *MigrationImportSuite) TestResumeImportExisting(c *gc.C) {
+	out, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only models which are being imported can be replaced.
+	_, _, err = s.Controller.ResumeImport(out)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *MigrationImportSuite) TestResumeImportNew(c *gc.C) {
+	out, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	uuid := utils.MustNewUUID().String()
+	in := newModel(out, uuid, "new")
+	newModel, newSt, err := s.Controller.ResumeImport(in)
+	c.Assert(err, jc.ErrorIsNil)
+	defer newSt.Close()
+
+	c.Assert(newModel.UUID(), gc.Equals, uuid)
+	c.Assert(newModel.MigrationMode(), gc.Equals, state.MigrationModeImporting)
+}
+
+func (s *MigrationImportSuite) TestResumeImportKeepsBinaries(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	curl, _ := app.CharmURL()
+
+	out, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	uuid := utils.MustNewUUID().String()
+	in := newModel(out, uuid, "new")
+
+	// Import the model and upload some binaries into it, one of which
+	// isn't used by the model.
+	_, firstSt, err := s.Controller.Import(in)
+	c.Assert(err, jc.ErrorIsNil)
+	defer firstSt.Close()
+	state.AddTestingCharm(c, firstSt, "wordpress")
+	unused := state.AddTestingCharm(c, firstSt, "mysql")
+	tools, err := firstSt.ToolsStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	err = tools.Add(strings.NewReader("abc"), binarystorage.Metadata{
+		Version: "2.6.1-bionic-amd64",
+		Size:    3,
+		SHA256:  "abc-sha256",
+	})
+	tools.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	newModel, newSt, err := s.Controller.ResumeImport(in)
+	c.Assert(err, jc.ErrorIsNil)
+	defer newSt.Close()
+	c.Assert(newModel.MigrationMode(), gc.Equals, state.MigrationModeImporting)
+
+	ch, err := newSt.Charm(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ch.IsUploaded(), jc.IsTrue)
+	_, err = newSt.Charm(unused.URL())
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+
+	tools, err = newSt.ToolsStorage()
+	c.Assert(err, jc.ErrorIsNil)
+	defer tools.Close()
+	metadata, err := tools.Metadata("2.6.1-bionic-amd64")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(metadata.SHA256, gc.Equals, "abc-sha256")
+}
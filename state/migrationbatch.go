@@ -0,0 +1,510 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/migration"
+)
+
+// This file contains functionality for managing batches of model
+// migrations. A batch holds a queue of models to be migrated to the
+// same target controller. Migrations are started from the queue as
+// earlier migrations in the batch finish, so that no more than the
+// batch's concurrency limit are in progress at once.
+
+// MigrationBatch represents a set of model migrations to the same
+// target controller.
+type MigrationBatch interface {
+	// Id returns a unique identifier for the batch.
+	Id() string
+
+	// InitiatedBy returns the username of the user who created the
+	// batch.
+	InitiatedBy() string
+
+	// StartTime returns the time the batch was created.
+	StartTime() time.Time
+
+	// Concurrency returns the maximum number of the batch's
+	// migrations which may be in progress at once.
+	Concurrency() int
+
+	// TargetInfo returns the details required to connect to the
+	// target controller of the batch's migrations.
+	TargetInfo() (*migration.TargetInfo, error)
+
+	// Models returns the models in the batch, along with the progress
+	// of their migrations.
+	Models() ([]MigrationBatchModel, error)
+
+	// Refresh updates the contents of the MigrationBatch from the
+	// underlying state.
+	Refresh() error
+}
+
+// MigrationBatchModel describes a model in a migration batch and the
+// progress of its migration.
+type MigrationBatchModel struct {
+	UUID  string
+	Name  string
+	Owner string
+
+	// Queued is true if the model's migration is yet to be started.
+	Queued bool
+
+	// StartError holds the reason the model's migration couldn't be
+	// started, if that was the case.
+	StartError string
+
+	// MigrationId, Phase and StatusMessage describe the model's
+	// migration once it has been started.
+	MigrationId   string
+	Phase         migration.Phase
+	StatusMessage string
+}
+
+// migrationBatch is an implementation of MigrationBatch.
+type migrationBatch struct {
+	st  *State
+	doc migrationBatchDoc
+}
+
+// migrationBatchDoc holds the parameters and progress of a batch of
+// model migrations. These are written into migrationBatchesC.
+type migrationBatchDoc struct {
+	Id          string `bson:"_id"`
+	InitiatedBy string `bson:"initiated-by"`
+	StartTime   int64  `bson:"start-time"`
+
+	// Concurrency holds the maximum number of the batch's migrations
+	// which may be in progress at once.
+	Concurrency int `bson:"concurrency"`
+
+	// Active holds the number of the batch's migrations which are in
+	// progress.
+	Active int `bson:"active"`
+
+	// Models holds the models to be migrated, in the order their
+	// migrations are started.
+	Models []migrationBatchModelDoc `bson:"models"`
+
+	// Queued holds the UUIDs of the models whose migrations are yet
+	// to be started.
+	Queued []string `bson:"queued"`
+
+	// StartErrors holds, keyed by model UUID, the reasons for any
+	// migrations which couldn't be started.
+	StartErrors map[string]string `bson:"start-errors,omitempty"`
+
+	// The target controller fields are as for modelMigDoc.
+	TargetController string   `bson:"target-controller"`
+	TargetAddrs      []string `bson:"target-addrs"`
+	TargetCACert     string   `bson:"target-cacert"`
+	TargetAuthTag    string   `bson:"target-entity"`
+	TargetPassword   string   `bson:"target-password,omitempty"`
+	TargetMacaroons  string   `bson:"target-macaroons,omitempty"`
+}
+
+type migrationBatchModelDoc struct {
+	UUID  string `bson:"uuid"`
+	Name  string `bson:"name"`
+	Owner string `bson:"owner"`
+}
+
+// errMigrationBatchFull is returned when creating a migration for a
+// batch which already has as many migrations in progress as it allows.
+var errMigrationBatchFull = errors.New("migration batch is full")
+
+// errNotQueued is returned when creating a migration for a batch which
+// has no queued migration for the model.
+var errNotQueued = errors.New("model not queued in migration batch")
+
+// Id implements MigrationBatch.
+func (b *migrationBatch) Id() string {
+	return b.doc.Id
+}
+
+// InitiatedBy implements MigrationBatch.
+func (b *migrationBatch) InitiatedBy() string {
+	return b.doc.InitiatedBy
+}
+
+// StartTime implements MigrationBatch.
+func (b *migrationBatch) StartTime() time.Time {
+	return unixNanoToTime0(b.doc.StartTime)
+}
+
+// Concurrency implements MigrationBatch.
+func (b *migrationBatch) Concurrency() int {
+	return b.doc.Concurrency
+}
+
+// TargetInfo implements MigrationBatch.
+func (b *migrationBatch) TargetInfo() (*migration.TargetInfo, error) {
+	return makeTargetInfo(
+		b.doc.TargetController,
+		b.doc.TargetAddrs,
+		b.doc.TargetCACert,
+		b.doc.TargetAuthTag,
+		b.doc.TargetPassword,
+		b.doc.TargetMacaroons,
+	)
+}
+
+// Models implements MigrationBatch.
+func (b *migrationBatch) Models() ([]MigrationBatchModel, error) {
+	migColl, closer := b.st.db().GetCollection(migrationsC)
+	defer closer()
+	var migDocs []modelMigDoc
+	if err := migColl.Find(bson.D{{"batch-id", b.doc.Id}}).All(&migDocs); err != nil {
+		return nil, errors.Annotate(err, "reading migrations")
+	}
+	migIds := make(map[string]string)
+	ids := make([]string, len(migDocs))
+	for i, doc := range migDocs {
+		migIds[doc.ModelUUID] = doc.Id
+		ids[i] = doc.Id
+	}
+
+	statusColl, closer := b.st.db().GetCollection(migrationsStatusC)
+	defer closer()
+	var statusDocs []modelMigStatusDoc
+	if err := statusColl.Find(bson.D{{"_id", bson.D{{"$in", ids}}}}).All(&statusDocs); err != nil {
+		return nil, errors.Annotate(err, "reading migration statuses")
+	}
+	statuses := make(map[string]modelMigStatusDoc)
+	for _, doc := range statusDocs {
+		statuses[doc.Id] = doc
+	}
+
+	queued := make(map[string]bool)
+	for _, uuid := range b.doc.Queued {
+		queued[uuid] = true
+	}
+	models := make([]MigrationBatchModel, len(b.doc.Models))
+	for i, doc := range b.doc.Models {
+		model := MigrationBatchModel{
+			UUID:       doc.UUID,
+			Name:       doc.Name,
+			Owner:      doc.Owner,
+			Queued:     queued[doc.UUID],
+			StartError: b.doc.StartErrors[doc.UUID],
+		}
+		if id, ok := migIds[doc.UUID]; ok {
+			model.MigrationId = id
+			model.Phase, _ = migration.ParsePhase(statuses[id].Phase)
+			model.StatusMessage = statuses[id].StatusMessage
+		}
+		models[i] = model
+	}
+	return models, nil
+}
+
+// Refresh implements MigrationBatch.
+func (b *migrationBatch) Refresh() error {
+	doc, err := getMigrationBatchDoc(b.st, b.doc.Id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	b.doc = *doc
+	return nil
+}
+
+// MigrationBatchSpec holds the information required to create a
+// MigrationBatch.
+type MigrationBatchSpec struct {
+	InitiatedBy names.UserTag
+	TargetInfo  migration.TargetInfo
+
+	// ModelUUIDs holds the models to be migrated, in the order their
+	// migrations should be started.
+	ModelUUIDs []string
+
+	// Concurrency holds the maximum number of the migrations which
+	// may be in progress at once.
+	Concurrency int
+}
+
+// Validate returns an error if the MigrationBatchSpec contains bad
+// data. Nil is returned otherwise.
+func (spec *MigrationBatchSpec) Validate() error {
+	if !names.IsValidUser(spec.InitiatedBy.Id()) {
+		return errors.NotValidf("InitiatedBy")
+	}
+	if len(spec.ModelUUIDs) == 0 {
+		return errors.NotValidf("empty ModelUUIDs")
+	}
+	if spec.Concurrency < 1 {
+		return errors.NotValidf("Concurrency %d", spec.Concurrency)
+	}
+	return spec.TargetInfo.Validate()
+}
+
+// CreateMigrationBatch queues migrations for the specified models. The
+// migrations are started by StatePool.StartQueuedMigrations. It should
+// be called on the controller model's State.
+func (st *State) CreateMigrationBatch(spec MigrationBatchSpec) (MigrationBatch, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := checkTargetController(st, spec.TargetInfo.ControllerTag); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	models, closer := st.db().GetCollection(modelsC)
+	defer closer()
+	var modelDocs []migrationBatchModelDoc
+	seen := make(map[string]bool)
+	for _, uuid := range spec.ModelUUIDs {
+		if seen[uuid] {
+			continue
+		}
+		seen[uuid] = true
+		if uuid == st.ControllerModelUUID() {
+			return nil, errors.New("controllers can't be migrated")
+		}
+		var doc modelDoc
+		if err := models.FindId(uuid).One(&doc); err == mgo.ErrNotFound {
+			return nil, errors.NotFoundf("model %q", uuid)
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "reading model %q", uuid)
+		}
+		modelDocs = append(modelDocs, migrationBatchModelDoc{
+			UUID:  doc.UUID,
+			Name:  doc.Name,
+			Owner: doc.Owner,
+		})
+	}
+
+	macsJSON, err := macaroonsToJSON(spec.TargetInfo.Macaroons)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	seq, err := sequence(st, "migrationbatch")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc := migrationBatchDoc{
+		Id:               strconv.Itoa(seq),
+		InitiatedBy:      spec.InitiatedBy.Id(),
+		StartTime:        st.clock().Now().UnixNano(),
+		Concurrency:      spec.Concurrency,
+		Models:           modelDocs,
+		TargetController: spec.TargetInfo.ControllerTag.Id(),
+		TargetAddrs:      spec.TargetInfo.Addrs,
+		TargetCACert:     spec.TargetInfo.CACert,
+		TargetAuthTag:    spec.TargetInfo.AuthTag.String(),
+		TargetPassword:   spec.TargetInfo.Password,
+		TargetMacaroons:  macsJSON,
+	}
+	for _, model := range modelDocs {
+		doc.Queued = append(doc.Queued, model.UUID)
+	}
+	ops := []txn.Op{{
+		C:      migrationBatchesC,
+		Id:     doc.Id,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := st.db().RunTransaction(ops); err != nil {
+		return nil, errors.Annotate(err, "failed to create migration batch")
+	}
+	return st.MigrationBatch(doc.Id)
+}
+
+// MigrationBatch retrieves a specific MigrationBatch by its id.
+func (st *State) MigrationBatch(id string) (MigrationBatch, error) {
+	doc, err := getMigrationBatchDoc(st, id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &migrationBatch{st: st, doc: *doc}, nil
+}
+
+func getMigrationBatchDoc(st *State, id string) (*migrationBatchDoc, error) {
+	batches, closer := st.db().GetCollection(migrationBatchesC)
+	defer closer()
+	var doc migrationBatchDoc
+	if err := batches.FindId(id).One(&doc); err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("migration batch %q", id)
+	} else if err != nil {
+		return nil, errors.Annotate(err, "migration batch lookup failed")
+	}
+	return &doc, nil
+}
+
+// MigrationPrecheckFunc checks whether the model of st is likely to
+// be migrated successfully to the target controller, updating
+// information in targetInfo as needed.
+type MigrationPrecheckFunc func(st *State, targetInfo *migration.TargetInfo) error
+
+// StartQueuedMigrations starts the queued migrations of a batch until
+// the batch has as many migrations in progress as it allows. Each
+// model's migration is only started once precheck passes for it.
+// Models whose migrations can never be started are recorded against
+// the batch, and taken out of the queue. Models whose migrations can't
+// be started yet are left queued; an error is returned if that leaves
+// the batch with nothing in progress, as there's then no migration
+// whose end will start them.
+//
+// It should be called when a batch is created, and whenever one of
+// its migrations ends.
+func (p *StatePool) StartQueuedMigrations(batchId string, precheck MigrationPrecheckFunc) error {
+	st := p.SystemState()
+	skipped := make(map[string]bool)
+	for {
+		doc, err := getMigrationBatchDoc(st, batchId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if doc.Active >= doc.Concurrency {
+			return nil
+		}
+		var modelUUID string
+		for _, uuid := range doc.Queued {
+			if !skipped[uuid] {
+				modelUUID = uuid
+				break
+			}
+		}
+		if modelUUID == "" {
+			if len(doc.Queued) > 0 && doc.Active == 0 {
+				return errors.Errorf("%d queued migrations can't be started yet", len(doc.Queued))
+			}
+			return nil
+		}
+		err = startBatchMigration(p, doc, modelUUID, precheck)
+		switch errors.Cause(err) {
+		case nil, errNotQueued:
+			continue
+		case errMigrationBatchFull:
+			return nil
+		}
+		if _, ok := errors.Cause(err).(*startMigrationError); !ok {
+			logger.Warningf("cannot start migration of model %q in batch %s yet: %v", modelUUID, batchId, err)
+			skipped[modelUUID] = true
+			continue
+		}
+		logger.Warningf("cannot start migration of model %q in batch %s: %v", modelUUID, batchId, err)
+		ops := []txn.Op{{
+			C:      migrationBatchesC,
+			Id:     batchId,
+			Assert: bson.D{{"queued", modelUUID}},
+			Update: bson.D{
+				{"$pull", bson.D{{"queued", modelUUID}}},
+				{"$set", bson.D{{"start-errors." + modelUUID, err.Error()}}},
+			},
+		}}
+		if err := st.db().RunTransaction(ops); err != nil && err != txn.ErrAborted {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// startMigrationError is returned by startBatchMigration when the
+// model's migration can never be started, so there's no point leaving
+// it queued.
+type startMigrationError struct {
+	cause error
+}
+
+// Error is part of the error interface.
+func (e *startMigrationError) Error() string {
+	return e.cause.Error()
+}
+
+// startBatchMigration starts the migration of a model queued in a
+// migration batch, once the migration's prechecks have passed.
+func startBatchMigration(pool *StatePool, doc *migrationBatchDoc, modelUUID string, precheck MigrationPrecheckFunc) error {
+	batch := &migrationBatch{st: pool.SystemState(), doc: *doc}
+	targetInfo, err := batch.TargetInfo()
+	if err != nil {
+		return &startMigrationError{err}
+	}
+	modelSt, err := pool.Get(modelUUID)
+	if errors.IsNotFound(err) {
+		return &startMigrationError{err}
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	defer modelSt.Release()
+
+	model, err := modelSt.Model()
+	if errors.IsNotFound(err) {
+		return &startMigrationError{err}
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if model.Life() != Alive {
+		return &startMigrationError{errors.New("model is not alive")}
+	}
+	if err := precheck(modelSt.State, targetInfo); err != nil {
+		return &startMigrationError{err}
+	}
+
+	_, err = modelSt.CreateMigration(MigrationSpec{
+		InitiatedBy: names.NewUserTag(doc.InitiatedBy),
+		TargetInfo:  *targetInfo,
+		BatchId:     doc.Id,
+	})
+	if errors.IsNotValid(err) {
+		return &startMigrationError{err}
+	}
+	return errors.Trace(err)
+}
+
+// migrationBatchStartOp returns the operation adding a model's
+// migration to those in progress for the batch, or an error if the
+// migration can't be started yet.
+func migrationBatchStartOp(st *State, batchId, modelUUID string) (txn.Op, error) {
+	doc, err := getMigrationBatchDoc(st, batchId)
+	if err != nil {
+		return txn.Op{}, errors.Trace(err)
+	}
+	queued := false
+	for _, uuid := range doc.Queued {
+		if uuid == modelUUID {
+			queued = true
+			break
+		}
+	}
+	if !queued {
+		return txn.Op{}, errNotQueued
+	}
+	if doc.Active >= doc.Concurrency {
+		return txn.Op{}, errMigrationBatchFull
+	}
+	return txn.Op{
+		C:  migrationBatchesC,
+		Id: batchId,
+		Assert: bson.D{
+			{"active", doc.Active},
+			{"queued", modelUUID},
+		},
+		Update: bson.D{
+			{"$inc", bson.D{{"active", 1}}},
+			{"$pull", bson.D{{"queued", modelUUID}}},
+		},
+	}, nil
+}
+
+// migrationBatchEndOp returns the operation removing a migration from
+// those in progress for its batch.
+func migrationBatchEndOp(batchId string) txn.Op {
+	return txn.Op{
+		C:      migrationBatchesC,
+		Id:     batchId,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$inc", bson.D{{"active", -1}}}},
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/state"
+)
+
+type MigrationBatchSuite struct {
+	ConnSuite
+	models  []*state.State
+	stdSpec state.MigrationBatchSpec
+}
+
+var _ = gc.Suite(new(MigrationBatchSuite))
+
+func (s *MigrationBatchSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+
+	s.models = nil
+	var uuids []string
+	for i := 0; i < 3; i++ {
+		st := s.Factory.MakeModel(c, nil)
+		s.AddCleanup(func(*gc.C) { st.Close() })
+		s.models = append(s.models, st)
+		uuids = append(uuids, st.ModelUUID())
+	}
+
+	s.stdSpec = state.MigrationBatchSpec{
+		InitiatedBy: names.NewUserTag("admin"),
+		TargetInfo: migration.TargetInfo{
+			ControllerTag: names.NewControllerTag(utils.MustNewUUID().String()),
+			Addrs:         []string{"1.2.3.4:5555"},
+			CACert:        "cert",
+			AuthTag:       names.NewUserTag("user"),
+			Password:      "password",
+		},
+		ModelUUIDs:  uuids,
+		Concurrency: 2,
+	}
+}
+
+func (s *MigrationBatchSuite) passPrecheck(*state.State, *migration.TargetInfo) error {
+	return nil
+}
+
+func (s *MigrationBatchSuite) TestCreate(c *gc.C) {
+	batch, err := s.State.CreateMigrationBatch(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(batch.Id(), gc.Equals, "1")
+	c.Check(batch.InitiatedBy(), gc.Equals, "admin")
+	c.Check(batch.StartTime(), gc.Equals, s.Clock.Now())
+	c.Check(batch.Concurrency(), gc.Equals, 2)
+	info, err := batch.TargetInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*info, jc.DeepEquals, s.stdSpec.TargetInfo)
+
+	// The migrations are queued, but not started.
+	models, err := batch.Models()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(models, gc.HasLen, 3)
+	for i, model := range models {
+		dbModel, err := s.models[i].Model()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(model.UUID, gc.Equals, dbModel.UUID())
+		c.Check(model.Name, gc.Equals, dbModel.Name())
+		c.Check(model.Owner, gc.Equals, dbModel.Owner().Id())
+		c.Check(model.Queued, jc.IsTrue)
+		c.Check(model.StartError, gc.Equals, "")
+		assertMigrationNotActive(c, s.models[i])
+	}
+}
+
+func (s *MigrationBatchSuite) TestStartQueuedMigrations(c *gc.C) {
+	batch, err := s.State.CreateMigrationBatch(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var prechecked []string
+	err = s.StatePool.StartQueuedMigrations(batch.Id(), func(st *state.State, info *migration.TargetInfo) error {
+		c.Check(*info, jc.DeepEquals, s.stdSpec.TargetInfo)
+		prechecked = append(prechecked, st.ModelUUID())
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only as many migrations as the batch allows are started, each
+	// once its prechecks have passed.
+	c.Check(prechecked, jc.DeepEquals, []string{s.models[0].ModelUUID(), s.models[1].ModelUUID()})
+	c.Assert(batch.Refresh(), jc.ErrorIsNil)
+	models, err := batch.Models()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(models, gc.HasLen, 3)
+	c.Check(models[0].Queued, jc.IsFalse)
+	c.Check(models[0].Phase, gc.Equals, migration.QUIESCE)
+	c.Check(models[1].Queued, jc.IsFalse)
+	c.Check(models[1].Phase, gc.Equals, migration.QUIESCE)
+	c.Check(models[2].Queued, jc.IsTrue)
+	c.Check(models[2].MigrationId, gc.Equals, "")
+
+	mig, err := s.models[0].LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(models[0].MigrationId, gc.Equals, mig.Id())
+	c.Check(mig.InitiatedBy(), gc.Equals, "admin")
+	c.Check(mig.BatchId(), gc.Equals, batch.Id())
+	assertMigrationNotActive(c, s.models[2])
+}
+
+func (s *MigrationBatchSuite) TestQueuedMigrationStartedAfterMigrationEnds(c *gc.C) {
+	batch, err := s.State.CreateMigrationBatch(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.StatePool.StartQueuedMigrations(batch.Id(), s.passPrecheck)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Nothing can be started while the batch is full.
+	err = s.StatePool.StartQueuedMigrations(batch.Id(), s.passPrecheck)
+	c.Assert(err, jc.ErrorIsNil)
+	assertMigrationNotActive(c, s.models[2])
+
+	mig, err := s.models[1].LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.ABORT), jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.ABORTDONE), jc.ErrorIsNil)
+	assertMigrationNotActive(c, s.models[2])
+
+	err = s.StatePool.StartQueuedMigrations(batch.Id(), s.passPrecheck)
+	c.Assert(err, jc.ErrorIsNil)
+	assertMigrationActive(c, s.models[2])
+
+	c.Assert(batch.Refresh(), jc.ErrorIsNil)
+	models, err := batch.Models()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(models[1].Phase, gc.Equals, migration.ABORTDONE)
+	c.Check(models[2].Queued, jc.IsFalse)
+	c.Check(models[2].Phase, gc.Equals, migration.QUIESCE)
+}
+
+func (s *MigrationBatchSuite) TestStartError(c *gc.C) {
+	model, err := s.models[0].Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Destroy(state.DestroyModelParams{}), jc.ErrorIsNil)
+
+	batch, err := s.State.CreateMigrationBatch(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.StatePool.StartQueuedMigrations(batch.Id(), s.passPrecheck)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The model which can't be migrated makes way for the next.
+	c.Assert(batch.Refresh(), jc.ErrorIsNil)
+	models, err := batch.Models()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(models[0].Queued, jc.IsFalse)
+	c.Check(models[0].StartError, gc.Equals, "model is not alive")
+	c.Check(models[0].MigrationId, gc.Equals, "")
+	c.Check(models[1].Phase, gc.Equals, migration.QUIESCE)
+	c.Check(models[2].Phase, gc.Equals, migration.QUIESCE)
+}
+
+func (s *MigrationBatchSuite) TestPrecheckFailure(c *gc.C) {
+	batch, err := s.State.CreateMigrationBatch(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	failing := s.models[1].ModelUUID()
+	err = s.StatePool.StartQueuedMigrations(batch.Id(), func(st *state.State, _ *migration.TargetInfo) error {
+		if st.ModelUUID() == failing {
+			return errors.New("source prechecks failed: boom")
+		}
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The model failing its prechecks isn't migrated, and makes way
+	// for the next.
+	assertMigrationNotActive(c, s.models[1])
+	c.Assert(batch.Refresh(), jc.ErrorIsNil)
+	models, err := batch.Models()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(models[0].Phase, gc.Equals, migration.QUIESCE)
+	c.Check(models[1].Queued, jc.IsFalse)
+	c.Check(models[1].StartError, gc.Equals, "source prechecks failed: boom")
+	c.Check(models[1].MigrationId, gc.Equals, "")
+	c.Check(models[2].Phase, gc.Equals, migration.QUIESCE)
+}
+
+func (s *MigrationBatchSuite) TestStartRetried(c *gc.C) {
+	otherMig, err := s.models[0].CreateMigration(state.MigrationSpec{
+		InitiatedBy: names.NewUserTag("admin"),
+		TargetInfo:  s.stdSpec.TargetInfo,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	batch, err := s.State.CreateMigrationBatch(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.StatePool.StartQueuedMigrations(batch.Id(), s.passPrecheck)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The model whose migration can't be started yet stays queued,
+	// without holding up the others.
+	c.Assert(batch.Refresh(), jc.ErrorIsNil)
+	models, err := batch.Models()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(models[0].Queued, jc.IsTrue)
+	c.Check(models[0].StartError, gc.Equals, "")
+	c.Check(models[1].Phase, gc.Equals, migration.QUIESCE)
+	c.Check(models[2].Phase, gc.Equals, migration.QUIESCE)
+
+	// Once the rest of the batch has finished, the queued migration
+	// is reported as stalled.
+	for _, st := range s.models[1:] {
+		mig, err := st.LatestMigration()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(mig.SetPhase(migration.ABORT), jc.ErrorIsNil)
+		c.Assert(mig.SetPhase(migration.ABORTDONE), jc.ErrorIsNil)
+	}
+	err = s.StatePool.StartQueuedMigrations(batch.Id(), s.passPrecheck)
+	c.Assert(err, gc.ErrorMatches, "1 queued migrations can't be started yet")
+
+	// It's started once possible.
+	c.Assert(otherMig.SetPhase(migration.ABORT), jc.ErrorIsNil)
+	c.Assert(otherMig.SetPhase(migration.ABORTDONE), jc.ErrorIsNil)
+	err = s.StatePool.StartQueuedMigrations(batch.Id(), s.passPrecheck)
+	c.Assert(err, jc.ErrorIsNil)
+	assertMigrationActive(c, s.models[0])
+	c.Assert(batch.Refresh(), jc.ErrorIsNil)
+	models, err = batch.Models()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(models[0].Queued, jc.IsFalse)
+	c.Check(models[0].Phase, gc.Equals, migration.QUIESCE)
+}
+
+func (s *MigrationBatchSuite) TestSpecValidation(c *gc.C) {
+	spec := s.stdSpec
+	spec.Concurrency = 0
+	_, err := s.State.CreateMigrationBatch(spec)
+	c.Check(err, gc.ErrorMatches, "Concurrency 0 not valid")
+
+	spec = s.stdSpec
+	spec.ModelUUIDs = nil
+	_, err = s.State.CreateMigrationBatch(spec)
+	c.Check(err, gc.ErrorMatches, "empty ModelUUIDs not valid")
+
+	spec = s.stdSpec
+	spec.ModelUUIDs = []string{s.State.ModelUUID()}
+	_, err = s.State.CreateMigrationBatch(spec)
+	c.Check(err, gc.ErrorMatches, "controllers can't be migrated")
+
+	spec = s.stdSpec
+	spec.ModelUUIDs = []string{utils.MustNewUUID().String()}
+	_, err = s.State.CreateMigrationBatch(spec)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MigrationBatchSuite) TestMigrationBatchNotFound(c *gc.C) {
+	_, err := s.State.MigrationBatch("42")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	c.Check(err, gc.ErrorMatches, `migration batch "42" not found`)
+}
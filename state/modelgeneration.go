@@ -18,6 +18,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/settings"
 	"github.com/juju/juju/mongo/utils"
 )
@@ -41,6 +42,13 @@ func (c *itemChange) coreChange() settings.ItemChange {
 	}
 }
 
+// branchResourceDoc identifies a pending resource revision that is
+// activated for units tracking a branch.
+type branchResourceDoc struct {
+	PendingID string `bson:"pending-id"`
+	Revision  int    `bson:"revision"`
+}
+
 // generationDoc represents the state of a model generation in MongoDB.
 type generationDoc struct {
 	DocId    string `bson:"_id"`
@@ -70,7 +78,21 @@ type generationDoc struct {
 	// Config is all changes made to charm configuration under this branch.
 	Config map[string][]itemChange `bson:"charm-config"`
 
-	// TODO (manadart 2019-04-02): CharmURLs, Resources.
+	// CharmURLs is the charm URL that units tracking this branch are
+	// upgraded to, keyed by application name.
+	CharmURLs map[string]string `bson:"charm-urls,omitempty"`
+
+	// Resources is the pending resources activated for units tracking this
+	// branch, keyed by application name, then by escaped resource name.
+	Resources map[string]map[string]branchResourceDoc `bson:"resources,omitempty"`
+
+	// Constraints is the application constraints set under this branch,
+	// keyed by application name.
+	Constraints map[string]constraintsDoc `bson:"constraints,omitempty"`
+
+	// Rollouts is the progressive assignment of units to this branch in
+	// waves, keyed by application name.
+	Rollouts map[string]rolloutDoc `bson:"rollouts,omitempty"`
 
 	// Created is a Unix timestamp indicating when this generation was created.
 	Created int64 `bson:"created"`
@@ -78,12 +100,18 @@ type generationDoc struct {
 	// CreatedBy is the user who created this generation.
 	CreatedBy string `bson:"created-by"`
 
-	// Completed, if set, indicates when this generation was completed and
-	// effectively became the current model generation.
+	// Completed, if set, indicates when this generation was completed,
+	// either by being committed and effectively becoming the current model
+	// generation, or by being aborted.
 	Completed int64 `bson:"completed"`
 
-	// CompletedBy is the user who committed this generation to the model.
+	// CompletedBy is the user who committed or aborted this generation.
 	CompletedBy string `bson:"completed-by"`
+
+	// CharmRefsReleased indicates that the charm references held by this
+	// generation were passed to the applications or released when it was
+	// committed or aborted.
+	CharmRefsReleased bool `bson:"charm-refs-released,omitempty"`
 }
 
 // Generation represents the state of a model generation.
@@ -128,6 +156,47 @@ func (g *Generation) Config() map[string]settings.ItemChanges {
 	return changes
 }
 
+// CharmURLs returns the charm URLs that applications are upgraded to under
+// the generation, keyed by application name.
+func (g *Generation) CharmURLs() map[string]string {
+	urls := make(map[string]string, len(g.doc.CharmURLs))
+	for appName, curl := range g.doc.CharmURLs {
+		urls[appName] = curl
+	}
+	return urls
+}
+
+// ResourceRevisions returns the revisions of the resources activated under
+// the generation, keyed by application name then resource name.
+func (g *Generation) ResourceRevisions() map[string]map[string]int {
+	revisions := make(map[string]map[string]int, len(g.doc.Resources))
+	for appName, appResources := range g.doc.Resources {
+		appRevisions := make(map[string]int, len(appResources))
+		for name, res := range appResources {
+			appRevisions[utils.UnescapeKey(name)] = res.Revision
+		}
+		revisions[appName] = appRevisions
+	}
+	return revisions
+}
+
+// resourcePendingID returns the ID of the pending resource with the input
+// name that is activated for the application under the generation.
+func (g *Generation) resourcePendingID(appName, name string) (string, bool) {
+	res, ok := g.doc.Resources[appName][utils.EscapeKey(name)]
+	return res.PendingID, ok
+}
+
+// Constraints returns the application constraints set under the generation,
+// keyed by application name.
+func (g *Generation) Constraints() map[string]constraints.Value {
+	cons := make(map[string]constraints.Value, len(g.doc.Constraints))
+	for appName, doc := range g.doc.Constraints {
+		cons[appName] = doc.value()
+	}
+	return cons
+}
+
 // Created returns the Unix timestamp at generation creation.
 func (g *Generation) Created() int64 {
 	return g.doc.Created
@@ -144,7 +213,7 @@ func (g *Generation) IsCompleted() bool {
 	return g.doc.Completed > 0
 }
 
-// CompletedBy returns the user who committed the generation.
+// CompletedBy returns the user who committed or aborted the generation.
 func (g *Generation) CompletedBy() string {
 	return g.doc.CompletedBy
 }
@@ -353,12 +422,142 @@ func (g *Generation) UpdateCharmConfig(appName string, master *Settings, validCh
 	return errors.Trace(g.st.db().Run(buildTxn))
 }
 
+// UpdateCharm records that units of the input application tracking this
+// branch are to be upgraded to the input charm, with the input pending
+// resources activated for them.
+// The branch holds a reference to the charm and its application settings
+// so that tracking units can be upgraded without affecting other units.
+// The charm is assumed to have been validated for use by the application.
+func (g *Generation) UpdateCharm(appName string, ch *Charm, resourceIDs map[string]string) error {
+	resources, err := g.branchResources(appName, resourceIDs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	curl := ch.URL()
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		app, err := g.st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		ops := []txn.Op{
+			{
+				C:  generationsC,
+				Id: g.doc.DocId,
+				Assert: bson.D{{"$and", []bson.D{
+					{{"completed", 0}},
+					{{"txn-revno", g.doc.TxnRevno}},
+				}}},
+				Update: bson.D{
+					{"$set", bson.D{
+						{"charm-urls." + appName, curl.String()},
+						{"resources." + appName, resources},
+					}},
+				},
+			},
+		}
+
+		if current := g.doc.CharmURLs[appName]; current != curl.String() {
+			charmOps, err := app.branchCharmOps(ch)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, charmOps...)
+
+			// Release the reference held for the charm previously
+			// set under this branch.
+			if current != "" {
+				currentURL, err := charm.ParseURL(current)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				decOps, err := g.releaseCharmOps(appName, currentURL)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				ops = append(ops, decOps...)
+			}
+		}
+
+		// Units watch the application for charm changes,
+		// so ensure that those tracking the branch notice this one.
+		return append(ops, incCharmModifiedVersionOps(app.doc.DocID)...), nil
+	}
+
+	return errors.Trace(g.st.db().Run(buildTxn))
+}
+
+// branchResources returns the persistable representation of the input
+// pending resources for the input application.
+func (g *Generation) branchResources(appName string, resourceIDs map[string]string) (map[string]branchResourceDoc, error) {
+	docs := make(map[string]branchResourceDoc, len(resourceIDs))
+	if len(resourceIDs) == 0 {
+		return docs, nil
+	}
+	resources, err := g.st.Resources()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for name, pendingID := range resourceIDs {
+		res, err := resources.GetPendingResource(appName, name, pendingID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		docs[utils.EscapeKey(name)] = branchResourceDoc{
+			PendingID: pendingID,
+			Revision:  res.Revision,
+		}
+	}
+	return docs, nil
+}
+
+// UpdateConstraints sets the input application's constraints under this
+// branch. The constraints are assumed to have been validated.
+func (g *Generation) UpdateConstraints(appName string, cons constraints.Value) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{
+			{
+				C:      generationsC,
+				Id:     g.doc.DocId,
+				Assert: bson.D{{"completed", 0}},
+				Update: bson.D{
+					{"$set", bson.D{{"constraints." + appName, newConstraintsDoc(cons)}}},
+				},
+			},
+		}, nil
+	}
+
+	return errors.Trace(g.st.db().Run(buildTxn))
+}
+
 // Commit marks the generation as completed and assigns it the next value from
 // the generation sequence. The new generation ID is returned.
+// Charm upgrades, resources and constraints set under the branch are applied
+// to their applications in the same transaction, and the charm references
+// held by the branch are passed to the applications or released.
 func (g *Generation) Commit(userName string) (int, error) {
 	var newGenId int
+	var aborted bool
 
 	buildTxn := func(attempt int) ([]txn.Op, error) {
+		aborted = false
 		if attempt > 0 {
 			if err := g.Refresh(); err != nil {
 				return nil, errors.Trace(err)
@@ -389,12 +588,21 @@ func (g *Generation) Commit(userName string) (int, error) {
 		// If assigned is empty, indicating no changes under this branch,
 		// then the generation ID in not incremented.
 		// This effectively means the generation is aborted, not committed.
-		if len(assigned) > 0 {
+		var appOps []txn.Op
+		aborted = len(assigned) == 0
+		if !aborted {
+			// Apply the charm, resource and constraint changes made
+			// under the branch to the applications.
+			if appOps, err = g.commitApplicationOps(); err != nil {
+				return nil, errors.Trace(err)
+			}
 			id, err := sequenceWithMin(g.st, "generation", 1)
 			if err != nil {
 				return nil, errors.Trace(err)
 			}
 			newGenId = id
+		} else if appOps, err = g.abortCharmOps(); err != nil {
+			return nil, errors.Trace(err)
 		}
 
 		// As a proxy for checking that the generation has not changed,
@@ -411,20 +619,239 @@ func (g *Generation) Commit(userName string) (int, error) {
 						{"completed", now.Unix()},
 						{"completed-by", userName},
 						{"generation-id", newGenId},
+						{"charm-refs-released", true},
 					}},
 				},
 			},
 		}
-		return ops, nil
+		return append(ops, appOps...), nil
 	}
 
 	if err := g.st.db().Run(buildTxn); err != nil {
 		return 0, errors.Trace(err)
 	}
+	if aborted {
+		return 0, errors.Trace(g.removePendingResources())
+	}
 	return newGenId, nil
 }
 
-// TODO (manadart 2019-03-19): Implement Abort().
+// commitApplicationOps returns the operations that apply the charm upgrades,
+// resources and constraints set under this branch to the applications.
+// The branch's references to a charm are passed to the application being
+// upgraded to it, or released if the application no longer needs them.
+func (g *Generation) commitApplicationOps() ([]txn.Op, error) {
+	var ops []txn.Op
+	for appName, curlStr := range g.doc.CharmURLs {
+		curl, err := charm.ParseURL(curlStr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		app, err := g.st.Application(appName)
+		if errors.IsNotFound(err) {
+			decOps, err := g.releaseCharmOps(appName, curl)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, decOps...)
+			continue
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		resourceIDs := g.resourcePendingIDs(appName)
+
+		if app.doc.CharmURL.String() == curlStr {
+			// The application already holds its own references to the charm.
+			decOps, err := g.releaseCharmOps(appName, curl)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, decOps...)
+			if len(resourceIDs) > 0 {
+				resOps, err := app.resolveResourceOps(resourceIDs)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				ops = append(ops, resOps...)
+			}
+			continue
+		}
+
+		ch, err := g.st.Charm(curl)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, txn.Op{
+			C:  applicationsC,
+			Id: app.doc.DocID,
+			Assert: append(notDeadDoc, bson.DocElem{
+				"charmmodifiedversion", app.doc.CharmModifiedVersion,
+			}),
+		})
+		charmOps, err := app.changeCharmOps(
+			ch, app.doc.Channel, nil, app.doc.ForceCharm, resourceIDs, nil, true,
+		)
+		if err != nil {
+			return nil, errors.Annotatef(err, "upgrading application %q to charm %q", appName, curlStr)
+		}
+		ops = append(ops, charmOps...)
+	}
+
+	for appName, doc := range g.doc.Constraints {
+		app, err := g.st.Application(appName)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops,
+			txn.Op{
+				C:      applicationsC,
+				Id:     app.doc.DocID,
+				Assert: notDeadDoc,
+			},
+			setConstraintsOp(app.globalKey(), doc.value()),
+		)
+	}
+	return ops, nil
+}
+
+// resourcePendingIDs returns the IDs of the pending resources activated
+// for the application under the generation, keyed by resource name.
+func (g *Generation) resourcePendingIDs(appName string) map[string]string {
+	pendingIDs := make(map[string]string, len(g.doc.Resources[appName]))
+	for name, res := range g.doc.Resources[appName] {
+		pendingIDs[utils.UnescapeKey(name)] = res.PendingID
+	}
+	return pendingIDs
+}
+
+// releaseCharmOps returns the operations that release the branch's
+// references to the input charm for the application.
+func (g *Generation) releaseCharmOps(appName string, curl *charm.URL) ([]txn.Op, error) {
+	op := &ForcedOperation{Force: true}
+	decOps, err := appCharmDecRefOps(g.st, appName, curl, true, op)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(op.Errors) != 0 {
+		logger.Errorf("could not remove branch charm references for %v: %v", curl, op.Errors)
+	}
+	return decOps, nil
+}
+
+// Abort marks the generation as completed without assigning it a generation
+// ID, so that none of the changes made under it are applied to the model.
+// Units assigned to the branch revert to tracking the master generation.
+// The charm references held by the branch are released, as are any pending
+// resources that were to be activated for units tracking it.
+func (g *Generation) Abort(userName string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if g.IsCompleted() {
+			if g.GenerationId() == 0 {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return nil, errors.New("branch was already committed")
+		}
+		now, err := g.st.ControllerTimestamp()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		ops := []txn.Op{
+			{
+				C:      generationsC,
+				Id:     g.doc.DocId,
+				Assert: bson.D{{"txn-revno", g.doc.TxnRevno}},
+				Update: bson.D{
+					{"$set", bson.D{
+						{"completed", now.Unix()},
+						{"completed-by", userName},
+						{"generation-id", 0},
+						{"charm-refs-released", true},
+					}},
+				},
+			},
+		}
+
+		charmOps, err := g.abortCharmOps()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, charmOps...), nil
+	}
+
+	if err := g.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(g.removePendingResources())
+}
+
+// abortCharmOps returns transaction operations that release the charm
+// references held by this branch. Applications with a charm upgrade under
+// the branch have their charm modified version incremented, so that units
+// that were tracking the branch revert to the application's charm.
+func (g *Generation) abortCharmOps() ([]txn.Op, error) {
+	ops, err := g.releaseAllCharmOps()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for appName := range g.doc.CharmURLs {
+		app, err := g.st.Application(appName)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, incCharmModifiedVersionOps(app.doc.DocID)...)
+	}
+	return ops, nil
+}
+
+// releaseAllCharmOps returns transaction operations that release all of
+// the charm references held by this branch.
+func (g *Generation) releaseAllCharmOps() ([]txn.Op, error) {
+	var ops []txn.Op
+	for appName, curlStr := range g.doc.CharmURLs {
+		curl, err := charm.ParseURL(curlStr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		decOps, err := g.releaseCharmOps(appName, curl)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, decOps...)
+	}
+	return ops, nil
+}
+
+// removePendingResources removes the pending resources that were to be
+// activated for units tracking this branch.
+func (g *Generation) removePendingResources() error {
+	if len(g.doc.Resources) == 0 {
+		return nil
+	}
+	resources, err := g.st.Resources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for appName := range g.doc.Resources {
+		pendingIDs := g.resourcePendingIDs(appName)
+		if err := resources.RemovePendingAppResources(appName, pendingIDs); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
 
 // CheckNotComplete returns an error if this
 // generation was committed or aborted.
@@ -569,6 +996,102 @@ func (st *State) getBranchDoc(name string) (*generationDoc, error) {
 	}
 }
 
+// PruneBranches removes branches that were committed or aborted
+// more than <maxAge> ago. A zero maxAge disables pruning.
+// Charm references still held by a pruned branch are released.
+func PruneBranches(st *State, maxAge time.Duration) error {
+	if maxAge <= 0 {
+		return nil
+	}
+	now, err := st.ControllerTimestamp()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	col, closer := st.db().GetCollection(generationsC)
+	defer closer()
+
+	var docs []generationDoc
+	query := bson.D{{"completed", bson.D{
+		{"$gt", 0},
+		{"$lt", now.Add(-maxAge).Unix()},
+	}}}
+	fields := bson.D{{"_id", 1}, {"charm-urls", 1}, {"charm-refs-released", 1}}
+	if err := col.Find(query).Select(fields).All(&docs); err != nil {
+		return errors.Annotate(err, "retrieving completed branches")
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+
+	var ops []txn.Op
+	for _, doc := range docs {
+		removeOp := txn.Op{
+			C:      generationsC,
+			Id:     doc.DocId,
+			Assert: bson.D{{"completed", bson.D{{"$gt", 0}}}},
+			Remove: true,
+		}
+		if doc.CharmRefsReleased || len(doc.CharmURLs) == 0 {
+			ops = append(ops, removeOp)
+			continue
+		}
+
+		// Branches holding charm references are removed one at a time,
+		// so that references to the same charm are released in turn.
+		releaseOps, err := newGeneration(st, &doc).releaseAllCharmOps()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := st.db().RunTransaction(append([]txn.Op{removeOp}, releaseOps...)); err != nil {
+			return errors.Annotatef(err, "removing completed branch %q", doc.DocId)
+		}
+	}
+	if len(ops) > 0 {
+		if err := st.db().RunTransaction(ops); err != nil {
+			return errors.Annotate(err, "removing completed branches")
+		}
+	}
+	logger.Debugf("pruned %d completed branches", len(docs))
+	return nil
+}
+
+// trackedBranch returns the in-flight branch that the unit is tracking.
+// Nil is returned if the unit is not tracking a branch, which means that it
+// is tracking the master generation.
+func (u *Unit) trackedBranch() (*Generation, error) {
+	col, closer := u.st.db().GetCollection(generationsC)
+	defer closer()
+
+	var doc generationDoc
+	err := col.Find(bson.D{
+		{"completed", 0},
+		{"assigned-units." + u.doc.Application, u.doc.Name},
+	}).One(&doc)
+
+	switch err {
+	case nil:
+		return newGeneration(u.st, &doc), nil
+	case mgo.ErrNotFound:
+		return nil, nil
+	default:
+		return nil, errors.Annotatef(err, "retrieving branch for unit %q", u.doc.Name)
+	}
+}
+
+// branchResourcePendingID returns the ID of the pending resource with the
+// input name that is activated for the unit by the branch it is tracking.
+// False is returned if the unit is not tracking a branch that changes
+// the resource.
+func (u *Unit) branchResourcePendingID(name string) (string, bool, error) {
+	branch, err := u.trackedBranch()
+	if err != nil || branch == nil {
+		return "", false, errors.Trace(err)
+	}
+	pendingID, ok := branch.resourcePendingID(u.doc.Application, name)
+	return pendingID, ok, nil
+}
+
 func newGeneration(st *State, doc *generationDoc) *Generation {
 	return &Generation{
 		st:  st,
@@ -12,7 +12,9 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
 
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/settings"
+	"github.com/juju/juju/resource/resourcetesting"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
 )
@@ -208,7 +210,195 @@ func (s *generationSuite) TestCommitNoChangesEffectivelyAborted(c *gc.C) {
 	c.Check(gen.CompletedBy(), gc.Equals, branchCommitter)
 }
 
-// TODO (manadart 2019-03-21): Tests for abort.
+func (s *generationSuite) TestCommitAppliesBranchCharmAndConstraints(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+	c.Assert(gen.AssignUnit("riak/0"), jc.ErrorIsNil)
+
+	riak, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	oldURL, _ := riak.CharmURL()
+
+	newCh := s.AddConfigCharm(c, "riak", stringConfig, 666)
+	c.Assert(riak.SetBranchCharm(newBranchName, state.SetCharmConfig{Charm: newCh}), jc.ErrorIsNil)
+	c.Assert(riak.SetBranchConstraints(newBranchName, constraints.MustParse("mem=4G")), jc.ErrorIsNil)
+
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	_, err = gen.Commit(branchCommitter)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(riak.Refresh(), jc.ErrorIsNil)
+	curl, _ := riak.CharmURL()
+	c.Check(curl, gc.DeepEquals, newCh.URL())
+
+	cons, err := riak.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*cons.Mem, gc.Equals, uint64(4096))
+
+	// The branch reference to the new charm is passed to the application,
+	// and the references to the old charm are released.
+	count, err := state.ApplicationSettingsRefCount(s.State, "riak", newCh.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(count, gc.Equals, 1)
+	_, err = state.ApplicationSettingsRefCount(s.State, "riak", oldURL)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *generationSuite) TestCommitResolvesBranchResources(c *gc.C) {
+	s.setupTestingClock(c)
+	ch := s.AddTestingCharm(c, "starsay")
+	app := s.AddTestingApplication(c, "starsay", ch)
+	_, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	gen := s.addBranch(c)
+	c.Assert(gen.AssignUnit("starsay/0"), jc.ErrorIsNil)
+
+	resources, err := s.State.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+	res := resourcetesting.NewCharmResource(c, "store-resource", "content")
+	res.Revision = 3
+	pendingID, err := resources.AddPendingResource("starsay", "", res)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(app.SetBranchCharm(newBranchName, state.SetCharmConfig{
+		Charm:       ch,
+		ResourceIDs: map[string]string{"store-resource": pendingID},
+	}), jc.ErrorIsNil)
+
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	_, err = gen.Commit(branchCommitter)
+	c.Assert(err, jc.ErrorIsNil)
+
+	appResources, err := resources.ListResources("starsay")
+	c.Assert(err, jc.ErrorIsNil)
+	var found bool
+	for _, r := range appResources.Resources {
+		if r.Name == "store-resource" {
+			found = true
+			c.Check(r.Revision, gc.Equals, 3)
+		}
+	}
+	c.Check(found, jc.IsTrue)
+
+	count, err := state.ApplicationSettingsRefCount(s.State, "starsay", ch.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(count, gc.Equals, 1)
+}
+
+func (s *generationSuite) TestAbortRevertsUnits(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+	c.Assert(gen.AssignUnit("riak/0"), jc.ErrorIsNil)
+
+	riak, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	oldURL, _ := riak.CharmURL()
+
+	newCh := s.AddConfigCharm(c, "riak", stringConfig, 666)
+	c.Assert(riak.SetBranchCharm(newBranchName, state.SetCharmConfig{Charm: newCh}), jc.ErrorIsNil)
+	c.Assert(riak.Refresh(), jc.ErrorIsNil)
+	version := riak.CharmModifiedVersion()
+
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Assert(gen.Abort(branchCommitter), jc.ErrorIsNil)
+
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.IsCompleted(), jc.IsTrue)
+	c.Check(gen.GenerationId(), gc.Equals, 0)
+	c.Check(gen.CompletedBy(), gc.Equals, branchCommitter)
+
+	branches, err := s.State.Branches()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(branches, gc.HasLen, 0)
+
+	// The unit that was tracking the branch is directed back to the
+	// application charm, and units are notified of the change.
+	unit, err := s.State.Unit("riak/0")
+	c.Assert(err, jc.ErrorIsNil)
+	curl, err := unit.TrackedCharmURL()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(curl, gc.DeepEquals, oldURL)
+
+	c.Assert(riak.Refresh(), jc.ErrorIsNil)
+	c.Check(riak.CharmModifiedVersion(), gc.Equals, version+1)
+
+	// Idempotent.
+	c.Assert(gen.Abort(branchCommitter), jc.ErrorIsNil)
+
+	_, err = gen.Commit(branchCommitter)
+	c.Assert(err, gc.ErrorMatches, "branch was already aborted")
+}
+
+func (s *generationSuite) TestAbortCommittedError(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+	c.Assert(gen.AssignUnit("riak/0"), jc.ErrorIsNil)
+
+	_, err := gen.Commit(branchCommitter)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(gen.Abort(branchCommitter), gc.ErrorMatches, "branch was already committed")
+}
+
+func (s *generationSuite) TestPruneBranches(c *gc.C) {
+	clock := testclock.NewClock(testing.NonZeroTime())
+	c.Assert(s.State.SetClockForTesting(clock), jc.ErrorIsNil)
+
+	gen := s.addBranch(c)
+	c.Assert(gen.Abort(branchCommitter), jc.ErrorIsNil)
+
+	const otherBranchName = "other-branch"
+	c.Assert(s.Model.AddBranch(otherBranchName, newBranchCreator), jc.ErrorIsNil)
+
+	// Branches completed within the retention period are kept.
+	c.Assert(state.PruneBranches(s.State, time.Hour), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	clock.Advance(2 * time.Hour)
+	c.Assert(state.PruneBranches(s.State, time.Hour), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), gc.ErrorMatches, "not found")
+
+	// In-flight branches are never pruned.
+	_, err := s.Model.Branch(otherBranchName)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *generationSuite) TestPruneBranchesReleasesCharmRefs(c *gc.C) {
+	clock := testclock.NewClock(testing.NonZeroTime())
+	c.Assert(s.State.SetClockForTesting(clock), jc.ErrorIsNil)
+
+	gen := s.setupAssignAllUnits(c)
+	riak, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	newCh := s.AddConfigCharm(c, "riak", stringConfig, 666)
+	c.Assert(riak.SetBranchCharm(newBranchName, state.SetCharmConfig{Charm: newCh}), jc.ErrorIsNil)
+
+	const otherBranchName = "other-branch"
+	c.Assert(s.Model.AddBranch(otherBranchName, newBranchCreator), jc.ErrorIsNil)
+	c.Assert(riak.SetBranchCharm(otherBranchName, state.SetCharmConfig{Charm: newCh}), jc.ErrorIsNil)
+
+	count, err := state.ApplicationSettingsRefCount(s.State, "riak", newCh.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(count, gc.Equals, 2)
+
+	// The aborted branch releases its reference, but the other branch
+	// is completed without releasing its own.
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Assert(gen.Abort(branchCommitter), jc.ErrorIsNil)
+	c.Assert(state.CompleteBranchHoldingCharmRefs(s.State, otherBranchName), jc.ErrorIsNil)
+
+	count, err = state.ApplicationSettingsRefCount(s.State, "riak", newCh.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(count, gc.Equals, 1)
+
+	// Pruning releases the reference held by the other branch only.
+	clock.Advance(2 * time.Hour)
+	c.Assert(state.PruneBranches(s.State, time.Hour), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), gc.ErrorMatches, "not found")
+
+	_, err = state.ApplicationSettingsRefCount(s.State, "riak", newCh.URL())
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
 
 func (s *generationSuite) TestBranchCharmConfigDeltas(c *gc.C) {
 	gen := s.setupAssignAllUnits(c)
@@ -261,6 +451,91 @@ func (s *generationSuite) TestBranchCharmConfigDeltas(c *gc.C) {
 	}})
 }
 
+func (s *generationSuite) TestBranchCharmUpgrade(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+	c.Assert(gen.AssignUnit("riak/0"), jc.ErrorIsNil)
+
+	riak, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	oldURL, _ := riak.CharmURL()
+	oldVersion := riak.CharmModifiedVersion()
+
+	newCh := s.AddConfigCharm(c, "riak", stringConfig, 666)
+	c.Assert(riak.SetBranchCharm(newBranchName, state.SetCharmConfig{Charm: newCh}), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.CharmURLs(), gc.DeepEquals, map[string]string{"riak": newCh.URL().String()})
+
+	// The application charm is unchanged, but its units are notified.
+	c.Assert(riak.Refresh(), jc.ErrorIsNil)
+	curl, _ := riak.CharmURL()
+	c.Check(curl, gc.DeepEquals, oldURL)
+	c.Check(riak.CharmModifiedVersion(), gc.Equals, oldVersion+1)
+
+	// Only the unit tracking the branch is directed to the new charm.
+	tracking, err := s.State.Unit("riak/0")
+	c.Assert(err, jc.ErrorIsNil)
+	curl, err = tracking.TrackedCharmURL()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(curl, gc.DeepEquals, newCh.URL())
+
+	other, err := s.State.Unit("riak/1")
+	c.Assert(err, jc.ErrorIsNil)
+	curl, err = other.TrackedCharmURL()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(curl, gc.DeepEquals, oldURL)
+
+	// The tracking unit can be upgraded, and sees settings for the new charm.
+	c.Assert(tracking.SetCharmURL(newCh.URL()), jc.ErrorIsNil)
+	cfg, err := tracking.ConfigSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg, gc.DeepEquals, charm.Settings{"key": "My Key"})
+}
+
+func (s *generationSuite) TestBranchCharmUpgradeConfigNotSupported(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+	riak, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+
+	newCh := s.AddConfigCharm(c, "riak", stringConfig, 666)
+	err = riak.SetBranchCharm(newBranchName, state.SetCharmConfig{
+		Charm:          newCh,
+		ConfigSettings: charm.Settings{"key": "value"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.CharmURLs(), gc.HasLen, 0)
+}
+
+func (s *generationSuite) TestBranchConstraints(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+	c.Assert(gen.AssignUnit("riak/0"), jc.ErrorIsNil)
+
+	riak, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	cons := constraints.MustParse("mem=4G")
+	c.Assert(riak.SetBranchConstraints(newBranchName, cons), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	c.Check(gen.Constraints(), jc.DeepEquals, map[string]constraints.Value{"riak": cons})
+
+	// Application constraints are unchanged.
+	appCons, err := riak.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(appCons.HasMem(), jc.IsFalse)
+
+	tracking, err := s.State.Unit("riak/0")
+	c.Assert(err, jc.ErrorIsNil)
+	unitCons, err := tracking.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*unitCons.Mem, gc.Equals, uint64(4096))
+
+	other, err := s.State.Unit("riak/1")
+	c.Assert(err, jc.ErrorIsNil)
+	unitCons, err = other.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(unitCons.HasMem(), jc.IsFalse)
+}
+
 func (s *generationSuite) TestBranches(c *gc.C) {
 	s.setupTestingClock(c)
 
@@ -0,0 +1,390 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/status"
+)
+
+// RolloutStatus describes the progress of a branch rollout.
+type RolloutStatus string
+
+const (
+	// RolloutRunning indicates that units are still being
+	// moved onto the branch.
+	RolloutRunning RolloutStatus = "running"
+
+	// RolloutCompleted indicates that all units of the
+	// application are tracking the branch.
+	RolloutCompleted RolloutStatus = "completed"
+
+	// RolloutHalted indicates that the rollout was stopped, either because
+	// a unit went into error or a wave did not settle within the timeout.
+	RolloutHalted RolloutStatus = "halted"
+)
+
+// RolloutArgs describes how units of an application
+// are to be moved onto a branch in waves.
+// Exactly one of WaveSize or WavePercent must be set.
+type RolloutArgs struct {
+	// WaveSize is the number of units in each wave.
+	WaveSize int
+
+	// WavePercent is the percentage of the application's
+	// units in each wave.
+	WavePercent int
+
+	// WaveTimeout is the time to wait for the units in a wave
+	// to become active and idle before halting the rollout.
+	WaveTimeout time.Duration
+}
+
+// Validate returns an error if the arguments do not describe a valid rollout.
+func (a RolloutArgs) Validate() error {
+	if a.WaveSize < 0 || a.WavePercent < 0 {
+		return errors.NotValidf("negative wave size")
+	}
+	if (a.WaveSize == 0) == (a.WavePercent == 0) {
+		return errors.NotValidf("rollout without exactly one of wave size or wave percentage")
+	}
+	if a.WavePercent > 100 {
+		return errors.NotValidf("wave percentage %d", a.WavePercent)
+	}
+	if a.WaveTimeout <= 0 {
+		return errors.NotValidf("wave timeout %v", a.WaveTimeout)
+	}
+	return nil
+}
+
+// BranchRollout describes the progress of moving
+// an application's units onto a branch in waves.
+type BranchRollout struct {
+	RolloutArgs
+
+	// Status indicates whether the rollout is running,
+	// completed or halted.
+	Status RolloutStatus
+
+	// Message describes why the rollout was halted.
+	Message string
+
+	// Wave is the number of the current wave, starting at 1.
+	// It is zero before the first wave is started.
+	Wave int
+
+	// WaveUnits is the names of the units in the current wave.
+	WaveUnits []string
+
+	// WaveStarted is when the current wave was started.
+	WaveStarted time.Time
+}
+
+// rolloutDoc is the persistent representation of a BranchRollout.
+type rolloutDoc struct {
+	WaveSize    int      `bson:"wave-size"`
+	WavePercent int      `bson:"wave-percent"`
+	WaveTimeout int64    `bson:"wave-timeout"`
+	Status      string   `bson:"status"`
+	Message     string   `bson:"message,omitempty"`
+	Wave        int      `bson:"wave"`
+	WaveUnits   []string `bson:"wave-units"`
+	WaveStarted int64    `bson:"wave-started"`
+}
+
+func (d rolloutDoc) rollout() BranchRollout {
+	r := BranchRollout{
+		RolloutArgs: RolloutArgs{
+			WaveSize:    d.WaveSize,
+			WavePercent: d.WavePercent,
+			WaveTimeout: time.Duration(d.WaveTimeout),
+		},
+		Status:    RolloutStatus(d.Status),
+		Message:   d.Message,
+		Wave:      d.Wave,
+		WaveUnits: d.WaveUnits,
+	}
+	if d.WaveStarted > 0 {
+		r.WaveStarted = time.Unix(d.WaveStarted, 0).UTC()
+	}
+	return r
+}
+
+// Rollouts returns the progressive rollouts of applications
+// onto this branch, keyed by application name.
+func (g *Generation) Rollouts() map[string]BranchRollout {
+	rollouts := make(map[string]BranchRollout, len(g.doc.Rollouts))
+	for appName, doc := range g.doc.Rollouts {
+		rollouts[appName] = doc.rollout()
+	}
+	return rollouts
+}
+
+// StartRollout begins moving the units of the input application onto this
+// branch in waves. The first wave is started the next time rollouts are
+// progressed. Any previous rollout of the application that is not running
+// is replaced.
+func (g *Generation) StartRollout(appName string, args RolloutArgs) error {
+	if err := args.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if err := g.CheckNotComplete(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if current, ok := g.doc.Rollouts[appName]; ok && current.Status == string(RolloutRunning) {
+			return nil, errors.AlreadyExistsf("running rollout of application %q", appName)
+		}
+		app, err := g.st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		ops := []txn.Op{
+			{
+				C:      applicationsC,
+				Id:     app.doc.DocID,
+				Assert: isAliveDoc,
+			},
+			{
+				C:  generationsC,
+				Id: g.doc.DocId,
+				Assert: bson.D{{"$and", []bson.D{
+					{{"completed", 0}},
+					{{"txn-revno", g.doc.TxnRevno}},
+				}}},
+				Update: bson.D{
+					{"$set", bson.D{{"rollouts." + appName, rolloutDoc{
+						WaveSize:    args.WaveSize,
+						WavePercent: args.WavePercent,
+						WaveTimeout: int64(args.WaveTimeout),
+						Status:      string(RolloutRunning),
+					}}}},
+				},
+			},
+		}
+		if _, ok := g.doc.AssignedUnits[appName]; !ok {
+			ops = append(ops, assignGenerationAppTxnOps(g.doc.DocId, appName)...)
+		}
+		return ops, nil
+	}
+
+	return errors.Trace(g.st.db().Run(buildTxn))
+}
+
+// ProgressRollouts advances each running rollout under this branch.
+// If any unit in the current wave is in error, or the wave has not become
+// active and idle within the wave timeout, the rollout is halted.
+// When all units in the current wave are active and idle, the next wave of
+// units is assigned to the branch, or the rollout is completed if there are
+// no more units to assign.
+func (g *Generation) ProgressRollouts() error {
+	appNames := make([]string, 0, len(g.doc.Rollouts))
+	for appName, doc := range g.doc.Rollouts {
+		if doc.Status == string(RolloutRunning) {
+			appNames = append(appNames, appName)
+		}
+	}
+	sort.Strings(appNames)
+
+	for _, appName := range appNames {
+		if err := g.progressRollout(appName); err != nil {
+			return errors.Annotatef(err, "progressing rollout of %q", appName)
+		}
+	}
+	return nil
+}
+
+func (g *Generation) progressRollout(appName string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := g.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if g.IsCompleted() {
+			return nil, jujutxn.ErrNoOperations
+		}
+		doc, ok := g.doc.Rollouts[appName]
+		if !ok || doc.Status != string(RolloutRunning) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		now, err := g.st.ControllerTimestamp()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		if doc.Wave > 0 {
+			settled, halt, err := g.rolloutWaveStatus(appName, doc)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if halt == "" && !settled && now.Sub(time.Unix(doc.WaveStarted, 0)) > time.Duration(doc.WaveTimeout) {
+				halt = fmt.Sprintf("wave %d did not become active and idle within %v", doc.Wave, time.Duration(doc.WaveTimeout))
+			}
+			if halt != "" {
+				doc.Status = string(RolloutHalted)
+				doc.Message = halt
+				return g.rolloutTxnOps(appName, doc), nil
+			}
+			if !settled {
+				return nil, jujutxn.ErrNoOperations
+			}
+		}
+
+		unitNames, err := appUnitNames(g.st, appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		assigned := set.NewStrings(g.doc.AssignedUnits[appName]...)
+		var pending []string
+		for _, name := range unitNames {
+			if !assigned.Contains(name) {
+				pending = append(pending, name)
+			}
+		}
+		if len(pending) == 0 {
+			doc.Status = string(RolloutCompleted)
+			return g.rolloutTxnOps(appName, doc), nil
+		}
+
+		sortUnitNames(pending)
+		waveUnits := pending[:rolloutWaveSize(doc, len(unitNames), len(pending))]
+
+		var ops []txn.Op
+		for _, name := range waveUnits {
+			unit, err := g.st.Unit(name)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, assignGenerationUnitTxnOps(g.doc.DocId, appName, unit)...)
+		}
+		doc.Wave++
+		doc.WaveUnits = waveUnits
+		doc.WaveStarted = now.Unix()
+		return append(ops, g.rolloutTxnOps(appName, doc)...), nil
+	}
+
+	return errors.Trace(g.st.db().Run(buildTxn))
+}
+
+// rolloutWaveStatus returns true if all of the units in the current wave
+// have picked up the branch changes and are active and idle. A unit has
+// picked up the branch when it is running the branch charm, if the branch
+// changes the charm, or otherwise when its agent status has changed since
+// the wave was started. If any unit is in error, a non-empty message
+// describing why the rollout should be halted is returned.
+func (g *Generation) rolloutWaveStatus(appName string, doc rolloutDoc) (bool, string, error) {
+	branchCURL := g.doc.CharmURLs[appName]
+	hasChanges := branchCURL != "" || len(g.doc.Config[appName]) > 0 || len(g.doc.Resources[appName]) > 0
+	waveStarted := time.Unix(doc.WaveStarted, 0)
+
+	settled := true
+	for _, name := range doc.WaveUnits {
+		unit, err := g.st.Unit(name)
+		if errors.IsNotFound(err) {
+			// Removed units do not hold up the rollout.
+			continue
+		}
+		if err != nil {
+			return false, "", errors.Trace(err)
+		}
+		workload, err := unit.Status()
+		if err != nil {
+			return false, "", errors.Trace(err)
+		}
+		if workload.Status == status.Error {
+			return false, fmt.Sprintf("unit %q is in error: %s", name, workload.Message), nil
+		}
+		agent, err := unit.AgentStatus()
+		if err != nil {
+			return false, "", errors.Trace(err)
+		}
+		if workload.Status != status.Active || agent.Status != status.Idle {
+			settled = false
+			continue
+		}
+		if branchCURL != "" {
+			if curl, ok := unit.CharmURL(); !ok || curl.String() != branchCURL {
+				settled = false
+			}
+		} else if hasChanges && (agent.Since == nil || agent.Since.Before(waveStarted)) {
+			settled = false
+		}
+	}
+	return settled, "", nil
+}
+
+func (g *Generation) rolloutTxnOps(appName string, doc rolloutDoc) []txn.Op {
+	return []txn.Op{
+		{
+			C:  generationsC,
+			Id: g.doc.DocId,
+			Assert: bson.D{{"$and", []bson.D{
+				{{"completed", 0}},
+				{{"rollouts." + appName + ".wave", g.doc.Rollouts[appName].Wave}},
+				{{"rollouts." + appName + ".status", string(RolloutRunning)}},
+			}}},
+			Update: bson.D{
+				{"$set", bson.D{{"rollouts." + appName, doc}}},
+			},
+		},
+	}
+}
+
+// rolloutWaveSize returns the number of units to assign to the branch in the
+// next wave, given the total number of application units and the number
+// still to be assigned.
+func rolloutWaveSize(doc rolloutDoc, total, pending int) int {
+	size := doc.WaveSize
+	if doc.WavePercent > 0 {
+		// Round up so that every wave makes progress.
+		size = (total*doc.WavePercent + 99) / 100
+	}
+	if size < 1 {
+		size = 1
+	}
+	if size > pending {
+		size = pending
+	}
+	return size
+}
+
+// sortUnitNames sorts the input unit names by unit number.
+func sortUnitNames(unitNames []string) {
+	sort.Slice(unitNames, func(i, j int) bool {
+		return names.NewUnitTag(unitNames[i]).Number() < names.NewUnitTag(unitNames[j]).Number()
+	})
+}
+
+// ProgressBranchRollouts advances the running rollouts
+// of all in-flight branches in the model.
+func (st *State) ProgressBranchRollouts() error {
+	branches, err := st.Branches()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, branch := range branches {
+		if err := branch.ProgressRollouts(); err != nil {
+			return errors.Annotatef(err, "branch %q", branch.BranchName())
+		}
+	}
+	return nil
+}
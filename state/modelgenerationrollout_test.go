@@ -0,0 +1,196 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+func (s *generationSuite) TestStartRolloutValidation(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+
+	for _, args := range []state.RolloutArgs{
+		{WaveTimeout: time.Minute},
+		{WaveSize: 1, WavePercent: 50, WaveTimeout: time.Minute},
+		{WavePercent: 150, WaveTimeout: time.Minute},
+		{WaveSize: -1, WaveTimeout: time.Minute},
+		{WaveSize: 1},
+	} {
+		err := gen.StartRollout("riak", args)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *generationSuite) TestStartRolloutAlreadyRunning(c *gc.C) {
+	gen := s.setupAssignAllUnits(c)
+
+	args := state.RolloutArgs{WaveSize: 1, WaveTimeout: time.Minute}
+	c.Assert(gen.StartRollout("riak", args), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+	units, ok := gen.AssignedUnits()["riak"]
+	c.Check(ok, jc.IsTrue)
+	c.Check(units, gc.HasLen, 0)
+
+	err := gen.StartRollout("riak", args)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *generationSuite) TestRolloutWaves(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+
+	args := state.RolloutArgs{WaveSize: 2, WaveTimeout: 10 * time.Minute}
+	c.Assert(gen.StartRollout("riak", args), jc.ErrorIsNil)
+
+	// The first wave is assigned to the branch.
+	s.progressRollouts(c, gen)
+	rollout := gen.Rollouts()["riak"]
+	c.Check(rollout.Status, gc.Equals, state.RolloutRunning)
+	c.Check(rollout.Wave, gc.Equals, 1)
+	c.Check(rollout.WaveUnits, jc.SameContents, []string{"riak/0", "riak/1"})
+	c.Check(gen.AssignedUnits()["riak"], jc.SameContents, []string{"riak/0", "riak/1"})
+
+	// Units in the wave have not settled, so there is no progress.
+	s.progressRollouts(c, gen)
+	c.Check(gen.Rollouts()["riak"].Wave, gc.Equals, 1)
+
+	s.setUnitsSettled(c, "riak/0", "riak/1")
+	s.progressRollouts(c, gen)
+	rollout = gen.Rollouts()["riak"]
+	c.Check(rollout.Status, gc.Equals, state.RolloutRunning)
+	c.Check(rollout.Wave, gc.Equals, 2)
+	c.Check(rollout.WaveUnits, jc.SameContents, []string{"riak/2", "riak/3"})
+	c.Check(gen.AssignedUnits()["riak"], gc.HasLen, 4)
+
+	s.setUnitsSettled(c, "riak/2", "riak/3")
+	s.progressRollouts(c, gen)
+	c.Check(gen.Rollouts()["riak"].Status, gc.Equals, state.RolloutCompleted)
+}
+
+func (s *generationSuite) TestRolloutWaitsForBranchCharm(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+
+	riak, err := s.State.Application("riak")
+	c.Assert(err, jc.ErrorIsNil)
+	newCh := s.AddConfigCharm(c, "riak", stringConfig, 666)
+	c.Assert(riak.SetBranchCharm(newBranchName, state.SetCharmConfig{Charm: newCh}), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	args := state.RolloutArgs{WaveSize: 2, WaveTimeout: 10 * time.Minute}
+	c.Assert(gen.StartRollout("riak", args), jc.ErrorIsNil)
+	s.progressRollouts(c, gen)
+	c.Check(gen.Rollouts()["riak"].WaveUnits, jc.SameContents, []string{"riak/0", "riak/1"})
+
+	// Units that are active and idle, but not yet running
+	// the branch charm, do not settle the wave.
+	s.setUnitsSettled(c, "riak/0", "riak/1")
+	s.progressRollouts(c, gen)
+	c.Check(gen.Rollouts()["riak"].Wave, gc.Equals, 1)
+
+	for _, name := range []string{"riak/0", "riak/1"} {
+		unit, err := s.State.Unit(name)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(unit.SetCharmURL(newCh.URL()), jc.ErrorIsNil)
+	}
+	s.progressRollouts(c, gen)
+	c.Check(gen.Rollouts()["riak"].Wave, gc.Equals, 2)
+}
+
+func (s *generationSuite) TestRolloutWaitsForBranchConfig(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+
+	current := state.GetPopulatedSettings(map[string]interface{}{"http_port": 8098})
+	c.Assert(gen.UpdateCharmConfig("riak", current, charm.Settings{"http_port": 8100}), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+
+	args := state.RolloutArgs{WaveSize: 2, WaveTimeout: 10 * time.Minute}
+	c.Assert(gen.StartRollout("riak", args), jc.ErrorIsNil)
+	s.progressRollouts(c, gen)
+	rollout := gen.Rollouts()["riak"]
+	c.Check(rollout.WaveUnits, jc.SameContents, []string{"riak/0", "riak/1"})
+
+	// Units whose status has not changed since the wave was
+	// started have not picked up the branch config.
+	s.setUnitsSettled(c, "riak/0", "riak/1")
+	s.progressRollouts(c, gen)
+	c.Check(gen.Rollouts()["riak"].Wave, gc.Equals, 1)
+
+	s.setUnitsSettledSince(c, rollout.WaveStarted.Add(time.Second), "riak/0", "riak/1")
+	s.progressRollouts(c, gen)
+	c.Check(gen.Rollouts()["riak"].Wave, gc.Equals, 2)
+}
+
+func (s *generationSuite) TestRolloutHaltsOnUnitError(c *gc.C) {
+	s.setupTestingClock(c)
+	gen := s.setupAssignAllUnits(c)
+
+	args := state.RolloutArgs{WaveSize: 1, WaveTimeout: 10 * time.Minute}
+	c.Assert(gen.StartRollout("riak", args), jc.ErrorIsNil)
+	s.progressRollouts(c, gen)
+
+	unit, err := s.State.Unit("riak/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.SetAgentStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: "hook failed",
+	}), jc.ErrorIsNil)
+
+	s.progressRollouts(c, gen)
+	rollout := gen.Rollouts()["riak"]
+	c.Check(rollout.Status, gc.Equals, state.RolloutHalted)
+	c.Check(rollout.Message, gc.Equals, `unit "riak/0" is in error: hook failed`)
+	c.Check(gen.AssignedUnits()["riak"], gc.DeepEquals, []string{"riak/0"})
+
+	// Halted rollouts do not progress.
+	s.progressRollouts(c, gen)
+	c.Check(gen.AssignedUnits()["riak"], gc.DeepEquals, []string{"riak/0"})
+}
+
+func (s *generationSuite) TestRolloutHaltsOnTimeout(c *gc.C) {
+	clock := testclock.NewClock(testing.NonZeroTime())
+	c.Assert(s.State.SetClockForTesting(clock), jc.ErrorIsNil)
+	gen := s.setupAssignAllUnits(c)
+
+	args := state.RolloutArgs{WavePercent: 50, WaveTimeout: time.Minute}
+	c.Assert(gen.StartRollout("riak", args), jc.ErrorIsNil)
+	s.progressRollouts(c, gen)
+	c.Check(gen.Rollouts()["riak"].WaveUnits, jc.SameContents, []string{"riak/0", "riak/1"})
+
+	clock.Advance(2 * time.Minute)
+	s.progressRollouts(c, gen)
+	rollout := gen.Rollouts()["riak"]
+	c.Check(rollout.Status, gc.Equals, state.RolloutHalted)
+	c.Check(rollout.Message, gc.Equals, "wave 1 did not become active and idle within 1m0s")
+	c.Check(gen.AssignedUnits()["riak"], gc.HasLen, 2)
+}
+
+func (s *generationSuite) progressRollouts(c *gc.C, gen *state.Generation) {
+	c.Assert(s.State.ProgressBranchRollouts(), jc.ErrorIsNil)
+	c.Assert(gen.Refresh(), jc.ErrorIsNil)
+}
+
+func (s *generationSuite) setUnitsSettled(c *gc.C, unitNames ...string) {
+	s.setUnitsSettledSince(c, testing.NonZeroTime(), unitNames...)
+}
+
+func (s *generationSuite) setUnitsSettledSince(c *gc.C, now time.Time, unitNames ...string) {
+	for _, name := range unitNames {
+		unit, err := s.State.Unit(name)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(unit.SetStatus(status.StatusInfo{Status: status.Active, Since: &now}), jc.ErrorIsNil)
+		c.Assert(unit.SetAgentStatus(status.StatusInfo{Status: status.Idle, Since: &now}), jc.ErrorIsNil)
+	}
+}
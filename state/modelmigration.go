@@ -67,6 +67,24 @@ type ModelMigration interface {
 	// migration's target controller.
 	TargetInfo() (*migration.TargetInfo, error)
 
+	// Resume returns true if the migration resumes an earlier,
+	// aborted attempt to migrate the model to the same target
+	// controller.
+	Resume() bool
+
+	// BatchId returns the id of the migration batch which started
+	// the migration, or "" if it wasn't started by a batch.
+	BatchId() string
+
+	// KeepImport returns true if the model imported into the target
+	// controller is to be kept if the migration aborts, so that the
+	// migration can be resumed.
+	KeepImport() bool
+
+	// SetKeepImport records whether the model imported into the
+	// target controller is to be kept if the migration aborts.
+	SetKeepImport(keep bool) error
+
 	// SetPhase sets the phase of the migration. An error will be
 	// returned if the new phase does not follow the current phase or
 	// if the migration is no longer active.
@@ -159,6 +177,15 @@ type modelMigDoc struct {
 
 	// The list of users and their access-level to the model being migrated.
 	ModelUsers []modelMigUserDoc `bson:"model-users,omitempty"`
+
+	// Resume is true when the migration resumes an earlier attempt,
+	// reusing the binaries that were already uploaded to the target
+	// controller.
+	Resume bool `bson:"resume,omitempty"`
+
+	// BatchId holds the id of the migration batch which the
+	// migration was started for, if any.
+	BatchId string `bson:"batch-id,omitempty"`
 }
 
 type modelMigUserDoc struct {
@@ -202,6 +229,10 @@ type modelMigStatusDoc struct {
 	// StatusMessage holds a human readable message about the
 	// migration's progress.
 	StatusMessage string `bson:"status-message"`
+
+	// KeepImport is true when the model imported into the target
+	// controller is to be kept if the migration aborts.
+	KeepImport bool `bson:"keep-import,omitempty"`
 }
 
 type modelMigMinionSyncDoc struct {
@@ -269,24 +300,65 @@ func (mig *modelMigration) InitiatedBy() string {
 
 // TargetInfo implements ModelMigration.
 func (mig *modelMigration) TargetInfo() (*migration.TargetInfo, error) {
-	authTag, err := names.ParseUserTag(mig.doc.TargetAuthTag)
+	return makeTargetInfo(
+		mig.doc.TargetController,
+		mig.doc.TargetAddrs,
+		mig.doc.TargetCACert,
+		mig.doc.TargetAuthTag,
+		mig.doc.TargetPassword,
+		mig.doc.TargetMacaroons,
+	)
+}
+
+func makeTargetInfo(controllerUUID string, addrs []string, caCert, authTag, password, macaroons string) (*migration.TargetInfo, error) {
+	tag, err := names.ParseUserTag(authTag)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	macs, err := jsonToMacaroons(mig.doc.TargetMacaroons)
+	macs, err := jsonToMacaroons(macaroons)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &migration.TargetInfo{
-		ControllerTag: names.NewControllerTag(mig.doc.TargetController),
-		Addrs:         mig.doc.TargetAddrs,
-		CACert:        mig.doc.TargetCACert,
-		AuthTag:       authTag,
-		Password:      mig.doc.TargetPassword,
+		ControllerTag: names.NewControllerTag(controllerUUID),
+		Addrs:         addrs,
+		CACert:        caCert,
+		AuthTag:       tag,
+		Password:      password,
 		Macaroons:     macs,
 	}, nil
 }
 
+// Resume implements ModelMigration.
+func (mig *modelMigration) Resume() bool {
+	return mig.doc.Resume
+}
+
+// BatchId implements ModelMigration.
+func (mig *modelMigration) BatchId() string {
+	return mig.doc.BatchId
+}
+
+// KeepImport implements ModelMigration.
+func (mig *modelMigration) KeepImport() bool {
+	return mig.statusDoc.KeepImport
+}
+
+// SetKeepImport implements ModelMigration.
+func (mig *modelMigration) SetKeepImport(keep bool) error {
+	ops := []txn.Op{{
+		C:      migrationsStatusC,
+		Id:     mig.statusDoc.Id,
+		Update: bson.M{"$set": bson.M{"keep-import": keep}},
+		Assert: txn.DocExists,
+	}}
+	if err := mig.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotate(err, "failed to set migration keep import")
+	}
+	mig.statusDoc.KeepImport = keep
+	return nil
+}
+
 // SetPhase implements ModelMigration.
 func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 	now := mig.st.clock().Now().UnixNano()
@@ -343,6 +415,9 @@ func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 			Assert: txn.DocExists,
 			Remove: true,
 		})
+		if mig.doc.BatchId != "" {
+			ops = append(ops, migrationBatchEndOp(mig.doc.BatchId))
+		}
 	}
 
 	ops = append(ops, txn.Op{
@@ -634,6 +709,11 @@ func (mig *modelMigration) ModelUserAccess(tag names.Tag) permission.Access {
 type MigrationSpec struct {
 	InitiatedBy names.UserTag
 	TargetInfo  migration.TargetInfo
+	Resume      bool
+
+	// BatchId, if set, holds the id of the migration batch which
+	// the model is queued in.
+	BatchId string
 }
 
 // Validate returns an error if the MigrationSpec contains bad
@@ -658,6 +738,11 @@ func (st *State) CreateMigration(spec MigrationSpec) (ModelMigration, error) {
 	if err := checkTargetController(st, spec.TargetInfo.ControllerTag); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if spec.Resume {
+		if err := checkResumableMigration(st, spec.TargetInfo.ControllerTag); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 
 	now := st.clock().Now().UnixNano()
 	modelUUID := st.ModelUUID()
@@ -713,6 +798,8 @@ func (st *State) CreateMigration(spec MigrationSpec) (ModelMigration, error) {
 			TargetPassword:   spec.TargetInfo.Password,
 			TargetMacaroons:  macsJSON,
 			ModelUsers:       userDocs,
+			Resume:           spec.Resume,
+			BatchId:          spec.BatchId,
 		}
 
 		statusDoc = modelMigStatusDoc{
@@ -747,6 +834,13 @@ func (st *State) CreateMigration(spec MigrationSpec) (ModelMigration, error) {
 			}},
 		}, model.assertActiveOp(),
 		}...)
+		if spec.BatchId != "" {
+			batchOp, err := migrationBatchStartOp(st, spec.BatchId, modelUUID)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, batchOp)
+		}
 		return ops, nil
 	}
 	if err := st.db().Run(buildTxn); err != nil {
@@ -806,6 +900,32 @@ func checkTargetController(st *State, targetControllerTag names.ControllerTag) e
 	return nil
 }
 
+// checkResumableMigration returns an error unless the model's most
+// recent migration attempt was aborted while migrating to the given
+// target controller.
+func checkResumableMigration(st *State, targetControllerTag names.ControllerTag) error {
+	mig, phase, err := st.latestMigration()
+	if errors.IsNotFound(err) {
+		return errors.New("no previous migration to resume")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if phase != migration.ABORTDONE {
+		return errors.Errorf("previous migration can't be resumed (phase %s)", phase)
+	}
+	target, err := mig.TargetInfo()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if target.ControllerTag != targetControllerTag {
+		return errors.Errorf("previous migration was to controller %q", target.ControllerTag.Id())
+	}
+	if !mig.KeepImport() {
+		return errors.New("previous migration did not keep the model on the target controller")
+	}
+	return nil
+}
+
 // LatestMigration returns the most recent ModelMigration (if any) for a model
 // that has not been removed from the state. Callers interested in
 // ModelMigrations for models that have been removed after a successful
@@ -74,6 +74,8 @@ func (s *MigrationSuite) TestCreate(c *gc.C) {
 	c.Check(mig.EndTime().IsZero(), jc.IsTrue)
 	c.Check(mig.StatusMessage(), gc.Equals, "starting")
 	c.Check(mig.InitiatedBy(), gc.Equals, "admin")
+	c.Check(mig.Resume(), jc.IsFalse)
+	c.Check(mig.KeepImport(), jc.IsFalse)
 
 	info, err := mig.TargetInfo()
 	c.Assert(err, jc.ErrorIsNil)
@@ -94,6 +96,80 @@ func (s *MigrationSuite) TestCreate(c *gc.C) {
 	c.Check(model.MigrationMode(), gc.Equals, state.MigrationModeExporting)
 }
 
+func (s *MigrationSuite) abortMigration(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetKeepImport(true), jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.ABORT), jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.ABORTDONE), jc.ErrorIsNil)
+}
+
+func (s *MigrationSuite) TestCreateResume(c *gc.C) {
+	s.abortMigration(c)
+
+	spec := s.stdSpec
+	spec.Resume = true
+	mig, err := s.State2.CreateMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	checkIdAndAttempt(c, mig, 1)
+	c.Check(mig.Resume(), jc.IsTrue)
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.Resume(), jc.IsTrue)
+}
+
+func (s *MigrationSuite) TestSetKeepImport(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetKeepImport(true), jc.ErrorIsNil)
+	c.Check(mig.KeepImport(), jc.IsTrue)
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.KeepImport(), jc.IsTrue)
+}
+
+func (s *MigrationSuite) TestCreateResumeImportNotKept(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.ABORT), jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.ABORTDONE), jc.ErrorIsNil)
+
+	spec := s.stdSpec
+	spec.Resume = true
+	_, err = s.State2.CreateMigration(spec)
+	c.Check(err, gc.ErrorMatches, "previous migration did not keep the model on the target controller")
+}
+
+func (s *MigrationSuite) TestCreateResumeNoPrevious(c *gc.C) {
+	spec := s.stdSpec
+	spec.Resume = true
+	_, err := s.State2.CreateMigration(spec)
+	c.Check(err, gc.ErrorMatches, "no previous migration to resume")
+}
+
+func (s *MigrationSuite) TestCreateResumeNotAborted(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.ABORT), jc.ErrorIsNil)
+
+	spec := s.stdSpec
+	spec.Resume = true
+	_, err = s.State2.CreateMigration(spec)
+	c.Check(err, gc.ErrorMatches, `previous migration can't be resumed \(phase ABORT\)`)
+}
+
+func (s *MigrationSuite) TestCreateResumeDifferentTarget(c *gc.C) {
+	s.abortMigration(c)
+
+	spec := s.stdSpec
+	spec.Resume = true
+	spec.TargetInfo.ControllerTag = names.NewControllerTag(utils.MustNewUUID().String())
+	_, err := s.State2.CreateMigration(spec)
+	c.Check(err, gc.ErrorMatches, `previous migration was to controller ".+"`)
+}
+
 func (s *MigrationSuite) TestIsMigrationActive(c *gc.C) {
 	check := func(expected bool) {
 		isActive, err := s.State2.IsMigrationActive()
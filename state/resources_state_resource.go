@@ -328,13 +328,30 @@ func (st resourceState) OpenResource(applicationID, name string) (resource.Resou
 		}
 		return resource.Resource{}, nil, errors.Annotate(err, "while getting resource info")
 	}
+	return st.openResource(resourceInfo, storagePath)
+}
+
+// openPendingResource returns metadata about the identified pending
+// resource, and a reader for the resource.
+func (st resourceState) openPendingResource(applicationID, name, pendingID string) (resource.Resource, io.ReadCloser, error) {
+	resourceInfo, err := st.GetPendingResource(applicationID, name, pendingID)
+	if err != nil {
+		return resource.Resource{}, nil, errors.Annotate(err, "while getting resource info")
+	}
+	return st.openResource(resourceInfo, storagePath(name, applicationID, pendingID))
+}
+
+// openResource returns a reader for the input resource,
+// the content of which is held at the input storage path.
+func (st resourceState) openResource(resourceInfo resource.Resource, storagePath string) (resource.Resource, io.ReadCloser, error) {
 	if resourceInfo.IsPlaceholder() {
-		logger.Tracef("placeholder resource %q treated as not found", name)
-		return resource.Resource{}, nil, errors.NotFoundf("resource %q", name)
+		logger.Tracef("placeholder resource %q treated as not found", resourceInfo.Name)
+		return resource.Resource{}, nil, errors.NotFoundf("resource %q", resourceInfo.Name)
 	}
 
 	var resourceReader io.ReadCloser
 	var resSize int64
+	var err error
 	switch resourceInfo.Type {
 	case charmresource.TypeContainerImage:
 		resourceReader, resSize, err = st.dockerMetadataStorage.Get(resourceInfo.ID)
@@ -375,7 +392,18 @@ func (st resourceState) OpenResourceForUniter(unit resource.Unit, name string) (
 		return resource.Resource{}, nil, errors.Trace(err)
 	}
 
-	resourceInfo, resourceReader, err := st.OpenResource(applicationID, name)
+	// Units tracking a branch get any resource activated under that branch.
+	var resourceInfo resource.Resource
+	var resourceReader io.ReadCloser
+	branchPendingID, ok, err := unitBranchResourcePendingID(unit, name)
+	if err != nil {
+		return resource.Resource{}, nil, errors.Trace(err)
+	}
+	if ok {
+		resourceInfo, resourceReader, err = st.openPendingResource(applicationID, name, branchPendingID)
+	} else {
+		resourceInfo, resourceReader, err = st.OpenResource(applicationID, name)
+	}
 	if err != nil {
 		return resource.Resource{}, nil, errors.Trace(err)
 	}
@@ -400,6 +428,17 @@ func (st resourceState) OpenResourceForUniter(unit resource.Unit, name string) (
 	return resourceInfo, resourceReader, nil
 }
 
+// unitBranchResourcePendingID returns the ID of the pending resource with
+// the input name that is activated for the unit by the branch it is tracking.
+// Only state units can track branches.
+func unitBranchResourcePendingID(unit resource.Unit, name string) (string, bool, error) {
+	u, ok := unit.(*Unit)
+	if !ok {
+		return "", false, nil
+	}
+	return u.branchResourcePendingID(name)
+}
+
 // SetCharmStoreResources sets the "polled" resources for the
 // application to the provided values.
 func (st resourceState) SetCharmStoreResources(applicationID string, info []charmresource.Resource, lastPolled time.Time) error {
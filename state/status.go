@@ -561,6 +561,55 @@ func probablyUpdateStatusHistory(db Database, globalKey string, doc statusDoc) (
 	return true, nil
 }
 
+// recordCloudEvents adds the events reported by the cloud for an entity to
+// the status history for the specified key. Each event is recorded with the
+// time it occurred. Events no newer than the latest one recorded have been
+// seen before, as the cloud reports them again whenever the provisioner
+// restarts, and are skipped. As with other status history, this is a best
+// effort update and any failures are logged.
+func recordCloudEvents(db Database, globalKey string, events []status.StatusInfo, clock clock.Clock) {
+	if len(events) == 0 {
+		return
+	}
+	latest, err := latestStatusHistoryTime(db, globalKey)
+	if err != nil {
+		logger.Errorf("failed to read status history: %v", err)
+		return
+	}
+	for _, event := range events {
+		updated := timeOrNow(event.Since, clock).UnixNano()
+		if updated <= latest {
+			continue
+		}
+		doc := statusDoc{
+			Status:     event.Status,
+			StatusInfo: event.Message,
+			StatusData: utils.EscapeKeys(event.Data),
+			Updated:    updated,
+		}
+		probablyUpdateStatusHistory(db, globalKey, doc)
+	}
+}
+
+// latestStatusHistoryTime returns the time, in Unix nanoseconds, of the
+// latest status history record for the specified key, or zero if there
+// are none.
+func latestStatusHistoryTime(db Database, globalKey string) (int64, error) {
+	history, closer := db.GetCollection(statusesHistoryC)
+	defer closer()
+
+	var latest []historicalStatusDoc
+	query := history.Find(bson.D{{globalKeyField, globalKey}})
+	query = query.Sort("-updated").Limit(1)
+	if err := query.All(&latest); err != nil {
+		return 0, errors.Trace(err)
+	}
+	if len(latest) == 0 {
+		return 0, nil
+	}
+	return latest[0].Updated, nil
+}
+
 func statusHistoryExists(db Database, historyDoc *historicalStatusDoc) (bool, bson.ObjectId) {
 	// Find the current value to see if it is worthwhile adding the new
 	// status value.
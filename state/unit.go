@@ -147,8 +147,15 @@ func (u *Unit) ConfigSettings() (charm.Settings, error) {
 		return nil, fmt.Errorf("unit charm not set")
 	}
 
-	// TODO (manadart 2019-02-21) Factor the current generation into this call.
-	s, err := charmSettingsWithDefaults(u.st, u.doc.CharmURL, u.doc.Application, model.GenerationMaster)
+	branchName := model.GenerationMaster
+	branch, err := u.trackedBranch()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if branch != nil {
+		branchName = branch.BranchName()
+	}
+	s, err := charmSettingsWithDefaults(u.st, u.doc.CharmURL, u.doc.Application, branchName)
 	if err != nil {
 		return nil, errors.Annotatef(err, "charm config for unit %q", u.Name())
 	}
@@ -457,6 +464,10 @@ func (op *UpdateUnitOperation) Done(err error) error {
 	for key, doc := range op.setStatusDocs {
 		probablyUpdateStatusHistory(op.unit.st.db(), key, doc)
 	}
+	recordCloudEvents(
+		op.unit.st.db(), globalCloudContainerEventsKey(op.unit.Name()),
+		op.props.CloudContainerEvents, op.unit.st.clock(),
+	)
 	return nil
 }
 
@@ -573,6 +584,13 @@ func (op *DestroyUnitOperation) eraseHistory() error {
 		}
 		op.AddError(one)
 	}
+	if err := eraseStatusHistory(op.unit.st, globalCloudContainerEventsKey(op.unit.Name())); err != nil {
+		one := errors.Annotate(err, "cloud container events")
+		if !op.Force {
+			return one
+		}
+		op.AddError(one)
+	}
 	return nil
 }
 
@@ -1323,6 +1341,12 @@ func (u *Unit) AgentHistory() status.StatusHistoryGetter {
 	return u.Agent()
 }
 
+// CloudContainerEventHistory returns a StatusHistoryGetter which can be used
+// to query the history of events reported by the cloud for the unit's container.
+func (u *Unit) CloudContainerEventHistory() status.StatusHistoryGetter {
+	return &HistoryGetter{st: u.st, globalKey: globalCloudContainerEventsKey(u.Name())}
+}
+
 // SetAgentStatus calls SetStatus for this unit's agent, this call
 // is equivalent to the former call to SetStatus when Agent and Unit
 // where not separate entities.
@@ -1593,6 +1617,27 @@ func (u *Unit) CharmURL() (*charm.URL, bool) {
 	return u.doc.CharmURL, true
 }
 
+// TrackedCharmURL returns the charm URL that the unit should be running.
+// This is the charm URL set for the application under the branch that the
+// unit is tracking, if there is one, otherwise that of the application.
+func (u *Unit) TrackedCharmURL() (*charm.URL, error) {
+	branch, err := u.trackedBranch()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if branch != nil {
+		if curl, ok := branch.CharmURLs()[u.doc.Application]; ok {
+			return charm.ParseURL(curl)
+		}
+	}
+	app, err := u.Application()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	curl, _ := app.CharmURL()
+	return curl, nil
+}
+
 // SetCharmURL marks the unit as currently using the supplied charm URL.
 // An error will be returned if the unit is dead, or the charm URL not known.
 func (u *Unit) SetCharmURL(curl *charm.URL) error {
@@ -2214,6 +2259,20 @@ func (u *Unit) Constraints() (*constraints.Value, error) {
 	} else if err != nil {
 		return nil, err
 	}
+
+	// Constraints set for the application under the branch that the unit
+	// is tracking supersede those the unit was created with.
+	branch, err := u.trackedBranch()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if branch != nil {
+		if branchCons, ok := branch.Constraints()[u.doc.Application]; ok {
+			if cons, err = u.st.ResolveConstraints(branchCons); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+	}
 	return &cons, nil
 }
 
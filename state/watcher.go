@@ -3251,6 +3251,12 @@ func (st *State) WatchMachineRemovals() NotifyWatcher {
 	return newNotifyCollWatcher(st, machineRemovalsC, isLocalID(st))
 }
 
+// WatchBranches returns a NotifyWatcher that triggers when any of the
+// model's branches change.
+func (st *State) WatchBranches() NotifyWatcher {
+	return newNotifyCollWatcher(st, generationsC, isLocalID(st))
+}
+
 // notifyCollWatcher implements NotifyWatcher, triggering when a
 // change is seen in a specific collection matching the provided
 // filter function.
@@ -0,0 +1,134 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. The backup
+// itself is prepared and created by the state/backups package, which is
+// shared with the backups facade.
+
+// stateBackups implements Backups using the controller's state and
+// the agent's configuration.
+type stateBackups struct {
+	st          *state.State
+	agentConfig agent.Config
+}
+
+// backupsDB implements backups.DB for the controller model.
+type backupsDB struct {
+	*state.State
+	model *state.Model
+}
+
+// ModelTag is part of backups.DB.
+func (db *backupsDB) ModelTag() names.ModelTag {
+	return db.model.ModelTag()
+}
+
+// ModelConfig is part of backups.DB.
+func (db *backupsDB) ModelConfig() (*config.Config, error) {
+	return db.model.ModelConfig()
+}
+
+// MachineSeries is part of backups.ControllerDB.
+func (db *backupsDB) MachineSeries(id string) (string, error) {
+	m, err := db.Machine(id)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return m.Series(), nil
+}
+
+func (b *stateBackups) db() (*backupsDB, error) {
+	model, err := b.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &backupsDB{b.st, model}, nil
+}
+
+// Create is part of the Backups interface.
+func (b *stateBackups) Create(notes string) (*backups.Metadata, error) {
+	db, err := b.db()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	session := b.st.MongoSession().Copy()
+	defer session.Close()
+
+	// Don't go if HA isn't ready.
+	if err := replicaset.WaitUntilReady(session, 60); err != nil {
+		return nil, errors.Annotatef(err, "HA not ready")
+	}
+
+	mgoInfo, ok := b.agentConfig.MongoInfo()
+	if !ok {
+		return nil, errors.New("no mongo info found in agent config")
+	}
+	meta, dbInfo, err := backups.PrepareBackup(db, session, mgoInfo, b.agentConfig.Tag().Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta.Notes = notes
+	meta.Scheduled = true
+
+	modelConfig, err := db.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	paths := &backups.Paths{
+		BackupDir: modelConfig.BackupDir(),
+		DataDir:   b.agentConfig.DataDir(),
+		LogsDir:   b.agentConfig.LogDir(),
+	}
+
+	stor, err := backups.OpenStorage(db)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer stor.Close()
+	if _, err := backups.NewBackups(stor).Create(meta, paths, dbInfo, true, true, nil); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
+
+// List is part of the Backups interface.
+func (b *stateBackups) List() ([]*backups.Metadata, error) {
+	db, err := b.db()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	stor, err := backups.OpenStorage(db)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer stor.Close()
+	return backups.NewBackups(stor).List()
+}
+
+// Remove is part of the Backups interface.
+func (b *stateBackups) Remove(id string) error {
+	db, err := b.db()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	stor, err := backups.OpenStorage(db)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer stor.Close()
+	return backups.NewBackups(stor).Remove(id)
+}
@@ -0,0 +1,296 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+var logger = loggo.GetLogger("juju.worker.backupscheduler")
+
+// Backend provides the state functionality needed by the worker.
+type Backend interface {
+	ControllerConfig() (controller.Config, error)
+	WatchControllerConfig() state.NotifyWatcher
+	SetScheduledBackupStatus(status.StatusInfo) error
+}
+
+// Backups creates, lists and removes controller backups.
+type Backups interface {
+	// Create creates and stores a new scheduled backup with the
+	// specified notes.
+	Create(notes string) (*backups.Metadata, error)
+
+	// List returns the metadata for all stored backups.
+	List() ([]*backups.Metadata, error)
+
+	// Remove deletes the backup from storage.
+	Remove(id string) error
+}
+
+// Config holds the dependencies and configuration for the worker.
+type Config struct {
+	Backend Backend
+	Backups Backups
+	Clock   clock.Clock
+}
+
+// Validate returns an error if the config cannot be expected
+// to drive a functional worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Backups == nil {
+		return errors.NotValidf("nil Backups")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// NewWorker returns a worker which creates controller backups on the
+// schedule in controller config, and removes old scheduled backups
+// according to the configured retention policy. This worker must not
+// be run in more than one agent concurrently.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &schedulerWorker{
+		config: config,
+	}
+	w.tomb.Go(w.loop)
+	return w, nil
+}
+
+type schedulerWorker struct {
+	tomb    tomb.Tomb
+	mu      sync.Mutex
+	config  Config
+	current report
+}
+
+type report struct {
+	interval   time.Duration
+	policy     backups.RetentionPolicy
+	lastBackup time.Time
+	nextBackup time.Time
+	message    string
+}
+
+// Report is part of the dependency.Reporter interface.
+func (w *schedulerWorker) Report() map[string]interface{} {
+	w.mu.Lock()
+	report := w.current
+	w.mu.Unlock()
+
+	result := map[string]interface{}{
+		"interval":         report.interval,
+		"retention-count":  report.policy.Count,
+		"retention-daily":  report.policy.Daily,
+		"retention-weekly": report.policy.Weekly,
+	}
+	if !report.lastBackup.IsZero() {
+		result["last-backup"] = report.lastBackup.Round(time.Second)
+	}
+	if !report.nextBackup.IsZero() {
+		result["next-backup"] = report.nextBackup.Round(time.Second)
+	}
+	if report.message != "" {
+		result["summary"] = report.message
+	}
+	return result
+}
+
+func (w *schedulerWorker) loop() error {
+	controllerConfigWatcher := w.config.Backend.WatchControllerConfig()
+	defer worker.Stop(controllerConfigWatcher)
+
+	var (
+		interval time.Duration
+		policy   backups.RetentionPolicy
+		next     <-chan time.Time
+		// done is non-nil while a backup is in progress, and is
+		// closed when it completes.
+		done chan struct{}
+	)
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+
+		case _, ok := <-controllerConfigWatcher.Changes():
+			if !ok {
+				return errors.New("controller configuration watcher closed")
+			}
+			controllerConfig, err := w.config.Backend.ControllerConfig()
+			if err != nil {
+				return errors.Annotate(err, "cannot load controller configuration")
+			}
+			newInterval := controllerConfig.BackupScheduleInterval()
+			policy = backups.RetentionPolicy{
+				Count:  controllerConfig.BackupRetentionCount(),
+				Daily:  controllerConfig.BackupRetentionDaily(),
+				Weekly: controllerConfig.BackupRetentionWeekly(),
+			}
+			if done != nil {
+				// The backup in progress reschedules the next
+				// one when it completes.
+				interval = newInterval
+			} else if newInterval != interval || next == nil {
+				interval = newInterval
+				logger.Infof("backup schedule: interval %v, retention %+v", interval, policy)
+				next, err = w.schedule(interval)
+				if err != nil {
+					return errors.Trace(err)
+				}
+			}
+			w.mu.Lock()
+			w.current.interval = interval
+			w.current.policy = policy
+			w.mu.Unlock()
+
+		case <-next:
+			// Backups can take a long time, so create them
+			// in the background to keep the worker responsive
+			// to being killed.
+			next = nil
+			done = make(chan struct{})
+			w.tomb.Go(w.backupFunc(policy, done))
+
+		case <-done:
+			done = nil
+			if interval <= 0 {
+				continue
+			}
+			next = w.config.Clock.After(interval)
+			w.mu.Lock()
+			w.current.nextBackup = w.config.Clock.Now().Add(interval)
+			w.mu.Unlock()
+		}
+	}
+}
+
+// schedule returns a channel which fires when the next scheduled backup
+// is due, based on the time of the most recent scheduled backup. A nil
+// channel is returned if scheduled backups are disabled.
+func (w *schedulerWorker) schedule(interval time.Duration) (<-chan time.Time, error) {
+	if interval <= 0 {
+		w.mu.Lock()
+		w.current.nextBackup = time.Time{}
+		w.mu.Unlock()
+		return nil, nil
+	}
+	metaList, err := w.config.Backups.List()
+	if err != nil {
+		return nil, errors.Annotate(err, "listing backups")
+	}
+	var last time.Time
+	for _, meta := range backups.ScheduledBackups(metaList) {
+		if meta.Started.After(last) {
+			last = meta.Started
+		}
+	}
+	now := w.config.Clock.Now()
+	nextBackup := now
+	if !last.IsZero() && last.Add(interval).After(now) {
+		nextBackup = last.Add(interval)
+	}
+	w.mu.Lock()
+	w.current.lastBackup = last
+	w.current.nextBackup = nextBackup
+	w.mu.Unlock()
+	return w.config.Clock.After(nextBackup.Sub(now)), nil
+}
+
+// backupFunc returns a function, to be run by the worker's tomb, which
+// creates a backup and closes done when finished.
+func (w *schedulerWorker) backupFunc(policy backups.RetentionPolicy, done chan<- struct{}) func() error {
+	return func() error {
+		defer close(done)
+		w.backup(policy)
+		return nil
+	}
+}
+
+// backup creates a new scheduled backup, removes any scheduled backups
+// no longer kept by the retention policy, and records the outcome in the
+// controller's scheduled backup status. Failures are recorded rather than
+// stopping the worker so the next scheduled backup is still attempted.
+func (w *schedulerWorker) backup(policy backups.RetentionPolicy) {
+	now := w.config.Clock.Now()
+	w.mu.Lock()
+	w.current.lastBackup = now
+	w.mu.Unlock()
+
+	sInfo := status.StatusInfo{Since: &now}
+	meta, err := w.config.Backups.Create(backups.ScheduledNotes)
+	if err != nil {
+		logger.Errorf("scheduled backup failed: %v", err)
+		sInfo.Status = status.Error
+		sInfo.Message = fmt.Sprintf("creating backup: %v", err)
+	} else {
+		logger.Infof("created scheduled backup %q", meta.ID())
+		sInfo.Status = status.Available
+		sInfo.Message = fmt.Sprintf("created backup %s", meta.ID())
+		sInfo.Data = map[string]interface{}{"id": meta.ID()}
+		removed, err := w.removeExpired(policy)
+		if err != nil {
+			logger.Errorf("removing expired backups failed: %v", err)
+			sInfo.Status = status.Error
+			sInfo.Message = fmt.Sprintf("created backup %s but removing expired backups failed: %v", meta.ID(), err)
+		} else if len(removed) > 0 {
+			sInfo.Data["removed"] = removed
+		}
+	}
+	w.mu.Lock()
+	w.current.message = sInfo.Message
+	w.mu.Unlock()
+	if err := w.config.Backend.SetScheduledBackupStatus(sInfo); err != nil {
+		logger.Errorf("cannot record scheduled backup status: %v", err)
+	}
+}
+
+// removeExpired removes the scheduled backups not kept by the retention
+// policy and returns their IDs. Backups created by users are never removed.
+func (w *schedulerWorker) removeExpired(policy backups.RetentionPolicy) ([]string, error) {
+	metaList, err := w.config.Backups.List()
+	if err != nil {
+		return nil, errors.Annotate(err, "listing backups")
+	}
+	var removed []string
+	for _, meta := range backups.ExpiredBackups(backups.ScheduledBackups(metaList), policy) {
+		if err := w.config.Backups.Remove(meta.ID()); err != nil {
+			return removed, errors.Annotatef(err, "removing backup %q", meta.ID())
+		}
+		logger.Debugf("removed expired scheduled backup %q", meta.ID())
+		removed = append(removed, meta.ID())
+	}
+	return removed, nil
+}
+
+// Kill implements Worker.Kill().
+func (w *schedulerWorker) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait implements Worker.Wait().
+func (w *schedulerWorker) Wait() error {
+	return w.tomb.Wait()
+}
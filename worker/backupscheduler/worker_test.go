@@ -0,0 +1,317 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock   *testclock.Clock
+	backend *fakeBackend
+	backups *fakeBackups
+	config  backupscheduler.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+var epoch = time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(epoch)
+	s.backend = &fakeBackend{
+		config: controller.Config{
+			controller.BackupScheduleInterval: "1h",
+			controller.BackupRetentionCount:   2,
+		},
+		configChanges: make(chan struct{}, 1),
+		statuses:      make(chan status.StatusInfo, 10),
+	}
+	s.backend.configChanges <- struct{}{}
+	s.backups = &fakeBackups{
+		created: make(chan string, 10),
+		now:     s.clock.Now,
+	}
+	s.config = backupscheduler.Config{
+		Backend: s.backend,
+		Backups: s.backups,
+		Clock:   s.clock,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config
+	config.Backend = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Backend not valid")
+	config = s.config
+	config.Backups = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Backups not valid")
+	config = s.config
+	config.Clock = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Clock not valid")
+}
+
+func (s *WorkerSuite) TestFirstBackupImmediately(c *gc.C) {
+	w, err := backupscheduler.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCreated(c)
+	sInfo := s.waitStatus(c)
+	c.Assert(sInfo.Status, gc.Equals, status.Available)
+	c.Assert(sInfo.Message, gc.Equals, "created backup backup-0")
+	c.Assert(sInfo.Since.Equal(epoch), jc.IsTrue)
+
+	// The next backup is created after the interval.
+	c.Assert(s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.waitCreated(c)
+	s.waitStatus(c)
+}
+
+func (s *WorkerSuite) TestScheduledFromLastBackup(c *gc.C) {
+	s.backups.add("previous", epoch.Add(-40*time.Minute), true)
+	// Backups created by users don't count.
+	s.backups.add("manual", epoch.Add(-5*time.Minute), false)
+
+	w, err := backupscheduler.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.clock.WaitAdvance(19*time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.assertNotCreated(c)
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.waitCreated(c)
+}
+
+func (s *WorkerSuite) TestDisabled(c *gc.C) {
+	s.backend.config[controller.BackupScheduleInterval] = ""
+
+	w, err := backupscheduler.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.backend.configChanges <- struct{}{}
+	s.clock.Advance(24 * time.Hour)
+	s.assertNotCreated(c)
+	c.Assert(s.backups.Calls(), gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestRetention(c *gc.C) {
+	s.backups.add("old-1", epoch.Add(-3*time.Hour), true)
+	s.backups.add("old-2", epoch.Add(-2*time.Hour), true)
+	// A user's backup is never removed, even if its notes
+	// match those of scheduled backups.
+	manual := s.backups.add("manual", epoch.Add(-4*time.Hour), false)
+	manual.Notes = backups.ScheduledNotes
+
+	w, err := backupscheduler.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCreated(c)
+	sInfo := s.waitStatus(c)
+	c.Assert(sInfo.Status, gc.Equals, status.Available)
+	c.Assert(sInfo.Data, jc.DeepEquals, map[string]interface{}{
+		"id":      "backup-0",
+		"removed": []string{"old-1"},
+	})
+	c.Assert(s.backups.ids(), jc.SameContents, []string{"manual", "old-2", "backup-0"})
+}
+
+func (s *WorkerSuite) TestCreateFailureRecorded(c *gc.C) {
+	s.backups.SetErrors(nil, errors.New("HA not ready"))
+
+	w, err := backupscheduler.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	sInfo := s.waitStatus(c)
+	c.Assert(sInfo.Status, gc.Equals, status.Error)
+	c.Assert(sInfo.Message, gc.Equals, "creating backup: HA not ready")
+
+	// The worker keeps running and tries again later.
+	c.Assert(s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.waitCreated(c)
+	sInfo = s.waitStatus(c)
+	c.Assert(sInfo.Status, gc.Equals, status.Available)
+}
+
+func (s *WorkerSuite) TestConfigChangeDuringBackup(c *gc.C) {
+	s.backups.started = make(chan struct{}, 1)
+	s.backups.release = make(chan struct{})
+
+	w, err := backupscheduler.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case <-s.backups.started:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for backup to start")
+	}
+
+	// Changing the schedule while the backup is in progress
+	// does not start another one.
+	s.backend.config[controller.BackupScheduleInterval] = "1m"
+	s.backend.configChanges <- struct{}{}
+	s.assertNotCreated(c)
+	close(s.backups.release)
+	s.waitCreated(c)
+	s.assertNotCreated(c)
+
+	// The next backup is scheduled using the new interval.
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	<-s.backups.started
+	s.waitCreated(c)
+}
+
+func (s *WorkerSuite) waitCreated(c *gc.C) string {
+	select {
+	case id := <-s.backups.created:
+		return id
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for backup")
+	}
+	return ""
+}
+
+func (s *WorkerSuite) assertNotCreated(c *gc.C) {
+	select {
+	case id := <-s.backups.created:
+		c.Fatalf("unexpected backup %q", id)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) waitStatus(c *gc.C) status.StatusInfo {
+	select {
+	case sInfo := <-s.backend.statuses:
+		return sInfo
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for backup status")
+	}
+	return status.StatusInfo{}
+}
+
+type fakeBackend struct {
+	config        controller.Config
+	configChanges chan struct{}
+	statuses      chan status.StatusInfo
+}
+
+func (b *fakeBackend) ControllerConfig() (controller.Config, error) {
+	return b.config, nil
+}
+
+func (b *fakeBackend) WatchControllerConfig() state.NotifyWatcher {
+	return statetesting.NewMockNotifyWatcher(b.configChanges)
+}
+
+func (b *fakeBackend) SetScheduledBackupStatus(sInfo status.StatusInfo) error {
+	b.statuses <- sInfo
+	return nil
+}
+
+type fakeBackups struct {
+	testing.Stub
+
+	mu      sync.Mutex
+	stored  []*backups.Metadata
+	count   int
+	created chan string
+	now     func() time.Time
+
+	// If started is non-nil, Create signals on it and then
+	// waits for release to be closed.
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *fakeBackups) add(id string, started time.Time, scheduled bool) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Started = started
+	meta.Scheduled = scheduled
+	b.mu.Lock()
+	b.stored = append(b.stored, meta)
+	b.mu.Unlock()
+	return meta
+}
+
+func (b *fakeBackups) ids() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var result []string
+	for _, meta := range b.stored {
+		result = append(result, meta.ID())
+	}
+	return result
+}
+
+func (b *fakeBackups) Create(notes string) (*backups.Metadata, error) {
+	b.MethodCall(b, "Create", notes)
+	if b.started != nil {
+		b.started <- struct{}{}
+		<-b.release
+	}
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	id := fmt.Sprintf("backup-%d", b.count)
+	b.count++
+	b.mu.Unlock()
+	meta := b.add(id, b.now(), true)
+	meta.Notes = notes
+	b.created <- id
+	return meta, nil
+}
+
+func (b *fakeBackups) List() ([]*backups.Metadata, error) {
+	b.MethodCall(b, "List")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	result := make([]*backups.Metadata, len(b.stored))
+	copy(result, b.stored)
+	return result, nil
+}
+
+func (b *fakeBackups) Remove(id string) error {
+	b.MethodCall(b, "Remove", id)
+	if err := b.NextErr(); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, meta := range b.stored {
+		if meta.ID() == id {
+			b.stored = append(b.stored[:i], b.stored[i+1:]...)
+			return nil
+		}
+	}
+	return errors.NotFoundf("backup %q", id)
+}
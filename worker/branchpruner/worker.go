@@ -0,0 +1,51 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchpruner
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/branchpruner"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/worker/pruner"
+)
+
+// Worker prunes committed and aborted branches at regular intervals.
+type Worker struct {
+	pruner.PrunerWorker
+}
+
+// NewFacade returns a branch pruner facade using the input API caller.
+func NewFacade(caller base.APICaller) pruner.Facade {
+	return branchpruner.NewFacade(caller)
+}
+
+func (w *Worker) loop() error {
+	return w.Work(func(config *config.Config) (time.Duration, uint) {
+		return config.MaxBranchAge(), 0
+	})
+}
+
+// New creates a new branch pruner worker
+func New(conf pruner.Config) (worker.Worker, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	w := &Worker{
+		pruner.New(conf),
+	}
+
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: w.Catacomb(),
+		Work: w.loop,
+	})
+
+	return w, errors.Trace(err)
+}
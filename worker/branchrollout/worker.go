@@ -0,0 +1,77 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchrollout
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+)
+
+// period is the amount of time to wait between progressing rollouts.
+// Rollouts depend on unit status, for which there is no convenient
+// model-wide watcher, so they are polled.
+const period = 30 * time.Second
+
+var logger = loggo.GetLogger("juju.worker.branchrollout")
+
+// Facade describes the API methods used by the branch rollout worker.
+type Facade interface {
+	ProgressRollouts() error
+}
+
+// Worker periodically advances the waved rollouts of model branches.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	facade   Facade
+	clock    clock.Clock
+}
+
+// NewWorker returns a worker.Worker that periodically
+// progresses the running rollouts of model branches.
+func NewWorker(facade Facade, clock clock.Clock) (worker.Worker, error) {
+	w := &Worker{
+		facade: facade,
+		clock:  clock,
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+func (w *Worker) loop() error {
+	timer := w.clock.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-timer.Chan():
+		}
+		if err := w.facade.ProgressRollouts(); err != nil {
+			// A failure to progress is retried when the timer next fires;
+			// it is usually due to a transient txn failure.
+			logger.Errorf("cannot progress branch rollouts: %v", err)
+		}
+		timer.Reset(period)
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
@@ -0,0 +1,81 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package branchrollout_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/branchrollout"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	facade *mockFacade
+	clock  *testclock.Clock
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade = &mockFacade{calls: make(chan struct{}, 1)}
+	s.clock = testclock.NewClock(time.Time{})
+}
+
+func (s *WorkerSuite) TestProgressesPeriodically(c *gc.C) {
+	w, err := branchrollout.NewWorker(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { c.Assert(worker.Stop(w), jc.ErrorIsNil) }()
+
+	s.assertCalled(c)
+	s.assertNotCalled(c)
+
+	c.Assert(s.clock.WaitAdvance(30*time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.assertCalled(c)
+}
+
+func (s *WorkerSuite) TestErrorDoesNotStopWorker(c *gc.C) {
+	s.facade.err = errors.New("boom")
+
+	w, err := branchrollout.NewWorker(s.facade, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() { c.Assert(worker.Stop(w), jc.ErrorIsNil) }()
+
+	s.assertCalled(c)
+	c.Assert(s.clock.WaitAdvance(30*time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.assertCalled(c)
+}
+
+func (s *WorkerSuite) assertCalled(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for ProgressRollouts")
+	}
+}
+
+func (s *WorkerSuite) assertNotCalled(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+		c.Fatalf("unexpected call to ProgressRollouts")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type mockFacade struct {
+	calls chan struct{}
+	err   error
+}
+
+func (m *mockFacade) ProgressRollouts() error {
+	m.calls <- struct{}{}
+	return m.err
+}
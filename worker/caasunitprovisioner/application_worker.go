@@ -6,6 +6,7 @@ package caasunitprovisioner
 import (
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/juju/caas"
@@ -108,6 +109,12 @@ func (aw *applicationWorker) loop() error {
 	// so we only report true changes.
 	lastReportedStatus := make(map[string]status.StatusInfo)
 	lastReportedScale := -1
+	// Likewise, remember the time of the last cloud event
+	// reported for each unit and the operator. Events reported
+	// again after a restart are discarded by the controller.
+	lastReportedEvent := make(map[string]time.Time)
+	var lastOperatorEvent time.Time
+	recordOperatorEvents := true
 
 	for {
 		// The caas watcher can just die from underneath so recreate if needed.
@@ -160,7 +167,7 @@ func (aw *applicationWorker) loop() error {
 				return errors.Trace(err)
 			}
 			logger.Debugf("service for %v: %+v", aw.application, service)
-			if err := aw.clusterChanged(service, lastReportedStatus); err != nil {
+			if err := aw.clusterChanged(service, lastReportedStatus, lastReportedEvent); err != nil {
 				return errors.Trace(err)
 			}
 		case _, ok := <-appDeploymentWatcher.Changes():
@@ -196,7 +203,7 @@ func (aw *applicationWorker) loop() error {
 				}
 				lastReportedScale = *service.Scale
 			}
-			if err := aw.clusterChanged(service, lastReportedStatus); err != nil {
+			if err := aw.clusterChanged(service, lastReportedStatus, lastReportedEvent); err != nil {
 				return errors.Trace(err)
 			}
 		case _, ok := <-appOperatorWatcher.Changes():
@@ -219,13 +226,45 @@ func (aw *applicationWorker) loop() error {
 				if err := aw.provisioningStatusSetter.SetOperatorStatus(aw.application, operator.Status.Status, operator.Status.Message, operator.Status.Data); err != nil {
 					return errors.Trace(err)
 				}
+				var events []status.StatusInfo
+				events, lastOperatorEvent = newEvents(operator.Events, lastOperatorEvent)
+				if len(events) > 0 && recordOperatorEvents {
+					err := aw.provisioningStatusSetter.RecordOperatorEvents(aw.application, events)
+					if errors.IsNotSupported(err) {
+						logger.Debugf("not recording operator events for %q: %v", aw.application, err)
+						recordOperatorEvents = false
+					} else if err != nil {
+						return errors.Trace(err)
+					}
+				}
 			}
 		}
 
 	}
 }
 
-func (aw *applicationWorker) clusterChanged(service *caas.Service, lastReportedStatus map[string]status.StatusInfo) error {
+// newEvents returns the events which occurred after the specified
+// time, along with the time of the latest event.
+func newEvents(events []status.StatusInfo, after time.Time) ([]status.StatusInfo, time.Time) {
+	var result []status.StatusInfo
+	latest := after
+	for _, event := range events {
+		if event.Since == nil || !event.Since.After(after) {
+			continue
+		}
+		result = append(result, event)
+		if event.Since.After(latest) {
+			latest = *event.Since
+		}
+	}
+	return result, latest
+}
+
+func (aw *applicationWorker) clusterChanged(
+	service *caas.Service,
+	lastReportedStatus map[string]status.StatusInfo,
+	lastReportedEvent map[string]time.Time,
+) error {
 	units, err := aw.containerBroker.Units(aw.application)
 	if err != nil {
 		return errors.Trace(err)
@@ -272,6 +311,16 @@ func (aw *applicationWorker) clusterChanged(service *caas.Service, lastReportedS
 			Info:       unitStatus.Message,
 			Data:       unitStatus.Data,
 		}
+		var events []status.StatusInfo
+		events, lastReportedEvent[u.Id] = newEvents(u.Events, lastReportedEvent[u.Id])
+		for _, event := range events {
+			unitParams.Events = append(unitParams.Events, params.EntityStatus{
+				Status: event.Status,
+				Info:   event.Message,
+				Data:   event.Data,
+				Since:  event.Since,
+			})
+		}
 		// Fill in any filesystem info for volumes attached to the unit.
 		// A unit will not become active until all required volumes are
 		// provisioned, so it makes sense to send this information along
@@ -66,4 +66,8 @@ type UnitUpdater interface {
 type ProvisioningStatusSetter interface {
 	// SetOperatorStatus sets the status for the application operator.
 	SetOperatorStatus(appName string, status status.Status, message string, data map[string]interface{}) error
+
+	// RecordOperatorEvents records the cloud events
+	// for the application operator.
+	RecordOperatorEvents(appName string, events []status.StatusInfo) error
 }
@@ -98,6 +98,7 @@ type mockContainerBroker struct {
 	operatorWatcher        *watchertest.MockNotifyWatcher
 	reportedUnitStatus     status.Status
 	reportedOperatorStatus status.Status
+	operatorEvents         []status.StatusInfo
 	podSpec                *caas.PodSpec
 }
 
@@ -144,6 +145,7 @@ func (m *mockContainerBroker) Operator(appName string) (*caas.Operator, error) {
 			Message: "testing 1. 2. 3.",
 			Data:    map[string]interface{}{"zip": "zap"},
 		},
+		Events: m.operatorEvents,
 	}, nil
 }
 
@@ -302,3 +304,11 @@ func (m *mockProvisioningStatusSetter) SetOperatorStatus(appName string, status
 	}
 	return nil
 }
+
+func (m *mockProvisioningStatusSetter) RecordOperatorEvents(appName string, events []status.StatusInfo) error {
+	m.MethodCall(m, "RecordOperatorEvents", appName, events)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	return nil
+}
@@ -793,6 +793,89 @@ func (s *WorkerSuite) TestOperatorChange(c *gc.C) {
 	})
 }
 
+func (s *WorkerSuite) TestOperatorEvents(c *gc.C) {
+	t0 := time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Minute)
+	pulled := status.StatusInfo{Status: "Pulled", Message: "pulled image", Since: &t0}
+	backOff := status.StatusInfo{Status: "BackOff", Message: "back-off restarting", Since: &t1}
+	s.containerBroker.operatorEvents = []status.StatusInfo{pulled}
+
+	w, err := caasunitprovisioner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+
+	sendOperatorChange := func(expectedCalls int) {
+		select {
+		case s.caasOperatorChanges <- struct{}{}:
+		case <-time.After(coretesting.LongWait):
+			c.Fatal("timed out sending operator change")
+		}
+		for a := coretesting.LongAttempt.Start(); a.Next(); {
+			if len(s.statusSetter.Calls()) >= expectedCalls {
+				break
+			}
+		}
+	}
+
+	sendOperatorChange(2)
+	s.statusSetter.CheckCallNames(c, "SetOperatorStatus", "RecordOperatorEvents")
+	s.statusSetter.CheckCall(c, 1, "RecordOperatorEvents", "gitlab", []status.StatusInfo{pulled})
+
+	// Only events newer than those already reported are recorded.
+	s.statusSetter.ResetCalls()
+	s.containerBroker.operatorEvents = []status.StatusInfo{pulled, backOff}
+	sendOperatorChange(2)
+	s.statusSetter.CheckCallNames(c, "SetOperatorStatus", "RecordOperatorEvents")
+	s.statusSetter.CheckCall(c, 1, "RecordOperatorEvents", "gitlab", []status.StatusInfo{backOff})
+}
+
+func (s *WorkerSuite) TestOperatorEventsNotSupported(c *gc.C) {
+	t0 := time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Minute)
+	s.containerBroker.operatorEvents = []status.StatusInfo{{Status: "Pulled", Since: &t0}}
+	s.statusSetter.SetErrors(nil, errors.NotSupportedf("recording operator events"))
+
+	w, err := caasunitprovisioner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+
+	sendOperatorChange := func(expectedCalls int) {
+		select {
+		case s.caasOperatorChanges <- struct{}{}:
+		case <-time.After(coretesting.LongWait):
+			c.Fatal("timed out sending operator change")
+		}
+		for a := coretesting.LongAttempt.Start(); a.Next(); {
+			if len(s.statusSetter.Calls()) >= expectedCalls {
+				break
+			}
+		}
+	}
+
+	sendOperatorChange(2)
+	s.statusSetter.CheckCallNames(c, "SetOperatorStatus", "RecordOperatorEvents")
+
+	// Once the controller is known not to support recording
+	// events, they are no longer sent.
+	s.statusSetter.ResetCalls()
+	s.containerBroker.operatorEvents = []status.StatusInfo{{Status: "BackOff", Since: &t1}}
+	sendOperatorChange(1)
+	workertest.CheckAlive(c, w)
+	s.statusSetter.CheckCallNames(c, "SetOperatorStatus")
+}
+
 func (s *WorkerSuite) assertUnitChange(c *gc.C, reported, expectedUnitStatus status.Status) {
 	s.containerBroker.ResetCalls()
 	s.unitUpdater.ResetCalls()
@@ -0,0 +1,115 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinehealth_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+	dt "gopkg.in/juju/worker.v1/dependency/testing"
+
+	"github.com/juju/juju/api/base"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/worker/common"
+	"github.com/juju/juju/worker/machinehealth"
+)
+
+type manifoldSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&manifoldSuite{})
+
+func (*manifoldSuite) TestInputs(c *gc.C) {
+	manifold := makeManifold(nil, nil)
+	c.Assert(manifold.Inputs, jc.SameContents, []string{"the-caller", "the-environ", "the-clock"})
+}
+
+func (*manifoldSuite) TestMissingInputs(c *gc.C) {
+	for _, missing := range []string{"the-caller", "the-environ", "the-clock"} {
+		resources := map[string]interface{}{
+			"the-caller":  apitesting.APICallerFunc(nil),
+			"the-environ": &fakeEnviron{},
+			"the-clock":   testclock.NewClock(time.Now()),
+		}
+		resources[missing] = dependency.ErrMissing
+		manifold := makeManifold(nil, nil)
+		result, err := manifold.Start(dt.StubContext(nil, resources))
+		c.Check(result, gc.IsNil)
+		c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+	}
+}
+
+func (*manifoldSuite) TestWorkerError(c *gc.C) {
+	manifold := makeManifold(nil, errors.New("boom"))
+	result, err := manifold.Start(dt.StubContext(nil, map[string]interface{}{
+		"the-caller":  apitesting.APICallerFunc(nil),
+		"the-environ": &fakeEnviron{},
+		"the-clock":   testclock.NewClock(time.Now()),
+	}))
+	c.Assert(result, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (*manifoldSuite) TestSuccess(c *gc.C) {
+	var config machinehealth.Config
+	w := &fakeWorker{}
+	manifold := machinehealth.Manifold(machinehealth.ManifoldConfig{
+		APICallerName: "the-caller",
+		EnvironName:   "the-environ",
+		ClockName:     "the-clock",
+		PollInterval:  pollInterval,
+		NewWorker: func(cfg machinehealth.Config) (worker.Worker, error) {
+			config = cfg
+			return w, nil
+		},
+		NewCredentialValidatorFacade: func(base.APICaller) (common.CredentialAPI, error) {
+			return &fakeCredentialAPI{}, nil
+		},
+	})
+	environ := &fakeEnviron{}
+	clock := testclock.NewClock(time.Now())
+	result, err := manifold.Start(dt.StubContext(nil, map[string]interface{}{
+		"the-caller":  apitesting.APICallerFunc(nil),
+		"the-environ": environ,
+		"the-clock":   clock,
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.Equals, w)
+	c.Assert(config.Facade, gc.NotNil)
+	c.Assert(config.Environ, gc.Equals, environ)
+	c.Assert(config.CredentialAPI, gc.NotNil)
+	c.Assert(config.Clock, gc.Equals, clock)
+	c.Assert(config.PollInterval, gc.Equals, pollInterval)
+}
+
+func makeManifold(workerResult worker.Worker, workerError error) dependency.Manifold {
+	return machinehealth.Manifold(machinehealth.ManifoldConfig{
+		APICallerName: "the-caller",
+		EnvironName:   "the-environ",
+		ClockName:     "the-clock",
+		PollInterval:  pollInterval,
+		NewWorker: func(machinehealth.Config) (worker.Worker, error) {
+			return workerResult, workerError
+		},
+		NewCredentialValidatorFacade: func(base.APICaller) (common.CredentialAPI, error) {
+			return &fakeCredentialAPI{}, nil
+		},
+	})
+}
+
+type fakeWorker struct {
+	worker.Worker
+}
+
+type fakeEnviron struct {
+	environs.Environ
+}
@@ -0,0 +1,221 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinehealth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/api/machinehealth"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/worker/common"
+)
+
+var logger = loggo.GetLogger("juju.worker.machinehealth")
+
+// Facade defines the interface we require from the machine health
+// facade.
+type Facade interface {
+	ModelConfig() (*config.Config, error)
+	LostMachines() ([]machinehealth.LostMachine, error)
+	MarkMachineFailed(tag names.MachineTag, message string, reprovision bool) error
+}
+
+// Environ defines the interface we require from the environ, to check
+// and stop the instances of lost machines.
+type Environ interface {
+	Instances(ctx context.ProviderCallContext, ids []instance.Id) ([]instances.Instance, error)
+	StopInstances(ctx context.ProviderCallContext, ids ...instance.Id) error
+}
+
+// Config holds the dependencies and configuration necessary to drive
+// a machine health worker.
+type Config struct {
+	Facade        Facade
+	Environ       Environ
+	CredentialAPI common.CredentialAPI
+	Clock         clock.Clock
+	PollInterval  time.Duration
+}
+
+// Validate returns an error if config cannot be expected to drive a
+// machine health worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Environ == nil {
+		return errors.NotValidf("nil Environ")
+	}
+	if config.CredentialAPI == nil {
+		return errors.NotValidf("nil CredentialAPI")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.PollInterval <= 0 {
+		return errors.NotValidf("non-positive PollInterval")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that periodically checks for machines
+// whose agents have been lost for longer than the model's
+// lost-machine-threshold. If the instance of such a machine is stopped
+// or missing, the machine is marked as failed and, if the model's
+// reprovision-lost-machines setting is true, its instance is replaced.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &healthWorker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type healthWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *healthWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *healthWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *healthWorker) loop() error {
+	ctx := common.NewCloudCallContext(w.config.CredentialAPI, w.catacomb.Dying)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.PollInterval):
+			if err := w.check(ctx); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// check marks as failed the machines that have been lost for longer
+// than the model's threshold, and whose instances are not running.
+func (w *healthWorker) check(ctx context.ProviderCallContext) error {
+	modelConfig, err := w.config.Facade.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	threshold := modelConfig.LostMachineThreshold()
+	if threshold == 0 {
+		return nil
+	}
+
+	lost, err := w.config.Facade.LostMachines()
+	if err != nil {
+		return errors.Annotate(err, "cannot get lost machines")
+	}
+	now := w.config.Clock.Now()
+	var overdue []machinehealth.LostMachine
+	for _, m := range lost {
+		if now.Sub(m.Since) >= threshold {
+			overdue = append(overdue, m)
+		} else {
+			logger.Debugf("agent of %s lost since %v", names.ReadableString(m.Tag), m.Since)
+		}
+	}
+	if len(overdue) == 0 {
+		return nil
+	}
+
+	ids := make([]instance.Id, len(overdue))
+	for i, m := range overdue {
+		ids[i] = m.InstanceId
+	}
+	insts, err := w.config.Environ.Instances(ctx, ids)
+	switch err {
+	case nil, environs.ErrPartialInstances, environs.ErrNoInstances:
+	default:
+		// The provider may be briefly unavailable; try again on
+		// the next poll rather than restarting the worker.
+		logger.Errorf("cannot get instances of lost machines: %v", err)
+		return nil
+	}
+
+	reprovision := modelConfig.ReprovisionLostMachines()
+	for i, m := range overdue {
+		var inst instances.Instance
+		if i < len(insts) {
+			inst = insts[i]
+		}
+		state := "missing"
+		if inst != nil {
+			instStatus := inst.Status(ctx)
+			switch instStatus.Status {
+			case status.Running, status.Pending, status.Allocating, status.Unknown:
+				// The machine's agent may yet come back.
+				continue
+			}
+			state = string(instStatus.Status)
+			if instStatus.Message != "" {
+				state = instStatus.Message
+			}
+		}
+		if err := w.markFailed(ctx, m, inst != nil, state, threshold, reprovision); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// markFailed marks the machine as failed, first stopping its instance
+// if it is to be reprovisioned.
+func (w *healthWorker) markFailed(
+	ctx context.ProviderCallContext,
+	m machinehealth.LostMachine,
+	exists bool,
+	state string,
+	threshold time.Duration,
+	reprovision bool,
+) error {
+	if reprovision && exists {
+		// Stop the old instance so that it does not come back
+		// alongside its replacement.
+		if err := w.config.Environ.StopInstances(ctx, m.InstanceId); err != nil {
+			logger.Errorf("cannot stop instance %s of %s: %v", m.InstanceId, names.ReadableString(m.Tag), err)
+			reprovision = false
+		}
+	}
+	message := fmt.Sprintf("agent lost for more than %v and instance %s is %s", threshold, m.InstanceId, state)
+	if err := w.config.Facade.MarkMachineFailed(m.Tag, message, reprovision); err != nil {
+		return errors.Annotatef(err, "cannot mark %s as failed", names.ReadableString(m.Tag))
+	}
+	if reprovision {
+		logger.Infof("%s failed (%s); replacing its instance", names.ReadableString(m.Tag), message)
+	} else {
+		logger.Infof("%s failed (%s)", names.ReadableString(m.Tag), message)
+	}
+	return nil
+}
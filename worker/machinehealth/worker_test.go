@@ -0,0 +1,304 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinehealth_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	apimachinehealth "github.com/juju/juju/api/machinehealth"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/machinehealth"
+)
+
+const pollInterval = 30 * time.Minute
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	clock   *testclock.Clock
+	facade  *mockFacade
+	environ *mockEnviron
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.facade = &mockFacade{
+		config: coretesting.CustomModelConfig(c, coretesting.Attrs{
+			"lost-machine-threshold": "1h",
+		}),
+		// The machine is lost from the first poll onwards, so the
+		// threshold is exceeded on the third.
+		lost: s.lostSince(pollInterval),
+	}
+	s.environ = &mockEnviron{
+		instances: map[instance.Id]instances.Instance{
+			"i-0": &mockInstance{status: instance.Status{Status: status.Empty, Message: "stopped"}},
+		},
+	}
+}
+
+// lostSince returns machine 0 reported as lost since the given time
+// after the test clock started.
+func (s *workerSuite) lostSince(d time.Duration) []apimachinehealth.LostMachine {
+	return []apimachinehealth.LostMachine{{
+		Tag:        names.NewMachineTag("0"),
+		InstanceId: "i-0",
+		Since:      s.clock.Now().Add(d),
+	}}
+}
+
+func (s *workerSuite) config() machinehealth.Config {
+	return machinehealth.Config{
+		Facade:        s.facade,
+		Environ:       s.environ,
+		CredentialAPI: &fakeCredentialAPI{},
+		Clock:         s.clock,
+		PollInterval:  pollInterval,
+	}
+}
+
+// run starts a worker and lets it poll the given number of times,
+// waiting for the last poll to complete before stopping the worker.
+func (s *workerSuite) run(c *gc.C, polls int) {
+	w, err := machinehealth.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	for i := 0; i < polls; i++ {
+		err := s.clock.WaitAdvance(pollInterval, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err = s.clock.WaitAdvance(0, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	for _, test := range []struct {
+		mutate func(*machinehealth.Config)
+		err    string
+	}{{
+		func(cfg *machinehealth.Config) { cfg.Facade = nil },
+		"nil Facade not valid",
+	}, {
+		func(cfg *machinehealth.Config) { cfg.Environ = nil },
+		"nil Environ not valid",
+	}, {
+		func(cfg *machinehealth.Config) { cfg.CredentialAPI = nil },
+		"nil CredentialAPI not valid",
+	}, {
+		func(cfg *machinehealth.Config) { cfg.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(cfg *machinehealth.Config) { cfg.PollInterval = 0 },
+		"non-positive PollInterval not valid",
+	}} {
+		config := s.config()
+		test.mutate(&config)
+		err := config.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *workerSuite) TestDisabled(c *gc.C) {
+	s.facade.config = coretesting.ModelConfig(c)
+	s.run(c, 4)
+	s.facade.CheckCallNames(c, "ModelConfig", "ModelConfig", "ModelConfig", "ModelConfig")
+	s.environ.CheckNoCalls(c)
+}
+
+func (s *workerSuite) TestWaitsForThreshold(c *gc.C) {
+	s.run(c, 2)
+	s.facade.CheckCallNames(c, "ModelConfig", "LostMachines", "ModelConfig", "LostMachines")
+	s.environ.CheckNoCalls(c)
+}
+
+func (s *workerSuite) TestLostBeforeWorkerStarted(c *gc.C) {
+	s.facade.lost = s.lostSince(-2 * time.Hour)
+	s.run(c, 1)
+	s.environ.CheckCallNames(c, "Instances")
+	s.checkMarked(c, "agent lost for more than 1h0m0s and instance i-0 is stopped", false)
+}
+
+func (s *workerSuite) TestMarksStoppedMachineFailed(c *gc.C) {
+	s.run(c, 3)
+	s.environ.CheckCallNames(c, "Instances")
+	s.environ.CheckCall(c, 0, "Instances", []instance.Id{"i-0"})
+	s.checkMarked(c, "agent lost for more than 1h0m0s and instance i-0 is stopped", false)
+}
+
+func (s *workerSuite) TestReprovisionStopsInstance(c *gc.C) {
+	s.setReprovision(c)
+	s.run(c, 3)
+	s.environ.CheckCallNames(c, "Instances", "StopInstances")
+	s.environ.CheckCall(c, 1, "StopInstances", []instance.Id{"i-0"})
+	s.checkMarked(c, "agent lost for more than 1h0m0s and instance i-0 is stopped", true)
+}
+
+func (s *workerSuite) TestReprovisionMissingInstance(c *gc.C) {
+	s.setReprovision(c)
+	s.environ.instances = nil
+	s.run(c, 3)
+	s.environ.CheckCallNames(c, "Instances")
+	s.checkMarked(c, "agent lost for more than 1h0m0s and instance i-0 is missing", true)
+}
+
+func (s *workerSuite) TestStopInstancesError(c *gc.C) {
+	s.setReprovision(c)
+	s.environ.SetErrors(nil, errors.New("boom"))
+	s.run(c, 3)
+	s.environ.CheckCallNames(c, "Instances", "StopInstances")
+	s.checkMarked(c, "agent lost for more than 1h0m0s and instance i-0 is stopped", false)
+}
+
+func (s *workerSuite) TestRunningInstanceLeftAlone(c *gc.C) {
+	s.environ.instances["i-0"] = &mockInstance{status: instance.Status{Status: status.Running}}
+	s.run(c, 3)
+	s.environ.CheckCallNames(c, "Instances")
+	s.checkNotMarked(c)
+}
+
+func (s *workerSuite) TestInstancesErrorRetried(c *gc.C) {
+	s.environ.SetErrors(errors.New("boom"))
+	s.run(c, 4)
+	s.environ.CheckCallNames(c, "Instances", "Instances")
+	s.checkMarked(c, "agent lost for more than 1h0m0s and instance i-0 is stopped", false)
+}
+
+func (s *workerSuite) TestRecoveredMachineForgotten(c *gc.C) {
+	lostAgain := s.lostSince(3 * pollInterval)
+	s.facade.lostResults = [][]apimachinehealth.LostMachine{s.facade.lost, nil, lostAgain, lostAgain}
+	s.run(c, 4)
+	s.environ.CheckNoCalls(c)
+	s.checkNotMarked(c)
+}
+
+func (s *workerSuite) TestMarkMachineFailedError(c *gc.C) {
+	s.facade.SetErrors(nil, nil, nil, nil, nil, nil, errors.New("boom"))
+	w, err := machinehealth.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+	for i := 0; i < 3; i++ {
+		err := s.clock.WaitAdvance(pollInterval, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "cannot mark machine 0 as failed: boom")
+}
+
+func (s *workerSuite) setReprovision(c *gc.C) {
+	s.facade.config = coretesting.CustomModelConfig(c, coretesting.Attrs{
+		"lost-machine-threshold":    "1h",
+		"reprovision-lost-machines": true,
+	})
+}
+
+func (s *workerSuite) checkMarked(c *gc.C, message string, reprovision bool) {
+	var calls []testing.StubCall
+	for _, call := range s.facade.Calls() {
+		if call.FuncName == "MarkMachineFailed" {
+			calls = append(calls, call)
+		}
+	}
+	c.Assert(calls, jc.DeepEquals, []testing.StubCall{{
+		FuncName: "MarkMachineFailed",
+		Args:     []interface{}{names.NewMachineTag("0"), message, reprovision},
+	}})
+}
+
+func (s *workerSuite) checkNotMarked(c *gc.C) {
+	for _, call := range s.facade.Calls() {
+		c.Check(call.FuncName, gc.Not(gc.Equals), "MarkMachineFailed")
+	}
+}
+
+type mockFacade struct {
+	testing.Stub
+	config *config.Config
+	lost   []apimachinehealth.LostMachine
+
+	// lostResults, if set, holds the results of successive calls
+	// to LostMachines, in place of lost.
+	lostResults [][]apimachinehealth.LostMachine
+}
+
+func (f *mockFacade) ModelConfig() (*config.Config, error) {
+	f.MethodCall(f, "ModelConfig")
+	return f.config, f.NextErr()
+}
+
+func (f *mockFacade) LostMachines() ([]apimachinehealth.LostMachine, error) {
+	f.MethodCall(f, "LostMachines")
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	if len(f.lostResults) > 0 {
+		lost := f.lostResults[0]
+		f.lostResults = f.lostResults[1:]
+		return lost, nil
+	}
+	return f.lost, nil
+}
+
+func (f *mockFacade) MarkMachineFailed(tag names.MachineTag, message string, reprovision bool) error {
+	f.MethodCall(f, "MarkMachineFailed", tag, message, reprovision)
+	return f.NextErr()
+}
+
+type mockEnviron struct {
+	testing.Stub
+	instances map[instance.Id]instances.Instance
+}
+
+func (e *mockEnviron) Instances(ctx context.ProviderCallContext, ids []instance.Id) ([]instances.Instance, error) {
+	e.MethodCall(e, "Instances", ids)
+	if err := e.NextErr(); err != nil {
+		return nil, err
+	}
+	result := make([]instances.Instance, len(ids))
+	found := 0
+	for i, id := range ids {
+		if inst, ok := e.instances[id]; ok {
+			result[i] = inst
+			found++
+		}
+	}
+	switch found {
+	case 0:
+		return nil, environs.ErrNoInstances
+	case len(ids):
+		return result, nil
+	}
+	return result, environs.ErrPartialInstances
+}
+
+func (e *mockEnviron) StopInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	e.MethodCall(e, "StopInstances", ids)
+	return e.NextErr()
+}
+
+type mockInstance struct {
+	instances.Instance
+	status instance.Status
+}
+
+func (i *mockInstance) Status(context.ProviderCallContext) instance.Status {
+	return i.status
+}
@@ -58,6 +58,11 @@ const (
 	// reports from minions and while it's transferring log messages
 	// to the newly-migrated model.
 	progressUpdateInterval = 30 * time.Second
+
+	// keptImportExpiry is how long a model imported into the target
+	// controller is kept after its migration aborts, so that the
+	// migration can be resumed. Imports kept for longer are removed.
+	keptImportExpiry = 24 * time.Hour
 )
 
 // Facade exposes controller functionality to a Worker.
@@ -78,6 +83,10 @@ type Facade interface {
 	// progress of a migration.
 	SetStatusMessage(string) error
 
+	// SetKeepImport records whether the imported model is kept on
+	// the target controller if the migration is aborted.
+	SetKeepImport(bool) error
+
 	// Prechecks performs pre-migration checks on the model and
 	// (source) controller.
 	Prechecks() error
@@ -186,6 +195,13 @@ type Worker struct {
 	config      Config
 	logger      loggo.Logger
 	lastFailure string
+
+	// keepImport is true while the model imported into the target
+	// controller should be kept if the migration aborts, so that the
+	// migration can be resumed without uploading the binaries again.
+	// It is persisted on the migration so that it survives a restart
+	// of the worker.
+	keepImport bool
 }
 
 // Kill implements worker.Worker.
@@ -203,6 +219,7 @@ func (w *Worker) run() error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	w.keepImport = status.KeepImport
 
 	err = w.config.Guard.Lockdown(w.catacomb.Dying())
 	if errors.Cause(err) == fortress.ErrAborted {
@@ -219,7 +236,7 @@ func (w *Worker) run() error {
 		case coremigration.QUIESCE:
 			phase, err = w.doQUIESCE(status)
 		case coremigration.IMPORT:
-			phase, err = w.doIMPORT(status.TargetInfo, status.ModelUUID)
+			phase, err = w.doIMPORT(status)
 		case coremigration.VALIDATION:
 			phase, err = w.doVALIDATION(status)
 		case coremigration.SUCCESS:
@@ -297,6 +314,17 @@ func (w *Worker) setStatus(message string) error {
 	return errors.Annotate(err, "failed to set status message")
 }
 
+func (w *Worker) setKeepImport(keep bool) error {
+	if keep == w.keepImport {
+		return nil
+	}
+	if err := w.config.Facade.SetKeepImport(keep); err != nil {
+		return errors.Annotate(err, "failed to set keep import")
+	}
+	w.keepImport = keep
+	return nil
+}
+
 func (w *Worker) doQUIESCE(status coremigration.MigrationStatus) (coremigration.Phase, error) {
 	// Run prechecks before waiting for minions to report back. This
 	// short-circuits the long timeout in the case of an agent being
@@ -351,8 +379,8 @@ func (w *Worker) prechecks(status coremigration.MigrationStatus) error {
 	return errors.Annotate(err, "target prechecks failed")
 }
 
-func (w *Worker) doIMPORT(targetInfo coremigration.TargetInfo, modelUUID string) (coremigration.Phase, error) {
-	err := w.transferModel(targetInfo, modelUUID)
+func (w *Worker) doIMPORT(status coremigration.MigrationStatus) (coremigration.Phase, error) {
+	err := w.transferModel(status)
 	if err != nil {
 		w.setErrorStatus("model data transfer failed, %v", err)
 		return coremigration.ABORT, nil
@@ -390,7 +418,8 @@ func (w *uploadWrapper) SetUnitResource(unitName string, res resource.Resource)
 	return w.client.SetUnitResource(w.modelUUID, unitName, res)
 }
 
-func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID string) error {
+func (w *Worker) transferModel(status coremigration.MigrationStatus) error {
+	modelUUID := status.ModelUUID
 	w.setInfoStatus("exporting model")
 	serialized, err := w.config.Facade.Export()
 	if err != nil {
@@ -398,22 +427,49 @@ func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID st
 	}
 
 	w.setInfoStatus("importing model into target controller")
-	conn, err := w.openAPIConn(targetInfo)
+	conn, err := w.openAPIConn(status.TargetInfo)
 	if err != nil {
 		return errors.Annotate(err, "failed to connect to target controller")
 	}
 	defer conn.Close()
 	targetClient := migrationtarget.NewClient(conn)
-	err = targetClient.Import(serialized.Bytes)
+	canResume := targetClient.CanResumeImport()
+	if status.Resume && !canResume {
+		w.logger.Warningf("target controller can't resume imports, transferring all binaries")
+	}
+	resume := status.Resume && canResume
+	if resume {
+		err = targetClient.ResumeImport(serialized.Bytes)
+	} else {
+		if canResume {
+			// Remove anything kept from an earlier attempt which
+			// isn't being resumed.
+			if err := targetClient.Abort(modelUUID); err != nil && !params.IsCodeNotFound(err) {
+				return errors.Annotate(err, "failed to remove previous import from target controller")
+			}
+		}
+		err = targetClient.Import(serialized.Bytes)
+	}
 	if err != nil {
 		return errors.Annotate(err, "failed to import model into target controller")
 	}
+	if err := w.setKeepImport(canResume); err != nil {
+		return errors.Trace(err)
+	}
 
 	if wrench.IsActive("migrationmaster", "die-in-export") {
 		// Simulate a abort causing failure to test last status not over written.
 		return errors.New("wrench in the transferModel works")
 	}
 
+	var imported coremigration.ImportedBinaries
+	if resume {
+		imported, err = targetClient.ImportedBinaries(modelUUID)
+		if err != nil {
+			return errors.Annotate(err, "failed to list binaries on target controller")
+		}
+	}
+
 	w.setInfoStatus("uploading model binaries into target controller")
 	wrapper := &uploadWrapper{targetClient, modelUUID}
 	err = w.config.UploadBinaries(migration.UploadBinariesConfig{
@@ -428,8 +484,14 @@ func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID st
 		Resources:          serialized.Resources,
 		ResourceDownloader: w.config.Facade,
 		ResourceUploader:   wrapper,
+
+		Imported: imported,
 	})
-	return errors.Annotate(err, "failed to migrate binaries")
+	if err != nil {
+		return errors.Annotate(err, "failed to migrate binaries")
+	}
+	// Only failures while transferring the model can be resumed.
+	return errors.Trace(w.setKeepImport(false))
 }
 
 func (w *Worker) doVALIDATION(status coremigration.MigrationStatus) (coremigration.Phase, error) {
@@ -619,6 +681,10 @@ func (w *Worker) doREAP() (coremigration.Phase, error) {
 }
 
 func (w *Worker) doABORT(targetInfo coremigration.TargetInfo, modelUUID string) (coremigration.Phase, error) {
+	if w.keepImport {
+		w.setInfoStatus("aborted, keeping model on target controller so the migration can be resumed: %s", w.lastFailure)
+		return coremigration.ABORTDONE, nil
+	}
 	w.setInfoStatus("aborted, removing model from target controller: %s", w.lastFailure)
 	if err := w.removeImportedModel(targetInfo, modelUUID); err != nil {
 		// This isn't fatal. Removing the imported model is a best
@@ -642,6 +708,8 @@ func (w *Worker) removeImportedModel(targetInfo coremigration.TargetInfo, modelU
 
 func (w *Worker) waitForActiveMigration() (coremigration.MigrationStatus, error) {
 	var empty coremigration.MigrationStatus
+	var aborted coremigration.MigrationStatus
+	var keptImportExpired <-chan time.Time
 
 	watcher, err := w.config.Facade.Watch()
 	if err != nil {
@@ -656,6 +724,10 @@ func (w *Worker) waitForActiveMigration() (coremigration.MigrationStatus, error)
 		select {
 		case <-w.catacomb.Dying():
 			return empty, w.catacomb.ErrDying()
+		case <-keptImportExpired:
+			keptImportExpired = nil
+			w.removeKeptImport(aborted)
+			continue
 		case <-watcher.Changes():
 		}
 
@@ -668,6 +740,15 @@ func (w *Worker) waitForActiveMigration() (coremigration.MigrationStatus, error)
 			if modelHasMigrated(status.Phase) {
 				return empty, ErrMigrated
 			}
+			if status.Phase == coremigration.ABORTDONE && status.KeepImport && status.MigrationId != aborted.MigrationId {
+				// The target controller kept the model imported by
+				// the aborted migration, so that the migration can
+				// be resumed. It's removed once the kept import
+				// expires.
+				aborted = status
+				clk := w.config.Clock
+				keptImportExpired = clk.After(keptImportExpiry - clk.Now().Sub(status.PhaseChangedTime))
+			}
 		case err != nil:
 			return empty, errors.Annotate(err, "retrieving migration status")
 		default:
@@ -682,6 +763,37 @@ func (w *Worker) waitForActiveMigration() (coremigration.MigrationStatus, error)
 	}
 }
 
+// removeKeptImport removes the model kept on the target controller by
+// an aborted migration which wasn't resumed before the kept import
+// expired. Failures are logged rather than returned, as the kept import
+// doesn't prevent the model from being migrated again.
+func (w *Worker) removeKeptImport(status coremigration.MigrationStatus) {
+	conn, err := w.openAPIConn(status.TargetInfo)
+	if err != nil {
+		w.logger.Debugf("not removing kept import, failed to connect to target controller: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	targetClient := migrationtarget.NewClient(conn)
+	if !targetClient.CanResumeImport() {
+		// The target controller never keeps imports.
+		return
+	}
+	err = targetClient.Abort(status.ModelUUID)
+	if err != nil && !params.IsCodeNotFound(err) {
+		w.logger.Warningf("failed to remove kept import from target controller: %v", err)
+		return
+	}
+	if err == nil {
+		w.logger.Infof("removed model kept on target controller by aborted migration %s", status.MigrationId)
+	}
+	// There's nothing left on the target controller to resume.
+	if err := w.config.Facade.SetKeepImport(false); err != nil {
+		w.logger.Warningf("failed to clear keep import: %v", err)
+	}
+}
+
 // Possible values for waitForMinion's waitPolicy argument.
 const failFast = false  // Stop waiting at first minion failure report
 const waitForAll = true // Wait for all minion reports to arrive (or timeout)
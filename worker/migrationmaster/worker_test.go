@@ -328,6 +328,39 @@ func (s *Suite) TestPreviouslyAbortedMigration(c *gc.C) {
 	})
 }
 
+func (s *Suite) TestPreviouslyAbortedMigrationKeptImportExpires(c *gc.C) {
+	status := s.makeStatus(coremigration.ABORTDONE)
+	status.KeepImport = true
+	s.facade.queueStatus(status)
+	s.connection.facadeVersion = 3
+
+	worker, err := migrationmaster.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, worker)
+
+	s.waitForStubCalls(c, []string{
+		"facade.Watch",
+		"facade.MigrationStatus",
+		"guard.Unlock",
+	})
+
+	// The model kept on the target controller is removed once the
+	// migration hasn't been resumed for a day.
+	err = s.clock.WaitAdvance(24*time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitForStubCalls(c, []string{
+		"facade.Watch",
+		"facade.MigrationStatus",
+		"guard.Unlock",
+		"apiOpen",
+		"MigrationTarget.Abort",
+		"facade.SetKeepImport",
+		"Connection.Close",
+	})
+	s.stub.CheckCall(c, 4, abortCall.FuncName, abortCall.Args...)
+	s.stub.CheckCall(c, 5, "facade.SetKeepImport", false)
+}
+
 func (s *Suite) TestPreviouslyCompletedMigration(c *gc.C) {
 	s.facade.queueStatus(s.makeStatus(coremigration.DONE))
 	s.checkWorkerReturns(c, migrationmaster.ErrMigrated)
@@ -525,6 +558,216 @@ func (s *Suite) TestImportFailure(c *gc.C) {
 	))
 }
 
+func (s *Suite) failingUploadBinaries(imported *coremigration.ImportedBinaries) func(migration.UploadBinariesConfig) error {
+	return func(config migration.UploadBinariesConfig) error {
+		s.stub.AddCall("UploadBinaries")
+		*imported = config.Imported
+		return errors.New("boom")
+	}
+}
+
+func (s *Suite) TestUploadFailureKeepsImport(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
+	s.connection.facadeVersion = 3
+	var imported coremigration.ImportedBinaries
+	s.config.UploadBinaries = s.failingUploadBinaries(&imported)
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.Export", nil},
+			apiOpenControllerCall,
+			// Anything left from an earlier attempt is removed.
+			abortCall,
+			importCall,
+			{"facade.SetKeepImport", []interface{}{true}},
+			{"UploadBinaries", nil},
+			apiCloseCall,
+			// The imported model is kept on the target.
+			{"facade.SetPhase", []interface{}{coremigration.ABORT}},
+			{"facade.SetPhase", []interface{}{coremigration.ABORTDONE}},
+		},
+	))
+	c.Check(imported, jc.DeepEquals, coremigration.ImportedBinaries{})
+	lastMessage := s.facade.statuses[len(s.facade.statuses)-1]
+	c.Check(lastMessage, gc.Equals, "aborted, keeping model on target controller so the migration "+
+		"can be resumed: model data transfer failed, failed to migrate binaries: boom")
+}
+
+func (s *Suite) TestABORTKeepsImportAfterRestart(c *gc.C) {
+	// A worker restarted after deciding to keep the import doesn't
+	// remove the model from the target controller.
+	status := s.makeStatus(coremigration.ABORT)
+	status.KeepImport = true
+	s.facade.queueStatus(status)
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.SetPhase", []interface{}{coremigration.ABORTDONE}},
+		},
+	))
+}
+
+func (s *Suite) TestResumeImport(c *gc.C) {
+	status := s.makeStatus(coremigration.IMPORT)
+	status.Resume = true
+	status.KeepImport = true
+	s.facade.queueStatus(status)
+	s.connection.facadeVersion = 3
+	s.connection.importedBinaries = params.MigrationImportedBinaries{
+		Charms: []params.MigrationImportedBinary{{ID: "cs:foo-1", SHA256: "charm-sha"}},
+		Tools:  []params.MigrationImportedBinary{{ID: "2.6.0-bionic-amd64", SHA256: "tools-sha"}},
+		Resources: []params.MigrationImportedResource{{
+			Application:    "app",
+			Name:           "blob",
+			FingerprintHex: "abcd",
+		}},
+	}
+	var imported coremigration.ImportedBinaries
+	s.config.UploadBinaries = s.failingUploadBinaries(&imported)
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.Export", nil},
+			apiOpenControllerCall,
+			{"MigrationTarget.ResumeImport", []interface{}{
+				params.SerializedModel{Bytes: fakeModelBytes},
+			}},
+			{"MigrationTarget.ImportedBinaries", []interface{}{
+				params.ModelArgs{ModelTag: modelTag.String()},
+			}},
+			{"UploadBinaries", nil},
+			apiCloseCall,
+			{"facade.SetPhase", []interface{}{coremigration.ABORT}},
+			{"facade.SetPhase", []interface{}{coremigration.ABORTDONE}},
+		},
+	))
+	c.Check(imported, jc.DeepEquals, coremigration.ImportedBinaries{
+		Charms:    map[string]string{"cs:foo-1": "charm-sha"},
+		Tools:     map[version.Binary]string{version.MustParseBinary("2.6.0-bionic-amd64"): "tools-sha"},
+		Resources: map[string]string{"app/blob": "abcd"},
+	})
+}
+
+func (s *Suite) TestResumeImportNotSupported(c *gc.C) {
+	status := s.makeStatus(coremigration.IMPORT)
+	status.Resume = true
+	s.facade.queueStatus(status)
+	var imported coremigration.ImportedBinaries
+	s.config.UploadBinaries = s.failingUploadBinaries(&imported)
+
+	// The target can't resume, so the model is imported from scratch
+	// and removed again when the migration aborts.
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.Export", nil},
+			apiOpenControllerCall,
+			importCall,
+			{"UploadBinaries", nil},
+			apiCloseCall,
+		},
+		abortCalls,
+	))
+}
+
+func (s *Suite) TestAbortResumeAndCompleteMigration(c *gc.C) {
+	s.connection.facadeVersion = 3
+	var uploads []coremigration.ImportedBinaries
+	s.config.UploadBinaries = func(config migration.UploadBinariesConfig) error {
+		s.stub.AddCall("UploadBinaries")
+		uploads = append(uploads, config.Imported)
+		if len(uploads) > 1 {
+			return nil
+		}
+		// The first charm reaches the target before the upload fails.
+		s.connection.importedBinaries = params.MigrationImportedBinaries{
+			Charms: []params.MigrationImportedBinary{{ID: "charm0", SHA256: "charm0-sha"}},
+		}
+		return errors.New("boom")
+	}
+
+	// The first attempt aborts, keeping the imported model.
+	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	lastMessage := s.facade.statuses[len(s.facade.statuses)-1]
+	c.Check(lastMessage, gc.Equals, "aborted, keeping model on target controller so the migration "+
+		"can be resumed: model data transfer failed, failed to migrate binaries: boom")
+
+	// The resumed migration only uploads what the target doesn't have,
+	// and then completes.
+	s.stub.ResetCalls()
+	status := s.makeStatus(coremigration.IMPORT)
+	status.MigrationId = "model-uuid:3"
+	status.Resume = true
+	status.KeepImport = true
+	s.facade.queueStatus(status)
+	s.facade.queueMinionReports(makeMinionReports(coremigration.VALIDATION))
+	s.facade.queueMinionReports(makeMinionReports(coremigration.SUCCESS))
+
+	s.checkWorkerReturns(c, migrationmaster.ErrMigrated)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			// IMPORT
+			{"facade.Export", nil},
+			apiOpenControllerCall,
+			{"MigrationTarget.ResumeImport", []interface{}{
+				params.SerializedModel{Bytes: fakeModelBytes},
+			}},
+			{"MigrationTarget.ImportedBinaries", []interface{}{
+				params.ModelArgs{ModelTag: modelTag.String()},
+			}},
+			{"UploadBinaries", nil},
+			{"facade.SetKeepImport", []interface{}{false}},
+			apiCloseCall,
+			{"facade.SetPhase", []interface{}{coremigration.VALIDATION}},
+
+			// VALIDATION
+			{"facade.WatchMinionReports", nil},
+			{"facade.MinionReports", nil},
+			apiOpenControllerCall,
+			checkMachinesCall,
+			activateCall,
+			apiCloseCall,
+			{"facade.SetPhase", []interface{}{coremigration.SUCCESS}},
+
+			// SUCCESS
+			{"facade.WatchMinionReports", nil},
+			{"facade.MinionReports", nil},
+			apiOpenControllerCall,
+			adoptResourcesCall,
+			apiCloseCall,
+			{"facade.SetPhase", []interface{}{coremigration.LOGTRANSFER}},
+
+			// LOGTRANSFER
+			apiOpenControllerCall,
+			latestLogTimeCall,
+			{"StreamModelLog", []interface{}{time.Time{}}},
+			openDestLogStreamCall,
+			{"facade.SetPhase", []interface{}{coremigration.REAP}},
+
+			// REAP
+			{"facade.Reap", nil},
+			{"facade.SetPhase", []interface{}{coremigration.DONE}},
+		},
+	))
+	c.Check(uploads, jc.DeepEquals, []coremigration.ImportedBinaries{
+		{},
+		{
+			Charms:    map[string]string{"charm0": "charm0-sha"},
+			Tools:     map[version.Binary]string{},
+			Resources: map[string]string{},
+		},
+	})
+}
+
 func (s *Suite) TestVALIDATIONMinionWaitWatchError(c *gc.C) {
 	s.checkMinionWaitWatchError(c, coremigration.VALIDATION)
 }
@@ -1203,6 +1446,11 @@ func (f *stubMasterFacade) SetStatusMessage(message string) error {
 	return nil
 }
 
+func (f *stubMasterFacade) SetKeepImport(keep bool) error {
+	f.stub.AddCall("facade.SetKeepImport", keep)
+	return nil
+}
+
 func (f *stubMasterFacade) Reap() error {
 	f.stub.AddCall("facade.Reap")
 	return nil
@@ -1256,9 +1504,15 @@ type stubConnection struct {
 
 	machineErrs     []string
 	checkMachineErr error
+
+	facadeVersion    int
+	importedBinaries params.MigrationImportedBinaries
 }
 
 func (c *stubConnection) BestFacadeVersion(string) int {
+	if c.facadeVersion != 0 {
+		return c.facadeVersion
+	}
 	return 1
 }
 
@@ -1269,9 +1523,12 @@ func (c *stubConnection) APICall(objType string, version int, id, request string
 		switch request {
 		case "Prechecks":
 			return c.prechecksErr
-		case "Import":
+		case "Import", "ResumeImport":
 			return c.importErr
-		case "Activate", "AdoptResources":
+		case "ImportedBinaries":
+			*response.(*params.MigrationImportedBinaries) = c.importedBinaries
+			return nil
+		case "Activate", "AdoptResources", "Abort":
 			return nil
 		case "LatestLogTime":
 			responseTime := response.(*time.Time)
@@ -598,6 +598,7 @@ func (task *provisionerTask) constructInstanceConfig(
 	}
 
 	instanceConfig.CloudInitUserData = pInfo.CloudInitUserData
+	instanceConfig.ApplicationCloudInitUserData = pInfo.ApplicationCloudInitUserData
 
 	return instanceConfig, nil
 }